@@ -0,0 +1,80 @@
+// Command tfmodules-gen generates the Variables and Outputs types of a
+// tfmodules package from a module vendored under .forge/modules.
+//
+// It is run by go generate from the package directory:
+//
+//	//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module sqs -source terraform-aws-modules/sqs/aws
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lewis/forge/internal/tfmodules/codegen"
+)
+
+func main() {
+	var (
+		module = flag.String("module", "", "vendored module directory under .forge/modules")
+		source = flag.String("source", "", "registry source of the module")
+		pkg    = flag.String("package", os.Getenv("GOPACKAGE"), "Go package name")
+		out    = flag.String("out", "variables_gen.go", "output file")
+	)
+	flag.Parse()
+
+	if err := run(*module, *source, *pkg, *out); err != nil {
+		fmt.Fprintln(os.Stderr, "tfmodules-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the types for one vendored module.
+// ACTION: Performs I/O (reads the vendored module, writes the output file).
+func run(module, source, pkg, out string) error {
+	if module == "" || source == "" || pkg == "" {
+		return errors.New("-module, -source and -package are required")
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	root, err := repoRoot(wd)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(".forge", "modules", module)
+	schema, err := codegen.ParseModule(filepath.Join(root, dir))
+	if err != nil {
+		return err
+	}
+
+	src, err := codegen.Generate(schema, codegen.Config{
+		Package: pkg,
+		Source:  source,
+		Dir:     filepath.ToSlash(dir),
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(out, src, 0o644)
+}
+
+// repoRoot walks up from dir to the directory holding go.mod.
+// ACTION: Performs I/O (stats files).
+func repoRoot(dir string) (string, error) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("go.mod not found")
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,78 @@
+package build
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lambda instruction set architectures, spelled the way the Lambda API and the
+// aws_lambda_function "architectures" argument expect them.
+const (
+	ArchitectureX86_64 = "x86_64"
+	ArchitectureARM64  = "arm64"
+)
+
+// defaultPythonVersion is used when a runtime string does not name a Python version.
+const defaultPythonVersion = "3.11"
+
+// NormalizeArchitecture returns the canonical Lambda architecture name.
+// Empty means the Lambda default (x86_64); Go-style aliases are accepted.
+// PURE: Calculation.
+func NormalizeArchitecture(arch string) string {
+	switch strings.ToLower(strings.TrimSpace(arch)) {
+	case "", ArchitectureX86_64, "amd64", "x86-64":
+		return ArchitectureX86_64
+	case ArchitectureARM64, "aarch64":
+		return ArchitectureARM64
+	default:
+		return arch
+	}
+}
+
+// ValidateArchitecture reports whether arch is an architecture Lambda supports.
+// PURE: Calculation.
+func ValidateArchitecture(arch string) error {
+	switch NormalizeArchitecture(arch) {
+	case ArchitectureX86_64, ArchitectureARM64:
+		return nil
+	default:
+		return fmt.Errorf("unsupported architecture %q (must be %s or %s)", arch, ArchitectureX86_64, ArchitectureARM64)
+	}
+}
+
+// goArch maps a Lambda architecture to GOARCH.
+// PURE: Calculation.
+func goArch(arch string) string {
+	if NormalizeArchitecture(arch) == ArchitectureARM64 {
+		return "arm64"
+	}
+	return "amd64"
+}
+
+// nodeArch maps a Lambda architecture to Node's process.arch naming.
+// PURE: Calculation.
+func nodeArch(arch string) string {
+	if NormalizeArchitecture(arch) == ArchitectureARM64 {
+		return "arm64"
+	}
+	return "x64"
+}
+
+// pythonPlatform maps a Lambda architecture to the manylinux wheel tag Lambda can load.
+// PURE: Calculation.
+func pythonPlatform(arch string) string {
+	if NormalizeArchitecture(arch) == ArchitectureARM64 {
+		return "manylinux2014_aarch64"
+	}
+	return "manylinux2014_x86_64"
+}
+
+// pythonVersion extracts "3.12" from a runtime like "python3.12".
+// PURE: Calculation.
+func pythonVersion(runtime string) string {
+	version := strings.TrimPrefix(runtime, "python")
+	if version == runtime || version == "" {
+		return defaultPythonVersion
+	}
+	return version
+}
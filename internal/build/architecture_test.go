@@ -0,0 +1,44 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalizeArchitecture tests canonical architecture names.
+func TestNormalizeArchitecture(t *testing.T) {
+	tests := map[string]string{
+		"":        ArchitectureX86_64,
+		"x86_64":  ArchitectureX86_64,
+		"amd64":   ArchitectureX86_64,
+		"arm64":   ArchitectureARM64,
+		"ARM64":   ArchitectureARM64,
+		"aarch64": ArchitectureARM64,
+		"riscv64": "riscv64",
+	}
+
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			assert.Equal(t, want, NormalizeArchitecture(input))
+		})
+	}
+}
+
+// TestValidateArchitecture tests rejection of architectures Lambda cannot run.
+func TestValidateArchitecture(t *testing.T) {
+	assert.NoError(t, ValidateArchitecture(""))
+	assert.NoError(t, ValidateArchitecture("x86_64"))
+	assert.NoError(t, ValidateArchitecture("arm64"))
+
+	err := ValidateArchitecture("riscv64")
+	assert.ErrorContains(t, err, `unsupported architecture "riscv64"`)
+}
+
+// TestPythonVersion tests extracting the interpreter version from a runtime.
+func TestPythonVersion(t *testing.T) {
+	assert.Equal(t, "3.12", pythonVersion("python3.12"))
+	assert.Equal(t, "3.9", pythonVersion("python3.9"))
+	assert.Equal(t, defaultPythonVersion, pythonVersion("python"))
+	assert.Equal(t, defaultPythonVersion, pythonVersion("nodejs20.x"))
+}
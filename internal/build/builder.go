@@ -18,11 +18,13 @@ import (
 type (
 	// Config struct stores all build parameters.
 	Config struct {
-		SourceDir  string            // Source code directory
-		OutputPath string            // Output file path
-		Handler    string            // Handler path/name
-		Runtime    string            // Runtime (go1.x, python3.11, etc.)
-		Env        map[string]string // Environment variables for build
+		SourceDir    string            // Source code directory
+		OutputPath   string            // Output file path
+		Handler      string            // Handler path/name
+		Runtime      string            // Runtime (go1.x, python3.11, etc.)
+		Env          map[string]string // Environment variables for build
+		Excludes     []string          // Extra zip exclusion patterns (see DefaultZipExcludes)
+		Architecture string            // Target architecture: x86_64 (default) or arm64
 	}
 
 	// Artifact represents a built artifact with metadata.
@@ -30,6 +32,7 @@ type (
 		Path     string
 		Checksum string
 		Size     int64
+		Cached   bool // True when restored from a build cache instead of built
 	}
 )
 
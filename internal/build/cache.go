@@ -0,0 +1,566 @@
+package build
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// cacheKeyVersion is mixed into every cache key; bump it when the key layout changes.
+const cacheKeyVersion = "forge-build-cache-v1"
+
+type (
+	// DiskCache is a content-addressed build cache stored on the local filesystem.
+	// Entries live at <dir>/<key[:2]>/<key>/ and hold a copy of the artifact plus metadata.
+	DiskCache struct {
+		dir string
+
+		mu      sync.Mutex
+		pending map[string]string // config identity -> key computed at lookup time
+	}
+
+	// cacheEntry is the metadata stored next to a cached artifact.
+	cacheEntry struct {
+		File     string `json:"file"`
+		Checksum string `json:"checksum"`
+		Size     int64  `json:"size"`
+	}
+)
+
+// lockfileNames are dependency manifests that can live above a function's source
+// directory (e.g. a shared go.mod at the project root) and still affect its build.
+var lockfileNames = []string{
+	"go.mod", "go.sum", "go.work", "go.work.sum",
+	"package.json", "package-lock.json", "yarn.lock", "pnpm-lock.yaml",
+	"requirements.txt", "poetry.lock", "Pipfile.lock", "uv.lock",
+	"pom.xml", "build.gradle", "build.gradle.kts",
+}
+
+// cacheIgnoredDirs are generated or tool-owned directories that never feed a cache key.
+var cacheIgnoredDirs = map[string]bool{
+	".git":         true,
+	".forge":       true,
+	".terraform":   true,
+	".venv":        true,
+	"node_modules": true,
+	"__pycache__":  true,
+}
+
+// workspaceManifests mark the root of a workspace whose other packages a
+// function can use, e.g. a package.json declaring npm workspaces or a parent
+// pom.xml, per runtime family. Go uses go list instead.
+var workspaceManifests = map[string][]string{
+	"nodejs": {"package.json"},
+	"python": {"pyproject.toml", "setup.py", "requirements.txt"},
+	"java":   {"pom.xml", "build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"},
+}
+
+// goListPackage is the subset of go list -json output CacheKey reads.
+type goListPackage struct {
+	Dir      string
+	Standard bool
+	Module   *struct {
+		Main    bool
+		GoMod   string
+		Replace *struct {
+			Version string
+		}
+	}
+	GoFiles, CgoFiles, CFiles, CXXFiles, HFiles, SFiles, SysoFiles, EmbedFiles []string
+}
+
+// NewDiskCache creates a cache rooted at dir (conventionally .forge/cache).
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{
+		dir:     dir,
+		pending: make(map[string]string),
+	}
+}
+
+// DefaultCacheDir returns the conventional cache directory for a project.
+// PURE: Calculation.
+func DefaultCacheDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".forge", "cache")
+}
+
+// CachedRegistry wraps every builder in registry with WithCache.
+// PURE: Returns a new registry, the input is not modified.
+func CachedRegistry(registry Registry, cache Cache) Registry {
+	cached := make(Registry, len(registry))
+	for runtime, builder := range registry {
+		cached[runtime] = WithCache(cache)(builder)
+	}
+	return cached
+}
+
+// CacheKey returns the content hash identifying a build of cfg: the function's
+// source tree, the sources it can use from outside it (the local packages a Go
+// function imports, or the workspace a function of another runtime sits in),
+// lockfiles in parent directories, runtime, handler, architecture, build env
+// and zip excludes.
+// ACTION: Reads the source tree and, for Go, runs go list.
+func CacheKey(cfg Config) (string, error) {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "%s\nruntime=%s\nhandler=%s\narch=%s\n",
+		cacheKeyVersion, cfg.Runtime, cfg.Handler, NormalizeArchitecture(cfg.Architecture))
+
+	envKeys := make([]string, 0, len(cfg.Env))
+	for k := range cfg.Env {
+		envKeys = append(envKeys, k)
+	}
+	sort.Strings(envKeys)
+	for _, k := range envKeys {
+		fmt.Fprintf(h, "env %s=%s\n", k, cfg.Env[k])
+	}
+	for _, pattern := range cfg.Excludes {
+		fmt.Fprintf(h, "exclude %s\n", pattern)
+	}
+
+	sourceDir, err := filepath.Abs(cfg.SourceDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve source dir: %w", err)
+	}
+	outputPath := ""
+	if cfg.OutputPath != "" {
+		if outputPath, err = filepath.Abs(cfg.OutputPath); err != nil {
+			return "", fmt.Errorf("failed to resolve output path: %w", err)
+		}
+	}
+
+	sources, err := treeFiles(sourceDir, outputPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash source tree: %w", err)
+	}
+	if err := hashFilesInto(h, "src", sourceDir, sources); err != nil {
+		return "", fmt.Errorf("failed to hash source tree: %w", err)
+	}
+
+	deps, err := dependencyFiles(cfg, sourceDir, outputPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash dependencies: %w", err)
+	}
+	// Hash locations relative to the source dir so moving the whole project keeps its cache
+	if err := hashFilesInto(h, "dep", sourceDir, deps); err != nil {
+		return "", fmt.Errorf("failed to hash dependencies: %w", err)
+	}
+
+	if err := hashFilesInto(h, "lock", sourceDir, parentLockfiles(sourceDir)); err != nil {
+		return "", fmt.Errorf("failed to hash lockfile: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// treeFiles returns the files under root in lexical order, skipping
+// cacheIgnoredDirs, the directories in skipDirs, outputPath and Python bytecode
+// written during builds.
+// ACTION: Reads the directory tree.
+func treeFiles(root, outputPath string, skipDirs map[string]bool) ([]string, error) {
+	var files []string
+	// WalkDir visits entries in lexical order, so the hash is deterministic
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.IsDir() {
+			if path != root && (cacheIgnoredDirs[d.Name()] || skipDirs[path]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if path == outputPath || strings.HasSuffix(path, ".pyc") {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	return files, err
+}
+
+// hashFilesInto hashes files into h under kind, named relative to base.
+// ACTION: Reads the files.
+func hashFilesInto(h io.Writer, kind, base string, files []string) error {
+	for _, path := range files {
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		if err := hashFileInto(h, kind, filepath.ToSlash(rel), path, fs.FileInfoToDirEntry(info)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dependencyFiles returns the files outside sourceDir a build of cfg can read:
+// for Go, the files of the local packages go list reports the build importing;
+// for other runtimes, the tree of the outermost workspace above sourceDir, up
+// to the project root, leaving out the project's infra/ directory.
+// ACTION: Reads the directory tree or runs go list.
+func dependencyFiles(cfg Config, sourceDir, outputPath string) ([]string, error) {
+	if isGoRuntime(cfg.Runtime) {
+		return goDependencyFiles(cfg, sourceDir)
+	}
+
+	root := workspaceRoot(sourceDir, cfg.Runtime)
+	if root == "" {
+		return nil, nil
+	}
+	return treeFiles(root, outputPath, map[string]bool{
+		sourceDir:                    true,
+		filepath.Join(root, "infra"): true,
+	})
+}
+
+// isGoRuntime reports whether runtime is built by GoBuild.
+// PURE: Calculation.
+func isGoRuntime(runtime string) bool {
+	return strings.HasPrefix(runtime, "go") || strings.HasPrefix(runtime, "provided")
+}
+
+// workspaceRoot returns the outermost ancestor of sourceDir, up to the project
+// root, holding one of the workspace manifests of runtime's family, or "" if
+// there is none.
+// ACTION: Reads the file system.
+func workspaceRoot(sourceDir, runtime string) string {
+	var manifests []string
+	for family, names := range workspaceManifests {
+		if strings.HasPrefix(runtime, family) {
+			manifests = names
+		}
+	}
+
+	root := ""
+	for dir := sourceDir; ; {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return root
+		}
+		dir = parent
+
+		for _, name := range manifests {
+			if info, err := os.Stat(filepath.Join(dir, name)); err == nil && info.Mode().IsRegular() {
+				root = dir
+				break
+			}
+		}
+		if isProjectRoot(dir) {
+			return root
+		}
+	}
+}
+
+// goDependencyFiles returns the files outside sourceDir of the local packages a
+// Go build of cfg compiles or embeds, plus their modules' go.mod and go.sum.
+// Packages from the module cache are pinned by go.sum already. Without a go.mod
+// or go.work above sourceDir nothing outside it can be imported.
+// ACTION: Runs go list.
+func goDependencyFiles(cfg Config, sourceDir string) ([]string, error) {
+	if !inGoModule(sourceDir) {
+		return nil, nil
+	}
+
+	spec := GenerateGoBuildSpec(cfg, "")
+	cmd := exec.Command("go", "list", "-deps", "-json", "-tags", "lambda.norpc", goBuildTarget(cfg.Handler))
+	cmd.Env = append(os.Environ(), spec.Env...)
+	cmd.Dir = sourceDir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list failed: %w\nOutput: %s", err, stderr.String())
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	add := func(path string) {
+		if path == "" || seen[path] || strings.HasPrefix(path, sourceDir+string(filepath.Separator)) {
+			return
+		}
+		if _, err := os.Lstat(path); err != nil {
+			return
+		}
+		seen[path] = true
+		files = append(files, path)
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(string(output)))
+	for decoder.More() {
+		var pkg goListPackage
+		if err := decoder.Decode(&pkg); err != nil {
+			return nil, fmt.Errorf("failed to parse go list output: %w", err)
+		}
+		if pkg.Standard || (pkg.Module != nil && !pkg.Module.Main && (pkg.Module.Replace == nil || pkg.Module.Replace.Version != "")) {
+			continue
+		}
+		if pkg.Module != nil {
+			add(pkg.Module.GoMod)
+			add(filepath.Join(filepath.Dir(pkg.Module.GoMod), "go.sum"))
+		}
+		for _, group := range [][]string{
+			pkg.GoFiles, pkg.CgoFiles, pkg.CFiles, pkg.CXXFiles, pkg.HFiles, pkg.SFiles, pkg.SysoFiles, pkg.EmbedFiles,
+		} {
+			for _, name := range group {
+				add(filepath.Join(pkg.Dir, name))
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// inGoModule reports whether a go.mod or go.work sits in sourceDir or one of
+// its ancestors, up to the project root.
+// ACTION: Reads the file system.
+func inGoModule(sourceDir string) bool {
+	for dir := sourceDir; ; {
+		for _, name := range []string{"go.mod", "go.work"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || isProjectRoot(dir) {
+			return false
+		}
+		dir = parent
+	}
+}
+
+// hashFileInto writes a file's name, executable bit and content digest to h.
+func hashFileInto(h io.Writer, kind, name, path string, d fs.DirEntry) error {
+	if d.Type()&fs.ModeSymlink != 0 {
+		target, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(h, "%s %s -> %s\n", kind, name, target)
+		return err
+	}
+
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+	checksum, err := calculateChecksum(path)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(h, "%s %s %t %s\n", kind, name, info.Mode().Perm()&0o111 != 0, checksum)
+	return err
+}
+
+// parentLockfiles returns lockfiles found in the ancestors of sourceDir, nearest first.
+// The search stops at the project root (a directory holding .forge or .git).
+func parentLockfiles(sourceDir string) []string {
+	var found []string
+	dir := sourceDir
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return found
+		}
+		dir = parent
+
+		for _, name := range lockfileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+				found = append(found, path)
+			}
+		}
+
+		if isProjectRoot(dir) {
+			return found
+		}
+	}
+}
+
+// isProjectRoot reports whether dir looks like the root of a project.
+func isProjectRoot(dir string) bool {
+	for _, marker := range []string{".forge", ".git"} {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// configIdentity identifies a build request so Set can reuse the key computed by Get.
+func configIdentity(cfg Config) string {
+	return cfg.Runtime + "\x00" + cfg.SourceDir + "\x00" + cfg.OutputPath
+}
+
+// Get restores a cached artifact to cfg.OutputPath if the inputs are unchanged.
+// The key is computed before the build runs and remembered for the matching Set,
+// so files a builder writes into the source tree (e.g. a fresh lockfile) do not
+// make the entry unreachable. Any I/O problem is treated as a cache miss.
+func (c *DiskCache) Get(cfg Config) (Artifact, bool) {
+	key, err := CacheKey(cfg)
+	if err != nil {
+		return Artifact{}, false
+	}
+
+	c.mu.Lock()
+	c.pending[configIdentity(cfg)] = key
+	c.mu.Unlock()
+
+	entryDir := c.entryDir(key)
+	data, err := os.ReadFile(filepath.Join(entryDir, "artifact.json"))
+	if err != nil {
+		return Artifact{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Artifact{}, false
+	}
+
+	cachedPath := filepath.Join(entryDir, entry.File)
+	if checksum, err := calculateChecksum(cachedPath); err != nil || checksum != entry.Checksum {
+		return Artifact{}, false
+	}
+
+	outputPath := cfg.OutputPath
+	if outputPath == "" {
+		outputPath = cachedPath
+	} else if checksum, err := calculateChecksum(outputPath); err != nil || checksum != entry.Checksum {
+		if err := copyFileAtomic(cachedPath, outputPath); err != nil {
+			return Artifact{}, false
+		}
+	}
+
+	return Artifact{
+		Path:     outputPath,
+		Checksum: entry.Checksum,
+		Size:     entry.Size,
+	}, true
+}
+
+// Forget drops the key Get remembered for cfg, for a build that failed.
+func (c *DiskCache) Forget(cfg Config) {
+	c.mu.Lock()
+	delete(c.pending, configIdentity(cfg))
+	c.mu.Unlock()
+}
+
+// Set stores a copy of a successfully built artifact. Failures are ignored:
+// the cache is an optimization and must never fail a build.
+func (c *DiskCache) Set(cfg Config, artifact Artifact) {
+	identity := configIdentity(cfg)
+
+	c.mu.Lock()
+	key, ok := c.pending[identity]
+	delete(c.pending, identity)
+	c.mu.Unlock()
+
+	if !ok {
+		var err error
+		if key, err = CacheKey(cfg); err != nil {
+			return
+		}
+	}
+
+	//nolint:errcheck // Best-effort write, see doc comment
+	_ = c.store(key, artifact)
+}
+
+// store writes an entry into a temp dir and renames it into place atomically.
+func (c *DiskCache) store(key string, artifact Artifact) error {
+	entryDir := c.entryDir(key)
+	if _, err := os.Stat(entryDir); err == nil {
+		return nil
+	}
+
+	parent := filepath.Dir(entryDir)
+	if err := os.MkdirAll(parent, 0o755); err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(parent, ".tmp-"+key[:8]+"-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		//nolint:errcheck // Cleanup of leftovers after a failed or lost rename
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	entry := cacheEntry{
+		File:     filepath.Base(artifact.Path),
+		Checksum: artifact.Checksum,
+		Size:     artifact.Size,
+	}
+	if err := copyFile(artifact.Path, filepath.Join(tmpDir, entry.File)); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "artifact.json"), data, 0o644); err != nil {
+		return err
+	}
+
+	// Another worker may have stored the same key concurrently; either copy is valid
+	return os.Rename(tmpDir, entryDir)
+}
+
+// entryDir returns the directory holding the entry for key.
+func (c *DiskCache) entryDir(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+// copyFile copies src to dst, preserving the file mode.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		//nolint:errcheck // Read-only file
+		_ = in.Close()
+	}()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		//nolint:errcheck // Already returning the copy error
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// copyFileAtomic copies src to dst via a temp file so readers never see a partial artifact.
+func copyFileAtomic(src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	tmp := dst + ".tmp"
+	if err := copyFile(src, tmp); err != nil {
+		//nolint:errcheck // Best-effort cleanup
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
@@ -0,0 +1,292 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCacheFixture creates a project with one function and returns its build config.
+func writeCacheFixture(t *testing.T) (string, Config) {
+	t.Helper()
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".forge"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "go.sum"), []byte("example.com/dep v1.0.0 h1:abc\n"), 0o644))
+
+	sourceDir := filepath.Join(projectDir, "src", "functions", "api")
+	require.NoError(t, os.MkdirAll(sourceDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "main.go"), []byte("package main\n"), 0o644))
+
+	return projectDir, Config{
+		SourceDir:  sourceDir,
+		OutputPath: filepath.Join(projectDir, ".forge", "build", "api.zip"),
+		Runtime:    "provided.al2023",
+		Handler:    "bootstrap",
+		Env:        map[string]string{"CGO_ENABLED": "0"},
+	}
+}
+
+// fakeZipBuild writes a fake artifact to cfg.OutputPath and counts invocations.
+func fakeZipBuild(calls *int) BuildFunc {
+	return func(_ context.Context, cfg Config) E.Either[error, Artifact] {
+		*calls++
+		if err := os.MkdirAll(filepath.Dir(cfg.OutputPath), 0o755); err != nil {
+			return E.Left[Artifact](err)
+		}
+		if err := os.WriteFile(cfg.OutputPath, []byte("zip-contents"), 0o644); err != nil {
+			return E.Left[Artifact](err)
+		}
+		checksum, err := calculateChecksum(cfg.OutputPath)
+		if err != nil {
+			return E.Left[Artifact](err)
+		}
+		return E.Right[error](Artifact{Path: cfg.OutputPath, Checksum: checksum, Size: 12})
+	}
+}
+
+// TestCacheKey tests which inputs change the content hash.
+func TestCacheKey(t *testing.T) {
+	projectDir, cfg := writeCacheFixture(t)
+
+	base, err := CacheKey(cfg)
+	require.NoError(t, err)
+
+	t.Run("is stable for unchanged inputs", func(t *testing.T) {
+		again, err := CacheKey(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, base, again)
+	})
+
+	t.Run("ignores env map ordering and generated directories", func(t *testing.T) {
+		nodeModules := filepath.Join(cfg.SourceDir, "node_modules", "dep")
+		require.NoError(t, os.MkdirAll(nodeModules, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(nodeModules, "index.js"), []byte("x"), 0o644))
+		t.Cleanup(func() { _ = os.RemoveAll(filepath.Join(cfg.SourceDir, "node_modules")) })
+
+		key, err := CacheKey(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, base, key)
+	})
+
+	changes := []struct {
+		name   string
+		mutate func(Config) Config
+	}{
+		{"runtime", func(c Config) Config { c.Runtime = "provided.al2"; return c }},
+		{"handler", func(c Config) Config { c.Handler = "main"; return c }},
+		{"architecture", func(c Config) Config { c.Architecture = ArchitectureARM64; return c }},
+		{"env", func(c Config) Config { c.Env = map[string]string{"CGO_ENABLED": "1"}; return c }},
+	}
+	for _, tc := range changes {
+		t.Run("changes with "+tc.name, func(t *testing.T) {
+			key, err := CacheKey(tc.mutate(cfg))
+			require.NoError(t, err)
+			assert.NotEqual(t, base, key)
+		})
+	}
+
+	t.Run("changes with source contents", func(t *testing.T) {
+		path := filepath.Join(cfg.SourceDir, "main.go")
+		require.NoError(t, os.WriteFile(path, []byte("package main\n\nfunc main() {}\n"), 0o644))
+		t.Cleanup(func() { _ = os.WriteFile(path, []byte("package main\n"), 0o644) })
+
+		key, err := CacheKey(cfg)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, key)
+	})
+
+	t.Run("changes with a lockfile above the source dir", func(t *testing.T) {
+		path := filepath.Join(projectDir, "go.sum")
+		require.NoError(t, os.WriteFile(path, []byte("example.com/dep v1.1.0 h1:def\n"), 0o644))
+		t.Cleanup(func() { _ = os.WriteFile(path, []byte("example.com/dep v1.0.0 h1:abc\n"), 0o644) })
+
+		key, err := CacheKey(cfg)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, key)
+	})
+
+	t.Run("fails for a missing source dir", func(t *testing.T) {
+		_, err := CacheKey(Config{SourceDir: filepath.Join(projectDir, "missing")})
+		assert.Error(t, err)
+	})
+}
+
+// TestCacheKeyDependencies tests that sources outside the function dir feed the key.
+func TestCacheKeyDependencies(t *testing.T) {
+	write := func(t *testing.T, path, content string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	t.Run("changes with a local Go package the function imports", func(t *testing.T) {
+		if _, err := exec.LookPath("go"); err != nil {
+			t.Skip("go toolchain not installed")
+		}
+		projectDir, cfg := writeCacheFixture(t)
+		write(t, filepath.Join(projectDir, "go.mod"), "module example.com/app\n\ngo 1.21\n")
+		write(t, filepath.Join(projectDir, "internal", "shared", "shared.go"), "package shared\n\nconst Name = \"a\"\n")
+		write(t, filepath.Join(projectDir, "internal", "unused", "unused.go"), "package unused\n")
+		write(t, filepath.Join(cfg.SourceDir, "main.go"),
+			"package main\n\nimport \"example.com/app/internal/shared\"\n\nfunc main() { println(shared.Name) }\n")
+
+		base, err := CacheKey(cfg)
+		require.NoError(t, err)
+
+		write(t, filepath.Join(projectDir, "internal", "unused", "unused.go"), "package unused\n\nconst X = 1\n")
+		key, err := CacheKey(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, base, key, "packages the function does not import should not feed the key")
+
+		write(t, filepath.Join(projectDir, "internal", "shared", "shared.go"), "package shared\n\nconst Name = \"b\"\n")
+		key, err = CacheKey(cfg)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, key)
+	})
+
+	t.Run("changes with a sibling package in a node workspace", func(t *testing.T) {
+		projectDir, cfg := writeCacheFixture(t)
+		cfg.Runtime = "nodejs20.x"
+		cfg.Handler = "index.handler"
+		write(t, filepath.Join(projectDir, "package.json"), `{"workspaces": ["src/*"]}`)
+		write(t, filepath.Join(projectDir, "src", "shared", "index.js"), "module.exports = 1\n")
+		write(t, filepath.Join(projectDir, "infra", "tfplan"), "plan-1")
+
+		base, err := CacheKey(cfg)
+		require.NoError(t, err)
+
+		write(t, filepath.Join(projectDir, "infra", "tfplan"), "plan-2")
+		key, err := CacheKey(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, base, key, "infra/ should not feed the key")
+
+		write(t, filepath.Join(projectDir, "src", "shared", "index.js"), "module.exports = 2\n")
+		key, err = CacheKey(cfg)
+		require.NoError(t, err)
+		assert.NotEqual(t, base, key)
+	})
+}
+
+// TestDiskCache tests storing and restoring artifacts on disk.
+func TestDiskCache(t *testing.T) {
+	t.Run("skips unchanged functions and restores the artifact", func(t *testing.T) {
+		projectDir, cfg := writeCacheFixture(t)
+		calls := 0
+		cachedBuild := WithCache(NewDiskCache(DefaultCacheDir(projectDir)))(fakeZipBuild(&calls))
+
+		first := cachedBuild(t.Context(), cfg)
+		require.True(t, E.IsRight(first))
+		built := E.GetOrElse(func(error) Artifact { return Artifact{} })(first)
+		assert.False(t, built.Cached)
+
+		// Simulate a clean build directory
+		require.NoError(t, os.RemoveAll(filepath.Join(projectDir, ".forge", "build")))
+
+		second := cachedBuild(t.Context(), cfg)
+		require.True(t, E.IsRight(second))
+		restored := E.GetOrElse(func(error) Artifact { return Artifact{} })(second)
+
+		assert.Equal(t, 1, calls, "second build should be served from cache")
+		assert.True(t, restored.Cached)
+		assert.Equal(t, cfg.OutputPath, restored.Path)
+		assert.Equal(t, built.Checksum, restored.Checksum)
+		assert.Equal(t, built.Size, restored.Size)
+
+		checksum, err := calculateChecksum(cfg.OutputPath)
+		require.NoError(t, err)
+		assert.Equal(t, built.Checksum, checksum, "artifact should be restored to the output path")
+	})
+
+	t.Run("persists across cache instances", func(t *testing.T) {
+		projectDir, cfg := writeCacheFixture(t)
+		calls := 0
+
+		WithCache(NewDiskCache(DefaultCacheDir(projectDir)))(fakeZipBuild(&calls))(t.Context(), cfg)
+		WithCache(NewDiskCache(DefaultCacheDir(projectDir)))(fakeZipBuild(&calls))(t.Context(), cfg)
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("rebuilds when sources change", func(t *testing.T) {
+		projectDir, cfg := writeCacheFixture(t)
+		calls := 0
+		cachedBuild := WithCache(NewDiskCache(DefaultCacheDir(projectDir)))(fakeZipBuild(&calls))
+
+		cachedBuild(t.Context(), cfg)
+		require.NoError(t, os.WriteFile(filepath.Join(cfg.SourceDir, "util.go"), []byte("package main\n"), 0o644))
+		cachedBuild(t.Context(), cfg)
+
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("keys entries by inputs seen before the build ran", func(t *testing.T) {
+		projectDir, cfg := writeCacheFixture(t)
+		calls := 0
+		// Builder that writes a lockfile into the source tree, like npm install
+		writesLockfile := func(ctx context.Context, c Config) E.Either[error, Artifact] {
+			if err := os.WriteFile(filepath.Join(c.SourceDir, "package-lock.json"), []byte("{}"), 0o644); err != nil {
+				return E.Left[Artifact](err)
+			}
+			return fakeZipBuild(&calls)(ctx, c)
+		}
+		cachedBuild := WithCache(NewDiskCache(DefaultCacheDir(projectDir)))(writesLockfile)
+
+		cachedBuild(t.Context(), cfg)
+		require.NoError(t, os.Remove(filepath.Join(cfg.SourceDir, "package-lock.json")))
+		cachedBuild(t.Context(), cfg)
+
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("forgets the pending key when the build fails", func(t *testing.T) {
+		projectDir, cfg := writeCacheFixture(t)
+		cache := NewDiskCache(DefaultCacheDir(projectDir))
+		failing := func(context.Context, Config) E.Either[error, Artifact] {
+			return E.Left[Artifact](errors.New("compile error"))
+		}
+
+		result := WithCache(cache)(failing)(t.Context(), cfg)
+
+		require.True(t, E.IsLeft(result))
+		assert.Empty(t, cache.pending)
+	})
+
+	t.Run("treats a corrupted entry as a miss", func(t *testing.T) {
+		projectDir, cfg := writeCacheFixture(t)
+		calls := 0
+		cacheDir := DefaultCacheDir(projectDir)
+		cachedBuild := WithCache(NewDiskCache(cacheDir))(fakeZipBuild(&calls))
+
+		cachedBuild(t.Context(), cfg)
+
+		key, err := CacheKey(cfg)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(cacheDir, key[:2], key, "api.zip"), []byte("tampered"), 0o644))
+
+		cachedBuild(t.Context(), cfg)
+		assert.Equal(t, 2, calls)
+	})
+}
+
+// TestCachedRegistry tests wrapping a registry with a cache.
+func TestCachedRegistry(t *testing.T) {
+	projectDir, cfg := writeCacheFixture(t)
+	calls := 0
+	registry := Registry{cfg.Runtime: fakeZipBuild(&calls)}
+
+	cached := CachedRegistry(registry, NewDiskCache(DefaultCacheDir(projectDir)))
+	results := BuildConcurrent(t.Context(), []Config{cfg}, cached, 1, nil)
+	results = append(results, BuildConcurrent(t.Context(), []Config{cfg}, cached, 1, nil)...)
+
+	assert.Equal(t, 1, calls)
+	assert.True(t, E.IsRight(results[1].Result))
+	assert.Len(t, registry, 1, "input registry should be untouched")
+}
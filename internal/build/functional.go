@@ -2,9 +2,7 @@ package build
 
 import (
 	"context"
-	"fmt"
 
-	A "github.com/IBM/fp-go/array"
 	E "github.com/IBM/fp-go/either"
 	O "github.com/IBM/fp-go/option"
 	"github.com/samber/lo"
@@ -27,6 +25,13 @@ type (
 		Set(cfg Config, artifact Artifact)
 	}
 
+	// forgetter is implemented by caches that hold state between the Get that
+	// missed and the Set of the build that follows, so that a failed build
+	// releases it.
+	forgetter interface {
+		Forget(cfg Config)
+	}
+
 	// Logger provides structured logging capabilities.
 	Logger interface {
 		Info(msg string, args ...interface{})
@@ -70,49 +75,15 @@ func GetBuilder(r Registry, runtime string) O.Option[BuildFunc] {
 	return O.None[BuildFunc]()
 }
 
-// BuildAll builds multiple configs using functional sequence pattern.
-// Uses Array.Reduce to convert []Either[error, Artifact] to Either[error, []Artifact].
-// Automatically short-circuits on first error (railway-oriented programming).
+// BuildAll builds multiple configs concurrently with one worker per CPU.
+// Artifacts are returned in config order; if any build fails, all failures are joined.
 func BuildAll(ctx context.Context, configs []Config, registry Registry) E.Either[error, []Artifact] {
-	// Map configs to build results ([]Either[error, Artifact])
-	buildResults := A.Map(func(cfg Config) E.Either[error, Artifact] {
-		builderOpt := GetBuilder(registry, cfg.Runtime)
-
-		return O.Fold(
-			// None case: runtime not found
-			func() E.Either[error, Artifact] {
-				return E.Left[Artifact](fmt.Errorf("unsupported runtime: %s", cfg.Runtime))
-			},
-			// Some case: execute builder
-			func(builder BuildFunc) E.Either[error, Artifact] {
-				return builder(ctx, cfg)
-			},
-		)(builderOpt)
-	})(configs)
-
-	// Use functional sequence to convert []Either[error, Artifact] → Either[error, []Artifact]
-	// This automatically short-circuits on first error
-	return sequenceEithers(buildResults)
+	return BuildAllWithJobs(ctx, configs, registry, DefaultJobs())
 }
 
-// sequenceEithers converts []Either[E, A] to Either[E, []A].
-// Short-circuits on first Left (error), otherwise collects all Right values.
-// PURE: Calculation - deterministic transformation.
-func sequenceEithers(eithers []E.Either[error, Artifact]) E.Either[error, []Artifact] {
-	return A.Reduce(
-		func(acc E.Either[error, []Artifact], item E.Either[error, Artifact]) E.Either[error, []Artifact] {
-			// Use E.Chain for railway-oriented programming
-			// If acc is Left (error), it stays Left
-			// If acc is Right and item is Left, it becomes Left
-			// If both are Right, append item to acc
-			return E.Chain(func(artifacts []Artifact) E.Either[error, []Artifact] {
-				return E.Map[error](func(artifact Artifact) []Artifact {
-					return append(artifacts, artifact)
-				})(item)
-			})(acc)
-		},
-		E.Right[error]([]Artifact{}), // Start with empty Right
-	)(eithers)
+// BuildAllWithJobs builds multiple configs on a bounded pool of jobs workers.
+func BuildAllWithJobs(ctx context.Context, configs []Config, registry Registry, jobs int) E.Either[error, []Artifact] {
+	return CollectResults(BuildConcurrent(ctx, configs, registry, jobs, nil))
 }
 
 // WithCache is a higher-order function that adds caching to a builder.
@@ -121,6 +92,7 @@ func WithCache(cache Cache) func(BuildFunc) BuildFunc {
 		return func(ctx context.Context, cfg Config) E.Either[error, Artifact] {
 			// Check cache
 			if artifact, ok := cache.Get(cfg); ok {
+				artifact.Cached = true
 				return E.Right[error](artifact)
 			}
 
@@ -131,7 +103,10 @@ func WithCache(cache Cache) func(BuildFunc) BuildFunc {
 			//nolint:errcheck // Fold return value is used for side effect only (caching)
 			E.Fold(
 				func(err error) error {
-					// Left case (error) - do nothing
+					// Left case (error) - release what Get remembered for the build
+					if f, ok := cache.(forgetter); ok {
+						f.Forget(cfg)
+					}
 					return err
 				},
 				func(artifact Artifact) error {
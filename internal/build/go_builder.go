@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	E "github.com/IBM/fp-go/either"
 )
@@ -16,31 +17,39 @@ type (
 		Command    []string
 		Env        []string
 		WorkDir    string
-		OutputPath string
+		BinaryPath string // Compiled bootstrap binary (scratch location)
+		OutputPath string // Deployment zip containing bootstrap
 	}
 )
 
+// goBootstrapName is the executable name required by the provided.* runtimes.
+const goBootstrapName = "bootstrap"
+
 // GenerateGoBuildSpec creates a build specification from config.
+// binaryPath is where the compiled bootstrap is written before it is zipped.
 // PURE: Calculation - same inputs always produce same outputs.
-func GenerateGoBuildSpec(cfg Config) GoBuildSpec {
+func GenerateGoBuildSpec(cfg Config, binaryPath string) GoBuildSpec {
 	outputPath := cfg.OutputPath
 	if outputPath == "" {
-		outputPath = filepath.Join(cfg.SourceDir, "bootstrap")
+		outputPath = filepath.Join(cfg.SourceDir, goBootstrapName+".zip")
 	}
 
-	// Build command arguments
+	// Build command arguments (-trimpath and -buildvcs=false keep the binary
+	// independent of checkout location and git state, so zips are reproducible)
 	command := []string{
 		"go", "build",
+		"-trimpath",
+		"-buildvcs=false",
 		"-tags", "lambda.norpc",
 		"-ldflags", "-s -w",
-		"-o", outputPath,
-		"./" + cfg.Handler,
+		"-o", binaryPath,
+		goBuildTarget(cfg.Handler),
 	}
 
-	// Set environment for Lambda (Linux AMD64)
+	// Set environment for Lambda (Linux on the function's architecture)
 	env := []string{
 		"GOOS=linux",
-		"GOARCH=amd64",
+		"GOARCH=" + goArch(cfg.Architecture),
 		"CGO_ENABLED=0",
 	}
 
@@ -53,10 +62,22 @@ func GenerateGoBuildSpec(cfg Config) GoBuildSpec {
 		Command:    command,
 		Env:        env,
 		WorkDir:    cfg.SourceDir,
+		BinaryPath: binaryPath,
 		OutputPath: outputPath,
 	}
 }
 
+// goBuildTarget maps a handler to the package passed to go build.
+// The Lambda handler for Go is the bootstrap binary itself, and entry files
+// like main.go name the package in SourceDir; anything else is a sub-package.
+// PURE: Calculation.
+func goBuildTarget(handler string) string {
+	if handler == "" || handler == "." || handler == goBootstrapName || strings.HasSuffix(handler, ".go") {
+		return "."
+	}
+	return "./" + strings.TrimPrefix(handler, "./")
+}
+
 // ExecuteGoBuildSpec executes a build specification using functional composition.
 // ACTION: Performs I/O operations (file system, process execution).
 func ExecuteGoBuildSpec(ctx context.Context, spec GoBuildSpec) E.Either[error, Artifact] {
@@ -64,21 +85,45 @@ func ExecuteGoBuildSpec(ctx context.Context, spec GoBuildSpec) E.Either[error, A
 	return E.Chain(func(_ struct{}) E.Either[error, Artifact] {
 		// I/O: Execute build command
 		return E.Chain(func(_ struct{}) E.Either[error, Artifact] {
-			// I/O: Calculate checksum
-			return E.Chain(func(checksum string) E.Either[error, Artifact] {
-				// I/O: Get file size and create artifact
-				return E.Map[error](func(size int64) Artifact {
-					return Artifact{
-						Path:     spec.OutputPath,
-						Checksum: checksum,
-						Size:     size,
-					}
-				})(ensureFileSize(spec.OutputPath))
-			})(ensureChecksum(spec.OutputPath))
+			// I/O: Package bootstrap into the deployment zip
+			return E.Chain(func(_ struct{}) E.Either[error, Artifact] {
+				// I/O: Calculate checksum over the zip
+				return E.Chain(func(checksum string) E.Either[error, Artifact] {
+					// I/O: Get file size and create artifact
+					return E.Map[error](func(size int64) Artifact {
+						return Artifact{
+							Path:     spec.OutputPath,
+							Checksum: checksum,
+							Size:     size,
+						}
+					})(ensureFileSize(spec.OutputPath))
+				})(ensureChecksum(spec.OutputPath))
+			})(ensureBootstrapZip(spec.BinaryPath, spec.OutputPath))
 		})(ensureCommand(ctx, spec.Command, spec.Env, spec.WorkDir))
 	})(ensureOutputDir(spec.OutputPath))
 }
 
+// ensureBootstrapZip packages the compiled binary as an executable "bootstrap" entry.
+// ACTION: I/O operation wrapped in Either monad.
+func ensureBootstrapZip(binaryPath, zipPath string) E.Either[error, struct{}] {
+	if err := writeBootstrapZip(binaryPath, zipPath); err != nil {
+		return E.Left[struct{}](fmt.Errorf("failed to package bootstrap: %w", err))
+	}
+	return E.Right[error](struct{}{})
+}
+
+// writeBootstrapZip writes a deterministic zip holding binaryPath as "bootstrap".
+// The entry is always marked executable, which Lambda requires.
+// ACTION: Performs I/O (file reads, zip writes).
+func writeBootstrapZip(binaryPath, zipPath string) error {
+	entry, err := FileZipEntry(binaryPath, goBootstrapName)
+	if err != nil {
+		return err
+	}
+	entry.Mode = 0o755 //nolint:mnd // Lambda requires bootstrap to be executable
+	return WriteDeterministicZip(zipPath, []ZipEntry{entry})
+}
+
 // ensureOutputDir creates output directory and returns unit Either.
 // ACTION: I/O operation wrapped in Either monad.
 func ensureOutputDir(outputPath string) E.Either[error, struct{}] {
@@ -118,8 +163,19 @@ func ensureFileSize(path string) E.Either[error, int64] {
 }
 
 // GoBuild composes pure specification generation with impure execution.
+// The binary is compiled into a scratch directory and only the zip is kept.
 // COMPOSITION: Pure core + Imperative shell.
 func GoBuild(ctx context.Context, cfg Config) E.Either[error, Artifact] {
-	spec := GenerateGoBuildSpec(cfg)     // PURE: Calculation
-	return ExecuteGoBuildSpec(ctx, spec) // ACTION: I/O
+	// I/O: Scratch directory for the bootstrap binary
+	tmpDir, err := os.MkdirTemp("", "forge-go-*")
+	if err != nil {
+		return E.Left[Artifact](fmt.Errorf("failed to create temp dir: %w", err))
+	}
+	defer func() {
+		//nolint:errcheck // Best-effort cleanup of scratch directory
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	spec := GenerateGoBuildSpec(cfg, filepath.Join(tmpDir, goBootstrapName)) // PURE: Calculation
+	return ExecuteGoBuildSpec(ctx, spec)                                     // ACTION: I/O
 }
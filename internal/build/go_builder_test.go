@@ -1,6 +1,7 @@
 package build
 
 import (
+	"archive/zip"
 	"os"
 	"path/filepath"
 	"testing"
@@ -179,12 +180,12 @@ go 1.21
 		err = os.WriteFile(modPath, []byte(modContent), 0o644)
 		require.NoError(t, err)
 
-		outputPath := filepath.Join(tmpDir, "bootstrap")
+		outputPath := filepath.Join(tmpDir, "build", "api.zip")
 		cfg := Config{
 			SourceDir:  tmpDir,
 			OutputPath: outputPath,
 			Runtime:    "provided.al2023",
-			Handler:    ".",
+			Handler:    "bootstrap",
 		}
 
 		result := GoBuild(t.Context(), cfg)
@@ -202,9 +203,22 @@ go 1.21
 		assert.NotEmpty(t, artifact.Checksum, "Should have checksum")
 		assert.Positive(t, artifact.Size, "Should have non-zero size")
 
-		// Verify binary exists
-		_, err = os.Stat(outputPath)
-		assert.NoError(t, err, "Binary should exist")
+		// Verify the zip holds an executable bootstrap and nothing else
+		reader, err := zip.OpenReader(outputPath)
+		require.NoError(t, err, "Output should be a zip archive")
+		defer func() { _ = reader.Close() }()
+
+		require.Len(t, reader.File, 1)
+		assert.Equal(t, "bootstrap", reader.File[0].Name)
+		assert.Equal(t, os.FileMode(0o755), reader.File[0].Mode().Perm(), "bootstrap must be executable")
+
+		// Checksum is computed over the zip, not the binary
+		checksum, err := calculateChecksum(outputPath)
+		require.NoError(t, err)
+		assert.Equal(t, checksum, artifact.Checksum)
+
+		// The binary is compiled to a scratch dir, not left next to the sources
+		assert.NoFileExists(t, filepath.Join(tmpDir, "bootstrap"))
 	})
 }
 
@@ -274,9 +288,10 @@ func TestGoBuildOutputPath(t *testing.T) {
 			Handler:    ".",
 		}
 
-		// Default should be bootstrap in source directory
-		expectedPath := filepath.Join(cfg.SourceDir, "bootstrap")
-		assert.Contains(t, expectedPath, "bootstrap")
+		// Default should be bootstrap.zip in source directory
+		spec := GenerateGoBuildSpec(cfg, "/scratch/bootstrap")
+		assert.Equal(t, filepath.Join(cfg.SourceDir, "bootstrap.zip"), spec.OutputPath)
+		assert.Equal(t, "/scratch/bootstrap", spec.BinaryPath)
 	})
 
 	t.Run("respects custom output path", func(t *testing.T) {
@@ -292,6 +307,83 @@ func TestGoBuildOutputPath(t *testing.T) {
 	})
 }
 
+// TestGoBuildReproducible tests that identical sources yield identical zips.
+func TestGoBuildReproducible(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n\nfunc main() {}\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/lambda\n\ngo 1.21\n"), 0o644))
+
+	build := func(name string) Artifact {
+		result := GoBuild(t.Context(), Config{
+			SourceDir:  tmpDir,
+			OutputPath: filepath.Join(t.TempDir(), name),
+			Runtime:    "provided.al2023",
+			Handler:    "bootstrap",
+		})
+		require.True(t, E.IsRight(result))
+		return E.GetOrElse(func(error) Artifact { return Artifact{} })(result)
+	}
+
+	first := build("first.zip")
+	second := build("second.zip")
+
+	assert.Equal(t, first.Checksum, second.Checksum)
+	assert.Equal(t, first.Size, second.Size)
+}
+
+// TestGenerateGoBuildSpec tests the pure Go build specification.
+func TestGenerateGoBuildSpec(t *testing.T) {
+	t.Run("compiles to the binary path, not the zip", func(t *testing.T) {
+		cfg := Config{
+			SourceDir:  "/src/functions/api",
+			OutputPath: "/project/.forge/build/api.zip",
+			Handler:    "bootstrap",
+		}
+
+		spec := GenerateGoBuildSpec(cfg, "/tmp/forge-go-1/bootstrap")
+
+		assert.Equal(t, []string{
+			"go", "build",
+			"-trimpath",
+			"-buildvcs=false",
+			"-tags", "lambda.norpc",
+			"-ldflags", "-s -w",
+			"-o", "/tmp/forge-go-1/bootstrap",
+			".",
+		}, spec.Command)
+		assert.Equal(t, "/project/.forge/build/api.zip", spec.OutputPath)
+		assert.Equal(t, "/src/functions/api", spec.WorkDir)
+	})
+
+	t.Run("maps handlers to build targets", func(t *testing.T) {
+		cases := map[string]string{
+			"":           ".",
+			".":          ".",
+			"bootstrap":  ".",
+			"main.go":    ".",
+			"cmd/lambda": "./cmd/lambda",
+			"./cmd/api":  "./cmd/api",
+		}
+		for handler, want := range cases {
+			spec := GenerateGoBuildSpec(Config{Handler: handler}, "bootstrap")
+			assert.Equal(t, want, spec.Command[len(spec.Command)-1], "handler %q", handler)
+		}
+	})
+
+	t.Run("targets GOARCH from the architecture", func(t *testing.T) {
+		cases := map[string]string{
+			"":       "GOARCH=amd64",
+			"x86_64": "GOARCH=amd64",
+			"arm64":  "GOARCH=arm64",
+		}
+		for arch, want := range cases {
+			spec := GenerateGoBuildSpec(Config{Architecture: arch}, "bootstrap")
+			assert.Contains(t, spec.Env, want, "architecture %q", arch)
+			assert.Contains(t, spec.Env, "GOOS=linux")
+		}
+	})
+}
+
 // TestGoBuildEnvironment tests environment variable handling.
 func TestGoBuildEnvironment(t *testing.T) {
 	t.Run("sets required Lambda environment variables", func(t *testing.T) {
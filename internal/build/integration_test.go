@@ -4,6 +4,7 @@
 package build
 
 import (
+	"archive/zip"
 	"context"
 	"os"
 	"path/filepath"
@@ -78,7 +79,7 @@ go 1.22
 		require.NoError(t, err)
 		cfg := Config{
 			SourceDir:  tmpDir,
-			OutputPath: filepath.Join(tmpDir, "bootstrap"),
+			OutputPath: filepath.Join(tmpDir, "bootstrap.zip"),
 			Runtime:    "go1.x",
 		}
 
@@ -104,14 +105,17 @@ go 1.22
 		assert.Greater(t, artifact.Size, int64(0))
 		assert.NotEmpty(t, artifact.Checksum)
 
-		// Verify binary is executable
-		info, err := os.Stat(artifact.Path)
+		// Verify bootstrap inside the zip is executable
+		reader, err := zip.OpenReader(artifact.Path)
 		require.NoError(t, err)
-		assert.True(t, info.Mode()&0111 != 0, "Binary should be executable")
+		defer reader.Close()
+		require.Len(t, reader.File, 1)
+		assert.Equal(t, "bootstrap", reader.File[0].Name)
+		assert.True(t, reader.File[0].Mode()&0111 != 0, "bootstrap should be executable")
 	})
 
 	t.Run("builds with custom output path", func(t *testing.T) {
-		customPath := filepath.Join(tmpDir, "custom-output")
+		customPath := filepath.Join(tmpDir, "custom-output.zip")
 		cfg := Config{
 			SourceDir:  tmpDir,
 			OutputPath: customPath,
@@ -157,7 +161,7 @@ go 1.22
 	t.Run("fails with invalid Go code", func(t *testing.T) {
 		cfg := Config{
 			SourceDir:  tmpDir,
-			OutputPath: filepath.Join(tmpDir, "bootstrap"),
+			OutputPath: filepath.Join(tmpDir, "bootstrap.zip"),
 			Runtime:    "go1.x",
 		}
 
@@ -345,7 +349,7 @@ go 1.22
 	t.Run("cache speeds up second build", func(t *testing.T) {
 		cfg := Config{
 			SourceDir:  tmpDir,
-			OutputPath: filepath.Join(tmpDir, "bootstrap"),
+			OutputPath: filepath.Join(tmpDir, "bootstrap.zip"),
 			Runtime:    "go1.x",
 		}
 
@@ -64,20 +64,12 @@ func ExecuteJavaBuildSpec(ctx context.Context, spec JavaBuildSpec) E.Either[erro
 			return Artifact{}, fmt.Errorf("failed to find jar: %w", err)
 		}
 
-		// I/O: Copy to output path if different
-		if jarPath != spec.OutputPath {
-			if err := os.MkdirAll(filepath.Dir(spec.OutputPath), 0o755); err != nil { //nolint:mnd // Standard directory permission
-				return Artifact{}, fmt.Errorf("failed to create output directory: %w", err)
-			}
-
-			input, err := os.ReadFile(jarPath)
-			if err != nil {
-				return Artifact{}, fmt.Errorf("failed to read jar: %w", err)
-			}
-
-			if err := os.WriteFile(spec.OutputPath, input, 0o644); err != nil {
-				return Artifact{}, fmt.Errorf("failed to write jar: %w", err)
-			}
+		// I/O: Rewrite the jar deterministically (Maven stamps build-time mtimes)
+		if err := os.MkdirAll(filepath.Dir(spec.OutputPath), 0o755); err != nil { //nolint:mnd // Standard directory permission
+			return Artifact{}, fmt.Errorf("failed to create output directory: %w", err)
+		}
+		if err := NormalizeZip(jarPath, spec.OutputPath); err != nil {
+			return Artifact{}, fmt.Errorf("failed to package jar: %w", err)
 		}
 
 		// I/O: Calculate checksum
@@ -1,7 +1,6 @@
 package build
 
 import (
-	"archive/zip"
 	"context"
 	"fmt"
 	"os"
@@ -28,6 +27,7 @@ type (
 		Env            []string
 		InstallCmd     []string // npm install command
 		BuildCmd       []string // npm run build command (for TypeScript)
+		Excludes       []string // Zip exclusion patterns
 	}
 )
 
@@ -57,9 +57,24 @@ func GenerateNodeBuildSpec(cfg Config, hasPackageJSON, hasTypeScript bool) NodeB
 		SourceDir:      cfg.SourceDir,
 		HasPackageJSON: hasPackageJSON,
 		HasTypeScript:  hasTypeScript,
-		Env:            envSlice(cfg.Env),
+		Env:            append(nodeArchEnv(cfg.Architecture), envSlice(cfg.Env)...),
 		InstallCmd:     installCmd,
 		BuildCmd:       buildCmd,
+		Excludes:       zipExcludes(cfg),
+	}
+}
+
+// nodeArchEnv returns npm settings that make install scripts and optional
+// dependencies (node-gyp, prebuild-install, esbuild-style platform packages)
+// fetch or compile native modules for Lambda's OS and architecture, not the host's.
+// PURE: Calculation.
+func nodeArchEnv(arch string) []string {
+	cpu := nodeArch(arch)
+	return []string{
+		"npm_config_os=linux",
+		"npm_config_platform=linux",
+		"npm_config_cpu=" + cpu,
+		"npm_config_arch=" + cpu,
 	}
 }
 
@@ -81,23 +96,9 @@ func ExecuteNodeBuildSpec(ctx context.Context, spec NodeBuildSpec) E.Either[erro
 			}
 		}
 
-		// I/O: Create zip file
-		zipFile, err := os.Create(spec.OutputPath)
-		if err != nil {
-			return Artifact{}, fmt.Errorf("failed to create zip: %w", err)
-		}
-		zipWriter := zip.NewWriter(zipFile)
-		// I/O: Add source code and node_modules
-		if err := addDirToZip(zipWriter, spec.SourceDir, ""); err != nil {
-			return Artifact{}, fmt.Errorf("failed to add source code: %w", err)
-		}
-
-		// I/O: Close zip before calculating checksum
-		if err := zipWriter.Close(); err != nil {
-			return Artifact{}, fmt.Errorf("failed to close zip writer: %w", err)
-		}
-		if err := zipFile.Close(); err != nil {
-			return Artifact{}, fmt.Errorf("failed to close zip file: %w", err)
+		// I/O: Package source code and node_modules
+		if err := WriteDirZip(spec.OutputPath, []string{spec.SourceDir}, spec.Excludes); err != nil {
+			return Artifact{}, fmt.Errorf("failed to package function: %w", err)
 		}
 
 		// I/O: Calculate checksum
@@ -198,6 +198,35 @@ func TestGenerateNodeBuildSpec(t *testing.T) {
 	})
 }
 
+// TestNodeBuildArchitecture tests that native modules are resolved for Lambda, not the host.
+func TestNodeBuildArchitecture(t *testing.T) {
+	cases := map[string]string{
+		"":                 "x64",
+		ArchitectureX86_64: "x64",
+		ArchitectureARM64:  "arm64",
+	}
+	for arch, cpu := range cases {
+		spec := GenerateNodeBuildSpec(Config{SourceDir: "/tmp/test", Architecture: arch}, true, false)
+
+		assert.Contains(t, spec.Env, "npm_config_os=linux", "architecture %q", arch)
+		assert.Contains(t, spec.Env, "npm_config_cpu="+cpu, "architecture %q", arch)
+		assert.Contains(t, spec.Env, "npm_config_arch="+cpu, "architecture %q", arch)
+	}
+
+	t.Run("config env overrides the defaults", func(t *testing.T) {
+		cfg := Config{
+			SourceDir:    "/tmp/test",
+			Architecture: ArchitectureARM64,
+			Env:          map[string]string{"npm_config_arch": "x64"},
+		}
+
+		spec := GenerateNodeBuildSpec(cfg, true, false)
+
+		// Later entries win in exec environments
+		assert.Equal(t, "npm_config_arch=x64", spec.Env[len(spec.Env)-1])
+	})
+}
+
 // TestNodeBuildBasic tests basic Node.js build without dependencies.
 func TestNodeBuildBasic(t *testing.T) {
 	t.Run("builds simple Node.js function without package.json", func(t *testing.T) {
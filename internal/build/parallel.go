@@ -0,0 +1,114 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	E "github.com/IBM/fp-go/either"
+	O "github.com/IBM/fp-go/option"
+)
+
+// BuildResult is the outcome of building a single config (immutable data).
+type BuildResult struct {
+	Index  int
+	Config Config
+	Result E.Either[error, Artifact]
+}
+
+// DefaultJobs returns the default number of concurrent builds (one per CPU).
+func DefaultJobs() int {
+	return runtime.NumCPU()
+}
+
+// buildWithRegistry looks up the builder for cfg.Runtime and runs it.
+func buildWithRegistry(ctx context.Context, registry Registry, cfg Config) E.Either[error, Artifact] {
+	return O.Fold(
+		// None case: runtime not found
+		func() E.Either[error, Artifact] {
+			return E.Left[Artifact](fmt.Errorf("unsupported runtime: %s", cfg.Runtime))
+		},
+		// Some case: execute builder
+		func(builder BuildFunc) E.Either[error, Artifact] {
+			return builder(ctx, cfg)
+		},
+	)(GetBuilder(registry, cfg.Runtime))
+}
+
+// BuildConcurrent builds configs on a bounded pool of jobs workers.
+// onDone (optional) is called from a single goroutine as each build finishes, in completion order.
+// The returned results are always in input order, regardless of which build finished first.
+func BuildConcurrent(ctx context.Context, configs []Config, registry Registry, jobs int, onDone func(BuildResult)) []BuildResult {
+	results := make([]BuildResult, len(configs))
+	if len(configs) == 0 {
+		return results
+	}
+
+	jobs = max(1, min(jobs, len(configs)))
+
+	indexes := make(chan int)
+	done := make(chan BuildResult)
+
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				done <- BuildResult{
+					Index:  i,
+					Config: configs[i],
+					Result: buildWithRegistry(ctx, registry, configs[i]),
+				}
+			}
+		}()
+	}
+
+	go func() {
+		for i := range configs {
+			indexes <- i
+		}
+		close(indexes)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	for result := range done {
+		results[result.Index] = result
+		if onDone != nil {
+			onDone(result)
+		}
+	}
+
+	return results
+}
+
+// CollectResults converts build results into artifacts in input order.
+// PURE: Every failure is reported (joined), not just the first.
+func CollectResults(results []BuildResult) E.Either[error, []Artifact] {
+	artifacts := make([]Artifact, 0, len(results))
+	var errs []error
+
+	for _, result := range results {
+		E.Fold(
+			func(err error) any {
+				errs = append(errs, err)
+				return nil
+			},
+			func(artifact Artifact) any {
+				artifacts = append(artifacts, artifact)
+				return nil
+			},
+		)(result.Result)
+	}
+
+	if len(errs) > 0 {
+		return E.Left[[]Artifact](errors.Join(errs...))
+	}
+	return E.Right[error](artifacts)
+}
@@ -0,0 +1,148 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// slowRegistry returns a registry whose builder sleeps longer for earlier configs,
+// so completion order is the reverse of input order, and tracks peak concurrency.
+func slowRegistry(inFlight, peak *int32) Registry {
+	return Registry{
+		"go1.x": func(_ context.Context, cfg Config) E.Either[error, Artifact] {
+			current := atomic.AddInt32(inFlight, 1)
+			for {
+				old := atomic.LoadInt32(peak)
+				if current <= old || atomic.CompareAndSwapInt32(peak, old, current) {
+					break
+				}
+			}
+			defer atomic.AddInt32(inFlight, -1)
+
+			time.Sleep(time.Duration(len(cfg.Handler)) * 5 * time.Millisecond)
+			return E.Right[error](Artifact{Path: cfg.SourceDir + ".zip"})
+		},
+		"broken": func(_ context.Context, cfg Config) E.Either[error, Artifact] {
+			return E.Left[Artifact](errors.New(cfg.SourceDir + " failed"))
+		},
+	}
+}
+
+// TestBuildConcurrent tests the bounded worker pool.
+func TestBuildConcurrent(t *testing.T) {
+	t.Run("returns results in input order regardless of completion order", func(t *testing.T) {
+		var inFlight, peak int32
+		configs := []Config{
+			{SourceDir: "a", Runtime: "go1.x", Handler: "xxxx"},
+			{SourceDir: "b", Runtime: "go1.x", Handler: "xxx"},
+			{SourceDir: "c", Runtime: "go1.x", Handler: "xx"},
+			{SourceDir: "d", Runtime: "go1.x", Handler: "x"},
+		}
+
+		var completed []string
+		results := BuildConcurrent(t.Context(), configs, slowRegistry(&inFlight, &peak), 4, func(r BuildResult) {
+			completed = append(completed, r.Config.SourceDir)
+		})
+
+		require.Len(t, results, 4)
+		for i, r := range results {
+			assert.Equal(t, i, r.Index)
+			assert.Equal(t, configs[i].SourceDir, r.Config.SourceDir)
+			assert.True(t, E.IsRight(r.Result))
+		}
+		assert.Len(t, completed, 4, "onDone should be called once per config")
+		assert.Equal(t, "d", completed[0], "fastest build should be reported first")
+	})
+
+	t.Run("never exceeds the job limit", func(t *testing.T) {
+		var inFlight, peak int32
+		configs := make([]Config, 8)
+		for i := range configs {
+			configs[i] = Config{SourceDir: "fn", Runtime: "go1.x", Handler: "xx"}
+		}
+
+		BuildConcurrent(t.Context(), configs, slowRegistry(&inFlight, &peak), 2, nil)
+
+		assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+		assert.Positive(t, atomic.LoadInt32(&peak))
+	})
+
+	t.Run("treats jobs below one as one", func(t *testing.T) {
+		var inFlight, peak int32
+		configs := []Config{
+			{SourceDir: "a", Runtime: "go1.x"},
+			{SourceDir: "b", Runtime: "go1.x"},
+		}
+
+		results := BuildConcurrent(t.Context(), configs, slowRegistry(&inFlight, &peak), 0, nil)
+
+		assert.Len(t, results, 2)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&peak))
+	})
+
+	t.Run("handles empty config list", func(t *testing.T) {
+		results := BuildConcurrent(t.Context(), nil, NewRegistry(), 4, func(BuildResult) {
+			t.Fatal("onDone should not be called")
+		})
+		assert.Empty(t, results)
+	})
+}
+
+// TestCollectResults tests joining build results.
+func TestCollectResults(t *testing.T) {
+	t.Run("collects artifacts in order", func(t *testing.T) {
+		results := []BuildResult{
+			{Index: 0, Result: E.Right[error](Artifact{Path: "a.zip"})},
+			{Index: 1, Result: E.Right[error](Artifact{Path: "b.zip"})},
+		}
+
+		artifacts := E.GetOrElse(func(error) []Artifact { return nil })(CollectResults(results))
+
+		assert.Equal(t, []Artifact{{Path: "a.zip"}, {Path: "b.zip"}}, artifacts)
+	})
+
+	t.Run("reports every failure", func(t *testing.T) {
+		results := []BuildResult{
+			{Index: 0, Result: E.Left[Artifact](errors.New("a failed"))},
+			{Index: 1, Result: E.Right[error](Artifact{Path: "b.zip"})},
+			{Index: 2, Result: E.Left[Artifact](errors.New("c failed"))},
+		}
+
+		result := CollectResults(results)
+
+		require.True(t, E.IsLeft(result))
+		err := E.Fold(
+			func(e error) error { return e },
+			func([]Artifact) error { return nil },
+		)(result)
+		assert.Contains(t, err.Error(), "a failed")
+		assert.Contains(t, err.Error(), "c failed")
+	})
+}
+
+// TestBuildAllWithJobs tests that BuildAll keeps building after a failure.
+func TestBuildAllWithJobs(t *testing.T) {
+	var inFlight, peak int32
+	configs := []Config{
+		{SourceDir: "api", Runtime: "broken"},
+		{SourceDir: "worker", Runtime: "go1.x"},
+		{SourceDir: "cron", Runtime: "rust"},
+	}
+
+	result := BuildAllWithJobs(t.Context(), configs, slowRegistry(&inFlight, &peak), 2)
+
+	require.True(t, E.IsLeft(result))
+	err := E.Fold(
+		func(e error) error { return e },
+		func([]Artifact) error { return nil },
+	)(result)
+	assert.Contains(t, err.Error(), "api failed")
+	assert.Contains(t, err.Error(), "unsupported runtime: rust")
+}
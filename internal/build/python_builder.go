@@ -1,10 +1,8 @@
 package build
 
 import (
-	"archive/zip"
 	"context"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -31,6 +29,7 @@ type (
 		Env              []string
 		DependencyCmd    []string // Command to install dependencies
 		UsesUV           bool     // Whether uv is available
+		Excludes         []string // Zip exclusion patterns
 	}
 )
 
@@ -52,8 +51,8 @@ func GeneratePythonBuildSpec(cfg Config, hasUV, hasRequirements bool) PythonBuil
 				"uv", "pip", "install",
 				"-r", requirementsPath,
 				"--target", "{tempDir}", // Placeholder for temp dir
-				"--python-platform", "linux",
-				"--python-version", "3.11",
+				"--python-platform", uvPythonPlatform(cfg.Architecture),
+				"--python-version", pythonVersion(cfg.Runtime),
 			}
 		} else {
 			// Fallback to pip
@@ -63,6 +62,15 @@ func GeneratePythonBuildSpec(cfg Config, hasUV, hasRequirements bool) PythonBuil
 				"-t", "{tempDir}", // Placeholder for temp dir
 				"--upgrade",
 			}
+			// Cross-installing for Graviton: only prebuilt aarch64 wheels are usable
+			if NormalizeArchitecture(cfg.Architecture) == ArchitectureARM64 {
+				depCmd = append(depCmd,
+					"--platform", pythonPlatform(cfg.Architecture),
+					"--implementation", "cp",
+					"--python-version", pythonVersion(cfg.Runtime),
+					"--only-binary=:all:",
+				)
+			}
 		}
 	}
 
@@ -74,7 +82,17 @@ func GeneratePythonBuildSpec(cfg Config, hasUV, hasRequirements bool) PythonBuil
 		Env:              envSlice(cfg.Env),
 		DependencyCmd:    depCmd,
 		UsesUV:           hasUV,
+		Excludes:         zipExcludes(cfg),
+	}
+}
+
+// uvPythonPlatform maps a Lambda architecture to uv's --python-platform target.
+// PURE: Calculation.
+func uvPythonPlatform(arch string) string {
+	if NormalizeArchitecture(arch) == ArchitectureARM64 {
+		return "aarch64-manylinux2014"
 	}
+	return "linux"
 }
 
 // ExecutePythonBuildSpec executes a Python build specification.
@@ -105,31 +123,13 @@ func ExecutePythonBuildSpec(ctx context.Context, spec PythonBuildSpec) E.Either[
 			}
 		}
 
-		// I/O: Create zip file
-		zipFile, err := os.Create(spec.OutputPath)
-		if err != nil {
-			return Artifact{}, fmt.Errorf("failed to create zip: %w", err)
-		}
-		zipWriter := zip.NewWriter(zipFile)
-
-		// I/O: Add dependencies from temp directory
+		// I/O: Package dependencies then source (source wins on conflicts)
+		dirs := []string{spec.SourceDir}
 		if spec.HasRequirements {
-			if err := addDirToZip(zipWriter, tempDir, ""); err != nil {
-				return Artifact{}, fmt.Errorf("failed to add dependencies: %w", err)
-			}
-		}
-
-		// I/O: Add source code
-		if err := addDirToZip(zipWriter, spec.SourceDir, ""); err != nil {
-			return Artifact{}, fmt.Errorf("failed to add source code: %w", err)
+			dirs = []string{tempDir, spec.SourceDir}
 		}
-
-		// I/O: Close zip before calculating checksum
-		if err := zipWriter.Close(); err != nil {
-			return Artifact{}, fmt.Errorf("failed to close zip writer: %w", err)
-		}
-		if err := zipFile.Close(); err != nil {
-			return Artifact{}, fmt.Errorf("failed to close zip file: %w", err)
+		if err := WriteDirZip(spec.OutputPath, dirs, spec.Excludes); err != nil {
+			return Artifact{}, fmt.Errorf("failed to package function: %w", err)
 		}
 
 		// I/O: Calculate checksum
@@ -194,71 +194,3 @@ func envSlice(envMap map[string]string) []string {
 	}
 	return env
 }
-
-// ACTION: Performs I/O (file system reads, zip writes).
-func addDirToZip(zipWriter *zip.Writer, baseDir, prefix string) error {
-	return filepath.Walk(baseDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		// Skip directories
-		if info.IsDir() {
-			return nil
-		}
-
-		// Skip certain files
-		if shouldSkipFile(info.Name()) {
-			return nil
-		}
-
-		// Get relative path
-		relPath, err := filepath.Rel(baseDir, path)
-		if err != nil {
-			return err
-		}
-
-		// Create zip entry
-		zipPath := filepath.Join(prefix, relPath)
-		zipPath = filepath.ToSlash(zipPath) // Use forward slashes in zip
-
-		w, err := zipWriter.Create(zipPath)
-		if err != nil {
-			return err
-		}
-
-		// Copy file contents
-		f, err := os.Open(path)
-		if err != nil {
-			return err
-		}
-		defer func() {
-			if err := f.Close(); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: failed to close file %s: %v\n", path, err)
-			}
-		}()
-
-		_, err = io.Copy(w, f)
-		return err
-	})
-}
-
-// PURE: Calculation - deterministic based on filename.
-func shouldSkipFile(name string) bool {
-	skipSuffixes := []string{
-		".pyc",
-		".pyo",
-		".pyd",
-		"__pycache__",
-		".git",
-		".DS_Store",
-	}
-
-	for _, suffix := range skipSuffixes {
-		if strings.HasSuffix(name, suffix) {
-			return true
-		}
-	}
-
-	return false
-}
@@ -196,6 +196,47 @@ func TestGeneratePythonBuildSpec(t *testing.T) {
 		assert.Contains(t, spec.DependencyCmd, "pip")
 		assert.Contains(t, spec.DependencyCmd, "install")
 	})
+
+	t.Run("installs aarch64 wheels for arm64 with pip", func(t *testing.T) {
+		cfg := Config{
+			SourceDir:    "/tmp/test",
+			Runtime:      "python3.12",
+			Architecture: ArchitectureARM64,
+		}
+
+		spec := GeneratePythonBuildSpec(cfg, false, true)
+
+		assert.Equal(t, []string{
+			"pip", "install",
+			"-r", "/tmp/test/requirements.txt",
+			"-t", "{tempDir}",
+			"--upgrade",
+			"--platform", "manylinux2014_aarch64",
+			"--implementation", "cp",
+			"--python-version", "3.12",
+			"--only-binary=:all:",
+		}, spec.DependencyCmd)
+	})
+
+	t.Run("installs aarch64 wheels for arm64 with uv", func(t *testing.T) {
+		cfg := Config{
+			SourceDir:    "/tmp/test",
+			Runtime:      "python3.13",
+			Architecture: ArchitectureARM64,
+		}
+
+		spec := GeneratePythonBuildSpec(cfg, true, true)
+
+		assert.Subset(t, spec.DependencyCmd, []string{"--python-platform", "aarch64-manylinux2014"})
+		assert.Subset(t, spec.DependencyCmd, []string{"--python-version", "3.13"})
+	})
+
+	t.Run("leaves pip platform unset for x86_64", func(t *testing.T) {
+		spec := GeneratePythonBuildSpec(Config{SourceDir: "/tmp/test", Runtime: "python3.11"}, false, true)
+
+		assert.NotContains(t, spec.DependencyCmd, "--platform")
+		assert.NotContains(t, spec.DependencyCmd, "--only-binary=:all:")
+	})
 }
 
 // TestPythonBuildBasic tests basic Python build without dependencies.
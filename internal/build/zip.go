@@ -0,0 +1,305 @@
+package build
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	// ZipEntry is a single file or directory to place in a deployment zip.
+	// Directory entries have a Name ending in "/" and a nil Open.
+	ZipEntry struct {
+		Name string                        // Slash-separated path inside the archive
+		Mode fs.FileMode                   // Source mode; normalized when written
+		Open func() (io.ReadCloser, error) // Content source (nil for directories)
+	}
+)
+
+// zipEpoch is the fixed modification time stamped on every entry.
+// It is the earliest time the zip format can represent, so archives built from
+// identical inputs are byte-identical regardless of when or where they were built.
+var zipEpoch = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// DefaultZipExcludes are patterns never packaged into deployment zips.
+var DefaultZipExcludes = []string{
+	"*.pyc",
+	"*.pyo",
+	"*.pyd",
+	"__pycache__",
+	".git",
+	".DS_Store",
+}
+
+// zipExcludes combines the default exclusions with a config's own patterns.
+// PURE: Returns a new slice.
+func zipExcludes(cfg Config) []string {
+	excludes := make([]string, 0, len(DefaultZipExcludes)+len(cfg.Excludes))
+	excludes = append(excludes, DefaultZipExcludes...)
+	return append(excludes, cfg.Excludes...)
+}
+
+// normalizeZipMode maps host permissions onto the two modes Lambda cares about.
+// PURE: Calculation.
+func normalizeZipMode(mode fs.FileMode) fs.FileMode {
+	switch {
+	case mode.IsDir():
+		return fs.ModeDir | 0o755
+	case mode&0o111 != 0:
+		return 0o755
+	default:
+		return 0o644
+	}
+}
+
+// matchesExclude reports whether a slash-separated relative path matches any pattern.
+// Patterns use path.Match syntax and are tried against the base name and the full path,
+// so "*.pyc" excludes files at any depth and "tests/*" only the top-level tests dir.
+// PURE: Calculation.
+func matchesExclude(rel string, patterns []string) bool {
+	base := path.Base(rel)
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldSkipFile reports whether a file name matches the default exclusions.
+// PURE: Calculation - deterministic based on filename.
+func shouldSkipFile(name string) bool {
+	return name != "" && matchesExclude(name, DefaultZipExcludes)
+}
+
+// DirZipEntries lists the files under dir as zip entries named prefix + relative path.
+// Anything matching excludes is skipped; an excluded directory is skipped entirely.
+// ACTION: Performs I/O (directory walk).
+func DirZipEntries(dir, prefix string, excludes []string) ([]ZipEntry, error) {
+	var entries []ZipEntry
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == dir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if matchesExclude(rel, excludes) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		// Stat follows symlinks so the target's content and mode are packaged
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		entries = append(entries, ZipEntry{
+			Name: path.Join(prefix, rel),
+			Mode: info.Mode(),
+			Open: func() (io.ReadCloser, error) { return os.Open(p) },
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// FileZipEntry returns an entry packaging the file at p under name.
+// ACTION: Performs I/O (stat).
+func FileZipEntry(p, name string) (ZipEntry, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return ZipEntry{}, err
+	}
+	return ZipEntry{
+		Name: name,
+		Mode: info.Mode(),
+		Open: func() (io.ReadCloser, error) { return os.Open(p) },
+	}, nil
+}
+
+// sortZipEntries orders entries by name and drops duplicates, keeping the last
+// occurrence so later sources (e.g. function code) override earlier ones (e.g. deps).
+// PURE: Returns a new slice.
+func sortZipEntries(entries []ZipEntry) []ZipEntry {
+	sorted := make([]ZipEntry, len(entries))
+	copy(sorted, entries)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	result := make([]ZipEntry, 0, len(sorted))
+	for i, entry := range sorted {
+		if i+1 < len(sorted) && sorted[i+1].Name == entry.Name {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// WriteDeterministicZip writes entries to zipPath so identical inputs always
+// produce byte-identical archives: entries are sorted by name, timestamps are
+// fixed, modes are normalized to 0644/0755 and host metadata is not recorded.
+// The archive is written to a temp file and renamed into place; the parent
+// directory must already exist.
+// ACTION: Performs I/O (file reads, zip writes).
+func WriteDeterministicZip(zipPath string, entries []ZipEntry) (err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(zipPath), "."+filepath.Base(zipPath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create zip: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			//nolint:errcheck // Best-effort cleanup after a failed write
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	zipWriter := zip.NewWriter(tmp)
+	for _, entry := range sortZipEntries(entries) {
+		if err = writeZipEntry(zipWriter, entry); err != nil {
+			//nolint:errcheck // Already returning the entry error
+			_ = tmp.Close()
+			return fmt.Errorf("failed to add %s: %w", entry.Name, err)
+		}
+	}
+
+	if err = zipWriter.Close(); err != nil {
+		//nolint:errcheck // Already returning the writer error
+		_ = tmp.Close()
+		return fmt.Errorf("failed to close zip writer: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close zip file: %w", err)
+	}
+	if err = os.Chmod(tmp.Name(), 0o644); err != nil { //nolint:mnd // Standard file permission
+		return fmt.Errorf("failed to set zip permissions: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), zipPath); err != nil {
+		return fmt.Errorf("failed to move zip into place: %w", err)
+	}
+	return nil
+}
+
+// writeZipEntry writes one normalized entry.
+// ACTION: Performs I/O.
+func writeZipEntry(zipWriter *zip.Writer, entry ZipEntry) error {
+	header := &zip.FileHeader{
+		Name:     entry.Name,
+		Method:   zip.Deflate,
+		Modified: zipEpoch,
+	}
+	header.SetMode(normalizeZipMode(entry.Mode))
+
+	if entry.Open == nil {
+		header.Method = zip.Store
+		if !strings.HasSuffix(header.Name, "/") {
+			header.Name += "/"
+		}
+		_, err := zipWriter.CreateHeader(header)
+		return err
+	}
+
+	w, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	r, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		//nolint:errcheck // Read-only source
+		_ = r.Close()
+	}()
+
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// WriteDirZip packages the contents of dirs (later dirs override earlier ones)
+// into a deterministic zip at zipPath, skipping excludes and the zip itself.
+// ACTION: Performs I/O.
+func WriteDirZip(zipPath string, dirs []string, excludes []string) error {
+	absZip, err := filepath.Abs(zipPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve zip path: %w", err)
+	}
+
+	var entries []ZipEntry
+	for _, dir := range dirs {
+		dirEntries, err := DirZipEntries(dir, "", excludes)
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", dir, err)
+		}
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", dir, err)
+		}
+		for _, entry := range dirEntries {
+			// A previous build may have left its output inside the source tree
+			if filepath.Join(absDir, filepath.FromSlash(entry.Name)) == absZip {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return WriteDeterministicZip(zipPath, entries)
+}
+
+// NormalizeZip rewrites the archive at srcPath (e.g. a Maven jar) into a
+// deterministic zip at dstPath. srcPath and dstPath may be the same file.
+// ACTION: Performs I/O.
+func NormalizeZip(srcPath, dstPath string) error {
+	reader, err := zip.OpenReader(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer func() {
+		//nolint:errcheck // Read-only archive
+		_ = reader.Close()
+	}()
+
+	entries := make([]ZipEntry, 0, len(reader.File))
+	for _, f := range reader.File {
+		entry := ZipEntry{Name: f.Name, Mode: f.Mode()}
+		if !f.FileInfo().IsDir() {
+			entry.Open = f.Open
+		}
+		entries = append(entries, entry)
+	}
+
+	return WriteDeterministicZip(dstPath, entries)
+}
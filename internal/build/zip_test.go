@@ -0,0 +1,209 @@
+package build
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeZipFixture creates a small function tree with the given file mode and mtime.
+func writeZipFixture(t *testing.T, mode os.FileMode, mtime time.Time) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"handler.py":              "def handler(e, c): pass\n",
+		"lib/util.py":             "X = 1\n",
+		"lib/__pycache__/u.pyc":   "bytecode",
+		"tests/test_handler.py":   "def test(): pass\n",
+		".DS_Store":               "finder",
+		"scripts/run.sh":          "#!/bin/sh\n",
+		"nested/deep/config.json": "{}",
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), mode))
+		require.NoError(t, os.Chtimes(p, mtime, mtime))
+	}
+	require.NoError(t, os.Chmod(filepath.Join(dir, "scripts", "run.sh"), 0o755))
+
+	return dir
+}
+
+func zipNames(t *testing.T, zipPath string) []string {
+	t.Helper()
+
+	reader, err := zip.OpenReader(zipPath)
+	require.NoError(t, err)
+	defer func() { _ = reader.Close() }()
+
+	names := make([]string, 0, len(reader.File))
+	for _, f := range reader.File {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+// TestWriteDirZip tests deterministic packaging of directories.
+func TestWriteDirZip(t *testing.T) {
+	t.Run("identical sources produce byte-identical zips", func(t *testing.T) {
+		dirA := writeZipFixture(t, 0o644, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+		dirB := writeZipFixture(t, 0o600, time.Now())
+
+		zipA := filepath.Join(t.TempDir(), "a.zip")
+		zipB := filepath.Join(t.TempDir(), "b.zip")
+		require.NoError(t, WriteDirZip(zipA, []string{dirA}, DefaultZipExcludes))
+		require.NoError(t, WriteDirZip(zipB, []string{dirB}, DefaultZipExcludes))
+
+		sumA, err := calculateChecksum(zipA)
+		require.NoError(t, err)
+		sumB, err := calculateChecksum(zipB)
+		require.NoError(t, err)
+		assert.Equal(t, sumA, sumB)
+	})
+
+	t.Run("sorts entries, fixes timestamps and normalizes modes", func(t *testing.T) {
+		dir := writeZipFixture(t, 0o600, time.Now())
+		zipPath := filepath.Join(t.TempDir(), "fn.zip")
+		require.NoError(t, WriteDirZip(zipPath, []string{dir}, DefaultZipExcludes))
+
+		reader, err := zip.OpenReader(zipPath)
+		require.NoError(t, err)
+		defer func() { _ = reader.Close() }()
+
+		for _, f := range reader.File {
+			assert.True(t, f.Modified.Equal(zipEpoch), "%s should have the fixed timestamp", f.Name)
+			if f.Name == "scripts/run.sh" {
+				assert.Equal(t, os.FileMode(0o755), f.Mode().Perm())
+			} else {
+				assert.Equal(t, os.FileMode(0o644), f.Mode().Perm(), f.Name)
+			}
+		}
+
+		assert.Equal(t, []string{
+			"handler.py",
+			"lib/util.py",
+			"nested/deep/config.json",
+			"scripts/run.sh",
+			"tests/test_handler.py",
+		}, zipNames(t, zipPath))
+	})
+
+	t.Run("applies exclusion patterns to files and directories", func(t *testing.T) {
+		dir := writeZipFixture(t, 0o644, time.Now())
+		zipPath := filepath.Join(t.TempDir(), "fn.zip")
+
+		excludes := append([]string{"tests", "*.json"}, DefaultZipExcludes...)
+		require.NoError(t, WriteDirZip(zipPath, []string{dir}, excludes))
+
+		assert.Equal(t, []string{"handler.py", "lib/util.py", "scripts/run.sh"}, zipNames(t, zipPath))
+	})
+
+	t.Run("later directories override earlier ones", func(t *testing.T) {
+		deps := t.TempDir()
+		src := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(deps, "shared.py"), []byte("deps"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(src, "shared.py"), []byte("source"), 0o644))
+
+		zipPath := filepath.Join(t.TempDir(), "fn.zip")
+		require.NoError(t, WriteDirZip(zipPath, []string{deps, src}, nil))
+
+		reader, err := zip.OpenReader(zipPath)
+		require.NoError(t, err)
+		defer func() { _ = reader.Close() }()
+
+		require.Len(t, reader.File, 1)
+		rc, err := reader.File[0].Open()
+		require.NoError(t, err)
+		defer func() { _ = rc.Close() }()
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		assert.Equal(t, "source", string(content))
+	})
+
+	t.Run("does not package the zip into itself", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "index.js"), []byte("x"), 0o644))
+		zipPath := filepath.Join(dir, "lambda.zip")
+
+		require.NoError(t, WriteDirZip(zipPath, []string{dir}, nil))
+		require.NoError(t, WriteDirZip(zipPath, []string{dir}, nil))
+
+		assert.Equal(t, []string{"index.js"}, zipNames(t, zipPath))
+	})
+}
+
+// TestNormalizeZip tests rewriting third-party archives deterministically.
+func TestNormalizeZip(t *testing.T) {
+	writeJar := func(t *testing.T, modified time.Time, names ...string) string {
+		t.Helper()
+		jarPath := filepath.Join(t.TempDir(), "app.jar")
+		f, err := os.Create(jarPath)
+		require.NoError(t, err)
+		w := zip.NewWriter(f)
+		for _, name := range names {
+			header := &zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified}
+			entry, err := w.CreateHeader(header)
+			require.NoError(t, err)
+			if name[len(name)-1] != '/' {
+				_, err = entry.Write([]byte("content of " + name))
+				require.NoError(t, err)
+			}
+		}
+		require.NoError(t, w.Close())
+		require.NoError(t, f.Close())
+		return jarPath
+	}
+
+	jarA := writeJar(t, time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC), "META-INF/", "META-INF/MANIFEST.MF", "com/App.class")
+	jarB := writeJar(t, time.Now(), "com/App.class", "META-INF/MANIFEST.MF", "META-INF/")
+
+	outA := filepath.Join(t.TempDir(), "a.jar")
+	outB := filepath.Join(t.TempDir(), "b.jar")
+	require.NoError(t, NormalizeZip(jarA, outA))
+	require.NoError(t, NormalizeZip(jarB, outB))
+
+	sumA, err := calculateChecksum(outA)
+	require.NoError(t, err)
+	sumB, err := calculateChecksum(outB)
+	require.NoError(t, err)
+	assert.Equal(t, sumA, sumB, "timestamps and entry order should not affect the output")
+	assert.Equal(t, []string{"META-INF/", "META-INF/MANIFEST.MF", "com/App.class"}, zipNames(t, outA))
+
+	t.Run("can rewrite in place", func(t *testing.T) {
+		require.NoError(t, NormalizeZip(jarA, jarA))
+		sum, err := calculateChecksum(jarA)
+		require.NoError(t, err)
+		assert.Equal(t, sumA, sum)
+	})
+}
+
+// TestMatchesExclude tests exclusion pattern matching.
+func TestMatchesExclude(t *testing.T) {
+	tests := []struct {
+		rel      string
+		patterns []string
+		want     bool
+	}{
+		{"module.pyc", []string{"*.pyc"}, true},
+		{"pkg/deep/module.pyc", []string{"*.pyc"}, true},
+		{"tests", []string{"tests/"}, true},
+		{"tests/unit.py", []string{"tests/*"}, true},
+		{"src/tests/unit.py", []string{"tests/*"}, false},
+		{"handler.py", []string{"*.pyc", "tests"}, false},
+		{"handler.py", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rel, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesExclude(tt.rel, tt.patterns))
+		})
+	}
+}
@@ -86,6 +86,82 @@ func TestAdaptTerraformExecutorInit(t *testing.T) {
 	})
 }
 
+// TestAdaptTerraformExecutorInitWithBackend tests the function.
+func TestAdaptTerraformExecutorInitWithBackend(t *testing.T) {
+	t.Run("reconfigures backend with sorted backend config", func(t *testing.T) {
+		state := &mockExecutorState{}
+		mock := newMockExecutor(state)
+		adapted := adaptTerraformExecutor(mock)
+
+		err := adapted.InitWithBackend(t.Context(), "/test/dir", map[string]string{
+			"key":    "pr-123/terraform.tfstate",
+			"bucket": "forge-state-app",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, state.initCalled)
+
+		var cfg terraform.InitConfig
+		for _, opt := range state.lastInitOpts {
+			opt(&cfg)
+		}
+		assert.True(t, cfg.Reconfigure)
+		assert.False(t, cfg.Upgrade)
+		assert.Equal(t, []string{"bucket=forge-state-app", "key=pr-123/terraform.tfstate"}, cfg.BackendConfig)
+	})
+
+	t.Run("propagates Init errors", func(t *testing.T) {
+		state := &mockExecutorState{initErr: assert.AnError}
+		mock := newMockExecutor(state)
+		adapted := adaptTerraformExecutor(mock)
+
+		err := adapted.InitWithBackend(t.Context(), "/test/dir", map[string]string{"key": "pr-1/terraform.tfstate"})
+
+		require.Error(t, err)
+	})
+}
+
+// TestAdaptTerraformExecutorPlanDestroy tests the function.
+func TestAdaptTerraformExecutorPlanDestroy(t *testing.T) {
+	t.Run("plans destroy and returns removed addresses", func(t *testing.T) {
+		state := &mockExecutorState{planResult: true}
+		mock := newMockExecutor(state)
+		var shownPlan string
+		mock.ShowPlan = func(_ context.Context, _ string, planFile string) ([]terraform.PlannedChange, error) {
+			shownPlan = planFile
+			return []terraform.PlannedChange{
+				{Address: "aws_lambda_function.api", Actions: []string{"delete"}},
+				{Address: "aws_iam_role.api", Actions: []string{"update"}},
+			}, nil
+		}
+		adapted := adaptTerraformExecutor(mock)
+
+		addresses, err := adapted.PlanDestroy(t.Context(), "/test/dir", map[string]string{"namespace": "pr-1-"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"aws_lambda_function.api"}, addresses)
+		assert.Equal(t, "/test/dir/tfplan", shownPlan)
+
+		var cfg terraform.PlanConfig
+		for _, opt := range state.lastPlanOpts {
+			opt(&cfg)
+		}
+		assert.True(t, cfg.Destroy)
+		assert.Equal(t, "/test/dir/tfplan", cfg.Out)
+		assert.Equal(t, "pr-1-", cfg.Vars["namespace"])
+	})
+
+	t.Run("propagates Plan errors", func(t *testing.T) {
+		state := &mockExecutorState{planErr: assert.AnError}
+		mock := newMockExecutor(state)
+		adapted := adaptTerraformExecutor(mock)
+
+		_, err := adapted.PlanDestroy(t.Context(), "/test/dir", nil)
+
+		require.Error(t, err)
+	})
+}
+
 // TestAdaptTerraformExecutorPlan tests the function.
 func TestAdaptTerraformExecutorPlan(t *testing.T) {
 	t.Run("calls PlanWithVars with nil vars", func(t *testing.T) {
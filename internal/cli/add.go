@@ -2,27 +2,57 @@
 package cli
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	E "github.com/IBM/fp-go/either"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/spf13/cobra"
 
+	"github.com/lewis/forge/internal/config"
+	"github.com/lewis/forge/internal/discovery"
 	"github.com/lewis/forge/internal/generators"
 	"github.com/lewis/forge/internal/generators/dynamodb"
+	"github.com/lewis/forge/internal/generators/eventbridge"
 	"github.com/lewis/forge/internal/generators/s3"
 	"github.com/lewis/forge/internal/generators/sns"
 	"github.com/lewis/forge/internal/generators/sqs"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/modvendor"
+	"github.com/lewis/forge/internal/ui"
 )
 
+// resourcesDir is where forge add records the code it generated for each
+// resource, relative to the project root.
+const resourcesDir = ".forge/resources"
+
+// addOptions holds the flags for 'forge add' (immutable data).
+type addOptions struct {
+	ToFunc string
+	Raw    bool
+	Force  bool
+	DryRun bool
+	// Flags holds the resource type's own flags, such as an EventBridge
+	// rule's schedule; empty ones are dropped.
+	Flags map[string]string
+}
+
 // NewAddCmd creates the 'add' command.
 func NewAddCmd() *cobra.Command {
 	var (
 		addToFunc   string
 		addRaw      bool
 		addNoModule bool
+		addForce    bool
+		addDryRun   bool
+		addSchedule string
+		addPattern  string
+		addBus      string
+		addToBus    string
 	)
 
 	addCmd := &cobra.Command{
@@ -41,6 +71,7 @@ Smart defaults, best practices, and Lambda integrations built-in.
   dynamodb     - DynamoDB table with streams and backup
   sns          - SNS topic with subscriptions
   s3           - S3 bucket with versioning and encryption
+  eventbridge  - EventBridge rule with DLQ and retry policy
 
 🎯 What You Get:
   • Production-ready Terraform modules
@@ -63,11 +94,30 @@ Smart defaults, best practices, and Lambda integrations built-in.
     → Generates IAM permissions
     → Configures batch settings
 
+  # Run a function on a schedule
+  forge add eventbridge nightly-report --schedule "cron(0 3 * * ? *)" --to=reporter
+    → Creates the rule and its dead letter queue
+    → Grants EventBridge permission to invoke the function
+    → Retries failed deliveries for up to an hour
+
+  # Forward matching events to another account's bus
+  forge add eventbridge order-events --pattern '{"source":["orders"]}' \
+    --to-bus=arn:aws:events:us-east-1:123456789012:event-bus/audit
+
   # Use raw Terraform resources (no modules)
   forge add sqs orders-queue --raw
 
+  # Regenerate a queue, replacing blocks edited by hand
+  forge add sqs orders-queue --force
+
+  # Preview the changes as a diff, writing nothing
+  forge add sqs orders-queue --dry-run
+
 💡 Pro Tips:
   • Generated code is fully editable
+  • Rerunning is safe: existing blocks are merged, not duplicated
+  • Generated code is recorded in .forge/resources, so that
+    forge remove can take it out again: commit it with infra/
   • Uses Terraform modules by default for simplicity
   • Use --raw for maximum control
   • Review generated code before applying
@@ -76,37 +126,61 @@ Smart defaults, best practices, and Lambda integrations built-in.
   infra/
   ├── sqs_orders_queue.tf      # Generated resource
   └── sqs_orders_queue_iam.tf  # Generated IAM policies
+
+  With terraform_format = "json" in forge.hcl's project block,
+  files are written as Terraform JSON (*.tf.json) instead.
 `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runAdd(cmd, args, addToFunc, addRaw, addNoModule)
+			return runAdd(cmd, args, addOptions{
+				ToFunc: addToFunc,
+				Raw:    addRaw || addNoModule,
+				Force:  addForce,
+				DryRun: addDryRun,
+				Flags: map[string]string{
+					"schedule": addSchedule,
+					"pattern":  addPattern,
+					"bus":      addBus,
+					"to-bus":   addToBus,
+				},
+			})
 		},
 	}
 
 	addCmd.Flags().StringVar(&addToFunc, "to", "", "Target Lambda function for integration")
 	addCmd.Flags().BoolVar(&addRaw, "raw", false, "Generate raw Terraform resources instead of modules")
 	addCmd.Flags().BoolVar(&addNoModule, "no-module", false, "Alias for --raw")
+	addCmd.Flags().BoolVar(&addForce, "force", false, "Replace generated blocks even if they were edited")
+	addCmd.Flags().BoolVar(&addDryRun, "dry-run", false, "Show the changes as a diff without writing them")
+	addCmd.Flags().BoolVar(&addDryRun, "diff", false, "Alias for --dry-run")
+	addCmd.Flags().StringVar(&addSchedule, "schedule", "", "EventBridge schedule, cron(...) or rate(...)")
+	addCmd.Flags().StringVar(&addPattern, "pattern", "", "EventBridge event pattern as JSON")
+	addCmd.Flags().StringVar(&addBus, "bus", "", "Event bus an EventBridge pattern rule listens on (default \"default\")")
+	addCmd.Flags().StringVar(&addToBus, "to-bus", "", "ARN of an event bus to forward EventBridge events to")
 
 	return addCmd
 }
 
-// runAdd executes the add command (I/O ACTION).
-func runAdd(cmd *cobra.Command, args []string, toFunc string, raw, noModule bool) error {
+// runAdd executes the add command. With opts.DryRun it prints the changes
+// instead of writing them, failing if there are any (I/O ACTION).
+func runAdd(cmd *cobra.Command, args []string, opts addOptions) error {
 	ctx := cmd.Context()
 	resourceType := args[0]
 	resourceName := args[1]
 
-	// Determine module preference
-	useModule := !raw && !noModule
-
 	// Create resource intent
 	intent := generators.ResourceIntent{
 		Type:      generators.ResourceType(resourceType),
 		Name:      resourceName,
-		ToFunc:    toFunc,
-		UseModule: useModule,
+		ToFunc:    opts.ToFunc,
+		UseModule: !opts.Raw,
 		Flags:     make(map[string]string),
 	}
+	for name, value := range opts.Flags {
+		if value != "" {
+			intent.Flags[name] = value
+		}
+	}
 
 	// Get project root (working directory)
 	projectRoot, err := os.Getwd()
@@ -114,8 +188,14 @@ func runAdd(cmd *cobra.Command, args []string, toFunc string, raw, noModule bool
 		return fmt.Errorf("failed to get working directory: %w", err)
 	}
 
+	// Progress goes to stderr in dry-run mode so stdout carries only the diff
+	progress := ui.DefaultOutput()
+	if opts.DryRun {
+		progress = ui.NewOutput(os.Stderr)
+	}
+
 	// Discover existing project state
-	fmt.Println("🔍 Discovering project resources...")
+	progress.Print("🔍 Discovering project resources...")
 
 	// Get generator for resource type
 	registry := createGeneratorRegistry()
@@ -126,21 +206,42 @@ func runAdd(cmd *cobra.Command, args []string, toFunc string, raw, noModule bool
 
 	infraDir := filepath.Join(projectRoot, "infra")
 
+	transforms, err := projectTransforms(projectRoot)
+	if err != nil {
+		return err
+	}
+
 	// Chain all operations - automatic error short-circuiting
-	writtenResult := E.Chain(func(state generators.ProjectState) E.Either[error, generators.WrittenFiles] {
+	codeResult := E.Chain(func(state generators.ProjectState) E.Either[error, generators.GeneratedCode] {
 		// Prompt for configuration (with defaults for MVP)
-		fmt.Printf("📋 Configuring %s '%s'...\n", intent.Type, intent.Name)
-		return E.Chain(func(config generators.ResourceConfig) E.Either[error, generators.WrittenFiles] {
+		progress.Print("📋 Configuring %s '%s'...", intent.Type, intent.Name)
+		return E.Chain(func(config generators.ResourceConfig) E.Either[error, generators.GeneratedCode] {
 			// Generate Terraform code
-			fmt.Println("🔨 Generating Terraform code...")
-			return E.Chain(func(code generators.GeneratedCode) E.Either[error, generators.WrittenFiles] {
-				// Write files to disk
-				fmt.Println("📝 Writing files...")
-				return writeGeneratedFiles(code, infraDir)
-			})(generator.Generate(config, state))
+			progress.Print("🔨 Generating Terraform code...")
+			return inProjectFormat(transforms, generator.Generate(config, state))
 		})(generator.Prompt(ctx, intent, state))
 	})(discoverProjectState(projectRoot))
 
+	if opts.DryRun {
+		return E.Fold(
+			func(e error) error { return e },
+			func(code generators.GeneratedCode) error {
+				return previewGeneratedFiles(ui.DefaultOutput(), code, infraDir, opts.Force)
+			},
+		)(codeResult)
+	}
+
+	writtenResult := E.Chain(func(code generators.GeneratedCode) E.Either[error, generators.WrittenFiles] {
+		// Write files to disk, then record them for forge remove
+		fmt.Println("📝 Writing files...")
+		return E.Chain(func(written generators.WrittenFiles) E.Either[error, generators.WrittenFiles] {
+			if err := recordGeneratedFiles(code, recordDir(projectRoot, intent.Type, intent.Name)); err != nil {
+				return E.Left[generators.WrittenFiles](err)
+			}
+			return E.Right[error](written)
+		})(writeGeneratedFiles(code, infraDir, opts.Force))
+	})(codeResult)
+
 	// Handle final result - report success or return error
 	return E.Fold(
 		func(e error) error {
@@ -149,6 +250,10 @@ func runAdd(cmd *cobra.Command, args []string, toFunc string, raw, noModule bool
 		},
 		func(written generators.WrittenFiles) error {
 			// Success case - report results
+			if len(written.Created) == 0 && len(written.Updated) == 0 {
+				fmt.Println("\n✅", intent.Type, intent.Name, "is already up to date")
+				return nil
+			}
 			fmt.Println("\n✅ Successfully added", intent.Type, intent.Name)
 			if len(written.Created) > 0 {
 				fmt.Println("\nCreated files:")
@@ -162,6 +267,12 @@ func runAdd(cmd *cobra.Command, args []string, toFunc string, raw, noModule bool
 					fmt.Printf("  ~ %s\n", file)
 				}
 			}
+			if len(written.Blocks) > 0 {
+				fmt.Println("\nBlocks:")
+				for _, block := range written.Blocks {
+					fmt.Printf("  %s %s (%s)\n", blockSymbol(block.Action), block.Address, block.File)
+				}
+			}
 
 			// Next steps
 			fmt.Println("\nNext steps:")
@@ -175,6 +286,18 @@ func runAdd(cmd *cobra.Command, args []string, toFunc string, raw, noModule bool
 	)(writtenResult)
 }
 
+// blockSymbol returns the symbol runAdd lists a block with (PURE).
+func blockSymbol(action hclgen.BlockAction) string {
+	switch action {
+	case hclgen.BlockCreated:
+		return "+"
+	case hclgen.BlockUpdated:
+		return "~"
+	default:
+		return "="
+	}
+}
+
 // createGeneratorRegistry creates registry with all generators (PURE).
 // Uses functional chaining with immutable copy-on-write semantics.
 func createGeneratorRegistry() generators.Registry {
@@ -182,58 +305,76 @@ func createGeneratorRegistry() generators.Registry {
 		Register(generators.ResourceSQS, sqs.New()).
 		Register(generators.ResourceDynamoDB, dynamodb.New()).
 		Register(generators.ResourceSNS, sns.New()).
-		Register(generators.ResourceS3, s3.New())
+		Register(generators.ResourceS3, s3.New()).
+		Register(generators.ResourceEventBridge, eventbridge.New())
 }
 
-// discoverProjectState scans project for existing resources (I/O ACTION).
-func discoverProjectState(projectRoot string) E.Either[error, generators.ProjectState] {
-	infraDir := filepath.Join(projectRoot, "infra")
-
-	// Check if infra directory exists
-	if _, err := os.Stat(infraDir); os.IsNotExist(err) {
-		return E.Left[generators.ProjectState](
-			errors.New("infra/ directory not found - run 'forge new' first"),
-		)
-	}
-
-	// For MVP, return minimal state
-	// Phase 2: implement full Terraform parsing
-	state := generators.ProjectState{
-		ProjectRoot: projectRoot,
-		Functions:   make(map[string]generators.FunctionInfo),
-		Queues:      make(map[string]generators.QueueInfo),
-		Tables:      make(map[string]generators.TableInfo),
-		APIs:        make(map[string]generators.APIInfo),
-		Topics:      make(map[string]generators.TopicInfo),
-		InfraFiles:  []string{},
+// projectTransforms returns the conversions forge.hcl asks for on generated
+// code, in order: module calls pointed at .forge/modules for module_source =
+// "vendored", then Terraform JSON for terraform_format = "json". Projects
+// without forge.hcl get HCL calling the registry (I/O ACTION).
+func projectTransforms(projectRoot string) ([]func(generators.GeneratedCode) E.Either[error, generators.GeneratedCode], error) {
+	if _, err := os.Stat(filepath.Join(projectRoot, "forge.hcl")); os.IsNotExist(err) {
+		return nil, nil
 	}
-
-	// Scan for .tf files
-	entries, err := os.ReadDir(infraDir)
+	cfg, err := config.Load(projectRoot)
 	if err != nil {
-		return E.Left[generators.ProjectState](
-			fmt.Errorf("failed to read infra directory: %w", err),
-		)
+		return nil, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".tf" {
-			state.InfraFiles = append(state.InfraFiles, filepath.Join(infraDir, entry.Name()))
+	var transforms []func(generators.GeneratedCode) E.Either[error, generators.GeneratedCode]
+	if config.ModuleSource(cfg) == config.ModuleSourceVendored {
+		lock, err := modvendor.ReadLock(projectRoot)
+		if err != nil {
+			return nil, err
 		}
+		transforms = append(transforms, generators.ToVendoredSources(lock))
+	}
+	if config.TerraformFormat(cfg) == config.TerraformFormatJSON {
+		transforms = append(transforms, generators.ToTerraformJSON)
 	}
+	return transforms, nil
+}
 
-	// TODO: Parse .tf files to populate Functions, Queues, etc.
-	// For MVP, just check if files exist
+// inProjectFormat applies the project's transforms to generated code (PURE).
+func inProjectFormat(transforms []func(generators.GeneratedCode) E.Either[error, generators.GeneratedCode], code E.Either[error, generators.GeneratedCode]) E.Either[error, generators.GeneratedCode] {
+	for _, transform := range transforms {
+		code = E.Chain(transform)(code)
+	}
+	return code
+}
 
-	return E.Right[error](state)
+// discoverProjectState indexes the resources the project declares in infra/
+// and the functions in src/functions (I/O ACTION).
+func discoverProjectState(projectRoot string) E.Either[error, generators.ProjectState] {
+	return discovery.IndexProject(projectRoot)
 }
 
-// writeGeneratedFiles persists code to disk (I/O ACTION).
-func writeGeneratedFiles(code generators.GeneratedCode, infraDir string) E.Either[error, generators.WrittenFiles] {
-	written := generators.WrittenFiles{
-		Created: []string{},
-		Updated: []string{},
-		Skipped: []string{},
+// plannedFile is the content forge add leaves a file with (PURE DATA).
+type plannedFile struct {
+	Path     string               // Relative path from infra/
+	Existing []byte               // Content before, nil if the file doesn't exist
+	Content  []byte               // Content after
+	Blocks   []hclgen.BlockChange // Top-level blocks merged into the file
+}
+
+// exists reports whether the file existed before.
+func (f plannedFile) exists() bool {
+	return f.Existing != nil
+}
+
+// changed reports whether writing the file changes it.
+func (f plannedFile) changed() bool {
+	return !f.exists() || !bytes.Equal(f.Existing, f.Content)
+}
+
+// writeGeneratedFiles persists code to disk, merging its blocks into existing
+// files. Nothing is written when a block can't be merged: blocks edited since
+// forge generated them are only replaced with force (I/O ACTION).
+func writeGeneratedFiles(code generators.GeneratedCode, infraDir string, force bool) E.Either[error, generators.WrittenFiles] {
+	planned, err := planGeneratedFiles(code, infraDir, force)
+	if err != nil {
+		return E.Left[generators.WrittenFiles](err)
 	}
 
 	// Ensure infra directory exists
@@ -244,84 +385,181 @@ func writeGeneratedFiles(code generators.GeneratedCode, infraDir string) E.Eithe
 		)
 	}
 
-	// Write each file
-	for _, file := range code.Files {
-		filePath := filepath.Join(infraDir, file.Path)
-
-		switch file.Mode {
-		case generators.WriteModeCreate:
-			// Create new file (error if exists)
-			if _, err := os.Stat(filePath); err == nil {
-				written.Skipped = append(written.Skipped, file.Path)
-				continue
-			}
-			//nolint:gosec // User-generated file permissions
-			if err := os.WriteFile(filePath, []byte(file.Content), 0o644); err != nil {
-				return E.Left[generators.WrittenFiles](
-					fmt.Errorf("failed to create %s: %w", file.Path, err),
-				)
-			}
+	written := generators.WrittenFiles{
+		Created: []string{},
+		Updated: []string{},
+		Skipped: []string{},
+	}
+	for _, file := range planned {
+		for _, block := range file.Blocks {
+			written.Blocks = append(written.Blocks, generators.WrittenBlock{
+				File: file.Path, Address: block.Address, Action: block.Action,
+			})
+		}
+
+		if !file.changed() {
+			written.Skipped = append(written.Skipped, file.Path)
+			continue
+		}
+		//nolint:gosec // User-generated file permissions
+		if err := os.WriteFile(filepath.Join(infraDir, file.Path), file.Content, 0o644); err != nil {
+			return E.Left[generators.WrittenFiles](
+				fmt.Errorf("failed to write %s: %w", file.Path, err),
+			)
+		}
+		if file.exists() {
+			written.Updated = append(written.Updated, file.Path)
+		} else {
 			written.Created = append(written.Created, file.Path)
+		}
+	}
 
-		case generators.WriteModeAppend:
-			// Append to existing file or create new
-			f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
-			if err != nil {
-				return E.Left[generators.WrittenFiles](
-					fmt.Errorf("failed to open %s: %w", file.Path, err),
-				)
-			}
-			defer func() {
-				if err := f.Close(); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to close file %s: %v\n", filePath, err)
-				}
-			}()
-
-			// Check if file existed before
-			stat, err := os.Stat(filePath)
-			if err != nil && !os.IsNotExist(err) {
-				return E.Left[generators.WrittenFiles](
-					fmt.Errorf("failed to stat %s: %w", filePath, err),
-				)
-			}
-			existed := stat != nil && stat.Size() > 0
+	return E.Right[error](written)
+}
 
-			if _, err := f.WriteString("\n" + file.Content); err != nil {
-				return E.Left[generators.WrittenFiles](
-					fmt.Errorf("failed to append to %s: %w", file.Path, err),
-				)
-			}
+// recordGeneratedFiles merges the files of code into a resource's record,
+// one file per file of infra/ it was written to. Records are merged as infra/
+// is, so adding a resource again records its blocks as they are now, and
+// forge remove takes out exactly the blocks forge add wrote (I/O ACTION).
+func recordGeneratedFiles(code generators.GeneratedCode, dir string) error {
+	for _, file := range code.Files {
+		if file.Mode == generators.WriteModeCreate {
+			continue
+		}
+		path := filepath.Join(dir, file.Path)
+		existing, err := readExistingFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read record of %s: %w", file.Path, err)
+		}
 
-			if existed {
-				written.Updated = append(written.Updated, file.Path)
-			} else {
-				written.Created = append(written.Created, file.Path)
-			}
+		var content []byte
+		if strings.HasSuffix(file.Path, ".tf.json") {
+			content, _, err = hclgen.MergeTFJSONBlocks(existing, []byte(file.Content), true)
+		} else {
+			content, _, err = hclgen.MergeHCL(existing, []byte(file.Content), file.Path, true)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record %s: %w", file.Path, err)
+		}
 
-		case generators.WriteModeUpdate:
-			// Update existing resource in file (requires parsing)
-			// For MVP, treat as append
-			// TODO: Implement smart update in Phase 2
-			f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
-			if err != nil {
-				return E.Left[generators.WrittenFiles](
-					fmt.Errorf("failed to open %s: %w", file.Path, err),
-				)
-			}
-			defer func() {
-				if err := f.Close(); err != nil {
-					fmt.Fprintf(os.Stderr, "Warning: failed to close file %s: %v\n", filePath, err)
-				}
-			}()
+		//nolint:gosec // User-facing directory needs read access
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+		//nolint:gosec // User-generated file permissions
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			return fmt.Errorf("failed to record %s: %w", file.Path, err)
+		}
+	}
+	return nil
+}
 
-			if _, err := f.WriteString("\n" + file.Content); err != nil {
-				return E.Left[generators.WrittenFiles](
-					fmt.Errorf("failed to write to %s: %w", file.Path, err),
-				)
+// recordDir returns the directory forge add records the code it generated
+// for a resource in, e.g. .forge/resources/sqs/orders (PURE).
+func recordDir(projectRoot string, resourceType generators.ResourceType, name string) string {
+	return filepath.Join(projectRoot, resourcesDir, string(resourceType), name)
+}
+
+// previewGeneratedFiles prints a unified diff of every file writing code would
+// change, without writing anything. Fails if there are changes, so CI can
+// check that infra/ is up to date (I/O ACTION).
+func previewGeneratedFiles(out *ui.Output, code generators.GeneratedCode, infraDir string, force bool) error {
+	planned, err := planGeneratedFiles(code, infraDir, force)
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	for _, file := range planned {
+		if !file.changed() {
+			continue
+		}
+		diff, err := unifiedDiff(file)
+		if err != nil {
+			return fmt.Errorf("failed to diff %s: %w", file.Path, err)
+		}
+		out.Print("")
+		out.Diff(diff)
+		changed++
+	}
+
+	out.Print("")
+	if changed == 0 {
+		out.Success("No changes: infra/ is up to date")
+		return nil
+	}
+	out.Info("Run without --dry-run to write these changes")
+	return fmt.Errorf("dry run: %d of %d files would change", changed, len(planned))
+}
+
+// unifiedDiff returns the unified diff of a planned file, from a/infra/<path>
+// (or /dev/null for new files) to b/infra/<path> (PURE).
+func unifiedDiff(file plannedFile) (string, error) {
+	from := "a/infra/" + file.Path
+	if !file.exists() {
+		from = "/dev/null"
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(file.Existing),
+		B:        diffLines(file.Content),
+		FromFile: from,
+		ToFile:   "b/infra/" + file.Path,
+		Context:  3,
+	})
+}
+
+// diffLines splits content into lines for difflib (PURE).
+func diffLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	return difflib.SplitLines(strings.TrimSuffix(string(content), "\n"))
+}
+
+// planGeneratedFiles works out what writing code does to each file, without
+// writing anything. WriteModeCreate files are left alone if they exist; other
+// files get the generated blocks merged in (I/O ACTION: reads infra/).
+func planGeneratedFiles(code generators.GeneratedCode, infraDir string, force bool) ([]plannedFile, error) {
+	planned := make([]plannedFile, 0, len(code.Files))
+	for _, file := range code.Files {
+		existing, err := readExistingFile(filepath.Join(infraDir, file.Path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+
+		plan := plannedFile{Path: file.Path, Existing: existing, Content: existing}
+		switch {
+		case file.Mode == generators.WriteModeCreate:
+			if !plan.exists() {
+				plan.Content = []byte(file.Content)
 			}
-			written.Updated = append(written.Updated, file.Path)
+		case strings.HasSuffix(file.Path, ".tf.json"):
+			plan.Content, plan.Blocks, err = hclgen.MergeTFJSONBlocks(existing, []byte(file.Content), force)
+		default:
+			plan.Content, plan.Blocks, err = hclgen.MergeHCL(existing, []byte(file.Content), file.Path, force)
+		}
+		if errors.Is(err, hclgen.ErrBlockEdited) {
+			return nil, fmt.Errorf("failed to update %s: %w (use --force to replace it)", file.Path, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", file.Path, err)
 		}
+		planned = append(planned, plan)
 	}
+	return planned, nil
+}
 
-	return E.Right[error](written)
+// readExistingFile returns a file's content: nil if it doesn't exist, empty if
+// it is empty (I/O ACTION).
+func readExistingFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case content == nil:
+		return []byte{}, nil
+	}
+	return content, nil
 }
@@ -1,15 +1,22 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	E "github.com/IBM/fp-go/either"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lewis/forge"
 	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/modvendor"
+	"github.com/lewis/forge/internal/ui"
 )
 
 // Helper to extract ProjectState from Either.
@@ -36,6 +43,14 @@ func extractWritten(result E.Either[error, generators.WrittenFiles]) generators.
 	)(result)
 }
 
+// Helper to extract error from WrittenFiles Either.
+func extractWrittenError(result E.Either[error, generators.WrittenFiles]) error {
+	return E.Fold(
+		func(e error) error { return e },
+		func(generators.WrittenFiles) error { return nil },
+	)(result)
+}
+
 // TestCreateGeneratorRegistry tests registry creation.
 func TestCreateGeneratorRegistry(t *testing.T) {
 	registry := createGeneratorRegistry()
@@ -48,6 +63,7 @@ func TestCreateGeneratorRegistry(t *testing.T) {
 		generators.ResourceDynamoDB,
 		generators.ResourceSNS,
 		generators.ResourceS3,
+		generators.ResourceEventBridge,
 	}
 
 	for _, resourceType := range generators {
@@ -129,7 +145,7 @@ func TestWriteGeneratedFiles(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 
 		require.True(t, E.IsRight(result), "Should succeed")
 		written := extractWritten(result)
@@ -163,7 +179,7 @@ func TestWriteGeneratedFiles(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 
 		require.True(t, E.IsRight(result), "Should succeed")
 		written := extractWritten(result)
@@ -191,7 +207,7 @@ func TestWriteGeneratedFiles(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 
 		require.True(t, E.IsRight(result), "Should succeed")
 		written := extractWritten(result)
@@ -224,7 +240,7 @@ func TestWriteGeneratedFiles(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 
 		require.True(t, E.IsRight(result), "Should succeed")
 		written := extractWritten(result)
@@ -263,7 +279,7 @@ func TestWriteGeneratedFiles(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 
 		require.True(t, E.IsRight(result), "Should succeed")
 		written := extractWritten(result)
@@ -292,7 +308,7 @@ func TestWriteGeneratedFiles(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 
 		require.True(t, E.IsRight(result), "Should succeed")
 
@@ -302,6 +318,113 @@ func TestWriteGeneratedFiles(t *testing.T) {
 		assert.True(t, stat.IsDir())
 	})
 
+	t.Run("merges into existing Terraform JSON", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+		filePath := filepath.Join(infraDir, "outputs.tf.json")
+		require.NoError(t, os.WriteFile(filePath, []byte(`{"output": {"a": {"value": 1}}}`), 0o644))
+
+		code := generators.GeneratedCode{
+			Files: []generators.FileToWrite{
+				{Path: "outputs.tf.json", Content: `{"output": {"b": {"value": 2}}}`, Mode: generators.WriteModeAppend},
+				{Path: "sqs.tf.json", Content: `{"module": {"q": {"source": "./q"}}}`, Mode: generators.WriteModeAppend},
+			},
+		}
+
+		result := writeGeneratedFiles(code, infraDir, false)
+
+		require.True(t, E.IsRight(result), "Should succeed")
+		written := extractWritten(result)
+		assert.Equal(t, []string{"outputs.tf.json"}, written.Updated)
+		assert.Equal(t, []string{"sqs.tf.json"}, written.Created)
+
+		content, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"output": {"a": {"value": 1}, "b": {"value": 2}}}`, string(content))
+	})
+
+	t.Run("rejects conflicting Terraform JSON", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+		filePath := filepath.Join(infraDir, "sqs.tf.json")
+		require.NoError(t, os.WriteFile(filePath, []byte(`{"module": {"q": {"source": "./q"}}}`), 0o644))
+
+		code := generators.GeneratedCode{
+			Files: []generators.FileToWrite{
+				{Path: "sqs.tf.json", Content: `{"module": {"q": {"source": "./other"}}}`, Mode: generators.WriteModeAppend},
+			},
+		}
+
+		result := writeGeneratedFiles(code, infraDir, false)
+
+		require.True(t, E.IsLeft(result))
+		content, err := os.ReadFile(filePath)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"module": {"q": {"source": "./q"}}}`, string(content), "file is left untouched")
+	})
+
+	t.Run("merges blocks instead of duplicating them", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+
+		code := generators.GeneratedCode{
+			Files: []generators.FileToWrite{
+				{Path: "outputs.tf", Content: "output \"a\" {\n  value = 1\n}\n", Mode: generators.WriteModeAppend},
+			},
+		}
+		require.True(t, E.IsRight(writeGeneratedFiles(code, infraDir, false)))
+		first, err := os.ReadFile(filepath.Join(infraDir, "outputs.tf"))
+		require.NoError(t, err)
+
+		code.Files[0].Content += "\noutput \"b\" {\n  value = 2\n}\n"
+		written := extractWritten(writeGeneratedFiles(code, infraDir, false))
+		assert.Equal(t, []string{"outputs.tf"}, written.Updated)
+		assert.Equal(t, []generators.WrittenBlock{
+			{File: "outputs.tf", Address: "output.a", Action: hclgen.BlockSkipped},
+			{File: "outputs.tf", Address: "output.b", Action: hclgen.BlockCreated},
+		}, written.Blocks)
+
+		second, err := os.ReadFile(filepath.Join(infraDir, "outputs.tf"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(second), string(first)))
+		assert.Equal(t, 1, strings.Count(string(second), `output "a"`))
+
+		written = extractWritten(writeGeneratedFiles(code, infraDir, false))
+		assert.Equal(t, []string{"outputs.tf"}, written.Skipped)
+		assert.Empty(t, written.Updated)
+	})
+
+	t.Run("writes nothing when a block was edited", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+		edited := "output \"a\" {\n  value = 3\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(infraDir, "outputs.tf"), []byte(edited), 0o644))
+
+		code := generators.GeneratedCode{
+			Files: []generators.FileToWrite{
+				{Path: "sqs.tf", Content: "module \"q\" {\n  source = \"./q\"\n}\n", Mode: generators.WriteModeAppend},
+				{Path: "outputs.tf", Content: "output \"a\" {\n  value = 1\n}\n", Mode: generators.WriteModeAppend},
+			},
+		}
+
+		result := writeGeneratedFiles(code, infraDir, false)
+		require.True(t, E.IsLeft(result))
+		err := extractWrittenError(result)
+		assert.ErrorIs(t, err, hclgen.ErrBlockEdited)
+		assert.Contains(t, err.Error(), "outputs.tf: output.a was edited since it was generated (use --force to replace it)")
+		assert.NoFileExists(t, filepath.Join(infraDir, "sqs.tf"))
+
+		result = writeGeneratedFiles(code, infraDir, true)
+		require.True(t, E.IsRight(result))
+		content, err := os.ReadFile(filepath.Join(infraDir, "outputs.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "value = 1")
+		assert.FileExists(t, filepath.Join(infraDir, "sqs.tf"))
+	})
+
 	t.Run("update mode appends to file", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		infraDir := filepath.Join(tmpDir, "infra")
@@ -321,7 +444,7 @@ func TestWriteGeneratedFiles(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 
 		require.True(t, E.IsRight(result), "Should succeed")
 		written := extractWritten(result)
@@ -351,7 +474,7 @@ func TestWriteGeneratedFiles_Permissions(t *testing.T) {
 		},
 	}
 
-	result := writeGeneratedFiles(code, infraDir)
+	result := writeGeneratedFiles(code, infraDir, false)
 	require.True(t, E.IsRight(result))
 
 	// Verify file permissions
@@ -384,7 +507,7 @@ func TestWriteGeneratedFiles_ErrorHandling(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 
 		require.True(t, E.IsLeft(result), "Should fail with invalid path")
 	})
@@ -406,6 +529,69 @@ func TestAddCommand_FlagDefaults(t *testing.T) {
 	noModuleFlag := cmd.Flags().Lookup("no-module")
 	assert.NotNil(t, noModuleFlag)
 	assert.Equal(t, "false", noModuleFlag.DefValue)
+
+	forceFlag := cmd.Flags().Lookup("force")
+	assert.NotNil(t, forceFlag)
+	assert.Equal(t, "false", forceFlag.DefValue)
+
+	for _, name := range []string{"dry-run", "diff"} {
+		flag := cmd.Flags().Lookup(name)
+		assert.NotNil(t, flag, name)
+		assert.Equal(t, "false", flag.DefValue, name)
+	}
+
+	for _, name := range []string{"schedule", "pattern", "bus", "to-bus"} {
+		flag := cmd.Flags().Lookup(name)
+		assert.NotNil(t, flag, name)
+		assert.Equal(t, "", flag.DefValue, name)
+	}
+}
+
+// TestPreviewGeneratedFiles tests the diff forge add --dry-run prints.
+func TestPreviewGeneratedFiles(t *testing.T) {
+	infraDir := filepath.Join(t.TempDir(), "infra")
+	require.NoError(t, os.MkdirAll(infraDir, 0o755))
+	existing := "output \"a\" {\n  value = 1\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(infraDir, "outputs.tf"), []byte(existing), 0o644))
+
+	code := generators.GeneratedCode{
+		Files: []generators.FileToWrite{
+			{Path: "notes.tf", Content: "# Notes\n", Mode: generators.WriteModeAppend},
+			{Path: "outputs.tf", Content: existing + "\noutput \"b\" {\n  value = 2\n}\n", Mode: generators.WriteModeAppend},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	err := previewGeneratedFiles(ui.NewOutput(buf), code, infraDir, false)
+	require.Error(t, err)
+	assert.Equal(t, "dry run: 2 of 2 files would change", err.Error())
+
+	output := buf.String()
+	assert.Contains(t, output, "--- /dev/null\n+++ b/infra/notes.tf\n@@ -0,0 +1 @@\n+# Notes\n")
+	assert.Contains(t, output, "--- a/infra/outputs.tf\n+++ b/infra/outputs.tf\n")
+	assert.Contains(t, output, "+output \"b\" {\n+  # forge:checksum ")
+	assert.NoFileExists(t, filepath.Join(infraDir, "notes.tf"))
+	content, err := os.ReadFile(filepath.Join(infraDir, "outputs.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, existing, string(content), "nothing is written")
+
+	t.Run("succeeds without changes", func(t *testing.T) {
+		require.True(t, E.IsRight(writeGeneratedFiles(code, infraDir, false)))
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, previewGeneratedFiles(ui.NewOutput(buf), code, infraDir, false))
+		assert.NotContains(t, buf.String(), "---")
+		assert.Contains(t, buf.String(), "No changes")
+	})
+
+	t.Run("fails on edited blocks", func(t *testing.T) {
+		edited := code
+		edited.Files = []generators.FileToWrite{{Path: "outputs.tf", Content: "output \"a\" {\n  value = 3\n}\n", Mode: generators.WriteModeAppend}}
+
+		err := previewGeneratedFiles(ui.NewOutput(&bytes.Buffer{}), edited, infraDir, false)
+		assert.ErrorIs(t, err, hclgen.ErrBlockEdited)
+		require.NoError(t, previewGeneratedFiles(ui.NewOutput(&bytes.Buffer{}), code, infraDir, true))
+	})
 }
 
 // TestRunAdd tests the runAdd command execution.
@@ -421,7 +607,7 @@ func TestRunAdd(t *testing.T) {
 		cmd := NewAddCmd()
 		args := []string{"sqs", "test-queue"}
 
-		err := runAdd(cmd, args, "", false, false)
+		err := runAdd(cmd, args, addOptions{})
 		assert.NoError(t, err)
 
 		// Verify SQS file was created (generators use generic names)
@@ -443,7 +629,7 @@ func TestRunAdd(t *testing.T) {
 		cmd := NewAddCmd()
 		args := []string{"invalid-type", "test-resource"}
 
-		err := runAdd(cmd, args, "", false, false)
+		err := runAdd(cmd, args, addOptions{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unsupported resource type")
 	})
@@ -456,7 +642,7 @@ func TestRunAdd(t *testing.T) {
 		cmd := NewAddCmd()
 		args := []string{"sqs", "test-queue"}
 
-		err := runAdd(cmd, args, "", false, false)
+		err := runAdd(cmd, args, addOptions{})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "infra/ directory not found")
 	})
@@ -474,7 +660,7 @@ func TestRunAdd(t *testing.T) {
 		cmd := NewAddCmd()
 		args := []string{"sqs", "test-queue"}
 
-		err = runAdd(cmd, args, "", true, false)
+		err = runAdd(cmd, args, addOptions{Raw: true})
 		assert.NoError(t, err)
 
 		// Verify file was created (implementation detail: raw mode still creates files)
@@ -499,11 +685,120 @@ func TestRunAdd(t *testing.T) {
 
 		// This will fail because processor-function doesn't exist
 		// We're testing error handling here
-		err = runAdd(cmd, args, "processor-function", false, false)
+		err = runAdd(cmd, args, addOptions{ToFunc: "processor-function"})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "not found")
 	})
 
+	t.Run("integrates with a function discovered in src/functions", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+		fnDir := filepath.Join(tmpDir, "src", "functions", "processor")
+		require.NoError(t, os.MkdirAll(fnDir, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(fnDir, "index.js"), []byte("exports.handler = async () => {}\n"), 0o644))
+
+		t.Chdir(tmpDir)
+
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "q"}, addOptions{ToFunc: "processor"}))
+
+		content, err := os.ReadFile(filepath.Join(infraDir, "lambda_processor.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "module.processor.lambda_function_arn")
+		assert.Contains(t, string(content), "module.processor.lambda_role_name")
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+
+		t.Chdir(tmpDir)
+
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{}))
+		first, err := os.ReadFile(filepath.Join(infraDir, "sqs.tf"))
+		require.NoError(t, err)
+
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{}))
+		second, err := os.ReadFile(filepath.Join(infraDir, "sqs.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, string(first), string(second))
+		assert.Equal(t, 1, strings.Count(string(second), `module "orders"`))
+
+		edited := strings.Replace(string(second), "visibility_timeout_seconds = 30", "visibility_timeout_seconds = 60", 1)
+		require.NotEqual(t, string(second), edited)
+		require.NoError(t, os.WriteFile(filepath.Join(infraDir, "sqs.tf"), []byte(edited), 0o644))
+
+		err = runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{})
+		require.ErrorIs(t, err, hclgen.ErrBlockEdited)
+		assert.Contains(t, err.Error(), "use --force")
+		content, err := os.ReadFile(filepath.Join(infraDir, "sqs.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, edited, string(content))
+
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{Force: true}))
+		content, err = os.ReadFile(filepath.Join(infraDir, "sqs.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, string(first), string(content))
+	})
+
+	t.Run("writes nothing in dry-run mode", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+
+		t.Chdir(tmpDir)
+
+		err := runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{DryRun: true})
+		require.Error(t, err, "changes fail the dry run")
+		assert.Contains(t, err.Error(), "would change")
+		assert.NoFileExists(t, filepath.Join(infraDir, "sqs.tf"))
+
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{}))
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{DryRun: true}))
+	})
+
+	t.Run("keeps progress lines off stdout in dry-run mode", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "infra"), 0o755))
+		t.Chdir(tmpDir)
+
+		oldStdout := os.Stdout
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		os.Stdout = w
+		defer func() { os.Stdout = oldStdout }()
+
+		err = runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{DryRun: true})
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		var stdout bytes.Buffer
+		_, _ = stdout.ReadFrom(r)
+
+		require.Error(t, err)
+		assert.Contains(t, stdout.String(), "+++ b/infra/sqs.tf")
+		assert.NotContains(t, stdout.String(), "Discovering project resources")
+		assert.NotContains(t, stdout.String(), "Generating Terraform code")
+	})
+
+	t.Run("records what it writes for forge remove", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "infra"), 0o755))
+		t.Chdir(tmpDir)
+
+		require.Error(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{DryRun: true}))
+		assert.NoDirExists(t, filepath.Join(tmpDir, resourcesDir), "dry runs record nothing")
+
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{}))
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{Raw: true}))
+
+		record, err := os.ReadFile(filepath.Join(tmpDir, resourcesDir, "sqs", "orders", "sqs.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(record), `module "orders"`)
+		assert.Contains(t, string(record), `resource "aws_sqs_queue" "orders"`, "adding again records the new blocks too")
+		assert.FileExists(t, filepath.Join(tmpDir, resourcesDir, "sqs", "orders", "outputs.tf"))
+	})
+
 	t.Run("respects no-module flag", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		infraDir := filepath.Join(tmpDir, "infra")
@@ -515,14 +810,13 @@ func TestRunAdd(t *testing.T) {
 		require.NoError(t, os.Chdir(tmpDir))
 
 		cmd := NewAddCmd()
-		args := []string{"sqs", "test-queue"}
+		cmd.SetArgs([]string{"sqs", "test-queue", "--no-module"})
+		require.NoError(t, cmd.Execute())
 
-		err = runAdd(cmd, args, "", false, true)
-		assert.NoError(t, err)
-
-		// Verify file was created
-		sqsFile := filepath.Join(infraDir, "sqs.tf")
-		assert.FileExists(t, sqsFile)
+		content, err := os.ReadFile(filepath.Join(infraDir, "sqs.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `resource "aws_sqs_queue" "test_queue"`)
+		assert.NotContains(t, string(content), "module ")
 	})
 
 	t.Run("supports DynamoDB resource type", func(t *testing.T) {
@@ -538,7 +832,7 @@ func TestRunAdd(t *testing.T) {
 		cmd := NewAddCmd()
 		args := []string{"dynamodb", "test-table"}
 
-		err = runAdd(cmd, args, "", false, false)
+		err = runAdd(cmd, args, addOptions{})
 		assert.NoError(t, err)
 
 		// Verify DynamoDB file was created
@@ -559,7 +853,7 @@ func TestRunAdd(t *testing.T) {
 		cmd := NewAddCmd()
 		args := []string{"sns", "test-topic"}
 
-		err = runAdd(cmd, args, "", false, false)
+		err = runAdd(cmd, args, addOptions{})
 		assert.NoError(t, err)
 
 		// Verify SNS file was created
@@ -580,11 +874,64 @@ func TestRunAdd(t *testing.T) {
 		cmd := NewAddCmd()
 		args := []string{"s3", "test-bucket"}
 
-		err = runAdd(cmd, args, "", false, false)
+		err = runAdd(cmd, args, addOptions{})
 		assert.NoError(t, err)
 
 		// Verify S3 file was created
 		s3File := filepath.Join(infraDir, "s3.tf")
 		assert.FileExists(t, s3File)
 	})
+
+	t.Run("writes Terraform JSON when forge.hcl asks for it", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+		forgeHCL := "project {\n  name             = \"app\"\n  region           = \"us-east-1\"\n  terraform_format = \"json\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "forge.hcl"), []byte(forgeHCL), 0o644))
+
+		t.Chdir(tmpDir)
+
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{}))
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "payments"}, addOptions{}))
+
+		assert.NoFileExists(t, filepath.Join(infraDir, "sqs.tf"))
+		content, err := os.ReadFile(filepath.Join(infraDir, "sqs.tf.json"))
+		require.NoError(t, err)
+
+		var doc struct {
+			Module map[string]map[string]interface{} `json:"module"`
+		}
+		require.NoError(t, json.Unmarshal(content, &doc))
+		assert.Contains(t, doc.Module, "orders")
+		assert.Contains(t, doc.Module, "payments")
+		assert.Equal(t, "terraform-aws-modules/sqs/aws", doc.Module["orders"]["source"])
+
+		outputs, err := os.ReadFile(filepath.Join(infraDir, "outputs.tf.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(outputs), "${module.orders")
+	})
+
+	t.Run("loads vendored modules when forge.hcl asks for it", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+		forgeHCL := "project {\n  name          = \"app\"\n  region        = \"us-east-1\"\n  module_source = \"vendored\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "forge.hcl"), []byte(forgeHCL), 0o644))
+
+		t.Chdir(tmpDir)
+
+		err := runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `run "forge modules vendor sqs"`)
+		assert.NoFileExists(t, filepath.Join(infraDir, "sqs.tf"))
+
+		_, err = modvendor.Vendor(forge.Modules(), tmpDir, []string{"sqs"})
+		require.NoError(t, err)
+		require.NoError(t, runAdd(NewAddCmd(), []string{"sqs", "orders"}, addOptions{}))
+
+		content, err := os.ReadFile(filepath.Join(infraDir, "sqs.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `source                          = "../.forge/modules/sqs"`)
+		assert.NotContains(t, string(content), "version")
+	})
 }
@@ -2,12 +2,14 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	A "github.com/IBM/fp-go/array"
 	E "github.com/IBM/fp-go/either"
+	O "github.com/IBM/fp-go/option"
 	"github.com/spf13/cobra"
 
 	"github.com/lewis/forge/internal/build"
@@ -17,7 +19,11 @@ import (
 
 // NewBuildCmd creates the 'build' command.
 func NewBuildCmd() *cobra.Command {
-	var stubOnly bool
+	var (
+		stubOnly bool
+		jobs     int
+		noCache  bool
+	)
 
 	cmd := &cobra.Command{
 		Use:   "build",
@@ -37,13 +43,21 @@ Automatically discovers functions, detects runtimes, and creates deployment pack
     - index.js/handler.js    → Node.js (nodejs20.x)
     - app.py/lambda_function → Python (python3.13)
   • Output: .forge/build/{name}.zip
+  • Terraform: infra/functions.gen.tf (one Lambda module per function),
+    or functions.gen.tf.json with terraform_format = "json" in forge.hcl
 
 📦 Build Process:
   1. Scans src/functions/* for function directories
   2. Detects runtime from entry file
-  3. Runs runtime-specific builder (go build, npm install, pip)
-  4. Creates deployment package with dependencies
-  5. Generates SHA256 checksum for caching
+  3. Regenerates infra/functions.gen.tf (forge-owned; do not edit).
+     Functions declared as module "<name>" in your own .tf files are skipped.
+  4. Runs runtime-specific builder (go build, npm install, pip)
+  5. Creates deployment package with dependencies
+  6. Generates SHA256 checksum for caching
+  Unchanged functions (same sources, lockfiles, runtime, handler and env)
+  are restored from .forge/cache instead of being rebuilt (--no-cache to skip).
+  Functions are built in parallel (--jobs, default: number of CPUs);
+  every failure is reported, not just the first.
 
 🚀 Examples:
 
@@ -53,8 +67,14 @@ Automatically discovers functions, detects runtimes, and creates deployment pack
   # Create stub zips only (for terraform init)
   forge build --stub-only
 
+  # Limit concurrent builds (e.g. on a small CI runner)
+  forge build --jobs 2
+
+  # Force a full rebuild
+  forge build --no-cache
+
 💡 Pro Tips:
-  • Build artifacts are cached by checksum
+  • Build artifacts are cached by a hash of their inputs in .forge/cache
   • Dependencies are bundled automatically
   • Stub zips allow Terraform to initialize before real build
   • Use --verbose to see detailed build output
@@ -67,17 +87,20 @@ Automatically discovers functions, detects runtimes, and creates deployment pack
       └── index.js  # Runtime: Node.js
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runBuild(stubOnly)
+			return runBuild(stubOnly, jobs, noCache)
 		},
 	}
 
 	cmd.Flags().BoolVar(&stubOnly, "stub-only", false, "Create stub zips without building")
+	cmd.Flags().IntVar(&jobs, "jobs", build.DefaultJobs(), "Number of functions to build concurrently")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Rebuild every function, ignoring .forge/cache")
 
 	return cmd
 }
 
-// runBuild executes the build process.
-func runBuild(stubOnly bool) error {
+// runBuild executes the build process, building up to jobs functions at once.
+// Unless noCache is set, functions whose inputs are unchanged are restored from .forge/cache.
+func runBuild(stubOnly bool, jobs int, noCache bool) error {
 	out := ui.DefaultOutput()
 
 	ctx := context.Background()
@@ -130,7 +153,7 @@ func runBuild(stubOnly bool) error {
 		}
 		out.Success("Created %d stub zip(s)", count)
 		out.Dim("Output: %s", buildDir)
-		return nil
+		return generateFunctionsTerraform(out, projectRoot, functions)
 	}
 
 	// Always ensure stubs exist before building (for terraform init)
@@ -139,82 +162,90 @@ func runBuild(stubOnly bool) error {
 		return fmt.Errorf("failed to create stub zips: %w", err)
 	}
 
-	// Create build registry
+	if err := generateFunctionsTerraform(out, projectRoot, functions); err != nil {
+		return err
+	}
+
+	// Create build registry; unchanged functions are restored from .forge/cache
 	registry := build.NewRegistry()
+	if !noCache {
+		registry = build.CachedRegistry(registry, build.NewDiskCache(build.DefaultCacheDir(projectRoot)))
+	}
 
-	// Build single function with UI feedback
-	buildOne := func(index int, fn discovery.Function) E.Either[error, build.Artifact] {
-		out.Step(index+1, len(functions), "Building "+fn.Name)
-
-		// Get builder from registry and convert Option to Either
-		builderEither := E.FromOption[build.BuildFunc](
-			func() error {
-				out.Error("Unsupported runtime: %s", fn.Runtime)
-				out.Warning("Supported runtimes:")
-				out.Print("  • provided.al2023, provided.al2 (Go)")
-				out.Print("  • nodejs20.x, nodejs18.x (Node.js)")
-				out.Print("  • python3.13, python3.12, python3.11 (Python)")
-				return fmt.Errorf("unsupported runtime: %s", fn.Runtime)
+	// Same conversion as the deploy pipeline, so both write .forge/build/{name}.zip
+	configs := make([]build.Config, 0, len(functions))
+	var configErrs []error
+	for _, fn := range functions {
+		E.Fold(
+			func(err error) any {
+				out.Error("Invalid build config for %s: %v", fn.Name, err)
+				configErrs = append(configErrs, fmt.Errorf("invalid build config for %s: %w", fn.Name, err))
+				return nil
 			},
-		)(build.GetBuilder(registry, fn.Runtime))
-
-		// Chain the build operation
-		return E.Chain(func(builder build.BuildFunc) E.Either[error, build.Artifact] {
-			cfg := build.Config{
-				SourceDir:  fn.Path,
-				OutputPath: filepath.Join(buildDir, fn.Name),
-				Handler:    fn.EntryPoint,
-				Runtime:    fn.Runtime,
-				Env:        make(map[string]string),
-			}
-
-			// Execute build and add error context
-			return E.MapLeft[build.Artifact](func(err error) error {
-				out.Error("Build failed for %s: %v", fn.Name, err)
-				out.Warning("Debug tips:")
-				out.Print("  • Check function source code in %s", fn.Path)
-				out.Print("  • Verify dependencies are specified correctly")
-				out.Print("  • Review build logs above for details")
-				return fmt.Errorf("failed to build %s: %w", fn.Name, err)
-			})(builder(ctx, cfg))
-		})(builderEither)
+			func(cfg build.Config) any {
+				configs = append(configs, cfg)
+				return nil
+			},
+		)(discovery.ToBuildConfig(fn, buildDir))
 	}
-
-	// Build all functions functionally using indexed map and fold
-	type indexedFunc struct {
-		index int
-		fn    discovery.Function
+	if len(configErrs) > 0 {
+		return errors.Join(configErrs...)
 	}
 
-	// Create indexed list
-	indexed := A.MapWithIndex(func(i int, fn discovery.Function) indexedFunc {
-		return indexedFunc{index: i, fn: fn}
-	})(functions)
-
-	// Build all and short-circuit on first error
-	artifactsEither := A.Reduce(
-		func(acc E.Either[error, []build.Artifact], item indexedFunc) E.Either[error, []build.Artifact] {
-			return E.Chain(func(artifacts []build.Artifact) E.Either[error, []build.Artifact] {
-				return E.Map[error](func(artifact build.Artifact) []build.Artifact {
-					// Print success message
-					sizeMB := float64(artifact.Size) / 1024 / 1024
-					out.Success("%s: %s (%.2f MB, checksum: %s)",
-						item.fn.Name,
-						filepath.Base(artifact.Path),
-						sizeMB,
-						artifact.Checksum[:8],
-					)
-					return append(artifacts, artifact)
-				})(buildOne(item.index, item.fn))
-			})(acc)
-		},
-		E.Right[error]([]build.Artifact{}),
-	)(indexed)
+	workers := max(1, min(jobs, len(functions)))
+	out.Info("Building %d function(s) with %d worker(s)", len(functions), workers)
+
+	// Report each function as soon as it finishes (callbacks are serialized)
+	completed := 0
+	results := build.BuildConcurrent(ctx, configs, registry, jobs, func(r build.BuildResult) {
+		completed++
+		fn := functions[r.Index]
+
+		E.Fold(
+			func(err error) struct{} {
+				out.Step(completed, len(functions), "Failed "+fn.Name)
+				reportBuildFailure(out, registry, fn, err)
+				return struct{}{}
+			},
+			func(artifact build.Artifact) struct{} {
+				verb := "Built "
+				if artifact.Cached {
+					verb = "Cached "
+				}
+				out.Step(completed, len(functions), verb+fn.Name)
+				sizeMB := float64(artifact.Size) / 1024 / 1024
+				out.Success("%s: %s (%.2f MB, checksum: %s)",
+					fn.Name,
+					filepath.Base(artifact.Path),
+					sizeMB,
+					artifact.Checksum[:min(8, len(artifact.Checksum))],
+				)
+				return struct{}{}
+			},
+		)(r.Result)
+	})
+
+	// Attach function names to every failure so none are lost
+	named := A.Map(func(r build.BuildResult) build.BuildResult {
+		r.Result = E.MapLeft[build.Artifact](func(err error) error {
+			return fmt.Errorf("failed to build %s: %w", functions[r.Index].Name, err)
+		})(r.Result)
+		return r
+	})(results)
+
+	failed := A.Reduce(func(count int, r build.BuildResult) int {
+		if E.IsLeft(r.Result) {
+			return count + 1
+		}
+		return count
+	}, 0)(results)
 
 	// Handle final result
 	return E.Fold(
 		func(err error) error {
-			return err
+			out.Print("")
+			out.Error("%d of %d function(s) failed to build", failed, len(functions))
+			return fmt.Errorf("%d of %d function(s) failed to build: %w", failed, len(functions), err)
 		},
 		func(artifacts []build.Artifact) error {
 			out.Print("")
@@ -222,5 +253,42 @@ func runBuild(stubOnly bool) error {
 			out.Dim("Output directory: %s", buildDir)
 			return nil
 		},
-	)(artifactsEither)
+	)(build.CollectResults(named))
+}
+
+// generateFunctionsTerraform regenerates infra/functions.gen.tf for the discovered functions.
+// ACTION: Performs I/O and writes to output.
+func generateFunctionsTerraform(out *ui.Output, projectRoot string, functions []discovery.Function) error {
+	result, err := discovery.WriteFunctionsTerraform(projectRoot, functions)
+	if err != nil {
+		out.Error("Failed to generate function Terraform: %v", err)
+		return fmt.Errorf("failed to generate function terraform: %w", err)
+	}
+
+	for _, name := range result.Skipped {
+		out.Dim("Skipping %s: module %q is declared in infra/", name, name)
+	}
+	if result.Written && len(result.Generated) > 0 {
+		out.Success("Generated infra/%s (%d function(s))", filepath.Base(result.Path), len(result.Generated))
+	}
+	return nil
+}
+
+// reportBuildFailure prints troubleshooting hints for a failed function build.
+// ACTION: Writes to output.
+func reportBuildFailure(out *ui.Output, registry build.Registry, fn discovery.Function, err error) {
+	if O.IsNone(build.GetBuilder(registry, fn.Runtime)) {
+		out.Error("Unsupported runtime for %s: %s", fn.Name, fn.Runtime)
+		out.Warning("Supported runtimes:")
+		out.Print("  • provided.al2023, provided.al2 (Go)")
+		out.Print("  • nodejs20.x, nodejs18.x (Node.js)")
+		out.Print("  • python3.13, python3.12, python3.11 (Python)")
+		return
+	}
+
+	out.Error("Build failed for %s: %v", fn.Name, err)
+	out.Warning("Debug tips:")
+	out.Print("  • Check function source code in %s", fn.Path)
+	out.Print("  • Verify dependencies are specified correctly")
+	out.Print("  • Review build logs above for details")
 }
@@ -37,7 +37,7 @@ func TestRunBuild(t *testing.T) {
 		defer func() { _ = os.Chdir(origDir) }()
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(false)
+		err := runBuild(false, 1, false)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to scan functions")
 	})
@@ -54,7 +54,7 @@ func TestRunBuild(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		// Verify stub was created
@@ -74,7 +74,7 @@ func TestRunBuild(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Should succeed with no functions message
-		err := runBuild(false)
+		err := runBuild(false, 1, false)
 		assert.NoError(t, err)
 	})
 
@@ -92,7 +92,7 @@ func TestRunBuild(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(false)
+		err := runBuild(false, 1, false)
 		// Should succeed with no functions message (Ruby is not detected)
 		assert.NoError(t, err)
 	})
@@ -121,7 +121,7 @@ func TestRunBuild(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(false)
+		err := runBuild(false, 1, false)
 		assert.NoError(t, err)
 
 		// Verify build artifact created
@@ -144,7 +144,7 @@ func TestRunBuild(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Use stub-only for faster test
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		// Verify all stubs created
@@ -170,7 +170,7 @@ func TestRunBuild(t *testing.T) {
 		buildDir := filepath.Join(tmpDir, ".forge", "build")
 		require.NoDirExists(t, buildDir)
 
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		// Verify build directory was created
@@ -195,7 +195,7 @@ this is not valid go code
 		_ = os.Chdir(tmpDir)
 
 		// Stub-only should still work (doesn't compile)
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 	})
 
@@ -217,7 +217,7 @@ this is not valid go code
 		_ = os.Chdir(tmpDir)
 
 		// Stub-only for faster test
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		// Verify stub created
@@ -248,7 +248,7 @@ this is not valid go code
 		_ = os.Chdir(tmpDir)
 
 		// Use stub-only for faster test
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		// Verify all stubs created
@@ -271,7 +271,7 @@ this is not valid go code
 		_ = os.Chdir(tmpDir)
 
 		// Run stub build - should output success messages
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 	})
 
@@ -291,7 +291,7 @@ this is not valid go code
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		stubPath := filepath.Join(tmpDir, ".forge", "build", "api.zip")
@@ -313,7 +313,7 @@ this is not valid go code
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		stubPath := filepath.Join(tmpDir, ".forge", "build", "worker.zip")
@@ -328,7 +328,7 @@ this is not valid go code
 		_ = os.Chdir(tmpDir)
 
 		// Without src/functions, we'll get scan error
-		err := runBuild(false)
+		err := runBuild(false, 1, false)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to scan functions")
 	})
@@ -352,7 +352,7 @@ func main() {
 		_ = os.Chdir(tmpDir)
 
 		// Stub-only should still work (doesn't compile)
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 	})
 
@@ -368,7 +368,7 @@ func main() {
 		_ = os.Chdir(tmpDir)
 
 		// Should succeed with no functions message
-		err := runBuild(false)
+		err := runBuild(false, 1, false)
 		assert.NoError(t, err)
 	})
 
@@ -388,7 +388,7 @@ func main() {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		stubPath := filepath.Join(tmpDir, ".forge", "build", "api.zip")
@@ -410,7 +410,7 @@ func main() {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		stubPath := filepath.Join(tmpDir, ".forge", "build", "worker.zip")
@@ -432,7 +432,7 @@ func main() {
 		_ = os.Chdir(tmpDir)
 
 		// Normal build should work
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 	})
 
@@ -469,7 +469,7 @@ func main() {
 		_ = os.Chdir(tmpDir)
 
 		// Use stub-only to avoid actual compilation
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 
 		// Verify all stubs were created
@@ -495,7 +495,7 @@ func main() {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 	})
 }
@@ -27,7 +27,7 @@ func TestRunBuildEdgeCases(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Should handle empty directory
-		err := runBuild(false)
+		err := runBuild(false, 1, false)
 		// Either succeeds with no functions or gives appropriate error
 		if err != nil {
 			assert.Contains(t, err.Error(), "no functions found")
@@ -51,7 +51,7 @@ func TestRunBuildEdgeCases(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Build with skip-cache should work
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		// May fail due to missing go.mod, but tests the code path
 		_ = err
 	})
@@ -73,7 +73,7 @@ func TestRunDeployEdgeCases(t *testing.T) {
 		// Test with various namespace values
 		namespaces := []string{"pr-123", "dev", "staging", ""}
 		for _, ns := range namespaces {
-			err := runDeploy(true, ns)
+			err := runDeploy(true, ns, 1, false)
 			// Will fail on missing infra, but tests namespace handling
 			_ = err
 		}
@@ -95,7 +95,7 @@ func TestRunDeployEdgeCases(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runDeploy(true, "")
+		err := runDeploy(true, "", 1, false)
 		require.Error(t, err)
 		// Should mention missing infra or terraform
 		_ = err
@@ -112,7 +112,7 @@ func TestRunDestroyEdgeCases(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Destroy without infra directory
-		err := runDestroy(false)
+		err := runDestroy(false, "")
 		require.Error(t, err)
 	})
 
@@ -136,7 +136,7 @@ func TestRunDestroyEdgeCases(t *testing.T) {
 		_ = os.Setenv("TF_VAR_namespace", "pr-123")
 		defer os.Unsetenv("TF_VAR_namespace")
 
-		err := runDestroy(false)
+		err := runDestroy(false, "")
 		// Will fail on terraform execution, but tests namespace path
 		_ = err
 	})
@@ -149,7 +149,7 @@ func TestRunDestroyEdgeCases(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Auto-approve false tests confirmation path
-		err := runDestroy(false)
+		err := runDestroy(false, "")
 		// Will error on missing infra, but tests the code path
 		_ = err
 	})
@@ -164,7 +164,7 @@ func TestWriteGeneratedFilesEdgeCases(t *testing.T) {
 
 		// Empty files list should succeed
 		code := generators.GeneratedCode{Files: []generators.FileToWrite{}}
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 		assert.True(t, E.IsRight(result))
 	})
 
@@ -184,7 +184,7 @@ func TestWriteGeneratedFilesEdgeCases(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 		if E.IsRight(result) {
 			written := E.Fold(
 				func(_ error) generators.WrittenFiles { return generators.WrittenFiles{} },
@@ -210,7 +210,7 @@ func TestWriteGeneratedFilesEdgeCases(t *testing.T) {
 			},
 		}
 
-		result := writeGeneratedFiles(code, infraDir)
+		result := writeGeneratedFiles(code, infraDir, false)
 		assert.True(t, E.IsRight(result))
 
 		// Verify content was appended
@@ -32,7 +32,7 @@ func TestRunDeployWithMockTerraform(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// This will fail at Terraform step but should pass early stages
-		err := runDeploy(true, "")
+		err := runDeploy(true, "", 1, false)
 		// Expect error at terraform execution (not mocked)
 		require.Error(t, err)
 		// But should contain deployment context, not scan/build errors
@@ -53,7 +53,7 @@ func TestRunDeployWithMockTerraform(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Should pass namespace through pipeline
-		err := runDeploy(true, "test-namespace")
+		err := runDeploy(true, "test-namespace", 1, false)
 		require.Error(t, err) // Will fail at terraform execution
 	})
 }
@@ -67,7 +67,7 @@ func TestRunDestroyWithMockTerraform(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runDestroy(false)
+		err := runDestroy(false, "")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load config")
 	})
@@ -91,7 +91,7 @@ this is invalid go code that will not compile
 		_ = os.Chdir(tmpDir)
 
 		// Stub build should succeed (doesn't compile)
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err)
 	})
 }
@@ -106,7 +106,7 @@ func TestNewCmdProjectCreation(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Test that auto-state flag is recognized (will fail at AWS provisioning)
-		err := createProject("test-project", "provided.al2023", true)
+		err := createProject("test-project", "provided.al2023", "", true)
 		// Will fail at actual state provisioning (not implemented in test)
 		// But should create project structure first
 		if err != nil {
@@ -129,7 +129,7 @@ func TestNewCmdProjectCreation(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := createProject("test-project-eu", "provided.al2023", false)
+		err := createProject("test-project-eu", "provided.al2023", "", false)
 		require.NoError(t, err)
 
 		assert.DirExists(t, filepath.Join(tmpDir, "test-project-eu"))
@@ -147,7 +147,7 @@ func TestNewCmdProjectCreation(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := createProject("test-project-ap", "provided.al2023", false)
+		err := createProject("test-project-ap", "provided.al2023", "", false)
 		require.NoError(t, err)
 
 		assert.DirExists(t, filepath.Join(tmpDir, "test-project-ap"))
@@ -164,7 +164,7 @@ func TestNewCmdProjectCreation(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := createProject("test-project-default", "provided.al2023", false)
+		err := createProject("test-project-default", "provided.al2023", "", false)
 		require.NoError(t, err)
 
 		assert.DirExists(t, filepath.Join(tmpDir, "test-project-default"))
@@ -180,7 +180,7 @@ func TestNewCmdProjectCreation(t *testing.T) {
 		// Create directory first
 		require.NoError(t, os.MkdirAll("existing-project", 0o755))
 
-		err := createProject("existing-project", "provided.al2023", false)
+		err := createProject("existing-project", "provided.al2023", "", false)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "already exists")
 	})
@@ -203,7 +203,7 @@ func TestAddCommandEdgeCases(t *testing.T) {
 		cmd := NewAddCmd()
 		args := []string{"sqs", "test-queue"}
 
-		err := runAdd(cmd, args, "", false, false)
+		err := runAdd(cmd, args, addOptions{})
 		assert.NoError(t, err)
 	})
 }
@@ -261,7 +261,7 @@ func TestCommandOutputFormatting(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// No src/functions directory
-		err := runBuild(false)
+		err := runBuild(false, 1, false)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to scan functions")
 	})
@@ -273,7 +273,7 @@ func TestCommandOutputFormatting(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runDeploy(false, "")
+		err := runDeploy(false, "", 1, false)
 		require.Error(t, err)
 	})
 
@@ -284,7 +284,7 @@ func TestCommandOutputFormatting(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runDestroy(false)
+		err := runDestroy(false, "")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load config")
 	})
@@ -309,7 +309,7 @@ func TestFlagInteractions(t *testing.T) {
 		args := []string{"sqs", "test-queue"}
 
 		// Both flags set to true - should use raw mode
-		err := runAdd(cmd, args, "", true, true)
+		err := runAdd(cmd, args, addOptions{Raw: true})
 		assert.NoError(t, err)
 	})
 
@@ -332,7 +332,7 @@ func TestFlagInteractions(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runBuild(true)
+		err := runBuild(true, 1, false)
 		assert.NoError(t, err) // Should succeed with no functions message
 	})
 }
@@ -349,7 +349,7 @@ func TestCreateStackEdgeCases(t *testing.T) {
 		// Create forge.hcl
 		require.NoError(t, os.WriteFile("forge.hcl", []byte("service = \"test\""), 0o644))
 
-		err := createStack("default-stack", "provided.al2023", "")
+		err := createStack("default-stack", "provided.al2023", "", "")
 		require.NoError(t, err)
 
 		assert.DirExists(t, filepath.Join(tmpDir, "default-stack"))
@@ -363,7 +363,7 @@ func TestCreateStackEdgeCases(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// No forge.hcl
-		err := createStack("stack", "provided.al2023", "description")
+		err := createStack("stack", "provided.al2023", "description", "")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "not in a Forge project")
 	})
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 
 	E "github.com/IBM/fp-go/either"
 	"github.com/spf13/cobra"
 
+	"github.com/lewis/forge/internal/build"
 	"github.com/lewis/forge/internal/pipeline"
 	"github.com/lewis/forge/internal/terraform"
 	"github.com/lewis/forge/internal/ui"
@@ -18,6 +20,8 @@ func NewDeployCmd() *cobra.Command {
 	var (
 		autoApprove bool
 		namespace   string
+		jobs        int
+		noCache     bool
 	)
 
 	cmd := &cobra.Command{
@@ -34,17 +38,19 @@ One command to go from code to production AWS resources.
 🎯 What It Does:
   1. Scans src/functions/* for Lambda functions
   2. Auto-detects runtimes (Go, Python, Node.js)
-  3. Builds deployment packages
-  4. Runs terraform init/plan/apply in infra/
-  5. Outputs deployed URLs and resources
+  3. Regenerates infra/functions.gen.tf with a Lambda module per function
+  4. Builds deployment packages in parallel (--jobs), reusing
+     unchanged ones from .forge/cache (--no-cache to rebuild all)
+  5. Runs terraform init/plan/apply in infra/
+  6. Outputs deployed URLs and resources
 
 🌟 Namespace Support (PR Previews):
   Deploy to isolated ephemeral environments for testing:
 
   forge deploy --namespace=pr-123
-    → Sets TF_VAR_namespace=pr-123
+    → Sets TF_VAR_namespace=pr-123-
     → All resources prefixed: my-app-pr-123-*
-    → Completely isolated AWS environment
+    → State stored at pr-123/terraform.tfstate in the state bucket
     → Perfect for PR preview deployments
 
 🚀 Examples:
@@ -63,7 +69,7 @@ One command to go from code to production AWS resources.
 
 💡 Pro Tips:
   • Use namespaces for PR preview environments
-  • Each namespace has isolated Terraform state
+  • Each namespace has its own state key (requires the S3 backend)
   • Combine with GitHub Actions for automatic PR deploys
   • Use --auto-approve in CI/CD pipelines
 
@@ -71,21 +77,25 @@ One command to go from code to production AWS resources.
   • Terraform installed (terraform version)
   • AWS credentials configured
   • infra/ directory with Terraform config
+  • infra/backend.tf with an S3 backend (for --namespace)
+  • variable "namespace" (default "") declared in infra/
   • src/functions/ with Lambda code
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDeploy(autoApprove, namespace)
+			return runDeploy(autoApprove, namespace, jobs, noCache)
 		},
 	}
 
 	cmd.Flags().BoolVar(&autoApprove, "auto-approve", false, "Skip interactive approval")
 	cmd.Flags().StringVar(&namespace, "namespace", "", "Namespace for ephemeral environments (e.g., pr-123)")
+	cmd.Flags().IntVar(&jobs, "jobs", build.DefaultJobs(), "Number of functions to build concurrently")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Rebuild every function, ignoring .forge/cache")
 
 	return cmd
 }
 
 // runDeploy executes the deployment using functional pipeline composition.
-func runDeploy(autoApprove bool, namespace string) error {
+func runDeploy(autoApprove bool, namespace string, jobs int, noCache bool) error {
 	out := ui.DefaultOutput()
 
 	ctx := context.Background()
@@ -120,14 +130,20 @@ func runDeploy(autoApprove bool, namespace string) error {
 		}
 	}
 
+	buildOpts := []pipeline.BuildStageOption{pipeline.WithJobs(jobs)}
+	if !noCache {
+		buildOpts = append(buildOpts, pipeline.WithBuildCache(build.NewDiskCache(build.DefaultCacheDir(projectRoot))))
+	}
+
 	// Compose functional pipeline using event-based stages:
 	// Scan → Stubs → Build → TF Init → TF Plan → TF Apply → TF Outputs
 	// Event-based stages return events as data instead of printing
 	deployPipeline := pipeline.NewEventPipeline(
 		pipeline.ConventionScanV2(),
 		pipeline.ConventionStubsV2(),
-		pipeline.ConventionBuildV2(),
-		pipeline.ConventionTerraformInitV2(tfExecutor),
+		pipeline.ConventionFunctionsTerraformV2(),
+		pipeline.ConventionBuildV2(buildOpts...),
+		pipeline.ConventionTerraformInitV2(tfExecutor, namespace),
 		pipeline.ConventionTerraformPlanV2(tfExecutor, namespace),
 		pipeline.ConventionTerraformApplyV2(tfExecutor, approvalFunc),
 		pipeline.ConventionTerraformOutputsV2(tfExecutor),
@@ -157,6 +173,9 @@ func runDeploy(autoApprove bool, namespace string) error {
 			out.Print("  • Verify AWS credentials are configured: aws sts get-caller-identity")
 			out.Print("  • Review function build logs in .forge/build/")
 			out.Print("  • Run 'forge build' separately to test builds")
+			if namespace != "" {
+				out.Print("  • Namespaces need an S3 backend in infra/backend.tf")
+			}
 			return fmt.Errorf("deployment failed: %w", err)
 		},
 		func(finalState pipeline.State) error {
@@ -191,6 +210,20 @@ func adaptTerraformExecutor(exec terraform.Executor) pipeline.TerraformExecutor
 			return exec.Init(ctx, dir, terraform.Upgrade(false))
 		},
 
+		// InitWithBackend function - re-initializes against partial backend settings
+		InitWithBackend: func(ctx context.Context, dir string, backendConfig map[string]string) error {
+			opts := []terraform.InitOption{terraform.Upgrade(false), terraform.Reconfigure(true)}
+			keys := make([]string, 0, len(backendConfig))
+			for k := range backendConfig {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				opts = append(opts, terraform.BackendConfig(k+"="+backendConfig[k]))
+			}
+			return exec.Init(ctx, dir, opts...)
+		},
+
 		// Plan function - calls PlanWithVars with nil vars
 		Plan: func(ctx context.Context, dir string) (bool, error) {
 			opts := []terraform.PlanOption{terraform.PlanOut(dir + "/tfplan")}
@@ -207,6 +240,23 @@ func adaptTerraformExecutor(exec terraform.Executor) pipeline.TerraformExecutor
 			return exec.Plan(ctx, dir, opts...)
 		},
 
+		// PlanDestroy function - saves a destroy plan and lists what it removes
+		PlanDestroy: func(ctx context.Context, dir string, vars map[string]string) ([]string, error) {
+			planFile := dir + "/tfplan"
+			opts := []terraform.PlanOption{terraform.PlanOut(planFile), terraform.PlanDestroy(true)}
+			for k, v := range vars {
+				opts = append(opts, terraform.PlanVar(k, v))
+			}
+			if _, err := exec.Plan(ctx, dir, opts...); err != nil {
+				return nil, err
+			}
+			changes, err := exec.ShowPlan(ctx, dir, planFile)
+			if err != nil {
+				return nil, err
+			}
+			return terraform.DeletedAddresses(changes), nil
+		},
+
 		// Apply function - pure function call, no state
 		Apply: func(ctx context.Context, dir string) error {
 			return exec.Apply(ctx, dir,
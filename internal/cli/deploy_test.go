@@ -43,7 +43,7 @@ func TestRunDeploy(t *testing.T) {
 		defer func() { _ = os.Chdir(originalDir) }()
 		_ = os.Chdir(tmpDir)
 
-		err := runDeploy(true, "")
+		err := runDeploy(true, "", 1, false)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to scan functions")
 	})
@@ -60,7 +60,7 @@ func TestRunDeploy(t *testing.T) {
 
 		_ = os.Chdir(tmpDir)
 
-		err := runDeploy(true, "")
+		err := runDeploy(true, "", 1, false)
 		require.Error(t, err)
 		// Build fails with command execution error
 		assert.Contains(t, err.Error(), "deployment failed")
@@ -73,7 +73,7 @@ func TestRunDeploy(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Will fail early due to missing functions, but namespace should be validated
-		err := runDeploy(true, "pr-123")
+		err := runDeploy(true, "pr-123", 1, false)
 		require.Error(t, err)
 	})
 
@@ -83,7 +83,7 @@ func TestRunDeploy(t *testing.T) {
 		defer func() { _ = os.Chdir(originalDir) }()
 		_ = os.Chdir(tmpDir)
 
-		err := runDeploy(true, "")
+		err := runDeploy(true, "", 1, false)
 		require.Error(t, err)
 	})
 
@@ -95,7 +95,7 @@ func TestRunDeploy(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Without src/functions, we'll get scan error
-		err := runDeploy(true, "")
+		err := runDeploy(true, "", 1, false)
 		require.Error(t, err)
 	})
 
@@ -106,7 +106,7 @@ func TestRunDeploy(t *testing.T) {
 		_ = os.Chdir(tmpDir)
 
 		// Will fail early, but namespace should be passed through
-		err := runDeploy(true, "pr-123-feature-x")
+		err := runDeploy(true, "pr-123-feature-x", 1, false)
 		require.Error(t, err)
 		// Should fail on scan, not namespace validation
 		assert.Contains(t, err.Error(), "failed to scan functions")
@@ -10,13 +10,17 @@ import (
 
 	"github.com/lewis/forge/internal/config"
 	"github.com/lewis/forge/internal/pipeline"
+	"github.com/lewis/forge/internal/state"
 	"github.com/lewis/forge/internal/terraform"
 	"github.com/lewis/forge/internal/ui"
 )
 
 // NewDestroyCmd creates the 'destroy' command.
 func NewDestroyCmd() *cobra.Command {
-	var autoApprove bool
+	var (
+		autoApprove bool
+		namespace   string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "destroy",
@@ -40,10 +44,16 @@ Includes confirmation prompts to prevent accidental deletion.
   • CloudWatch log groups
 
 🛡️  Safety Features:
+  • Runs 'terraform plan -destroy' and lists every resource first
   • Interactive confirmation required by default
-  • Shows resource plan before destruction
   • Requires --auto-approve to skip confirmation
-  • Dry-run with 'terraform plan -destroy' first
+  • Applies exactly the plan that was shown
+
+🌟 Namespace Cleanup (PR Previews):
+  forge destroy --namespace=pr-123
+    → Initializes against pr-123/terraform.tfstate
+    → Destroys only that namespace's resources
+    → Deletes the namespace's state object afterwards
 
 🚀 Examples:
 
@@ -63,27 +73,28 @@ Includes confirmation prompts to prevent accidental deletion.
   • Consider using 'terraform state' commands for partial cleanup
 
 📋 Recommended Workflow:
-  1. Review what will be destroyed:
-     cd infra && terraform plan -destroy
-
-  2. If satisfied, run:
+  1. Run:
      forge destroy
 
+  2. Review the resources listed in the destroy plan
+
   3. Confirm when prompted
 `,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDestroy(autoApprove)
+			return runDestroy(autoApprove, namespace)
 		},
 	}
 
 	cmd.Flags().BoolVar(&autoApprove, "auto-approve", false, "Skip interactive approval")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Destroy only this ephemeral environment (e.g., pr-123)")
 
 	return cmd
 }
 
-// runDestroy executes the destroy operation.
-func runDestroy(autoApprove bool) error {
+// runDestroy executes the destroy operation as two event pipelines:
+// Init → Plan -destroy (shown to the user), then Apply → State cleanup once confirmed.
+func runDestroy(autoApprove bool, namespace string) error {
 	out := ui.DefaultOutput()
 	prompter := ui.NewPrompter(os.Stdin, os.Stdout)
 
@@ -105,55 +116,94 @@ func runDestroy(autoApprove bool) error {
 	}
 
 	out.Header("Destroying Infrastructure")
-	out.Warning("This will destroy all AWS resources managed by this project")
+	if namespace != "" {
+		out.Warning("This will destroy all AWS resources in namespace: %s", namespace)
+	} else {
+		out.Warning("This will destroy all AWS resources managed by this project")
+	}
+
+	// Create Terraform executor using pure functional composition
+	tfPath := findTerraformPath()
+	tfExecutor := adaptTerraformExecutor(terraform.NewExecutor(tfPath))
+
+	initialState := pipeline.State{
+		ProjectDir: projectRoot,
+		Artifacts:  make(map[string]pipeline.Artifact),
+		Outputs:    make(map[string]interface{}),
+		Config:     cfg,
+	}
+
+	// Phase 1: Init → Plan -destroy, printed before anything is removed
+	planResult := pipeline.RunWithEvents(pipeline.NewEventPipeline(
+		pipeline.ConventionTerraformInitV2(tfExecutor, namespace),
+		pipeline.ConventionTerraformPlanDestroyV2(tfExecutor, namespace),
+	), ctx, initialState)
+	pipeline.PrintEvents(planResult.Events)
+
+	if E.IsLeft(planResult.Result) {
+		return destroyFailed(out, E.Fold(
+			func(err error) error { return err },
+			func(pipeline.State) error { return nil },
+		)(planResult.Result))
+	}
 
-	// Require explicit confirmation for destructive action
+	// Require explicit confirmation for destructive action (I/O at edge)
 	if !autoApprove {
+		target := projectRoot
+		if namespace != "" {
+			target = fmt.Sprintf("%s (namespace %s)", projectRoot, namespace)
+		}
 		if !prompter.ConfirmDestruction(
-			"You are about to PERMANENTLY DELETE all infrastructure",
-			projectRoot,
+			"You are about to PERMANENTLY DELETE the resources listed above",
+			target,
 		) {
-
 			out.Info("Destroy canceled")
 			return nil
 		}
 	}
 
-	// Create functional executor
-	tfPath := findTerraformPath()
-	exec := terraform.NewExecutor(tfPath)
-
-	// Create destroy pipeline
-	destroyPipeline := pipeline.New(
-		pipeline.TerraformDestroy(exec, true),
-	)
-
-	// Initial state
-	initialState := pipeline.State{
-		ProjectDir: projectRoot,
-		Config:     cfg,
+	// Phase 2: Apply the saved destroy plan → remove the namespace's state object
+	backend := state.GenerateBackendConfig(cfg.Project.Name, cfg.Project.Region, namespace)
+	deleteState := func(ctx context.Context, key string) error {
+		store, err := state.NewS3Store(ctx, backend)
+		if err != nil {
+			return err
+		}
+		return store.Delete(ctx, key)
 	}
 
-	// Run pipeline
-	result := pipeline.Run(destroyPipeline, ctx, initialState)
+	applyResult := pipeline.RunWithEvents(pipeline.NewEventPipeline(
+		pipeline.ConventionTerraformApplyV2(tfExecutor, nil),
+		pipeline.ConventionStateCleanupV2(deleteState, namespace),
+	), ctx, initialState)
+	pipeline.PrintEvents(applyResult.Events)
 
 	// Handle result using functional pattern
 	return E.Fold(
 		func(err error) error {
-			out.Error("Destroy failed: %v", err)
-			out.Print("")
-			out.Warning("Troubleshooting tips:")
-			out.Print("  • Check Terraform state is accessible")
-			out.Print("  • Verify AWS credentials are valid")
-			out.Print("  • Review .terraform/ directory for issues")
-			out.Print("  • Try running 'terraform destroy' manually in infra/")
-			return fmt.Errorf("destroy failed: %w", err)
+			return destroyFailed(out, err)
 		},
 		func(finalState pipeline.State) error {
 			out.Success("Infrastructure destroyed successfully")
 			out.Print("")
-			out.Dim("All AWS resources have been removed")
+			if namespace != "" {
+				out.Dim("All AWS resources and state for namespace %s have been removed", namespace)
+			} else {
+				out.Dim("All AWS resources have been removed")
+			}
 			return nil
 		},
-	)(result)
+	)(applyResult.Result)
+}
+
+// destroyFailed prints troubleshooting tips and wraps the error (ACTION - I/O).
+func destroyFailed(out *ui.Output, err error) error {
+	out.Error("Destroy failed: %v", err)
+	out.Print("")
+	out.Warning("Troubleshooting tips:")
+	out.Print("  • Check Terraform state is accessible")
+	out.Print("  • Verify AWS credentials are valid")
+	out.Print("  • Review .terraform/ directory for issues")
+	out.Print("  • Try running 'terraform destroy' manually in infra/")
+	return fmt.Errorf("destroy failed: %w", err)
 }
@@ -35,6 +35,14 @@ func TestNewDestroyCmd(t *testing.T) {
 		assert.Equal(t, "false", flag.DefValue)
 	})
 
+	t.Run("has namespace flag", func(t *testing.T) {
+		cmd := NewDestroyCmd()
+
+		flag := cmd.Flags().Lookup("namespace")
+		assert.NotNil(t, flag)
+		assert.Equal(t, "", flag.DefValue)
+	})
+
 	t.Run("requires no args", func(t *testing.T) {
 		cmd := NewDestroyCmd()
 		assert.NotNil(t, cmd.Args)
@@ -59,7 +67,7 @@ func TestRunDestroy(t *testing.T) {
 		// Change to directory without forge.hcl
 		_ = os.Chdir(tmpDir)
 
-		err := runDestroy(true)
+		err := runDestroy(true, "")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load config")
 	})
@@ -85,7 +93,7 @@ project {
 		_ = os.Chdir(tmpDir)
 
 		// This will fail with terraform not found or terraform errors, but config loading should succeed
-		err := runDestroy(true)
+		err := runDestroy(true, "")
 		// We expect terraform-related errors, not config errors
 		if err != nil {
 			assert.NotContains(t, err.Error(), "failed to load config")
@@ -102,7 +110,7 @@ project {
 		_ = os.Chdir(tmpDir)
 
 		// Without forge.hcl, we'll get config error
-		err := runDestroy(true)
+		err := runDestroy(true, "")
 		require.Error(t, err)
 	})
 }
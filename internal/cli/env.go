@@ -0,0 +1,345 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lewis/forge/internal/config"
+	"github.com/lewis/forge/internal/state"
+	"github.com/lewis/forge/internal/ui"
+)
+
+// envGCOptions holds the flags for 'forge env gc' (immutable data).
+type envGCOptions struct {
+	TTL         time.Duration
+	ClosedPRs   []int
+	DryRun      bool
+	AutoApprove bool
+}
+
+// NewEnvCmd creates the 'env' command for managing namespaced environments.
+func NewEnvCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "List and clean up namespaced preview environments",
+		Long: `
+╭──────────────────────────────────────────────────────────────╮
+│  🌱 Forge Environments                                      │
+╰──────────────────────────────────────────────────────────────╯
+
+Inspect and reap the preview environments created with
+'forge deploy --namespace'. Every namespace keeps its own state at
+s3://forge-state-<project>/<namespace>/terraform.tfstate.
+
+🚀 Examples:
+
+  # Show every namespace with age, resource count and tags
+  forge env list
+
+  # Destroy namespaces untouched for a week
+  forge env gc --ttl=168h
+
+  # Destroy namespaces for closed pull requests
+  gh pr list --state closed --json number -q '.[].number' | forge env gc --stdin --auto-approve
+
+💡 Pro Tips:
+  • Run 'forge env gc --dry-run' first to see what would be destroyed
+  • PR namespaces are matched by name: pr-123 or pr123
+  • --stdin needs --auto-approve: piped input can't answer the prompt
+  • The default (non-namespaced) state is never listed or collected
+  • Set AWS_ENDPOINT_URL_S3 to point at an S3 stand-in such as MinIO
+`,
+	}
+
+	cmd.AddCommand(newEnvListCmd(), newEnvGCCmd())
+
+	return cmd
+}
+
+// newEnvListCmd creates the 'env list' subcommand.
+func newEnvListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List namespaced environments in the state bucket",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			store, err := openProjectStateStore(ctx)
+			if err != nil {
+				return err
+			}
+			return listEnvironments(ctx, ui.DefaultOutput(), store)
+		},
+	}
+}
+
+// newEnvGCCmd creates the 'env gc' subcommand.
+func newEnvGCCmd() *cobra.Command {
+	var (
+		opts      envGCOptions
+		fromStdin bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Destroy stale or closed-PR environments",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEnvGC(context.Background(), ui.DefaultOutput(), os.Stdin, opts, fromStdin,
+				openProjectStateStore,
+				func(namespace string) error {
+					return runDestroy(true, namespace)
+				},
+			)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.TTL, "ttl", 0, "Destroy namespaces not modified within this duration (e.g., 72h)")
+	cmd.Flags().BoolVar(&fromStdin, "stdin", false, "Read closed PR numbers from stdin and destroy their namespaces")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Show what would be destroyed without destroying it")
+	cmd.Flags().BoolVar(&opts.AutoApprove, "auto-approve", false, "Skip interactive approval")
+
+	return cmd
+}
+
+// runEnvGC reads closed PR numbers from stdin when asked to, then destroys the
+// selected namespaces after confirming on stdin. Piped PR numbers leave stdin
+// at EOF with no answer to read, so --stdin requires --auto-approve unless
+// nothing is destroyed (ACTION - I/O).
+func runEnvGC(
+	ctx context.Context,
+	out *ui.Output,
+	stdin io.Reader,
+	opts envGCOptions,
+	fromStdin bool,
+	openStore func(ctx context.Context) (state.Store, error),
+	destroy func(namespace string) error,
+) error {
+	if fromStdin {
+		if !opts.AutoApprove && !opts.DryRun {
+			return errors.New("--stdin reads PR numbers from stdin, leaving no input to confirm with: pass --auto-approve (or --dry-run)")
+		}
+		prs, err := parsePRNumbers(stdin)
+		if err != nil {
+			return err
+		}
+		opts.ClosedPRs = prs
+	}
+
+	store, err := openStore(ctx)
+	if err != nil {
+		return err
+	}
+
+	prompter := ui.NewPrompter(stdin, os.Stdout)
+	return collectEnvironments(ctx, out, store, opts,
+		func(count int) bool {
+			return prompter.ConfirmDestruction(
+				fmt.Sprintf("You are about to PERMANENTLY DELETE %d environment(s)", count),
+				"namespaces listed above",
+			)
+		},
+		destroy,
+	)
+}
+
+// openProjectStateStore loads forge.hcl and opens the project's S3 state store (ACTION - I/O).
+func openProjectStateStore(ctx context.Context) (state.Store, error) {
+	projectRoot, err := os.Getwd()
+	if err != nil {
+		return state.Store{}, fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	cfg, err := config.Load(projectRoot)
+	if err != nil {
+		return state.Store{}, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	backend := state.GenerateBackendConfig(cfg.Project.Name, cfg.Project.Region, "")
+	return state.NewS3Store(ctx, backend)
+}
+
+// listEnvironments prints every namespace found in the store (ACTION - I/O).
+func listEnvironments(ctx context.Context, out *ui.Output, store state.Store) error {
+	namespaces, err := state.ListNamespaces(ctx, store)
+	if err != nil {
+		out.Error("Failed to list environments: %v", err)
+		return fmt.Errorf("failed to list environments: %w", err)
+	}
+
+	if len(namespaces) == 0 {
+		out.Info("No namespaced environments found")
+		return nil
+	}
+
+	out.Header("Environments")
+	printEnvironmentTable(out, namespaces, time.Now())
+	warnUnreadable(out, namespaces)
+	return nil
+}
+
+// warnUnreadable prints a warning for every namespace whose state could not be read (ACTION - I/O).
+func warnUnreadable(out *ui.Output, namespaces []state.NamespaceInfo) {
+	for _, ns := range namespaces {
+		if ns.Err != nil {
+			out.Warning("Could not read state for %s: %v", ns.Name, ns.Err)
+		}
+	}
+}
+
+// collectEnvironments destroys the namespaces selected by the GC options (ACTION - I/O).
+// Confirmation and destruction are injected so the selection logic stays testable.
+func collectEnvironments(
+	ctx context.Context,
+	out *ui.Output,
+	store state.Store,
+	opts envGCOptions,
+	confirm func(count int) bool,
+	destroy func(namespace string) error,
+) error {
+	if opts.TTL <= 0 && len(opts.ClosedPRs) == 0 {
+		return errors.New("nothing to collect: pass --ttl and/or closed PR numbers via --stdin")
+	}
+
+	namespaces, err := state.ListNamespaces(ctx, store)
+	if err != nil {
+		out.Error("Failed to list environments: %v", err)
+		return fmt.Errorf("failed to list environments: %w", err)
+	}
+
+	// Namespaces whose state could not be read are never selected
+	warnUnreadable(out, namespaces)
+
+	stale, reasons := state.SelectStaleNamespaces(namespaces, state.GCPolicy{
+		TTL:       opts.TTL,
+		ClosedPRs: opts.ClosedPRs,
+		Now:       time.Now(),
+	})
+
+	if len(stale) == 0 {
+		out.Success("No stale environments found")
+		return nil
+	}
+
+	out.Header("Stale Environments")
+	for _, ns := range stale {
+		out.Print("  • %s (%d resources) - %s", ns.Name, ns.ResourceCount, reasons[ns.Name])
+	}
+	out.Print("")
+
+	if opts.DryRun {
+		out.Info("Dry run: %d environment(s) would be destroyed", len(stale))
+		return nil
+	}
+
+	if !opts.AutoApprove && !confirm(len(stale)) {
+		out.Info("Garbage collection canceled")
+		return nil
+	}
+
+	// Destroy every stale namespace, collecting failures instead of stopping at the first
+	var errs []error
+	for _, ns := range stale {
+		out.Info("Destroying namespace %s...", ns.Name)
+		if err := destroy(ns.Name); err != nil {
+			out.Error("Failed to destroy %s: %v", ns.Name, err)
+			errs = append(errs, fmt.Errorf("namespace %s: %w", ns.Name, err))
+			continue
+		}
+		out.Success("Destroyed namespace %s", ns.Name)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to destroy %d of %d environment(s): %w", len(errs), len(stale), errors.Join(errs...))
+	}
+
+	out.Success("Destroyed %d environment(s)", len(stale))
+	return nil
+}
+
+// printEnvironmentTable renders namespaces as an aligned table (ACTION - I/O).
+func printEnvironmentTable(out *ui.Output, namespaces []state.NamespaceInfo, now time.Time) {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAMESPACE\tLAST MODIFIED\tAGE\tRESOURCES\tTAGS")
+	for _, ns := range namespaces {
+		resources := strconv.Itoa(ns.ResourceCount)
+		if ns.Err != nil {
+			resources = "?"
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			ns.Name,
+			ns.LastModified.UTC().Format("2006-01-02 15:04 UTC"),
+			formatAge(now.Sub(ns.LastModified)),
+			resources,
+			formatTags(ns.Tags),
+		)
+	}
+	_ = tw.Flush()
+
+	for _, line := range strings.Split(strings.TrimRight(sb.String(), "\n"), "\n") {
+		out.Print("  %s", line)
+	}
+}
+
+// formatAge renders a duration in the largest whole unit (PURE).
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+}
+
+// formatTags renders tags as sorted key=value pairs (PURE).
+func formatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// parsePRNumbers reads PR numbers separated by whitespace or commas, allowing a leading '#'.
+func parsePRNumbers(r io.Reader) ([]int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(bufio.ScanWords)
+
+	prs := []int{}
+	for scanner.Scan() {
+		for _, field := range strings.Split(scanner.Text(), ",") {
+			field = strings.TrimPrefix(strings.TrimSpace(field), "#")
+			if field == "" {
+				continue
+			}
+			n, err := strconv.Atoi(field)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid PR number %q", field)
+			}
+			prs = append(prs, n)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read PR numbers: %w", err)
+	}
+
+	return prs, nil
+}
@@ -0,0 +1,284 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/state"
+	"github.com/lewis/forge/internal/ui"
+)
+
+// writeNamespaceState writes a namespace state file into a filesystem-backed bucket.
+func writeNamespaceState(t *testing.T, root, namespace string, age time.Duration, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(state.GenerateStateKey(namespace)))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	modified := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modified, modified))
+}
+
+const envTestState = `{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "aws_lambda_function", "name": "api",
+     "instances": [{"attributes": {"tags": {"Namespace": "pr-1", "Owner": "alice"}}}]}
+  ]
+}`
+
+// TestNewEnvCmd tests the env command creation.
+func TestNewEnvCmd(t *testing.T) {
+	t.Run("creates env command with list and gc", func(t *testing.T) {
+		cmd := NewEnvCmd()
+
+		assert.Equal(t, "env", cmd.Use)
+		assert.NotEmpty(t, cmd.Long)
+
+		names := []string{}
+		for _, sub := range cmd.Commands() {
+			names = append(names, sub.Name())
+		}
+		assert.ElementsMatch(t, []string{"list", "gc"}, names)
+	})
+
+	t.Run("gc has ttl, stdin, dry-run and auto-approve flags", func(t *testing.T) {
+		gc, _, err := NewEnvCmd().Find([]string{"gc"})
+		require.NoError(t, err)
+
+		for _, name := range []string{"ttl", "stdin", "dry-run", "auto-approve"} {
+			assert.NotNil(t, gc.Flags().Lookup(name), "missing flag %s", name)
+		}
+	})
+
+	t.Run("list fails outside a project", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		origDir, _ := os.Getwd()
+		defer func() { _ = os.Chdir(origDir) }()
+		_ = os.Chdir(tmpDir)
+
+		cmd := NewEnvCmd()
+		cmd.SetArgs([]string{"list"})
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load config")
+	})
+}
+
+// TestListEnvironments tests listing namespaces from a filesystem-backed store.
+func TestListEnvironments(t *testing.T) {
+	t.Run("prints namespaces with resource count and tags", func(t *testing.T) {
+		root := t.TempDir()
+		writeNamespaceState(t, root, "pr-1", 3*24*time.Hour, envTestState)
+		writeNamespaceState(t, root, "staging", time.Hour, `{"version": 4, "resources": []}`)
+
+		var buf bytes.Buffer
+		err := listEnvironments(t.Context(), ui.NewOutput(&buf), state.NewFileStore(root))
+
+		require.NoError(t, err)
+		output := buf.String()
+		assert.Contains(t, output, "NAMESPACE")
+		assert.Contains(t, output, "pr-1")
+		assert.Contains(t, output, "staging")
+		assert.Contains(t, output, "Namespace=pr-1,Owner=alice")
+		assert.Contains(t, output, "3d")
+		assert.Less(t, strings.Index(output, "pr-1"), strings.Index(output, "staging"))
+	})
+
+	t.Run("lists the other namespaces when one state is unreadable", func(t *testing.T) {
+		root := t.TempDir()
+		writeNamespaceState(t, root, "pr-1", time.Hour, "garbage")
+		writeNamespaceState(t, root, "staging", time.Hour, envTestState)
+
+		var buf bytes.Buffer
+		err := listEnvironments(t.Context(), ui.NewOutput(&buf), state.NewFileStore(root))
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "staging")
+		assert.Contains(t, buf.String(), "Could not read state for pr-1")
+	})
+
+	t.Run("reports when there are no namespaces", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := listEnvironments(t.Context(), ui.NewOutput(&buf), state.NewFileStore(t.TempDir()))
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "No namespaced environments found")
+	})
+}
+
+// TestCollectEnvironments tests GC selection and destruction against a filesystem-backed store.
+func TestCollectEnvironments(t *testing.T) {
+	setup := func(t *testing.T) state.Store {
+		t.Helper()
+		root := t.TempDir()
+		writeNamespaceState(t, root, "pr-1", time.Hour, envTestState)
+		writeNamespaceState(t, root, "pr-2", 10*24*time.Hour, envTestState)
+		writeNamespaceState(t, root, "staging", 30*24*time.Hour, envTestState)
+		return state.NewFileStore(root)
+	}
+	approve := func(int) bool { return true }
+
+	t.Run("destroys namespaces older than TTL", func(t *testing.T) {
+		var destroyed []string
+		var buf bytes.Buffer
+
+		err := collectEnvironments(t.Context(), ui.NewOutput(&buf), setup(t),
+			envGCOptions{TTL: 7 * 24 * time.Hour, AutoApprove: true},
+			approve,
+			func(ns string) error { destroyed = append(destroyed, ns); return nil },
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-2", "staging"}, destroyed)
+		assert.Contains(t, buf.String(), "Destroyed 2 environment(s)")
+	})
+
+	t.Run("destroys namespaces for closed PRs", func(t *testing.T) {
+		var destroyed []string
+
+		err := collectEnvironments(t.Context(), ui.NewOutput(&bytes.Buffer{}), setup(t),
+			envGCOptions{ClosedPRs: []int{1}, AutoApprove: true},
+			approve,
+			func(ns string) error { destroyed = append(destroyed, ns); return nil },
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, destroyed)
+	})
+
+	t.Run("dry run destroys nothing", func(t *testing.T) {
+		var buf bytes.Buffer
+
+		err := collectEnvironments(t.Context(), ui.NewOutput(&buf), setup(t),
+			envGCOptions{TTL: time.Minute, DryRun: true},
+			approve,
+			func(ns string) error { t.Fatalf("destroy called for %s", ns); return nil },
+		)
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "3 environment(s) would be destroyed")
+	})
+
+	t.Run("stops when confirmation is declined", func(t *testing.T) {
+		err := collectEnvironments(t.Context(), ui.NewOutput(&bytes.Buffer{}), setup(t),
+			envGCOptions{TTL: time.Minute},
+			func(count int) bool { assert.Equal(t, 3, count); return false },
+			func(ns string) error { t.Fatalf("destroy called for %s", ns); return nil },
+		)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("continues past failures and reports them", func(t *testing.T) {
+		var destroyed []string
+
+		err := collectEnvironments(t.Context(), ui.NewOutput(&bytes.Buffer{}), setup(t),
+			envGCOptions{TTL: time.Minute, AutoApprove: true},
+			approve,
+			func(ns string) error {
+				if ns == "pr-2" {
+					return errors.New("state locked")
+				}
+				destroyed = append(destroyed, ns)
+				return nil
+			},
+		)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to destroy 1 of 3")
+		assert.Contains(t, err.Error(), "namespace pr-2: state locked")
+		assert.Equal(t, []string{"pr-1", "staging"}, destroyed)
+	})
+
+	t.Run("skips namespaces it could not read", func(t *testing.T) {
+		root := t.TempDir()
+		writeNamespaceState(t, root, "pr-1", 10*24*time.Hour, "garbage")
+		writeNamespaceState(t, root, "pr-2", 10*24*time.Hour, envTestState)
+		var destroyed []string
+		var buf bytes.Buffer
+
+		err := collectEnvironments(t.Context(), ui.NewOutput(&buf), state.NewFileStore(root),
+			envGCOptions{TTL: 7 * 24 * time.Hour, ClosedPRs: []int{1}, AutoApprove: true},
+			approve,
+			func(ns string) error { destroyed = append(destroyed, ns); return nil },
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-2"}, destroyed)
+		assert.Contains(t, buf.String(), "Could not read state for pr-1")
+	})
+
+	t.Run("requires a TTL or closed PRs", func(t *testing.T) {
+		err := collectEnvironments(t.Context(), ui.NewOutput(&bytes.Buffer{}), setup(t),
+			envGCOptions{}, approve, func(string) error { return nil })
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "nothing to collect")
+	})
+}
+
+// TestRunEnvGC tests reading closed PR numbers piped to forge env gc --stdin.
+func TestRunEnvGC(t *testing.T) {
+	openStore := func(context.Context) (state.Store, error) {
+		root := t.TempDir()
+		writeNamespaceState(t, root, "pr-1", time.Hour, envTestState)
+		writeNamespaceState(t, root, "pr-2", time.Hour, envTestState)
+		return state.NewFileStore(root), nil
+	}
+
+	t.Run("destroys the namespaces of piped PR numbers", func(t *testing.T) {
+		var destroyed []string
+
+		err := runEnvGC(t.Context(), ui.NewOutput(&bytes.Buffer{}), strings.NewReader("1\n3\n"),
+			envGCOptions{AutoApprove: true}, true, openStore,
+			func(ns string) error { destroyed = append(destroyed, ns); return nil },
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"pr-1"}, destroyed)
+	})
+
+	t.Run("requires auto-approve", func(t *testing.T) {
+		err := runEnvGC(t.Context(), ui.NewOutput(&bytes.Buffer{}), strings.NewReader("1\n"),
+			envGCOptions{}, true, openStore,
+			func(ns string) error { t.Fatalf("destroy called for %s", ns); return nil },
+		)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pass --auto-approve")
+	})
+}
+
+// TestParsePRNumbers tests reading PR numbers from stdin.
+func TestParsePRNumbers(t *testing.T) {
+	t.Run("parses whitespace, comma and hash separated numbers", func(t *testing.T) {
+		prs, err := parsePRNumbers(strings.NewReader("12\n#34, 56\n\n78"))
+
+		require.NoError(t, err)
+		assert.Equal(t, []int{12, 34, 56, 78}, prs)
+	})
+
+	t.Run("rejects non-numeric input", func(t *testing.T) {
+		_, err := parsePRNumbers(strings.NewReader("12 abc"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid PR number "abc"`)
+	})
+}
+
+// TestFormatAge tests age formatting.
+func TestFormatAge(t *testing.T) {
+	assert.Equal(t, "3d", formatAge(3*24*time.Hour+time.Hour))
+	assert.Equal(t, "5h", formatAge(5*time.Hour+10*time.Minute))
+	assert.Equal(t, "42m", formatAge(42*time.Minute))
+}
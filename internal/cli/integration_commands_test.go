@@ -148,7 +148,7 @@ func TestRunDeployErrorCases(t *testing.T) {
 		require.NoError(t, err)
 
 		// Try to deploy without forge.hcl (convention-based discovery)
-		err = runDeploy(false, "")
+		err = runDeploy(false, "", 1, false)
 		require.Error(t, err)
 		// Convention-based discovery may fail at different stages
 	})
@@ -166,7 +166,7 @@ func TestRunDeployErrorCases(t *testing.T) {
 		require.NoError(t, err)
 
 		// Try to deploy with no functions (convention-based expects src/functions/)
-		err = runDeploy(false, "")
+		err = runDeploy(false, "", 1, false)
 		require.Error(t, err)
 		// Convention-based discovery expects src/functions/* directories
 	})
@@ -195,7 +195,7 @@ func main() {}`
 		require.NoError(t, err)
 
 		// Try to deploy with namespace (exercises namespace parameter path)
-		err = runDeploy(false, "pr-123")
+		err = runDeploy(false, "pr-123", 1, false)
 		// May fail at terraform stage, but exercises the namespace parameter
 		_ = err
 	})
@@ -212,7 +212,7 @@ func TestRunDestroyErrorCases(t *testing.T) {
 		require.NoError(t, err)
 
 		// Try to destroy without forge.hcl
-		err = runDestroy(false)
+		err = runDestroy(false, "")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load config")
 	})
@@ -282,7 +282,7 @@ func TestCreateProjectErrorCases(t *testing.T) {
 		require.NoError(t, err)
 
 		// Try to create project in same location
-		err = createProject("test-project", "go1.x", false)
+		err = createProject("test-project", "go1.x", "", false)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "already exists")
 	})
@@ -299,7 +299,7 @@ func TestCreateStackErrorCases(t *testing.T) {
 		require.NoError(t, err)
 
 		// Try to create stack without forge.hcl
-		err = createStack("my-stack", "go1.x", "My stack")
+		err = createStack("my-stack", "go1.x", "My stack", "")
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "not in a Forge project")
 	})
@@ -333,7 +333,7 @@ func main() {}`
 		}
 
 		// Try to deploy all functions (convention-based discovery)
-		err = runDeploy(false, "")
+		err = runDeploy(false, "", 1, false)
 		// May succeed or fail depending on terraform setup
 		_ = err
 	})
@@ -365,7 +365,7 @@ func main() {}`
 		require.NoError(t, err)
 
 		// Try to deploy with auto-approve (exercises auto-approve parameter)
-		err = runDeploy(true, "")
+		err = runDeploy(true, "", 1, false)
 		// May succeed or fail depending on terraform setup
 		_ = err
 	})
@@ -404,7 +404,7 @@ func TestRunDestroyWithMultipleStacks(t *testing.T) {
 		}
 
 		// Try to destroy with auto-approve (avoids confirmation prompt)
-		err = runDestroy(true)
+		err = runDestroy(true, "")
 		// May succeed or fail depending on terraform setup, but we exercised the multi-stack path
 		// We don't assert error here since the test environment may have mock terraform
 	})
@@ -471,6 +471,7 @@ func TestCommandIntegration(t *testing.T) {
 		assert.True(t, cmdMap["new"], "Should have 'new' command")
 		assert.True(t, cmdMap["deploy"], "Should have 'deploy' command")
 		assert.True(t, cmdMap["destroy"], "Should have 'destroy' command")
+		assert.True(t, cmdMap["env"], "Should have 'env' command")
 		assert.True(t, cmdMap["version"], "Should have 'version' command")
 	})
 
@@ -483,7 +484,7 @@ func TestCommandIntegration(t *testing.T) {
 		err := os.Chdir(tmpDir)
 		require.NoError(t, err)
 
-		err = createProject("test-project", "go1.x", false)
+		err = createProject("test-project", "go1.x", "", false)
 		require.NoError(t, err)
 
 		// Verify project was created in current directory
@@ -502,7 +503,7 @@ func TestCommandIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Create a project first since createStack requires forge.hcl
-		err = createProject("test-project", "go1.x", false)
+		err = createProject("test-project", "go1.x", "", false)
 		require.NoError(t, err)
 
 		// Change to project directory
@@ -511,7 +512,7 @@ func TestCommandIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Now create the stack
-		err = createStack("my-stack", "go1.x", "My stack")
+		err = createStack("my-stack", "go1.x", "My stack", "")
 		require.NoError(t, err)
 
 		// Verify stack was created directly in project root (not in stacks/)
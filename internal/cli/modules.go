@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lewis/forge"
+	"github.com/lewis/forge/internal/tfmodules/modvendor"
+	"github.com/lewis/forge/internal/ui"
+)
+
+// NewModulesCmd creates the 'modules' command.
+func NewModulesCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "modules",
+		Short: "Vendor the Terraform modules forge generates calls to",
+		Long: `
+╭──────────────────────────────────────────────────────────────╮
+│  📦 Forge Modules                                           │
+╰──────────────────────────────────────────────────────────────╯
+
+Forge bundles the terraform-aws-modules it generates calls to.
+Vendor them into .forge/modules and set module_source = "vendored"
+in forge.hcl, and generated module calls load the local copies:
+terraform init needs no access to the Terraform registry.
+
+🎯 Subcommands:
+  • vendor  Copy bundled modules into .forge/modules
+  • verify  Check .forge/modules against .forge/modules.lock.hcl
+  • list    Show bundled and vendored module versions
+
+🚀 Examples:
+
+  # Vendor every bundled module
+  forge modules vendor
+
+  # Vendor only the modules you use
+  forge modules vendor sqs lambda
+
+  # Fail CI when a vendored module was edited
+  forge modules verify
+`,
+	}
+
+	cmd.AddCommand(
+		newModulesVendorCmd(),
+		newModulesVerifyCmd(),
+		newModulesListCmd(),
+	)
+
+	return cmd
+}
+
+// newModulesVendorCmd creates the 'modules vendor' command.
+func newModulesVendorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "vendor [module...]",
+		Short: "Copy bundled modules into .forge/modules",
+		Long: `Copy the named bundled modules, or all of them, into .forge/modules and
+record their versions and checksums in .forge/modules.lock.hcl. Modules
+vendored before are replaced with this forge's copy.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get working directory: %w", err)
+			}
+			return runModulesVendor(ui.DefaultOutput(), projectRoot, args)
+		},
+	}
+}
+
+// newModulesVerifyCmd creates the 'modules verify' command.
+func newModulesVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Check .forge/modules against .forge/modules.lock.hcl",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get working directory: %w", err)
+			}
+			return runModulesVerify(ui.DefaultOutput(), projectRoot)
+		},
+	}
+}
+
+// newModulesListCmd creates the 'modules list' command.
+func newModulesListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Show bundled and vendored module versions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get working directory: %w", err)
+			}
+			return runModulesList(ui.DefaultOutput(), projectRoot)
+		},
+	}
+}
+
+// runModulesVendor vendors bundled modules into projectRoot.
+func runModulesVendor(out *ui.Output, projectRoot string, names []string) error {
+	vendored, err := modvendor.Vendor(forge.Modules(), projectRoot, names)
+	if err != nil {
+		out.Error("Failed to vendor modules: %v", err)
+		return fmt.Errorf("failed to vendor modules: %w", err)
+	}
+
+	for _, m := range vendored {
+		out.Success("%s %s", m.Name, m.Version)
+	}
+	out.Print("")
+	out.Info("Vendored %d modules into %s", len(vendored), modvendor.Dir)
+	out.Dim(`Set module_source = "vendored" in forge.hcl to generate calls to them`)
+	return nil
+}
+
+// runModulesVerify reports every vendored module that doesn't match the lock file.
+func runModulesVerify(out *ui.Output, projectRoot string) error {
+	statuses, err := modvendor.Verify(projectRoot)
+	if err != nil {
+		out.Error("Failed to verify modules: %v", err)
+		return fmt.Errorf("failed to verify modules: %w", err)
+	}
+	if len(statuses) == 0 {
+		out.Warning("No modules vendored: run 'forge modules vendor'")
+		return nil
+	}
+
+	problems := 0
+	for _, s := range statuses {
+		if s.Problem != "" {
+			problems++
+			out.Error("%s: %s", s.Name, s.Problem)
+			continue
+		}
+		out.Success("%s %s", s.Name, s.Version)
+	}
+
+	if problems > 0 {
+		out.Warning("Run 'forge modules vendor' to restore the bundled copies")
+		return fmt.Errorf("%d of %d vendored modules failed verification", problems, len(statuses))
+	}
+	out.Info("%d vendored modules match %s", len(statuses), modvendor.LockFile)
+	return nil
+}
+
+// runModulesList prints the bundled modules with the version vendored in projectRoot.
+func runModulesList(out *ui.Output, projectRoot string) error {
+	bundled, err := modvendor.Bundled(forge.Modules())
+	if err != nil {
+		return err
+	}
+	lock, err := modvendor.ReadLock(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	out.Print("%-16s %-10s %-10s %s", "MODULE", "BUNDLED", "VENDORED", "SOURCE")
+	for _, m := range bundled {
+		vendored := "-"
+		if locked, ok := lock.Module(m.Name); ok {
+			vendored = locked.Version
+		}
+		out.Print("%-16s %-10s %-10s %s", m.Name, m.Version, vendored, m.Source)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/ui"
+)
+
+// TestNewModulesCmd tests the modules command creation.
+func TestNewModulesCmd(t *testing.T) {
+	cmd := NewModulesCmd()
+
+	assert.Equal(t, "modules", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.Contains(t, cmd.Long, "📦 Forge Modules")
+
+	for _, name := range []string{"vendor", "verify", "list"} {
+		sub, _, err := cmd.Find([]string{name})
+		require.NoError(t, err, name)
+		assert.Equal(t, name, sub.Name())
+	}
+}
+
+// TestRunModules tests vendoring, verifying and listing a project's modules.
+func TestRunModules(t *testing.T) {
+	root := t.TempDir()
+
+	t.Run("verify warns before anything is vendored", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runModulesVerify(ui.NewOutput(&buf), root))
+		assert.Contains(t, buf.String(), "No modules vendored")
+	})
+
+	t.Run("vendor copies the named modules", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runModulesVendor(ui.NewOutput(&buf), root, []string{"sqs", "lambda"}))
+
+		assert.Contains(t, buf.String(), "Vendored 2 modules into .forge/modules")
+		assert.FileExists(t, filepath.Join(root, ".forge", "modules", "sqs", "main.tf"))
+		assert.FileExists(t, filepath.Join(root, ".forge", "modules", "lambda", "package.py"))
+		assert.FileExists(t, filepath.Join(root, ".forge", "modules.lock.hcl"))
+	})
+
+	t.Run("vendor rejects unknown modules", func(t *testing.T) {
+		assert.Error(t, runModulesVendor(ui.NewOutput(&bytes.Buffer{}), root, []string{"vpc"}))
+	})
+
+	t.Run("list shows vendored versions", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runModulesList(ui.NewOutput(&buf), root))
+
+		assert.Regexp(t, `(?m)^sqs\s+\d+\.\d+\.\d+\s+\d+\.\d+\.\d+\s+terraform-aws-modules/sqs/aws$`, buf.String())
+		assert.Regexp(t, `(?m)^sns\s+\d+\.\d+\.\d+\s+-\s+terraform-aws-modules/sns/aws$`, buf.String())
+	})
+
+	t.Run("verify passes unmodified modules", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, runModulesVerify(ui.NewOutput(&buf), root))
+		assert.Contains(t, buf.String(), "2 vendored modules match .forge/modules.lock.hcl")
+	})
+
+	t.Run("verify fails on modified modules", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(root, ".forge", "modules", "sqs", "main.tf"), []byte("# edited\n"), 0o644))
+
+		var buf bytes.Buffer
+		err := runModulesVerify(ui.NewOutput(&buf), root)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 of 2 vendored modules failed verification")
+		assert.Contains(t, buf.String(), "sqs: modified since it was vendored")
+	})
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/lewis/forge/internal/build"
 	"github.com/lewis/forge/internal/scaffold"
 	"github.com/lewis/forge/internal/state"
 	"github.com/lewis/forge/internal/terraform"
@@ -17,11 +18,12 @@ import (
 // NewNewCmd creates the 'new' command.
 func NewNewCmd() *cobra.Command {
 	var (
-		projectName string
-		stackName   string
-		runtime     string
-		description string
-		autoState   bool
+		projectName  string
+		stackName    string
+		runtime      string
+		description  string
+		architecture string
+		autoState    bool
 	)
 
 	cmd := &cobra.Command{
@@ -65,6 +67,10 @@ No YAML files, no config templates - just pure Terraform + smart defaults.
     → Generates backend.tf with encryption
     → Ready for team collaboration!
 
+  # Graviton (arm64) functions by default
+  forge new my-app --architecture=arm64
+    → Writes defaults { architecture = "arm64" } to forge.hcl
+
   # Specialized Lambda projects
   forge new lambda my-api
     → Full Lambda + API Gateway setup
@@ -91,7 +97,7 @@ No YAML files, no config templates - just pure Terraform + smart defaults.
 
 			if isNewProject {
 				projectName = args[0]
-				return createProject(projectName, runtime, autoState)
+				return createProject(projectName, runtime, architecture, autoState)
 			}
 
 			// New stack in existing project
@@ -99,13 +105,14 @@ No YAML files, no config templates - just pure Terraform + smart defaults.
 				return errors.New("--stack flag is required when creating a new stack")
 			}
 
-			return createStack(stackName, runtime, description)
+			return createStack(stackName, runtime, description, architecture)
 		},
 	}
 
 	cmd.Flags().StringVar(&stackName, "stack", "", "Create a new stack in existing project")
 	cmd.Flags().StringVar(&runtime, "runtime", "go1.x", "Runtime for the stack (go1.x, python3.11, nodejs20.x)")
 	cmd.Flags().StringVar(&description, "description", "", "Stack description")
+	cmd.Flags().StringVar(&architecture, "architecture", "x86_64", "Lambda architecture (x86_64, arm64)")
 	cmd.Flags().BoolVar(&autoState, "auto-state", false, "Auto-provision S3 bucket and DynamoDB table for Terraform state")
 
 	// Add lambda subcommand
@@ -115,7 +122,11 @@ No YAML files, no config templates - just pure Terraform + smart defaults.
 }
 
 // createProject creates a new Forge project.
-func createProject(name, defaultRuntime string, autoState bool) error {
+func createProject(name, defaultRuntime, architecture string, autoState bool) error {
+	if err := build.ValidateArchitecture(architecture); err != nil {
+		return err
+	}
+
 	projectDir := filepath.Join(".", name)
 
 	// Check if directory already exists
@@ -134,8 +145,9 @@ func createProject(name, defaultRuntime string, autoState bool) error {
 	}
 
 	opts := &scaffold.ProjectOptions{
-		Name:   name,
-		Region: region,
+		Name:         name,
+		Region:       region,
+		Architecture: architecture,
 	}
 
 	if err := scaffold.GenerateProject(projectDir, opts); err != nil {
@@ -237,7 +249,11 @@ func provisionStateBackend(projectDir, projectName, region string) error {
 }
 
 // createStack creates a new stack in the current project.
-func createStack(name, runtime, desc string) error {
+func createStack(name, runtime, desc, architecture string) error {
+	if err := build.ValidateArchitecture(architecture); err != nil {
+		return err
+	}
+
 	// Verify we're in a Forge project
 	if _, err := os.Stat("forge.hcl"); os.IsNotExist(err) {
 		return errors.New("not in a Forge project (forge.hcl not found)")
@@ -251,9 +267,10 @@ func createStack(name, runtime, desc string) error {
 
 	// Generate stack (pure functional - no OOP)
 	opts := &scaffold.StackOptions{
-		Name:        name,
-		Runtime:     runtime,
-		Description: desc,
+		Name:         name,
+		Runtime:      runtime,
+		Description:  desc,
+		Architecture: architecture,
 	}
 	if opts.Description == "" {
 		_, _, _ = opts.Description, fmt.Sprintf, name
@@ -265,6 +282,7 @@ func createStack(name, runtime, desc string) error {
 
 	fmt.Printf("Created stack: %s\n", name)
 	fmt.Printf("Runtime: %s\n", runtime)
+	fmt.Printf("Architecture: %s\n", build.NormalizeArchitecture(architecture))
 	fmt.Printf("\nNext steps:\n")
 	fmt.Printf("  cd %s\n", name)
 	fmt.Printf("  # Edit your function code\n")
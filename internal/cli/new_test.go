@@ -50,6 +50,14 @@ func TestNewNewCmd(t *testing.T) {
 		assert.NotNil(t, flag)
 		assert.Equal(t, "", flag.DefValue)
 	})
+
+	t.Run("has architecture flag", func(t *testing.T) {
+		cmd := NewNewCmd()
+
+		flag := cmd.Flags().Lookup("architecture")
+		assert.NotNil(t, flag)
+		assert.Equal(t, "x86_64", flag.DefValue)
+	})
 }
 
 func TestCreateProject(t *testing.T) {
@@ -59,13 +67,37 @@ func TestCreateProject(t *testing.T) {
 		defer func() { _ = os.Chdir(originalDir) }()
 		_ = os.Chdir(tmpDir)
 
-		err := createProject("test-project", "provided.al2023", false)
+		err := createProject("test-project", "provided.al2023", "", false)
 		require.NoError(t, err)
 
 		// Verify project was created
 		assert.DirExists(t, filepath.Join(tmpDir, "test-project"))
 		assert.FileExists(t, filepath.Join(tmpDir, "test-project", "forge.hcl"))
 	})
+
+	t.Run("writes an arm64 default to forge.hcl", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		originalDir, _ := os.Getwd()
+		defer func() { _ = os.Chdir(originalDir) }()
+		_ = os.Chdir(tmpDir)
+
+		require.NoError(t, createProject("graviton", "provided.al2023", "arm64", false))
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "graviton", "forge.hcl"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `architecture = "arm64"`)
+	})
+
+	t.Run("rejects unsupported architectures", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		originalDir, _ := os.Getwd()
+		defer func() { _ = os.Chdir(originalDir) }()
+		_ = os.Chdir(tmpDir)
+
+		err := createProject("bad-arch", "provided.al2023", "s390x", false)
+		require.Error(t, err)
+		assert.NoDirExists(t, filepath.Join(tmpDir, "bad-arch"))
+	})
 }
 
 func TestCreateStack(t *testing.T) {
@@ -79,7 +111,7 @@ func TestCreateStack(t *testing.T) {
 		// Create forge.hcl to indicate we're in a project
 		require.NoError(t, os.WriteFile("forge.hcl", []byte("service = \"test\""), 0o644))
 
-		err := createStack("api", "provided.al2023", "API Lambda")
+		err := createStack("api", "provided.al2023", "API Lambda", "")
 		require.NoError(t, err)
 
 		// Verify stack was created
@@ -89,6 +121,21 @@ func TestCreateStack(t *testing.T) {
 		assert.FileExists(t, filepath.Join(stackDir, "stack.forge.hcl"))
 	})
 
+	t.Run("creates arm64 stack", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		originalDir, _ := os.Getwd()
+		defer func() { _ = os.Chdir(originalDir) }()
+
+		_ = os.Chdir(tmpDir)
+		require.NoError(t, os.WriteFile("forge.hcl", []byte("service = \"test\""), 0o644))
+
+		require.NoError(t, createStack("api", "provided.al2023", "API Lambda", "arm64"))
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, "api", "main.tf"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `architectures = ["arm64"]`)
+	})
+
 	t.Run("creates Python stack", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		originalDir, _ := os.Getwd()
@@ -99,7 +146,7 @@ func TestCreateStack(t *testing.T) {
 		// Create forge.hcl to indicate we're in a project
 		require.NoError(t, os.WriteFile("forge.hcl", []byte("service = \"test\""), 0o644))
 
-		err := createStack("worker", "python3.13", "Worker Lambda")
+		err := createStack("worker", "python3.13", "Worker Lambda", "")
 		require.NoError(t, err)
 
 		stackDir := filepath.Join(tmpDir, "worker")
@@ -118,7 +165,7 @@ func TestCreateStack(t *testing.T) {
 		// Create forge.hcl to indicate we're in a project
 		require.NoError(t, os.WriteFile("forge.hcl", []byte("service = \"test\""), 0o644))
 
-		err := createStack("frontend", "nodejs22.x", "Frontend Lambda")
+		err := createStack("frontend", "nodejs22.x", "Frontend Lambda", "")
 		require.NoError(t, err)
 
 		stackDir := filepath.Join(tmpDir, "frontend")
@@ -137,7 +184,7 @@ func TestCreateStack(t *testing.T) {
 		// Create forge.hcl to indicate we're in a project
 		require.NoError(t, os.WriteFile("forge.hcl", []byte("service = \"test\""), 0o644))
 
-		err := createStack("service", "java21", "Service Lambda")
+		err := createStack("service", "java21", "Service Lambda", "")
 		require.NoError(t, err)
 
 		stackDir := filepath.Join(tmpDir, "service")
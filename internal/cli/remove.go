@@ -0,0 +1,336 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/ui"
+)
+
+// removedFile is the file forge remove writes removed blocks to.
+const removedFile = "removed.tf"
+
+// NewRemoveCmd creates the 'remove' command.
+func NewRemoveCmd() *cobra.Command {
+	var (
+		removeForget bool
+		removeForce  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "remove <resource-type> <name>",
+		Short: "Remove AWS resources generated by forge add",
+		Long: `
+╭──────────────────────────────────────────────────────────────╮
+│  ➖ Forge Remove Resource                                   │
+╰──────────────────────────────────────────────────────────────╯
+
+Undo 'forge add': delete every block forge add recorded for a
+resource in .forge/resources from infra/, wherever it was
+written, with whichever flags it was added.
+
+🧹 What Gets Removed:
+  • The module call or raw resources
+  • Its outputs in outputs.tf
+  • Event source mappings and IAM policies in lambda_<fn>.tf
+  • Files left empty
+
+References to the resource elsewhere in infra/ are reported, not
+edited: fix them before running terraform plan.
+
+🚀 Examples:
+
+  # Remove a queue and its Lambda wiring
+  forge remove sqs orders-queue
+
+  # Remove it from the configuration but keep it in AWS
+  forge remove sqs orders-queue --forget
+
+  # Remove it even though its blocks were edited by hand
+  forge remove sqs orders-queue --force
+
+💡 Pro Tips:
+  • Blocks edited since forge add wrote them, or not written by
+    forge at all, are kept unless you pass --force
+  • Without --forget, terraform apply destroys the resource
+  • --forget writes removed blocks to infra/removed.tf, so
+    Terraform drops the resource from state instead
+`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRoot, err := os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get working directory: %w", err)
+			}
+			return runRemove(ui.DefaultOutput(), projectRoot, args[0], args[1], removeForget, removeForce)
+		},
+	}
+
+	cmd.Flags().BoolVar(&removeForget, "forget", false, "Write removed blocks so Terraform forgets the resource instead of destroying it")
+	cmd.Flags().BoolVar(&removeForce, "force", false, "Remove generated blocks even if they were edited")
+
+	return cmd
+}
+
+// runRemove removes the blocks forge add recorded for a resource from
+// projectRoot's infra/, with the record, and warns about references to them
+// left behind. With forget it also writes removed blocks for the resources
+// and modules it removed. Blocks edited since forge add wrote them are an
+// error, and nothing is removed, unless force is set (I/O ACTION).
+func runRemove(out *ui.Output, projectRoot, resourceType, name string, forget, force bool) error {
+	if _, ok := createGeneratorRegistry().Get(generators.ResourceType(resourceType)); !ok {
+		return fmt.Errorf("unsupported resource type: %s", resourceType)
+	}
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid resource name: %s", name)
+	}
+
+	dir := recordDir(projectRoot, generators.ResourceType(resourceType), name)
+	recorded, err := readRecordedFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(recorded.Files) == 0 {
+		return fmt.Errorf("no record of %s %s in %s: forge remove only removes what forge add recorded", resourceType, name, resourcesDir)
+	}
+
+	infraDir := filepath.Join(projectRoot, "infra")
+	planned, err := planRemovedFiles(recorded, infraDir, force)
+	if err != nil {
+		return err
+	}
+	if len(planned) == 0 {
+		return fmt.Errorf("no generated code for %s %s found in infra/", resourceType, name)
+	}
+
+	var addresses []string
+	for _, file := range planned {
+		if err := writeRemovedFile(infraDir, file); err != nil {
+			return err
+		}
+		for _, block := range file.Blocks {
+			out.Success("Removed %s (%s)", block.Address, file.Path)
+			addresses = append(addresses, block.Address)
+		}
+	}
+	if err := removeRecord(dir); err != nil {
+		return err
+	}
+
+	references, err := findReferences(infraDir, addresses)
+	if err != nil {
+		return err
+	}
+	if len(references) > 0 {
+		out.Print("")
+		out.Warning("infra/ still references what was removed:")
+		for _, ref := range references {
+			out.Print("  %s", ref)
+		}
+	}
+
+	if forget {
+		appended, err := forgetAddresses(infraDir, addresses)
+		if err != nil {
+			return err
+		}
+		out.Print("")
+		out.Info("Wrote %d removed blocks to infra/%s", len(appended), removedFile)
+		out.Dim("terraform apply will forget them without destroying them")
+		return nil
+	}
+
+	out.Print("")
+	out.Info("terraform apply will destroy %s %s", resourceType, name)
+	out.Dim("Use --forget to keep it in AWS instead")
+	return nil
+}
+
+// readRecordedFiles returns the code forge add recorded in a resource's
+// record directory, one file per file of infra/ it was written to. A missing
+// directory has no files (I/O ACTION).
+func readRecordedFiles(dir string) (generators.GeneratedCode, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return generators.GeneratedCode{}, nil
+	}
+	if err != nil {
+		return generators.GeneratedCode{}, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var code generators.GeneratedCode
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return generators.GeneratedCode{}, fmt.Errorf("failed to read record of %s: %w", entry.Name(), err)
+		}
+		code.Files = append(code.Files, generators.FileToWrite{
+			Path:    entry.Name(),
+			Content: string(content),
+			Mode:    generators.WriteModeAppend,
+		})
+	}
+	return code, nil
+}
+
+// removeRecord deletes a resource's record directory, and the directory of
+// its resource type once no other record is left in it (I/O ACTION).
+func removeRecord(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", dir, err)
+	}
+	if entries, err := os.ReadDir(filepath.Dir(dir)); err == nil && len(entries) == 0 {
+		if err := os.Remove(filepath.Dir(dir)); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", filepath.Dir(dir), err)
+		}
+	}
+	return nil
+}
+
+// planRemovedFiles works out what removing the recorded blocks does to the
+// files they were written to, without writing anything. Only files that had
+// blocks removed are returned. Edited blocks fail with hclgen.ErrBlockEdited
+// unless force is set (I/O ACTION: reads infra/).
+func planRemovedFiles(recorded generators.GeneratedCode, infraDir string, force bool) ([]plannedFile, error) {
+	var planned []plannedFile
+	for _, file := range recorded.Files {
+		existing, err := readExistingFile(filepath.Join(infraDir, file.Path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file.Path, err)
+		}
+		if len(bytes.TrimSpace(existing)) == 0 {
+			continue
+		}
+
+		plan := plannedFile{Path: file.Path, Existing: existing}
+		if strings.HasSuffix(file.Path, ".tf.json") {
+			plan.Content, plan.Blocks, err = hclgen.UnmergeTFJSON(existing, []byte(file.Content), force)
+		} else {
+			plan.Content, plan.Blocks, err = hclgen.UnmergeHCL(existing, []byte(file.Content), file.Path, force)
+		}
+		if errors.Is(err, hclgen.ErrBlockEdited) {
+			return nil, fmt.Errorf("failed to update %s: %w (use --force to remove it)", file.Path, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", file.Path, err)
+		}
+		if len(plan.Blocks) > 0 {
+			planned = append(planned, plan)
+		}
+	}
+	return planned, nil
+}
+
+// writeRemovedFile writes a file blocks were removed from, deleting it if
+// nothing is left (I/O ACTION).
+func writeRemovedFile(infraDir string, file plannedFile) error {
+	path := filepath.Join(infraDir, file.Path)
+	if isEmptyTerraform(file.Content) {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", file.Path, err)
+		}
+		return nil
+	}
+	//nolint:gosec // User-generated file permissions
+	if err := os.WriteFile(path, file.Content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// isEmptyTerraform reports whether a .tf or .tf.json file declares nothing (PURE).
+func isEmptyTerraform(content []byte) bool {
+	trimmed := bytes.TrimSpace(content)
+	return len(trimmed) == 0 || bytes.Equal(trimmed, []byte("{}"))
+}
+
+// findReferences returns "file:line: text" for every line of infra/*.tf and
+// infra/*.tf.json that references one of the addresses, skipping comments.
+// Outputs can't be referenced from the module declaring them, so they are
+// skipped too (I/O ACTION).
+func findReferences(infraDir string, addresses []string) ([]string, error) {
+	var patterns []*regexp.Regexp
+	for _, address := range addresses {
+		if strings.HasPrefix(address, "output.") {
+			continue
+		}
+		patterns = append(patterns, regexp.MustCompile(`(^|[^\w.-])`+regexp.QuoteMeta(address)+`($|[^\w-])`))
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(infraDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read infra directory: %w", err)
+	}
+
+	var references []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !(strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json")) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(infraDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for line := 1; scanner.Scan(); line++ {
+			text := strings.TrimSpace(scanner.Text())
+			if strings.HasPrefix(text, "#") || strings.HasPrefix(text, "//") {
+				continue
+			}
+			for _, pattern := range patterns {
+				if pattern.MatchString(text) {
+					references = append(references, fmt.Sprintf("%s:%d: %s", name, line, text))
+					break
+				}
+			}
+		}
+	}
+	return references, nil
+}
+
+// forgetAddresses appends removed blocks for the removed resources and
+// modules to infra/removed.tf, returning the addresses it appended blocks for
+// (I/O ACTION).
+func forgetAddresses(infraDir string, addresses []string) ([]string, error) {
+	var stateful []string
+	for _, address := range addresses {
+		if !strings.HasPrefix(address, "output.") && !strings.HasPrefix(address, "data.") {
+			stateful = append(stateful, address)
+		}
+	}
+
+	existing, err := readExistingFile(filepath.Join(infraDir, removedFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", removedFile, err)
+	}
+	content, appended, err := hclgen.AppendRemovedBlocks(existing, stateful, removedFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(appended) == 0 {
+		return nil, nil
+	}
+	//nolint:gosec // User-generated file permissions
+	if err := os.WriteFile(filepath.Join(infraDir, removedFile), content, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", removedFile, err)
+	}
+	return appended, nil
+}
@@ -0,0 +1,331 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/ui"
+)
+
+// addResource writes what forge add generates for intent into root's infra/,
+// in the format forge.hcl asks for, and records it as forge add does.
+func addResource(t *testing.T, root string, intent generators.ResourceIntent) {
+	t.Helper()
+	generator, ok := createGeneratorRegistry().Get(intent.Type)
+	require.True(t, ok)
+	transforms, err := projectTransforms(root)
+	require.NoError(t, err)
+
+	code, err := E.UnwrapError(E.Chain(func(state generators.ProjectState) E.Either[error, generators.GeneratedCode] {
+		return E.Chain(func(config generators.ResourceConfig) E.Either[error, generators.GeneratedCode] {
+			return inProjectFormat(transforms, generator.Generate(config, state))
+		})(generator.Prompt(context.Background(), intent, state))
+	})(discoverProjectState(root)))
+	require.NoError(t, err)
+	require.NoError(t, extractWrittenError(writeGeneratedFiles(code, filepath.Join(root, "infra"), false)))
+	require.NoError(t, recordGeneratedFiles(code, recordDir(root, intent.Type, intent.Name)))
+}
+
+// TestNewRemoveCmd tests the remove command creation.
+func TestNewRemoveCmd(t *testing.T) {
+	cmd := NewRemoveCmd()
+
+	assert.Equal(t, "remove <resource-type> <name>", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.Contains(t, cmd.Long, "Forge Remove Resource")
+
+	for _, name := range []string{"forget", "force"} {
+		flag := cmd.Flags().Lookup(name)
+		require.NotNil(t, flag, "missing flag %s", name)
+		assert.Equal(t, "false", flag.DefValue)
+	}
+}
+
+// TestRunRemove tests removing everything forge add generated for a resource.
+func TestRunRemove(t *testing.T) {
+	root := t.TempDir()
+	infraDir := filepath.Join(root, "infra")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "src", "functions", "processor"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "src", "functions", "processor", "main.go"), []byte("package main\n"), 0o644))
+	require.NoError(t, os.MkdirAll(infraDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(infraDir, "main.tf"), []byte("locals {\n  queue = module.orders.queue_url\n}\n"), 0o644))
+
+	addResource(t, root, generators.ResourceIntent{Type: generators.ResourceSQS, Name: "orders", ToFunc: "processor", UseModule: true})
+	addResource(t, root, generators.ResourceIntent{Type: generators.ResourceSQS, Name: "payments", UseModule: true})
+
+	var buf bytes.Buffer
+	require.NoError(t, runRemove(ui.NewOutput(&buf), root, "sqs", "orders", false, false))
+
+	t.Run("removes every generated block", func(t *testing.T) {
+		sqsTF, err := os.ReadFile(filepath.Join(infraDir, "sqs.tf"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(sqsTF), "orders")
+		assert.Contains(t, string(sqsTF), `module "payments"`)
+
+		outputs, err := os.ReadFile(filepath.Join(infraDir, "outputs.tf"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(outputs), "orders")
+		assert.Contains(t, string(outputs), `output "payments_url"`)
+
+		assert.NoFileExists(t, filepath.Join(infraDir, "lambda_processor.tf"), "files left empty are deleted")
+		assert.Contains(t, buf.String(), "Removed aws_lambda_event_source_mapping.processor_orders (lambda_processor.tf)")
+	})
+
+	t.Run("warns about remaining references", func(t *testing.T) {
+		assert.Contains(t, buf.String(), "main.tf:2: queue = module.orders.queue_url")
+		assert.NotContains(t, buf.String(), "main.tf:1:")
+	})
+
+	t.Run("removes the record", func(t *testing.T) {
+		assert.NoDirExists(t, filepath.Join(root, resourcesDir, "sqs", "orders"))
+		assert.DirExists(t, filepath.Join(root, resourcesDir, "sqs", "payments"))
+	})
+
+	t.Run("fails when nothing was recorded", func(t *testing.T) {
+		err := runRemove(ui.NewOutput(&bytes.Buffer{}), root, "sqs", "orders", false, false)
+		assert.EqualError(t, err, "no record of sqs orders in .forge/resources: forge remove only removes what forge add recorded")
+	})
+
+	t.Run("fails when the recorded blocks are gone", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(infraDir, "sqs.tf")))
+		require.NoError(t, os.Remove(filepath.Join(infraDir, "outputs.tf")))
+
+		err := runRemove(ui.NewOutput(&bytes.Buffer{}), root, "sqs", "payments", false, false)
+		assert.EqualError(t, err, "no generated code for sqs payments found in infra/")
+	})
+
+	t.Run("fails for names outside the records", func(t *testing.T) {
+		err := runRemove(ui.NewOutput(&bytes.Buffer{}), root, "sqs", "../sqs", false, false)
+		assert.EqualError(t, err, "invalid resource name: ../sqs")
+	})
+
+	t.Run("fails for unsupported types", func(t *testing.T) {
+		err := runRemove(ui.NewOutput(&bytes.Buffer{}), root, "unknown", "orders", false, false)
+		assert.EqualError(t, err, "unsupported resource type: unknown")
+	})
+}
+
+// TestRunRemove_Forget tests writing removed blocks for removed resources.
+func TestRunRemove_Forget(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "infra"), 0o755))
+	addResource(t, root, generators.ResourceIntent{Type: generators.ResourceSQS, Name: "orders", UseModule: true})
+
+	var buf bytes.Buffer
+	require.NoError(t, runRemove(ui.NewOutput(&buf), root, "sqs", "orders", true, false))
+
+	removed, err := os.ReadFile(filepath.Join(root, "infra", removedFile))
+	require.NoError(t, err)
+	assert.Equal(t, "removed {\n  from = module.orders\n\n  lifecycle {\n    destroy = false\n  }\n}\n", string(removed),
+		"outputs have no state to forget")
+	assert.NoFileExists(t, filepath.Join(root, "infra", "sqs.tf"))
+	assert.NoFileExists(t, filepath.Join(root, "infra", "outputs.tf"))
+	assert.Contains(t, buf.String(), "Wrote 1 removed blocks to infra/removed.tf")
+}
+
+// TestRunRemove_EditedBlocks tests that blocks forge add didn't write as they
+// are now are only removed with force.
+func TestRunRemove_EditedBlocks(t *testing.T) {
+	setup := func(t *testing.T, sqsTF func(string) string) string {
+		t.Helper()
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "infra"), 0o755))
+		addResource(t, root, generators.ResourceIntent{Type: generators.ResourceSQS, Name: "orders", UseModule: true})
+
+		path := filepath.Join(root, "infra", "sqs.tf")
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, []byte(sqsTF(string(content))), 0o644))
+		return root
+	}
+	edit := func(content string) string {
+		return strings.Replace(content, "visibility_timeout_seconds = 30", "visibility_timeout_seconds = 90", 1)
+	}
+	handWrite := func(string) string {
+		return "module \"orders\" {\n  source = \"terraform-aws-modules/sqs/aws\"\n  name   = \"orders\"\n}\n"
+	}
+
+	for name, sqsTF := range map[string]func(string) string{"edited": edit, "hand-written": handWrite} {
+		t.Run("refuses "+name+" blocks", func(t *testing.T) {
+			root := setup(t, sqsTF)
+			before, err := os.ReadFile(filepath.Join(root, "infra", "outputs.tf"))
+			require.NoError(t, err)
+
+			err = runRemove(ui.NewOutput(&bytes.Buffer{}), root, "sqs", "orders", false, false)
+
+			require.ErrorIs(t, err, hclgen.ErrBlockEdited)
+			assert.Contains(t, err.Error(), "module.orders")
+			assert.Contains(t, err.Error(), "use --force")
+			assert.FileExists(t, filepath.Join(root, "infra", "sqs.tf"))
+			after, err := os.ReadFile(filepath.Join(root, "infra", "outputs.tf"))
+			require.NoError(t, err)
+			assert.Equal(t, string(before), string(after), "nothing is removed")
+		})
+
+		t.Run("removes "+name+" blocks with force", func(t *testing.T) {
+			root := setup(t, sqsTF)
+
+			require.NoError(t, runRemove(ui.NewOutput(&bytes.Buffer{}), root, "sqs", "orders", false, true))
+
+			assert.NoFileExists(t, filepath.Join(root, "infra", "sqs.tf"))
+			assert.NoFileExists(t, filepath.Join(root, "infra", "outputs.tf"))
+		})
+	}
+}
+
+// TestRunRemove_FlagBlocks tests removing blocks forge add only generates
+// with flags, such as the target and role of a rule forwarding to a bus.
+func TestRunRemove_FlagBlocks(t *testing.T) {
+	root := t.TempDir()
+	infraDir := filepath.Join(root, "infra")
+	require.NoError(t, os.MkdirAll(infraDir, 0o755))
+	addResource(t, root, generators.ResourceIntent{
+		Type: generators.ResourceEventBridge,
+		Name: "fwd",
+		Flags: map[string]string{
+			"pattern": `{"source":["orders"]}`,
+			"to-bus":  "arn:aws:events:us-east-1:123456789012:event-bus/audit",
+		},
+	})
+
+	var buf bytes.Buffer
+	require.NoError(t, runRemove(ui.NewOutput(&buf), root, "eventbridge", "fwd", false, false))
+
+	assert.NoFileExists(t, filepath.Join(infraDir, "eventbridge.tf"))
+	assert.NoFileExists(t, filepath.Join(infraDir, "outputs.tf"))
+	for _, address := range []string{
+		"aws_cloudwatch_event_target.fwd_event_bus",
+		"aws_iam_role.fwd_events",
+		"aws_iam_role_policy.fwd_events",
+	} {
+		assert.Contains(t, buf.String(), "Removed "+address+" (eventbridge.tf)")
+	}
+}
+
+// TestRunRemove_TerraformJSON tests that blocks forge add wrote as Terraform
+// JSON are removed without being taken for edited ones.
+func TestRunRemove_TerraformJSON(t *testing.T) {
+	setup := func(t *testing.T) string {
+		t.Helper()
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "src", "functions", "processor"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, "src", "functions", "processor", "main.go"), []byte("package main\n"), 0o644))
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "infra"), 0o755))
+		forgeHCL := "project {\n  name             = \"app\"\n  region           = \"us-east-1\"\n  terraform_format = \"json\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(root, "forge.hcl"), []byte(forgeHCL), 0o644))
+		return root
+	}
+
+	tests := map[string]struct {
+		intent generators.ResourceIntent
+		files  []string
+	}{
+		"raw queue wired to a function": {
+			intent: generators.ResourceIntent{Type: generators.ResourceSQS, Name: "orders", ToFunc: "processor"},
+			files:  []string{"sqs.tf.json", "outputs.tf.json", "lambda_processor.tf.json"},
+		},
+		"scheduled rule": {
+			intent: generators.ResourceIntent{
+				Type:      generators.ResourceEventBridge,
+				Name:      "nightly",
+				UseModule: true,
+				Flags:     map[string]string{"schedule": "cron(0 3 * * ? *)"},
+			},
+			files: []string{"eventbridge.tf.json", "outputs.tf.json"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run("removes "+name, func(t *testing.T) {
+			root := setup(t)
+			addResource(t, root, tt.intent)
+			for _, file := range tt.files {
+				require.FileExists(t, filepath.Join(root, "infra", file))
+			}
+
+			require.NoError(t, runRemove(ui.NewOutput(&bytes.Buffer{}), root, string(tt.intent.Type), tt.intent.Name, false, false))
+
+			for _, file := range tt.files {
+				assert.NoFileExists(t, filepath.Join(root, "infra", file))
+			}
+		})
+	}
+
+	t.Run("refuses edited blocks", func(t *testing.T) {
+		root := setup(t)
+		addResource(t, root, tests["raw queue wired to a function"].intent)
+		path := filepath.Join(root, "infra", "sqs.tf.json")
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, bytes.Replace(content, []byte(`"visibility_timeout_seconds": 30`), []byte(`"visibility_timeout_seconds": 90`), 1), 0o644))
+
+		err = runRemove(ui.NewOutput(&bytes.Buffer{}), root, "sqs", "orders", false, false)
+
+		require.ErrorIs(t, err, hclgen.ErrBlockEdited)
+		assert.Contains(t, err.Error(), "aws_sqs_queue.orders")
+	})
+}
+
+// TestRunRemove_EventBridgeFlags tests that rules added with any of their
+// flags are removed cleanly, leaving other rules on the same bus alone.
+func TestRunRemove_EventBridgeFlags(t *testing.T) {
+	flags := map[string]map[string]string{
+		"schedule": {"schedule": "rate(1 hour)"},
+		"pattern":  {"pattern": `{"source":["orders"],"detail-type":["OrderCreated"]}`},
+		"bus":      {"pattern": `{"source":["orders"]}`, "bus": "orders"},
+		"to-bus":   {"pattern": `{"source":["orders"]}`, "to-bus": "arn:aws:events:us-east-1:123456789012:event-bus/audit"},
+	}
+
+	for name, ruleFlags := range flags {
+		for _, useModule := range []bool{true, false} {
+			t.Run(fmt.Sprintf("%s (module: %t)", name, useModule), func(t *testing.T) {
+				root := t.TempDir()
+				infraDir := filepath.Join(root, "infra")
+				require.NoError(t, os.MkdirAll(filepath.Join(root, "src", "functions", "processor"), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(root, "src", "functions", "processor", "main.go"), []byte("package main\n"), 0o644))
+				require.NoError(t, os.MkdirAll(infraDir, 0o755))
+
+				keep := generators.ResourceIntent{
+					Type: generators.ResourceEventBridge, Name: "audit", ToFunc: "processor", UseModule: useModule,
+					Flags: map[string]string{"pattern": `{"source":["audit"]}`, "bus": ruleFlags["bus"]},
+				}
+				addResource(t, root, keep)
+				before := readInfra(t, infraDir)
+				addResource(t, root, generators.ResourceIntent{
+					Type: generators.ResourceEventBridge, Name: "rule", ToFunc: "processor", UseModule: useModule, Flags: ruleFlags,
+				})
+
+				var buf bytes.Buffer
+				require.NoError(t, runRemove(ui.NewOutput(&buf), root, "eventbridge", "rule", false, false))
+
+				assert.Equal(t, before, readInfra(t, infraDir), "infra/ is back to what it was before the rule was added")
+				assert.NotContains(t, buf.String(), "still references")
+			})
+		}
+	}
+}
+
+// readInfra returns the content of every file in infraDir by name.
+func readInfra(t *testing.T, infraDir string) map[string]string {
+	t.Helper()
+	entries, err := os.ReadDir(infraDir)
+	require.NoError(t, err)
+	files := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		content, err := os.ReadFile(filepath.Join(infraDir, entry.Name()))
+		require.NoError(t, err)
+		files[entry.Name()] = string(content)
+	}
+	return files
+}
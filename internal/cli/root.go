@@ -72,9 +72,13 @@ Forge combines the power of Terraform with zero-config Lambda workflows.
 	cmd.AddCommand(
 		NewNewCmd(),
 		NewAddCmd(),
+		NewRemoveCmd(),
 		NewBuildCmd(),
 		NewDeployCmd(),
 		NewDestroyCmd(),
+		NewEnvCmd(),
+		NewModulesCmd(),
+		NewValidateCmd(),
 		NewVersionCmd(),
 	)
 
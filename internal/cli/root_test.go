@@ -38,9 +38,12 @@ func TestNewRootCmd(t *testing.T) {
 		expectedCommands := []string{
 			"new",
 			"add",
+			"remove",
 			"build",
 			"deploy",
 			"destroy",
+			"modules",
+			"validate",
 			"version",
 		}
 
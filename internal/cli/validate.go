@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/spf13/cobra"
+
+	"github.com/lewis/forge/internal/tfmodules/modcheck"
+	"github.com/lewis/forge/internal/ui"
+)
+
+// NewValidateCmd creates the 'validate' command.
+func NewValidateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check module calls in infra/ against vendored module schemas",
+		Long: `
+╭──────────────────────────────────────────────────────────────╮
+│  🔎 Forge Validate                                          │
+╰──────────────────────────────────────────────────────────────╯
+
+Check every module call in infra/ against the variables of the
+modules vendored in .forge/modules, before terraform ever runs.
+Works offline: no terraform init, provider download or AWS access.
+
+🎯 What it catches:
+  • Unknown arguments (typos), with the closest variable name
+  • Missing required variables
+  • Constant values of the wrong type (e.g. create_dlq = "yes")
+
+Arguments that reference other values (var.x, module.y.z) are only
+known at plan time and are not type checked. Calls pinning a version
+other than the vendored one are skipped with a warning.
+
+🚀 Examples:
+
+  # Check the current project
+  forge validate
+`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(os.Stderr)
+		},
+	}
+
+	return cmd
+}
+
+// runValidate checks infra/ and prints every diagnostic with its source line.
+func runValidate(w io.Writer) error {
+	out := ui.DefaultOutput()
+
+	// Relative paths keep diagnostics short: infra/sqs.tf:12
+	result, err := modcheck.CheckDir("infra", filepath.Join(".forge", "modules"))
+	if err != nil {
+		out.Error("Failed to check module calls: %v", err)
+		out.Warning("Make sure you're in a Forge project directory with an infra/ folder")
+		return fmt.Errorf("failed to validate: %w", err)
+	}
+
+	if len(result.Diagnostics) > 0 {
+		writer := hcl.NewDiagnosticTextWriter(w, result.Files, 78, false)
+		if err := writer.WriteDiagnostics(result.Diagnostics); err != nil {
+			return fmt.Errorf("failed to print diagnostics: %w", err)
+		}
+	}
+
+	if errs := result.Diagnostics.Errs(); len(errs) > 0 {
+		out.Error("%d problems found in infra/", len(errs))
+		return fmt.Errorf("validation failed with %d errors", len(errs))
+	}
+
+	out.Success("Checked %d of %d module calls against .forge/modules", result.Checked, result.Calls)
+	return nil
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/codegen"
+	"github.com/lewis/forge/internal/tfmodules/modcheck"
+)
+
+// TestNewValidateCmd tests the validate command creation.
+func TestNewValidateCmd(t *testing.T) {
+	cmd := NewValidateCmd()
+
+	assert.Equal(t, "validate", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.Contains(t, cmd.Long, "🔎 Forge Validate")
+	assert.Error(t, cmd.Args(cmd, []string{"extra"}), "Should reject extra arguments")
+}
+
+// TestRunValidate tests checking a project's module calls.
+func TestRunValidate(t *testing.T) {
+	setup := func(t *testing.T, mainTF string) {
+		t.Helper()
+		dir := t.TempDir()
+		origDir, _ := os.Getwd()
+		t.Cleanup(func() { _ = os.Chdir(origDir) })
+		require.NoError(t, os.Chdir(dir))
+
+		require.NoError(t, os.MkdirAll("infra", 0o755))
+		require.NoError(t, os.MkdirAll(filepath.Join(".forge", "modules", "sqs"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(".forge", "modules", "sqs", "variables.tf"), []byte(`
+variable "name" {
+  type = string
+}
+
+variable "visibility_timeout_seconds" {
+  type    = number
+  default = null
+}
+`), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join("infra", "main.tf"), []byte(mainTF), 0o644))
+	}
+
+	t.Run("passes valid module calls", func(t *testing.T) {
+		setup(t, `module "orders" {
+  source                     = "terraform-aws-modules/sqs/aws"
+  name                       = "orders"
+  visibility_timeout_seconds = 30
+}
+`)
+		var stderr bytes.Buffer
+		require.NoError(t, runValidate(&stderr))
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("reports problems with file and line", func(t *testing.T) {
+		setup(t, `module "orders" {
+  source                    = "terraform-aws-modules/sqs/aws"
+  visibilty_timeout_seconds = 30
+}
+`)
+		var stderr bytes.Buffer
+		err := runValidate(&stderr)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2 errors")
+
+		assert.Contains(t, stderr.String(), "Error: Unsupported argument")
+		assert.Contains(t, stderr.String(), "on infra/main.tf line 3")
+		assert.Contains(t, stderr.String(), `mean "visibility_timeout_seconds"?`)
+		assert.Contains(t, stderr.String(), "Error: Missing required argument")
+	})
+
+	t.Run("fails outside a project", func(t *testing.T) {
+		dir := t.TempDir()
+		origDir, _ := os.Getwd()
+		t.Cleanup(func() { _ = os.Chdir(origDir) })
+		require.NoError(t, os.Chdir(dir))
+
+		assert.Error(t, runValidate(&bytes.Buffer{}))
+	})
+}
+
+// TestAdd_GeneratesCheckedModuleCalls runs the module calls forge add writes,
+// integrated with a function, through the checks forge validate makes against
+// the vendored modules, and checks that they only reference outputs the
+// vendored modules declare.
+func TestAdd_GeneratesCheckedModuleCalls(t *testing.T) {
+	modulesDir, err := filepath.Abs(filepath.Join("..", "..", ".forge", "modules"))
+	require.NoError(t, err)
+
+	flags := map[string]map[string]string{
+		"sqs":         nil,
+		"sns":         nil,
+		"dynamodb":    nil,
+		"s3":          nil,
+		"eventbridge": {"schedule": "rate(5 minutes)"},
+	}
+
+	for resourceType, resourceFlags := range flags {
+		t.Run(resourceType, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "infra"), 0o755))
+			fnDir := filepath.Join(tmpDir, "src", "functions", "processor")
+			require.NoError(t, os.MkdirAll(fnDir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(fnDir, "index.js"), []byte("exports.handler = async () => {}\n"), 0o644))
+			t.Chdir(tmpDir)
+
+			require.NoError(t, runAdd(NewAddCmd(), []string{resourceType, "orders"}, addOptions{ToFunc: "processor", Flags: resourceFlags}))
+
+			result, err := modcheck.CheckDir("infra", modulesDir)
+			require.NoError(t, err)
+			assert.Equal(t, 1, result.Calls)
+			assert.Equal(t, 1, result.Checked, "forge add pins another version than .forge/modules: %s", result.Diagnostics)
+			assert.Empty(t, result.Diagnostics.Errs())
+
+			schema, err := codegen.ParseModule(filepath.Join(modulesDir, resourceType))
+			require.NoError(t, err)
+			declared := make(map[string]bool, len(schema.Outputs))
+			for _, output := range schema.Outputs {
+				declared[output.Name] = true
+			}
+			refs := moduleOutputRefs(result.Files, "orders")
+			assert.NotEmpty(t, refs)
+			for _, ref := range refs {
+				assert.True(t, declared[ref], "module.orders.%s is not an output of .forge/modules/%s", ref, resourceType)
+			}
+		})
+	}
+}
+
+// moduleOutputRefs returns the outputs of module.<name> that files reference.
+func moduleOutputRefs(files map[string]*hcl.File, name string) []string {
+	var refs []string
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+			expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+			if !ok || expr.Traversal.RootName() != "module" || len(expr.Traversal) < 3 {
+				return nil
+			}
+			module, ok := expr.Traversal[1].(hcl.TraverseAttr)
+			output, isAttr := expr.Traversal[2].(hcl.TraverseAttr)
+			if ok && isAttr && module.Name == name {
+				refs = append(refs, output.Name)
+			}
+			return nil
+		})
+	}
+	return refs
+}
@@ -7,27 +7,65 @@ import (
 	"path/filepath"
 
 	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/lewis/forge/internal/build"
 )
 
 type (
 	// Config represents the forge.hcl configuration file.
 	Config struct {
-		Project  *ProjectBlock  `hcl:"project,block"`
-		Defaults *DefaultsBlock `hcl:"defaults,block"`
+		Project   *ProjectBlock   `hcl:"project,block"`
+		Defaults  *DefaultsBlock  `hcl:"defaults,block"`
+		Functions []FunctionBlock `hcl:"function,block"`
 	}
 
 	// ProjectBlock contains project-wide configuration.
 	ProjectBlock struct {
 		Name   string `hcl:"name"`
 		Region string `hcl:"region"`
+		// TerraformFormat selects the syntax forge writes to infra/: hcl
+		// (.tf, the default) or json (.tf.json).
+		TerraformFormat string `hcl:"terraform_format,optional"`
+		// ModuleSource selects where generated module calls load modules
+		// from: registry (the default) or vendored (the copies forge modules
+		// vendor writes to .forge/modules, for runners without internet).
+		ModuleSource string `hcl:"module_source,optional"`
 	}
 
 	// DefaultsBlock contains default values for stacks.
 	DefaultsBlock struct {
-		Runtime string `hcl:"runtime,optional"`
-		Timeout int    `hcl:"timeout,optional"`
-		Memory  int    `hcl:"memory,optional"`
+		Runtime      string `hcl:"runtime,optional"`
+		Timeout      int    `hcl:"timeout,optional"`
+		Memory       int    `hcl:"memory,optional"`
+		Architecture string `hcl:"architecture,optional"` // x86_64 or arm64
 	}
+
+	// FunctionBlock overrides defaults for a single function in src/functions/<name>.
+	// It is written as a labeled function "<name>" block in forge.hcl, or as the
+	// top-level body of src/functions/<name>/function.hcl (see LoadFunctionFile).
+	// Zero values mean "not set" and fall through to the next layer.
+	FunctionBlock struct {
+		Name         string            `hcl:"name,label"`
+		Runtime      string            `hcl:"runtime,optional"`
+		Handler      string            `hcl:"handler,optional"`
+		Memory       int               `hcl:"memory,optional"`  // MB
+		Timeout      int               `hcl:"timeout,optional"` // Seconds
+		Architecture string            `hcl:"architecture,optional"`
+		Env          map[string]string `hcl:"env,optional"`      // Lambda environment variables
+		Layers       []string          `hcl:"layers,optional"`   // Layer version ARNs
+		Excludes     []string          `hcl:"excludes,optional"` // Extra zip exclusion patterns
+	}
+)
+
+// Terraform syntaxes forge can generate, set with project.terraform_format.
+const (
+	TerraformFormatHCL  = "hcl"
+	TerraformFormatJSON = "json"
+)
+
+// Module sources forge can generate, set with project.module_source.
+const (
+	ModuleSourceRegistry = "registry"
+	ModuleSourceVendored = "vendored"
 )
 
 // ACTION: I/O operation that reads file and applies pure transformations.
@@ -73,9 +111,10 @@ func applyDefaults(cfg Config, regionOverride string) Config {
 	// Set defaults immutably
 	if newCfg.Defaults == nil {
 		newCfg.Defaults = &DefaultsBlock{
-			Runtime: "go1.x",
-			Timeout: 30,
-			Memory:  256,
+			Runtime:      "go1.x",
+			Timeout:      30,
+			Memory:       256,
+			Architecture: build.ArchitectureX86_64,
 		}
 	} else {
 		// Create new DefaultsBlock instead of mutating
@@ -89,6 +128,7 @@ func applyDefaults(cfg Config, regionOverride string) Config {
 		if defaults.Memory == 0 {
 			defaults.Memory = 256
 		}
+		defaults.Architecture = build.NormalizeArchitecture(defaults.Architecture)
 		newCfg.Defaults = &defaults
 	}
 
@@ -106,6 +146,39 @@ func ValidateConfig(c *Config) error {
 	if c.Project.Region == "" {
 		return errors.New("project region is required")
 	}
+	switch c.Project.TerraformFormat {
+	case "", TerraformFormatHCL, TerraformFormatJSON:
+	default:
+		return fmt.Errorf("unsupported terraform_format %q: use %q or %q",
+			c.Project.TerraformFormat, TerraformFormatHCL, TerraformFormatJSON)
+	}
+	switch c.Project.ModuleSource {
+	case "", ModuleSourceRegistry, ModuleSourceVendored:
+	default:
+		return fmt.Errorf("unsupported module_source %q: use %q or %q",
+			c.Project.ModuleSource, ModuleSourceRegistry, ModuleSourceVendored)
+	}
+	if c.Defaults != nil {
+		defaults := FunctionBlock{
+			Memory:       c.Defaults.Memory,
+			Timeout:      c.Defaults.Timeout,
+			Architecture: c.Defaults.Architecture,
+		}
+		if err := ValidateFunction(defaults); err != nil {
+			return fmt.Errorf("defaults: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool, len(c.Functions))
+	for _, fn := range c.Functions {
+		if seen[fn.Name] {
+			return fmt.Errorf("function %q is declared more than once", fn.Name)
+		}
+		seen[fn.Name] = true
+		if err := ValidateFunction(fn); err != nil {
+			return fmt.Errorf("function %q: %w", fn.Name, err)
+		}
+	}
 	return nil
 }
 
@@ -113,10 +186,38 @@ func ValidateConfig(c *Config) error {
 func GetStackDefaults(c *Config) *DefaultsBlock {
 	if c.Defaults == nil {
 		return &DefaultsBlock{
-			Runtime: "go1.x",
-			Timeout: 30,
-			Memory:  256,
+			Runtime:      "go1.x",
+			Timeout:      30,
+			Memory:       256,
+			Architecture: build.ArchitectureX86_64,
 		}
 	}
 	return c.Defaults
 }
+
+// TerraformFormat returns the Terraform syntax forge generates for the
+// project, hcl unless project.terraform_format is json.
+// Pure function - no methods, takes Config as parameter.
+func TerraformFormat(c *Config) string {
+	if c == nil || c.Project == nil || c.Project.TerraformFormat == "" {
+		return TerraformFormatHCL
+	}
+	return c.Project.TerraformFormat
+}
+
+// ModuleSource returns where generated module calls load modules from,
+// registry unless project.module_source is vendored.
+// Pure function - no methods, takes Config as parameter.
+func ModuleSource(c *Config) string {
+	if c == nil || c.Project == nil || c.Project.ModuleSource == "" {
+		return ModuleSourceRegistry
+	}
+	return c.Project.ModuleSource
+}
+
+// FunctionArchitecture resolves a function's architecture: its function block
+// override first, then the project default, then x86_64.
+// Pure function - no methods, takes Config as parameter.
+func FunctionArchitecture(c *Config, name string) string {
+	return ResolveFunction(c, name, nil).Architecture
+}
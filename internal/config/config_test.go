@@ -99,10 +99,110 @@ project {
 			assert.Equal(t, tt.want.Defaults.Runtime, got.Defaults.Runtime)
 			assert.Equal(t, tt.want.Defaults.Timeout, got.Defaults.Timeout)
 			assert.Equal(t, tt.want.Defaults.Memory, got.Defaults.Memory)
+			assert.Equal(t, "x86_64", got.Defaults.Architecture)
 		})
 	}
 }
 
+func TestLoadArchitecture(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "forge.hcl"), []byte(content), 0o644))
+		return tmpDir
+	}
+
+	t.Run("reads default and per-function architectures", func(t *testing.T) {
+		dir := writeConfig(t, `
+project {
+  name   = "graviton"
+  region = "us-east-1"
+}
+
+defaults {
+  architecture = "arm64"
+}
+
+function "legacy" {
+  architecture = "x86_64"
+}
+`)
+
+		cfg, err := Load(dir)
+		require.NoError(t, err)
+
+		assert.Equal(t, "arm64", cfg.Defaults.Architecture)
+		require.Len(t, cfg.Functions, 1)
+		assert.Equal(t, "legacy", cfg.Functions[0].Name)
+		assert.Equal(t, "x86_64", FunctionArchitecture(cfg, "legacy"))
+		assert.Equal(t, "arm64", FunctionArchitecture(cfg, "api"))
+	})
+
+	t.Run("normalizes architecture aliases", func(t *testing.T) {
+		dir := writeConfig(t, `
+project {
+  name   = "aliases"
+  region = "us-east-1"
+}
+
+defaults {
+  architecture = "aarch64"
+}
+`)
+
+		cfg, err := Load(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "arm64", cfg.Defaults.Architecture)
+	})
+
+	t.Run("rejects unsupported default architecture", func(t *testing.T) {
+		dir := writeConfig(t, `
+project {
+  name   = "bad"
+  region = "us-east-1"
+}
+
+defaults {
+  architecture = "sparc"
+}
+`)
+
+		_, err := Load(dir)
+		assert.ErrorContains(t, err, `unsupported architecture "sparc"`)
+	})
+
+	t.Run("rejects unsupported function architecture", func(t *testing.T) {
+		dir := writeConfig(t, `
+project {
+  name   = "bad"
+  region = "us-east-1"
+}
+
+function "api" {
+  architecture = "mips"
+}
+`)
+
+		_, err := Load(dir)
+		assert.ErrorContains(t, err, `function "api"`)
+	})
+
+	t.Run("rejects duplicate function blocks", func(t *testing.T) {
+		dir := writeConfig(t, `
+project {
+  name   = "dupes"
+  region = "us-east-1"
+}
+
+function "api" {}
+function "api" {}
+`)
+
+		_, err := Load(dir)
+		assert.ErrorContains(t, err, "declared more than once")
+	})
+}
+
 func TestLoadNonexistentConfig(t *testing.T) {
 	// Test that missing forge.hcl returns specific error
 	tmpDir := t.TempDir()
@@ -222,6 +322,50 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "json terraform format",
+			cfg: &Config{
+				Project: &ProjectBlock{
+					Name:            "test",
+					Region:          "us-east-1",
+					TerraformFormat: TerraformFormatJSON,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported terraform format",
+			cfg: &Config{
+				Project: &ProjectBlock{
+					Name:            "test",
+					Region:          "us-east-1",
+					TerraformFormat: "yaml",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "vendored module source",
+			cfg: &Config{
+				Project: &ProjectBlock{
+					Name:         "test",
+					Region:       "us-east-1",
+					ModuleSource: ModuleSourceVendored,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unsupported module source",
+			cfg: &Config{
+				Project: &ProjectBlock{
+					Name:         "test",
+					Region:       "us-east-1",
+					ModuleSource: "git",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -236,6 +380,20 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestTerraformFormat(t *testing.T) {
+	assert.Equal(t, TerraformFormatHCL, TerraformFormat(nil))
+	assert.Equal(t, TerraformFormatHCL, TerraformFormat(&Config{}))
+	assert.Equal(t, TerraformFormatHCL, TerraformFormat(&Config{Project: &ProjectBlock{Name: "a"}}))
+	assert.Equal(t, TerraformFormatJSON, TerraformFormat(&Config{Project: &ProjectBlock{TerraformFormat: "json"}}))
+}
+
+func TestModuleSource(t *testing.T) {
+	assert.Equal(t, ModuleSourceRegistry, ModuleSource(nil))
+	assert.Equal(t, ModuleSourceRegistry, ModuleSource(&Config{}))
+	assert.Equal(t, ModuleSourceRegistry, ModuleSource(&Config{Project: &ProjectBlock{Name: "a"}}))
+	assert.Equal(t, ModuleSourceVendored, ModuleSource(&Config{Project: &ProjectBlock{ModuleSource: "vendored"}}))
+}
+
 func TestGetStackDefaults(t *testing.T) {
 	t.Run("returns explicit defaults when set", func(t *testing.T) {
 		cfg := &Config{
@@ -267,6 +425,7 @@ func TestGetStackDefaults(t *testing.T) {
 
 		defaults := GetStackDefaults(cfg)
 		assert.Equal(t, "go1.x", defaults.Runtime)
+		assert.Equal(t, "x86_64", defaults.Architecture)
 		assert.Equal(t, 30, defaults.Timeout, "Default timeout must be exactly 30")
 		assert.Equal(t, 256, defaults.Memory, "Default memory must be exactly 256")
 	})
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	O "github.com/IBM/fp-go/option"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+
+	"github.com/lewis/forge/internal/build"
+)
+
+// FunctionFileName is the optional per-function config file in src/functions/<name>/.
+const FunctionFileName = "function.hcl"
+
+// Lambda limits enforced on function settings.
+const (
+	minMemoryMB      = 128
+	maxMemoryMB      = 10240
+	minTimeoutSecond = 1
+	maxTimeoutSecond = 900
+	maxLayers        = 5
+)
+
+// envNamePattern matches names Lambda accepts for environment variables.
+var envNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// ACTION: I/O operation that reads and validates src/functions/<name>/function.hcl.
+// Returns nil when the function has no function.hcl.
+func LoadFunctionFile(functionDir string) (*FunctionBlock, error) {
+	path := filepath.Join(functionDir, FunctionFileName)
+
+	// I/O: Check if the file exists
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	// I/O: Parse HCL (the file body is a function block without the label)
+	var fn FunctionBlock
+	if err := hclsimple.DecodeFile(path, nil, &fn); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	fn.Name = filepath.Base(functionDir)
+
+	// PURE: Validate
+	if err := ValidateFunction(fn); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &fn, nil
+}
+
+// ValidateFunction checks function settings against Lambda's limits and
+// reports every problem found, not just the first.
+// Pure function - no methods, takes FunctionBlock as parameter.
+func ValidateFunction(fn FunctionBlock) error {
+	var errs []error
+
+	if fn.Runtime != "" && O.IsNone(build.GetBuilder(build.NewRegistry(), fn.Runtime)) {
+		errs = append(errs, fmt.Errorf("unsupported runtime %q", fn.Runtime))
+	}
+	if fn.Memory != 0 && (fn.Memory < minMemoryMB || fn.Memory > maxMemoryMB) {
+		errs = append(errs, fmt.Errorf("memory must be between %d and %d MB, got %d", minMemoryMB, maxMemoryMB, fn.Memory))
+	}
+	if fn.Timeout != 0 && (fn.Timeout < minTimeoutSecond || fn.Timeout > maxTimeoutSecond) {
+		errs = append(errs, fmt.Errorf("timeout must be between %d and %d seconds, got %d", minTimeoutSecond, maxTimeoutSecond, fn.Timeout))
+	}
+	if err := build.ValidateArchitecture(fn.Architecture); err != nil {
+		errs = append(errs, err)
+	}
+	if len(fn.Layers) > maxLayers {
+		errs = append(errs, fmt.Errorf("at most %d layers are allowed, got %d", maxLayers, len(fn.Layers)))
+	}
+	for name := range fn.Env {
+		if !envNamePattern.MatchString(name) {
+			errs = append(errs, fmt.Errorf("invalid environment variable name %q", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ResolveFunction merges a function's settings in precedence order: project
+// defaults, then its function block in forge.hcl, then its function.hcl (file may be nil).
+// Scalars are replaced by the most specific non-zero value, env vars are merged
+// key by key, and layers/excludes are replaced as a whole when set.
+// Runtime is left empty unless a function block or file sets it; callers fall
+// back to the detected runtime.
+// Pure function - no methods, takes Config as parameter.
+func ResolveFunction(c *Config, name string, file *FunctionBlock) FunctionBlock {
+	defaults := GetStackDefaults(c)
+	resolved := FunctionBlock{
+		Name:         name,
+		Memory:       defaults.Memory,
+		Timeout:      defaults.Timeout,
+		Architecture: defaults.Architecture,
+	}
+
+	for _, fn := range c.Functions {
+		if fn.Name == name {
+			resolved = mergeFunctionBlock(resolved, fn)
+		}
+	}
+	if file != nil {
+		resolved = mergeFunctionBlock(resolved, *file)
+	}
+
+	resolved.Architecture = build.NormalizeArchitecture(resolved.Architecture)
+	return resolved
+}
+
+// PURE: Calculation - returns a new FunctionBlock with override applied over base.
+func mergeFunctionBlock(base, override FunctionBlock) FunctionBlock {
+	merged := base
+
+	if override.Runtime != "" {
+		merged.Runtime = override.Runtime
+	}
+	if override.Handler != "" {
+		merged.Handler = override.Handler
+	}
+	if override.Memory != 0 {
+		merged.Memory = override.Memory
+	}
+	if override.Timeout != 0 {
+		merged.Timeout = override.Timeout
+	}
+	if override.Architecture != "" {
+		merged.Architecture = override.Architecture
+	}
+	if len(override.Env) > 0 {
+		env := make(map[string]string, len(base.Env)+len(override.Env))
+		maps.Copy(env, base.Env)
+		maps.Copy(env, override.Env)
+		merged.Env = env
+	}
+	if override.Layers != nil {
+		merged.Layers = override.Layers
+	}
+	if override.Excludes != nil {
+		merged.Excludes = override.Excludes
+	}
+
+	return merged
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFunctionFile(t *testing.T, content string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "api")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, FunctionFileName), []byte(content), 0o644))
+	return dir
+}
+
+func TestLoadFunctionFile(t *testing.T) {
+	t.Run("returns nil without function.hcl", func(t *testing.T) {
+		fn, err := LoadFunctionFile(t.TempDir())
+		require.NoError(t, err)
+		assert.Nil(t, fn)
+	})
+
+	t.Run("parses every supported setting", func(t *testing.T) {
+		dir := writeFunctionFile(t, `
+runtime      = "python3.12"
+handler      = "app.main"
+memory       = 1024
+timeout      = 120
+architecture = "arm64"
+env = {
+  LOG_LEVEL = "debug"
+}
+layers   = ["arn:aws:lambda:us-east-1:123456789012:layer:deps:3"]
+excludes = ["tests/", "*.md"]
+`)
+
+		fn, err := LoadFunctionFile(dir)
+		require.NoError(t, err)
+		require.NotNil(t, fn)
+
+		assert.Equal(t, FunctionBlock{
+			Name:         "api",
+			Runtime:      "python3.12",
+			Handler:      "app.main",
+			Memory:       1024,
+			Timeout:      120,
+			Architecture: "arm64",
+			Env:          map[string]string{"LOG_LEVEL": "debug"},
+			Layers:       []string{"arn:aws:lambda:us-east-1:123456789012:layer:deps:3"},
+			Excludes:     []string{"tests/", "*.md"},
+		}, *fn)
+	})
+
+	t.Run("reports invalid HCL with the file path", func(t *testing.T) {
+		dir := writeFunctionFile(t, `memory = `)
+
+		_, err := LoadFunctionFile(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), FunctionFileName)
+	})
+
+	t.Run("rejects unknown settings", func(t *testing.T) {
+		dir := writeFunctionFile(t, `memroy = 512`)
+
+		_, err := LoadFunctionFile(dir)
+		assert.Error(t, err)
+	})
+
+	t.Run("reports every validation error", func(t *testing.T) {
+		dir := writeFunctionFile(t, `
+memory  = 64
+timeout = 901
+`)
+
+		_, err := LoadFunctionFile(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "memory must be between 128 and 10240 MB, got 64")
+		assert.Contains(t, err.Error(), "timeout must be between 1 and 900 seconds, got 901")
+	})
+}
+
+func TestValidateFunction(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      FunctionBlock
+		wantErr string
+	}{
+		{name: "empty block is valid", fn: FunctionBlock{}},
+		{name: "valid settings", fn: FunctionBlock{Runtime: "nodejs20.x", Memory: 10240, Timeout: 900, Architecture: "arm64"}},
+		{name: "unknown runtime", fn: FunctionBlock{Runtime: "cobol85"}, wantErr: `unsupported runtime "cobol85"`},
+		{name: "memory too large", fn: FunctionBlock{Memory: 10241}, wantErr: "memory must be between"},
+		{name: "negative timeout", fn: FunctionBlock{Timeout: -1}, wantErr: "timeout must be between"},
+		{name: "bad architecture", fn: FunctionBlock{Architecture: "mips"}, wantErr: "unsupported architecture"},
+		{name: "too many layers", fn: FunctionBlock{Layers: []string{"a", "b", "c", "d", "e", "f"}}, wantErr: "at most 5 layers"},
+		{name: "bad env name", fn: FunctionBlock{Env: map[string]string{"1BAD": "x"}}, wantErr: `invalid environment variable name "1BAD"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFunction(tt.fn)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResolveFunction(t *testing.T) {
+	cfg := &Config{
+		Project: &ProjectBlock{Name: "app", Region: "us-east-1"},
+		Defaults: &DefaultsBlock{
+			Runtime:      "go1.x",
+			Memory:       256,
+			Timeout:      30,
+			Architecture: "x86_64",
+		},
+		Functions: []FunctionBlock{
+			{
+				Name:         "api",
+				Memory:       512,
+				Architecture: "arm64",
+				Env:          map[string]string{"STAGE": "prod", "LOG_LEVEL": "info"},
+				Layers:       []string{"arn:layer:shared"},
+			},
+		},
+	}
+
+	t.Run("uses project defaults", func(t *testing.T) {
+		fn := ResolveFunction(cfg, "worker", nil)
+
+		assert.Equal(t, FunctionBlock{Name: "worker", Memory: 256, Timeout: 30, Architecture: "x86_64"}, fn)
+	})
+
+	t.Run("applies the forge.hcl function block", func(t *testing.T) {
+		fn := ResolveFunction(cfg, "api", nil)
+
+		assert.Equal(t, 512, fn.Memory)
+		assert.Equal(t, 30, fn.Timeout)
+		assert.Equal(t, "arm64", fn.Architecture)
+		assert.Empty(t, fn.Runtime, "project default runtime is applied by discovery")
+	})
+
+	t.Run("function.hcl wins and env vars merge", func(t *testing.T) {
+		file := &FunctionBlock{
+			Name:     "api",
+			Timeout:  60,
+			Env:      map[string]string{"LOG_LEVEL": "debug"},
+			Layers:   []string{},
+			Excludes: []string{"fixtures/"},
+		}
+
+		fn := ResolveFunction(cfg, "api", file)
+
+		assert.Equal(t, 512, fn.Memory)
+		assert.Equal(t, 60, fn.Timeout)
+		assert.Equal(t, map[string]string{"STAGE": "prod", "LOG_LEVEL": "debug"}, fn.Env)
+		assert.Empty(t, fn.Layers, "an explicit empty list clears inherited layers")
+		assert.Equal(t, []string{"fixtures/"}, fn.Excludes)
+	})
+
+	t.Run("does not modify its inputs", func(t *testing.T) {
+		ResolveFunction(cfg, "api", &FunctionBlock{Env: map[string]string{"NEW": "1"}})
+
+		assert.Equal(t, map[string]string{"STAGE": "prod", "LOG_LEVEL": "info"}, cfg.Functions[0].Env)
+	})
+}
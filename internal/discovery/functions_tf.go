@@ -0,0 +1,304 @@
+package discovery
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+
+	"github.com/lewis/forge/internal/config"
+	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/modvendor"
+)
+
+const (
+	// FunctionsTerraformFile is the forge-owned file in infra/ holding one Lambda
+	// module per discovered function. It is rewritten on every build and deploy.
+	FunctionsTerraformFile = "functions.gen.tf"
+
+	// FunctionsTerraformJSONFile replaces FunctionsTerraformFile in projects
+	// whose forge.hcl sets terraform_format = "json".
+	FunctionsTerraformJSONFile = "functions.gen.tf.json"
+
+	// functionsPackageDir is where infra/ finds the zips forge builds into .forge/build.
+	functionsPackageDir = "${path.module}/../.forge/build"
+
+	// functionsModulesDir is where infra/ finds the modules vendored into .forge/modules.
+	functionsModulesDir = "../.forge/modules"
+
+	functionsTerraformHeader = `# Code generated by forge from src/functions. DO NOT EDIT.
+#
+# Regenerated on every "forge build" and "forge deploy". To customize a function,
+# use its function.hcl, or declare module "<name>" in another .tf file and forge
+# will stop generating it here.
+`
+
+	// functionsTerraformJSONHeader carries the header as a Terraform JSON comment.
+	functionsTerraformJSONHeader = `{"//": "Code generated by forge from src/functions. DO NOT EDIT."}`
+
+	// namespaceVariable declares var.namespace for infra/ directories that don't.
+	namespaceVariable = `variable "namespace" {
+  description = "Prefix of resource names, e.g. \"pr-123-\" for a preview environment"
+  type        = string
+  default     = ""
+}
+`
+)
+
+// moduleBlocks selects module blocks from a Terraform file.
+var moduleBlocks = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "module", LabelNames: []string{"name"}}},
+}
+
+// declarationBlocks selects the blocks declaredInfra reads from a Terraform file.
+var declarationBlocks = &hcl.BodySchema{
+	Blocks: append([]hcl.BlockHeaderSchema{{Type: "variable", LabelNames: []string{"name"}}}, infraBlocks.Blocks...),
+}
+
+// FunctionsTerraformResult describes what WriteFunctionsTerraform did.
+type FunctionsTerraformResult struct {
+	Path      string   // Generated file path
+	Written   bool     // False when the file was already up to date
+	Generated []string // Functions rendered into the file, sorted
+	Skipped   []string // Functions already declared by hand-written .tf files, sorted
+}
+
+// RenderFunctionsTerraform renders one terraform-aws-modules/lambda module per function,
+// sorted by name. Each module is labeled with the function name, deploys
+// <buildDir>/<name>.zip and prefixes the function name with var.namespace.
+// PURE: Same functions always render the same bytes.
+func RenderFunctionsTerraform(functions []Function, buildDir string) E.Either[error, string] {
+	sorted := make([]Function, len(functions))
+	copy(sorted, functions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var buf strings.Builder
+	buf.WriteString(functionsTerraformHeader)
+
+	for _, f := range sorted {
+		block, err := renderFunctionModule(f, buildDir)
+		if err != nil {
+			return E.Left[string](fmt.Errorf("failed to render function %s: %w", f.Name, err))
+		}
+		buf.WriteString("\n")
+		buf.WriteString(block)
+	}
+
+	return E.Right[error](string(hclwrite.Format([]byte(buf.String()))))
+}
+
+// renderFunctionModule renders a single module block for f.
+// PURE: Calculation.
+func renderFunctionModule(f Function, buildDir string) (string, error) {
+	module, err := E.UnwrapError(ToLambdaModule(f, buildDir))
+	if err != nil {
+		return "", err
+	}
+
+	// LocalExistingPackage is already a template when buildDir is, e.g.
+	// "${path.module}/../.forge/build/api.zip"
+	functionName := hclgen.Template(hclgen.Ref("var.namespace"), f.Name)
+	if err := functionName.Err(); err != nil {
+		return "", err
+	}
+	name := functionName.String()
+	module.FunctionName = &name
+
+	// Labeled with the plain name: LocalName would now be the template
+	if err := module.Validate(); err != nil {
+		return "", err
+	}
+	return hclgen.ToHCLWrite(f.Name, module.Source, module.Version, module)
+}
+
+// RenderFunctionsTerraformJSON renders the same modules as RenderFunctionsTerraform
+// in Terraform JSON syntax, for infra/functions.gen.tf.json.
+// PURE: Same functions always render the same bytes.
+func RenderFunctionsTerraformJSON(functions []Function, buildDir string) E.Either[error, string] {
+	return E.Chain(functionsTerraformJSON)(RenderFunctionsTerraform(functions, buildDir))
+}
+
+// functionsTerraformJSON converts rendered functions to Terraform JSON syntax.
+// PURE: Calculation.
+func functionsTerraformJSON(config string) E.Either[error, string] {
+	out, err := hclgen.HCLToJSON([]byte(config), FunctionsTerraformFile)
+	if err != nil {
+		return E.Left[string](err)
+	}
+	out, err = hclgen.MergeTFJSON([]byte(functionsTerraformJSONHeader), out)
+	if err != nil {
+		return E.Left[string](err)
+	}
+	return E.Right[error](string(out))
+}
+
+// vendoredFunctionsTerraform points rendered functions at the modules vendored
+// in .forge/modules.
+// PURE: Calculation.
+func vendoredFunctionsTerraform(lock modvendor.Lock) func(string) E.Either[error, string] {
+	return func(config string) E.Either[error, string] {
+		out, err := modvendor.RewriteSources([]byte(config), FunctionsTerraformFile, lock, functionsModulesDir)
+		if err != nil {
+			return E.Left[string](err)
+		}
+		return E.Right[error](string(out))
+	}
+}
+
+// WriteFunctionsTerraform regenerates infra/functions.gen.tf for the given functions,
+// or infra/functions.gen.tf.json when forge.hcl sets terraform_format = "json".
+// Functions already declared in a hand-written .tf or .tf.json file, as a module or
+// an aws_lambda_function, are skipped so the user's definition wins; hand-written
+// files are only read, never modified. The file declares var.namespace unless a
+// hand-written file does. The file is rewritten only when its content changes and removed when no
+// function remains to generate; the file of the other format is removed. When
+// forge.hcl sets module_source = "vendored", modules load from .forge/modules.
+// Projects without an infra/ directory are left alone.
+// ACTION: Performs I/O (reads forge.hcl and infra/*.tf, writes the generated file).
+func WriteFunctionsTerraform(projectRoot string, functions []Function) (FunctionsTerraformResult, error) {
+	infraDir := filepath.Join(projectRoot, "infra")
+	result := FunctionsTerraformResult{Path: filepath.Join(infraDir, FunctionsTerraformFile)}
+
+	if info, err := os.Stat(infraDir); err != nil || !info.IsDir() {
+		return result, nil
+	}
+
+	projectConfig, err := loadProjectConfig(projectRoot)
+	if err != nil {
+		return result, err
+	}
+	// Modules are rendered in HCL, pointed at vendored modules, then converted
+	var steps []func(string) E.Either[error, string]
+	if config.ModuleSource(projectConfig) == config.ModuleSourceVendored {
+		lock, err := modvendor.ReadLock(projectRoot)
+		if err != nil {
+			return result, err
+		}
+		steps = append(steps, vendoredFunctionsTerraform(lock))
+	}
+	stale := FunctionsTerraformJSONFile
+	if config.TerraformFormat(projectConfig) == config.TerraformFormatJSON {
+		steps = append(steps, functionsTerraformJSON)
+		stale = FunctionsTerraformFile
+		result.Path = filepath.Join(infraDir, FunctionsTerraformJSONFile)
+	}
+
+	stalePath := filepath.Join(infraDir, stale)
+	if err := os.Remove(stalePath); err == nil {
+		result.Written = true
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return result, fmt.Errorf("failed to remove %s: %w", stalePath, err)
+	}
+
+	declared, err := declaredInfra(infraDir)
+	if err != nil {
+		return result, err
+	}
+	if !declared.Namespace {
+		steps = append([]func(string) E.Either[error, string]{withNamespaceVariable}, steps...)
+	}
+
+	var generate []Function
+	for _, f := range functions {
+		if declared.Functions[f.Name] {
+			result.Skipped = append(result.Skipped, f.Name)
+			continue
+		}
+		generate = append(generate, f)
+		result.Generated = append(result.Generated, f.Name)
+	}
+	sort.Strings(result.Generated)
+	sort.Strings(result.Skipped)
+
+	existing, err := os.ReadFile(result.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return result, fmt.Errorf("failed to read %s: %w", result.Path, err)
+	}
+	exists := err == nil
+
+	if len(generate) == 0 {
+		if exists {
+			if err := os.Remove(result.Path); err != nil {
+				return result, fmt.Errorf("failed to remove %s: %w", result.Path, err)
+			}
+			result.Written = true
+		}
+		return result, nil
+	}
+
+	rendered := RenderFunctionsTerraform(generate, functionsPackageDir)
+	for _, step := range steps {
+		rendered = E.Chain(step)(rendered)
+	}
+	content, err := E.UnwrapError(rendered)
+	if err != nil {
+		return result, err
+	}
+	if exists && bytes.Equal(existing, []byte(content)) {
+		return result, nil
+	}
+
+	if err := os.WriteFile(result.Path, []byte(content), 0o644); err != nil {
+		return result, fmt.Errorf("failed to write %s: %w", result.Path, err)
+	}
+	result.Written = true
+	return result, nil
+}
+
+// infraDeclarations is what hand-written infra/ files already declare.
+type infraDeclarations struct {
+	Functions map[string]bool // Functions declared as a module call or an aws_lambda_function
+	Namespace bool            // Whether variable "namespace" is declared
+}
+
+// declaredInfra reads the functions and namespace variable declared in
+// infraDir's .tf and .tf.json files, excluding the generated files. Functions
+// are recognized the way IndexProject recognizes them: a module counts only
+// when its source is the terraform-aws-modules/lambda module, registry or
+// vendored, so a queue or table sharing a function's name does not hide it.
+// ACTION: Performs I/O (reads files).
+func declaredInfra(infraDir string) (infraDeclarations, error) {
+	blocks, _, err := readInfraBlocks(infraDir, declarationBlocks, func(name string) bool {
+		return name != FunctionsTerraformFile && name != FunctionsTerraformJSONFile
+	})
+	if err != nil {
+		return infraDeclarations{}, err
+	}
+
+	state := generators.ProjectState{
+		Functions: make(map[string]generators.FunctionInfo),
+		Queues:    make(map[string]generators.QueueInfo),
+		Tables:    make(map[string]generators.TableInfo),
+		APIs:      make(map[string]generators.APIInfo),
+		Topics:    make(map[string]generators.TopicInfo),
+	}
+	declared := infraDeclarations{Functions: make(map[string]bool)}
+	for _, block := range blocks {
+		switch block.Type {
+		case "variable":
+			declared.Namespace = declared.Namespace || block.Labels[0] == "namespace"
+		default:
+			indexBlock(&state, block)
+		}
+	}
+	for name := range state.Functions {
+		declared.Functions[name] = true
+	}
+	return declared, nil
+}
+
+// withNamespaceVariable declares var.namespace, which every generated module
+// prefixes its function name with, after the header.
+// PURE: Calculation.
+func withNamespaceVariable(config string) E.Either[error, string] {
+	return E.Right[error](strings.Replace(config, functionsTerraformHeader,
+		functionsTerraformHeader+"\n"+namespaceVariable, 1))
+}
@@ -0,0 +1,340 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/modvendor"
+)
+
+// assertAttribute checks that content sets name to value, ignoring alignment.
+func assertAttribute(t *testing.T, content, name, value string) {
+	t.Helper()
+	assert.Regexp(t, `(?m)^\s*`+regexp.QuoteMeta(name)+`\s+= `+regexp.QuoteMeta(value)+`$`, content)
+}
+
+// TestRenderFunctionsTerraform tests rendering lambda modules for discovered functions.
+func TestRenderFunctionsTerraform(t *testing.T) {
+	functions := []Function{
+		{Name: "worker", Runtime: "python3.12", EntryPoint: "app.py", Architecture: "arm64"},
+		{Name: "api", Runtime: "provided.al2023", EntryPoint: "main.go", Memory: 512, Env: map[string]string{"TABLE": "orders"}},
+	}
+
+	content, err := E.UnwrapError(RenderFunctionsTerraform(functions, functionsPackageDir))
+	require.NoError(t, err)
+
+	t.Run("is valid HCL with one module per function sorted by name", func(t *testing.T) {
+		file, diags := hclsyntax.ParseConfig([]byte(content), FunctionsTerraformFile, hcl.InitialPos)
+		require.False(t, diags.HasErrors(), diags.Error())
+
+		var labels []string
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			assert.Equal(t, "module", block.Type)
+			labels = append(labels, block.Labels...)
+		}
+		assert.Equal(t, []string{"api", "worker"}, labels)
+	})
+
+	t.Run("deploys the zip forge built", func(t *testing.T) {
+		assertAttribute(t, content, "local_existing_package", `"${path.module}/../.forge/build/api.zip"`)
+		assertAttribute(t, content, "local_existing_package", `"${path.module}/../.forge/build/worker.zip"`)
+		assertAttribute(t, content, "create_package", "false")
+		assertAttribute(t, content, "ignore_source_code_hash", "false")
+		assert.NotContains(t, content, "$${")
+	})
+
+	t.Run("prefixes function names with the namespace", func(t *testing.T) {
+		assertAttribute(t, content, "function_name", `"${var.namespace}api"`)
+		assertAttribute(t, content, "function_name", `"${var.namespace}worker"`)
+	})
+
+	t.Run("maps resolved function settings", func(t *testing.T) {
+		assertAttribute(t, content, "handler", `"app.handler"`)
+		assertAttribute(t, content, "architectures", `["arm64"]`)
+		assertAttribute(t, content, "memory_size", "512")
+		assertAttribute(t, content, "TABLE", `"orders"`)
+	})
+
+	t.Run("is deterministic regardless of input order", func(t *testing.T) {
+		reversed := []Function{functions[1], functions[0]}
+		again, err := E.UnwrapError(RenderFunctionsTerraform(reversed, functionsPackageDir))
+		require.NoError(t, err)
+		assert.Equal(t, content, again)
+	})
+
+	t.Run("rejects invalid functions", func(t *testing.T) {
+		result := RenderFunctionsTerraform([]Function{{Name: "broken"}}, functionsPackageDir)
+		assert.True(t, E.IsLeft(result))
+	})
+}
+
+// TestWriteFunctionsTerraform tests regenerating infra/functions.gen.tf.
+func TestWriteFunctionsTerraform(t *testing.T) {
+	functions := []Function{
+		{Name: "api", Runtime: "provided.al2023", EntryPoint: "main.go"},
+		{Name: "worker", Runtime: "nodejs20.x", EntryPoint: "index.js"},
+	}
+
+	newProject := func(t *testing.T) string {
+		t.Helper()
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "infra"), 0o755))
+		return root
+	}
+
+	t.Run("writes the file and is idempotent", func(t *testing.T) {
+		root := newProject(t)
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.True(t, result.Written)
+		assert.Equal(t, []string{"api", "worker"}, result.Generated)
+		assert.Equal(t, filepath.Join(root, "infra", FunctionsTerraformFile), result.Path)
+
+		first, err := os.ReadFile(result.Path)
+		require.NoError(t, err)
+		assert.Contains(t, string(first), "DO NOT EDIT")
+
+		again, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.False(t, again.Written, "unchanged content should not be rewritten")
+	})
+
+	t.Run("leaves hand-written files untouched and skips their modules", func(t *testing.T) {
+		root := newProject(t)
+		mainTF := "module \"api\" {\n  source = \"terraform-aws-modules/lambda/aws\"\n}\n"
+		mainPath := filepath.Join(root, "infra", "main.tf")
+		require.NoError(t, os.WriteFile(mainPath, []byte(mainTF), 0o644))
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"worker"}, result.Generated)
+		assert.Equal(t, []string{"api"}, result.Skipped)
+
+		content, err := os.ReadFile(mainPath)
+		require.NoError(t, err)
+		assert.Equal(t, mainTF, string(content))
+
+		generated, err := os.ReadFile(result.Path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(generated), `module "api"`)
+		assert.Contains(t, string(generated), `module "worker"`)
+	})
+
+	t.Run("generates functions sharing a name with other modules", func(t *testing.T) {
+		root := newProject(t)
+		mainTF := "module \"api\" {\n  source = \"terraform-aws-modules/sqs/aws\"\n}\n" +
+			"module \"worker\" {\n  source = \"./modules/custom\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(root, "infra", "main.tf"), []byte(mainTF), 0o644))
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api", "worker"}, result.Generated)
+		assert.Empty(t, result.Skipped)
+	})
+
+	t.Run("skips functions declared as raw resources", func(t *testing.T) {
+		root := newProject(t)
+		mainTF := "resource \"aws_lambda_function\" \"api\" {\n  function_name = \"api\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(root, "infra", "main.tf"), []byte(mainTF), 0o644))
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"worker"}, result.Generated)
+		assert.Equal(t, []string{"api"}, result.Skipped)
+	})
+
+	t.Run("declares var.namespace unless infra does", func(t *testing.T) {
+		root := newProject(t)
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		generated, err := os.ReadFile(result.Path)
+		require.NoError(t, err)
+		assert.Contains(t, string(generated), "variable \"namespace\" {\n")
+		assert.Contains(t, string(generated), "default     = \"\"\n")
+
+		variables := "variable \"namespace\" {\n  default = \"\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(root, "infra", "variables.tf"), []byte(variables), 0o644))
+
+		result, err = WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.True(t, result.Written)
+		generated, err = os.ReadFile(result.Path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(generated), "variable \"namespace\"")
+	})
+
+	t.Run("regenerates when functions change", func(t *testing.T) {
+		root := newProject(t)
+		_, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+
+		result, err := WriteFunctionsTerraform(root, functions[:1])
+		require.NoError(t, err)
+		assert.True(t, result.Written)
+
+		content, err := os.ReadFile(result.Path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(content), `module "worker"`)
+	})
+
+	t.Run("removes the file when nothing is left to generate", func(t *testing.T) {
+		root := newProject(t)
+		_, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+
+		result, err := WriteFunctionsTerraform(root, nil)
+		require.NoError(t, err)
+		assert.True(t, result.Written)
+		assert.NoFileExists(t, result.Path)
+	})
+
+	t.Run("does nothing without an infra directory", func(t *testing.T) {
+		root := t.TempDir()
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.False(t, result.Written)
+		assert.NoDirExists(t, filepath.Join(root, "infra"))
+	})
+}
+
+func TestWriteFunctionsTerraform_JSON(t *testing.T) {
+	functions := []Function{
+		{Name: "api", Runtime: "provided.al2023", EntryPoint: "main.go"},
+		{Name: "worker", Runtime: "nodejs20.x", EntryPoint: "index.js"},
+	}
+
+	newProject := func(t *testing.T, format string) string {
+		t.Helper()
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "infra"), 0o755))
+		forgeHCL := "project {\n  name             = \"app\"\n  region           = \"us-east-1\"\n  terraform_format = \"" + format + "\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(root, "forge.hcl"), []byte(forgeHCL), 0o644))
+		return root
+	}
+
+	t.Run("writes functions.gen.tf.json", func(t *testing.T) {
+		root := newProject(t, "json")
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.True(t, result.Written)
+		assert.Equal(t, filepath.Join(root, "infra", FunctionsTerraformJSONFile), result.Path)
+		assert.NoFileExists(t, filepath.Join(root, "infra", FunctionsTerraformFile))
+
+		content, err := os.ReadFile(result.Path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"//": "Code generated by forge from src/functions. DO NOT EDIT."`)
+		assert.Contains(t, string(content), `"function_name": "${var.namespace}api"`)
+		assert.Contains(t, string(content), `"local_existing_package": "${path.module}/../.forge/build/api.zip"`)
+
+		again, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.False(t, again.Written, "unchanged content should not be rewritten")
+	})
+
+	t.Run("renders the same modules as HCL", func(t *testing.T) {
+		hclSrc, err := E.UnwrapError(RenderFunctionsTerraform(functions, functionsPackageDir))
+		require.NoError(t, err)
+		jsonSrc, err := E.UnwrapError(RenderFunctionsTerraformJSON(functions, functionsPackageDir))
+		require.NoError(t, err)
+
+		hclFile, diags := hclsyntax.ParseConfig([]byte(hclSrc), "functions.gen.tf", hcl.InitialPos)
+		require.False(t, diags.HasErrors(), diags.Error())
+		jsonFile, diags := json.Parse([]byte(jsonSrc), "functions.gen.tf.json")
+		require.False(t, diags.HasErrors(), diags.Error())
+
+		hclContent, _, _ := hclFile.Body.PartialContent(moduleBlocks)
+		jsonContent, _, _ := jsonFile.Body.PartialContent(moduleBlocks)
+		require.Len(t, jsonContent.Blocks, len(hclContent.Blocks))
+		for i, block := range hclContent.Blocks {
+			hclAttrs, _ := block.Body.JustAttributes()
+			jsonAttrs, _ := jsonContent.Blocks[i].Body.JustAttributes()
+			assert.Len(t, jsonAttrs, len(hclAttrs), block.Labels[0])
+		}
+	})
+
+	t.Run("replaces the file of the other format", func(t *testing.T) {
+		root := newProject(t, "hcl")
+		_, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+
+		forgeHCL := "project {\n  name             = \"app\"\n  region           = \"us-east-1\"\n  terraform_format = \"json\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(root, "forge.hcl"), []byte(forgeHCL), 0o644))
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.True(t, result.Written)
+		assert.FileExists(t, filepath.Join(root, "infra", FunctionsTerraformJSONFile))
+		assert.NoFileExists(t, filepath.Join(root, "infra", FunctionsTerraformFile))
+	})
+
+	t.Run("skips modules declared in .tf.json files", func(t *testing.T) {
+		root := newProject(t, "json")
+		handWritten := `{"module": {"api": {"source": "../.forge/modules/lambda"}}}`
+		require.NoError(t, os.WriteFile(filepath.Join(root, "infra", "main.tf.json"), []byte(handWritten), 0o644))
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"worker"}, result.Generated)
+		assert.Equal(t, []string{"api"}, result.Skipped)
+	})
+}
+
+func TestWriteFunctionsTerraform_VendoredModules(t *testing.T) {
+	functions := []Function{{Name: "api", Runtime: "provided.al2023", EntryPoint: "main.go"}}
+
+	newProject := func(t *testing.T, format string) string {
+		t.Helper()
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "infra"), 0o755))
+		forgeHCL := "project {\n  name             = \"app\"\n  region           = \"us-east-1\"\n  terraform_format = \"" + format + "\"\n  module_source    = \"vendored\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(root, "forge.hcl"), []byte(forgeHCL), 0o644))
+		return root
+	}
+	lock := modvendor.Lock{Modules: []modvendor.Module{{Name: "lambda", Source: "terraform-aws-modules/lambda/aws"}}}
+
+	t.Run("loads modules from .forge/modules", func(t *testing.T) {
+		root := newProject(t, "hcl")
+		require.NoError(t, modvendor.WriteLock(root, lock))
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(result.Path)
+		require.NoError(t, err)
+		assertAttribute(t, string(content), "source", `"../.forge/modules/lambda"`)
+		assert.NotContains(t, string(content), "version")
+	})
+
+	t.Run("loads modules from .forge/modules in JSON", func(t *testing.T) {
+		root := newProject(t, "json")
+		require.NoError(t, modvendor.WriteLock(root, lock))
+
+		result, err := WriteFunctionsTerraform(root, functions)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(result.Path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"source": "../.forge/modules/lambda"`)
+	})
+
+	t.Run("fails when the lambda module is not vendored", func(t *testing.T) {
+		root := newProject(t, "hcl")
+
+		_, err := WriteFunctionsTerraform(root, functions)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `run "forge modules vendor lambda"`)
+	})
+}
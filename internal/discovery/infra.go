@@ -0,0 +1,254 @@
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/modcheck"
+)
+
+// infraBlocks selects the blocks IndexProject reads from a Terraform file.
+var infraBlocks = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "resource", LabelNames: []string{"type", "name"}},
+		{Type: "module", LabelNames: []string{"name"}},
+	},
+}
+
+// infraAttributes selects the arguments IndexProject reads from a block.
+var infraAttributes = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "source"},
+		{Name: "protocol_type"},
+		{Name: "role"},
+	},
+}
+
+// IndexProject reads the resources a project already declares: raw aws_*
+// resources and terraform-aws-modules calls in infra/*.tf and infra/*.tf.json,
+// keyed by their Terraform label, plus the functions ScanFunctions finds in
+// src/functions, which functions.gen.tf declares as modules. Files that fail
+// to parse contribute whatever blocks could be recovered; Terraform reports
+// the syntax errors itself. Implements generators.DiscoverFunc.
+// ACTION: Performs I/O (reads infra/ and src/functions).
+func IndexProject(projectRoot string) E.Either[error, generators.ProjectState] {
+	infraDir := filepath.Join(projectRoot, "infra")
+	if _, err := os.Stat(infraDir); os.IsNotExist(err) {
+		return E.Left[generators.ProjectState](
+			errors.New("infra/ directory not found - run 'forge new' first"),
+		)
+	}
+
+	state := generators.ProjectState{
+		ProjectRoot: projectRoot,
+		Functions:   make(map[string]generators.FunctionInfo),
+		Queues:      make(map[string]generators.QueueInfo),
+		Tables:      make(map[string]generators.TableInfo),
+		APIs:        make(map[string]generators.APIInfo),
+		Topics:      make(map[string]generators.TopicInfo),
+		InfraFiles:  []string{},
+	}
+
+	blocks, files, err := readInfraBlocks(infraDir, infraBlocks, func(string) bool { return true })
+	if err != nil {
+		return E.Left[generators.ProjectState](
+			fmt.Errorf("failed to read infra directory: %w", err),
+		)
+	}
+	state.InfraFiles = append(state.InfraFiles, files...)
+	for _, block := range blocks {
+		indexBlock(&state, block)
+	}
+
+	functions, err := scanProjectFunctions(projectRoot)
+	if err != nil {
+		return E.Left[generators.ProjectState](err)
+	}
+	for _, f := range functions {
+		state.Functions[f.Name] = mergeFunction(state.Functions[f.Name], f)
+	}
+
+	return E.Right[error](state)
+}
+
+// readInfraBlocks returns the blocks schema selects from infraDir's .tf and
+// .tf.json files that include accepts, along with the paths of those files.
+// Files that fail to parse contribute whatever blocks could be recovered.
+// ACTION: Performs I/O (reads files).
+func readInfraBlocks(infraDir string, schema *hcl.BodySchema, include func(name string) bool) ([]*hcl.Block, []string, error) {
+	entries, err := os.ReadDir(infraDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		blocks []*hcl.Block
+		files  []string
+	)
+	parser := hclparse.NewParser()
+	for _, entry := range entries {
+		name := entry.Name()
+		isJSON := strings.HasSuffix(name, ".tf.json")
+		if entry.IsDir() || !(isJSON || filepath.Ext(name) == ".tf") || !include(name) {
+			continue
+		}
+		filePath := filepath.Join(infraDir, name)
+		files = append(files, filePath)
+
+		var file *hcl.File
+		if isJSON {
+			file, _ = parser.ParseJSONFile(filePath)
+		} else {
+			file, _ = parser.ParseHCLFile(filePath)
+		}
+		if file == nil || file.Body == nil {
+			continue
+		}
+		content, _, _ := file.Body.PartialContent(schema)
+		blocks = append(blocks, content.Blocks...)
+	}
+	return blocks, files, nil
+}
+
+// indexBlock records a resource or module block in state, if it declares a
+// function, queue, table, API or topic.
+// PURE: Calculation (mutates only the state being built).
+func indexBlock(state *generators.ProjectState, block *hcl.Block) {
+	attrs, _, _ := block.Body.PartialContent(infraAttributes)
+
+	if block.Type == "module" {
+		name := block.Labels[0]
+		address := "module." + name
+		switch moduleDir(literalAttribute(attrs.Attributes, "source")) {
+		case "lambda":
+			state.Functions[name] = generators.FunctionInfo{Name: name, TFResource: address}
+		case "sqs":
+			state.Queues[name] = generators.QueueInfo{
+				Name: name, URL: address + ".queue_url", ARN: address + ".queue_arn", TFResource: address,
+			}
+		case "dynamodb":
+			state.Tables[name] = generators.TableInfo{Name: name, ARN: address + ".dynamodb_table_arn", TFResource: address}
+		case "apigateway-v2":
+			state.APIs[name] = generators.APIInfo{Name: name, Type: apiType(attrs.Attributes), TFResource: address}
+		case "sns":
+			state.Topics[name] = generators.TopicInfo{Name: name, ARN: address + ".topic_arn", TFResource: address}
+		}
+		return
+	}
+
+	name := block.Labels[1]
+	address := block.Labels[0] + "." + name
+	switch block.Labels[0] {
+	case "aws_lambda_function":
+		state.Functions[name] = generators.FunctionInfo{Name: name, TFResource: address, Role: roleAddress(attrs.Attributes)}
+	case "aws_sqs_queue":
+		state.Queues[name] = generators.QueueInfo{Name: name, URL: address + ".url", ARN: address + ".arn", TFResource: address}
+	case "aws_dynamodb_table":
+		state.Tables[name] = generators.TableInfo{Name: name, ARN: address + ".arn", TFResource: address}
+	case "aws_apigatewayv2_api":
+		state.APIs[name] = generators.APIInfo{Name: name, Type: apiType(attrs.Attributes), TFResource: address}
+	case "aws_api_gateway_rest_api":
+		state.APIs[name] = generators.APIInfo{Name: name, Type: "REST", TFResource: address}
+	case "aws_sns_topic":
+		state.Topics[name] = generators.TopicInfo{Name: name, ARN: address + ".arn", TFResource: address}
+	}
+}
+
+// moduleDir returns the .forge/modules directory name of a terraform-aws-modules
+// source, either from the registry or vendored, e.g. sqs for
+// terraform-aws-modules/sqs/aws and for ../.forge/modules/sqs.
+// PURE: Calculation.
+func moduleDir(source string) string {
+	if dir, ok := modcheck.VendoredDir(source); ok {
+		return dir
+	}
+	if clean := path.Clean(source); strings.HasSuffix(path.Dir(clean), ".forge/modules") {
+		return path.Base(clean)
+	}
+	return ""
+}
+
+// apiType returns the protocol of an API Gateway v2 API: HTTP unless
+// protocol_type says WEBSOCKET.
+// PURE: Calculation.
+func apiType(attrs hcl.Attributes) string {
+	if strings.EqualFold(literalAttribute(attrs, "protocol_type"), "WEBSOCKET") {
+		return "WebSocket"
+	}
+	return "HTTP"
+}
+
+// roleAddress returns the aws_iam_role a raw function's role argument
+// references, e.g. aws_iam_role.api for aws_iam_role.api.arn.
+// PURE: Calculation.
+func roleAddress(attrs hcl.Attributes) string {
+	attr, ok := attrs["role"]
+	if !ok {
+		return ""
+	}
+	for _, traversal := range attr.Expr.Variables() {
+		if traversal.RootName() != "aws_iam_role" || len(traversal) < 2 {
+			continue
+		}
+		if step, ok := traversal[1].(hcl.TraverseAttr); ok {
+			return "aws_iam_role." + step.Name
+		}
+	}
+	return ""
+}
+
+// literalAttribute returns the value of a literal string argument, or "" when
+// it is missing or computed.
+// PURE: Calculation.
+func literalAttribute(attrs hcl.Attributes, name string) string {
+	attr, ok := attrs[name]
+	if !ok {
+		return ""
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+		return ""
+	}
+	return value.AsString()
+}
+
+// scanProjectFunctions returns the functions in src/functions, sorted by name,
+// or none when the project has no src/functions directory.
+// ACTION: Performs I/O (reads src/functions).
+func scanProjectFunctions(projectRoot string) ([]Function, error) {
+	if _, err := os.Stat(filepath.Join(projectRoot, "src", "functions")); os.IsNotExist(err) {
+		return nil, nil
+	}
+	functions, err := ScanFunctions(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan functions: %w", err)
+	}
+	sort.Slice(functions, func(i, j int) bool { return functions[i].Name < functions[j].Name })
+	return functions, nil
+}
+
+// mergeFunction adds a scanned function's source details to what infra/
+// declares about it. Functions infra/ doesn't declare are the modules
+// functions.gen.tf generates.
+// PURE: Calculation.
+func mergeFunction(declared generators.FunctionInfo, f Function) generators.FunctionInfo {
+	if declared.TFResource == "" {
+		declared.TFResource = "module." + f.Name
+	}
+	declared.Name = f.Name
+	declared.Runtime = f.Runtime
+	declared.SourcePath = f.Path
+	declared.Handler = lambdaHandler(f)
+	return declared
+}
@@ -0,0 +1,141 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/generators"
+)
+
+// TestIndexProject tests indexing the resources a project declares.
+func TestIndexProject(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"infra/main.tf": `
+module "orders" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "~> 4.0"
+  name    = "${var.namespace}orders"
+}
+
+module "users" {
+  source = "../.forge/modules/dynamodb"
+}
+
+module "http" {
+  source = "terraform-aws-modules/apigateway-v2/aws"
+}
+
+module "custom" {
+  source = "./modules/custom"
+}
+
+resource "aws_lambda_function" "legacy" {
+  function_name = "legacy"
+  role          = aws_iam_role.legacy_exec.arn
+
+  environment {
+    variables = { A = "b" }
+  }
+}
+
+resource "aws_sns_topic" "alerts" {
+  name = "alerts"
+}
+
+resource "aws_apigatewayv2_api" "chat" {
+  protocol_type = "WEBSOCKET"
+}
+
+resource "aws_api_gateway_rest_api" "v1" {}
+`,
+		"infra/events.tf.json": `{
+  "module": {"events": {"source": "registry.terraform.io/terraform-aws-modules/sns/aws"}},
+  "resource": {"aws_sqs_queue": {"jobs": {"name": "jobs"}}}
+}`,
+		"infra/functions.gen.tf": `
+module "api" {
+  source = "terraform-aws-modules/lambda/aws"
+}
+`,
+		"infra/broken.tf":                   `resource "aws_dynamodb_table" "sessions" {}` + "\nmodule \"x\" {",
+		"infra/README.md":                   "module \"ignored\" {}",
+		"src/functions/api/main.go":         "package main\n\nfunc main() {}",
+		"src/functions/worker/handler.py":   "def handler(event, context):\n    pass",
+		"src/functions/worker/function.hcl": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	state, err := E.UnwrapError(IndexProject(root))
+	require.NoError(t, err)
+
+	assert.Equal(t, root, state.ProjectRoot)
+	assert.Len(t, state.InfraFiles, 4)
+
+	t.Run("indexes module calls", func(t *testing.T) {
+		assert.Equal(t, generators.QueueInfo{
+			Name: "orders", URL: "module.orders.queue_url", ARN: "module.orders.queue_arn", TFResource: "module.orders",
+		}, state.Queues["orders"])
+		assert.Equal(t, "module.users.dynamodb_table_arn", state.Tables["users"].ARN, "vendored sources are recognized")
+		assert.Equal(t, generators.APIInfo{Name: "http", Type: "HTTP", TFResource: "module.http"}, state.APIs["http"])
+		assert.Equal(t, "module.events.topic_arn", state.Topics["events"].ARN)
+	})
+
+	t.Run("indexes raw resources", func(t *testing.T) {
+		assert.Equal(t, generators.FunctionInfo{
+			Name: "legacy", TFResource: "aws_lambda_function.legacy", Role: "aws_iam_role.legacy_exec",
+		}, state.Functions["legacy"])
+		assert.Equal(t, "aws_sns_topic.alerts.arn", state.Topics["alerts"].ARN)
+		assert.Equal(t, "aws_sqs_queue.jobs.url", state.Queues["jobs"].URL)
+		assert.Equal(t, "WebSocket", state.APIs["chat"].Type)
+		assert.Equal(t, "REST", state.APIs["v1"].Type)
+	})
+
+	t.Run("recovers blocks from files with syntax errors", func(t *testing.T) {
+		assert.Equal(t, "aws_dynamodb_table.sessions", state.Tables["sessions"].TFResource)
+	})
+
+	t.Run("merges functions from src/functions", func(t *testing.T) {
+		api := state.Functions["api"]
+		assert.Equal(t, "module.api", api.TFResource)
+		assert.Equal(t, RuntimeGo, api.Runtime)
+		assert.Equal(t, filepath.Join(root, "src", "functions", "api"), api.SourcePath)
+		assert.Equal(t, "bootstrap", api.Handler)
+
+		worker := state.Functions["worker"]
+		assert.Equal(t, "module.worker", worker.TFResource, "undeclared functions are generated modules")
+		assert.Equal(t, RuntimePython, worker.Runtime)
+	})
+
+	t.Run("skips unknown modules", func(t *testing.T) {
+		assert.NotContains(t, state.Functions, "custom")
+		assert.NotContains(t, state.Queues, "custom")
+	})
+}
+
+// TestIndexProject_Errors tests indexing projects that can't be indexed.
+func TestIndexProject_Errors(t *testing.T) {
+	t.Run("fails without infra/", func(t *testing.T) {
+		_, err := E.UnwrapError(IndexProject(t.TempDir()))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "infra/ directory not found")
+	})
+
+	t.Run("works without src/functions", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "infra"), 0o755))
+
+		state, err := E.UnwrapError(IndexProject(root))
+		require.NoError(t, err)
+		assert.Empty(t, state.Functions)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	E "github.com/IBM/fp-go/either"
 
 	"github.com/lewis/forge/internal/build"
+	"github.com/lewis/forge/internal/config"
 )
 
 const (
@@ -26,11 +27,19 @@ const (
 
 type (
 	// Function represents a discovered Lambda function.
+	// Settings are resolved from forge.hcl defaults, its function block and function.hcl.
 	Function struct {
-		Name       string // Function name (directory name)
-		Path       string // Absolute path to function source
-		Runtime    string // Detected runtime
-		EntryPoint string // Entry file (main.go, index.js, app.py, etc.)
+		Name         string            // Function name (directory name)
+		Path         string            // Absolute path to function source
+		Runtime      string            // Detected runtime, unless overridden
+		EntryPoint   string            // Entry file (main.go, index.js, app.py, etc.)
+		Architecture string            // Target architecture (x86_64 or arm64)
+		Handler      string            // Handler override (empty means the runtime convention)
+		Memory       int               // Memory in MB
+		Timeout      int               // Timeout in seconds
+		Env          map[string]string // Lambda environment variables
+		Layers       []string          // Layer version ARNs
+		Excludes     []string          // Extra zip exclusion patterns
 	}
 )
 
@@ -43,6 +52,12 @@ func ScanFunctions(projectRoot string) ([]Function, error) {
 		return nil, errors.New("src/functions directory not found")
 	}
 
+	// Per-function settings are optional; without forge.hcl conventions apply
+	projectConfig, err := loadProjectConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
 	// Read all subdirectories
 	entries, err := os.ReadDir(functionsDir)
 	if err != nil {
@@ -57,24 +72,73 @@ func ScanFunctions(projectRoot string) ([]Function, error) {
 
 		functionPath := filepath.Join(functionsDir, entry.Name())
 
+		// Optional per-function overrides (validated on load)
+		functionFile, err := config.LoadFunctionFile(functionPath)
+		if err != nil {
+			return nil, err
+		}
+		settings := config.ResolveFunction(projectConfig, entry.Name(), functionFile)
+
 		// Detect runtime by checking for entry files
 		runtime, entryPoint, err := detectRuntime(functionPath)
-		if err != nil {
+		if err != nil && settings.Runtime == "" {
 			// Skip directories without recognizable entry points
 			continue
 		}
 
 		functions = append(functions, Function{
-			Name:       entry.Name(),
-			Path:       functionPath,
-			Runtime:    runtime,
-			EntryPoint: entryPoint,
+			Name:         entry.Name(),
+			Path:         functionPath,
+			Runtime:      resolveRuntime(runtime, settings.Runtime, config.GetStackDefaults(projectConfig).Runtime),
+			EntryPoint:   entryPoint,
+			Architecture: settings.Architecture,
+			Handler:      settings.Handler,
+			Memory:       settings.Memory,
+			Timeout:      settings.Timeout,
+			Env:          settings.Env,
+			Layers:       settings.Layers,
+			Excludes:     settings.Excludes,
 		})
 	}
 
 	return functions, nil
 }
 
+// resolveRuntime picks a function's runtime: an explicit override wins; otherwise
+// the project default applies when it is the same language as the detected
+// runtime (e.g. defaults { runtime = "python3.12" } pins detected Python functions).
+// PURE: Calculation.
+func resolveRuntime(detected, override, projectDefault string) string {
+	if override != "" {
+		return override
+	}
+	if projectDefault != "" && runtimeFamily(projectDefault) == runtimeFamily(detected) {
+		return projectDefault
+	}
+	return detected
+}
+
+// runtimeFamily strips the version from a runtime ("python3.12" -> "python").
+// PURE: Calculation.
+func runtimeFamily(runtime string) string {
+	for _, family := range []string{"provided", "python", "nodejs", "java"} {
+		if strings.HasPrefix(runtime, family) {
+			return family
+		}
+	}
+	return runtime
+}
+
+// loadProjectConfig reads forge.hcl if the project has one.
+// A missing file yields an empty config so every function gets the defaults.
+// ACTION: Performs I/O (file read).
+func loadProjectConfig(projectRoot string) (*config.Config, error) {
+	if !fileExists(projectRoot, "forge.hcl") {
+		return &config.Config{}, nil
+	}
+	return config.Load(projectRoot)
+}
+
 // Pure function - no methods, takes path as parameter.
 func detectRuntime(functionPath string) (string, string, error) {
 	// Go: main.go or *.go files
@@ -156,15 +220,22 @@ func ToBuildConfig(f Function, buildDir string) E.Either[error, build.Config] {
 
 	outputPath := filepath.Join(buildDir, f.Name+".zip")
 
-	// Determine handler based on runtime (safe with strings.HasPrefix)
+	// Determine handler based on runtime (safe with strings.HasPrefix).
+	// f.Handler is what Lambda invokes (see lambdaHandler), not a build target:
+	// for Go, build.Config.Handler names the package go build compiles.
 	handler := determineHandler(f.Runtime)
 
+	// function.hcl configures the function, it is never part of its package
+	excludes := append([]string{config.FunctionFileName}, f.Excludes...)
+
 	return E.Right[error](build.Config{
-		SourceDir:  f.Path,
-		OutputPath: outputPath,
-		Runtime:    f.Runtime,
-		Handler:    handler,
-		Env:        make(map[string]string),
+		SourceDir:    f.Path,
+		OutputPath:   outputPath,
+		Runtime:      f.Runtime,
+		Handler:      handler,
+		Env:          make(map[string]string),
+		Excludes:     excludes,
+		Architecture: build.NormalizeArchitecture(f.Architecture),
 	})
 }
 
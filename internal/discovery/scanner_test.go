@@ -233,6 +233,183 @@ func TestScanner_detectRuntime(t *testing.T) {
 	}
 }
 
+func TestScanner_ScanFunctionsArchitecture(t *testing.T) {
+	writeProject := func(t *testing.T, forgeHCL string) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		for _, name := range []string{"api", "legacy"} {
+			dir := filepath.Join(tmpDir, "src", "functions", name)
+			require.NoError(t, os.MkdirAll(dir, 0o755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main"), 0o644))
+		}
+		if forgeHCL != "" {
+			require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "forge.hcl"), []byte(forgeHCL), 0o644))
+		}
+		return tmpDir
+	}
+
+	architectures := func(functions []Function) map[string]string {
+		result := make(map[string]string, len(functions))
+		for _, fn := range functions {
+			result[fn.Name] = fn.Architecture
+		}
+		return result
+	}
+
+	t.Run("defaults to x86_64 without forge.hcl", func(t *testing.T) {
+		functions, err := ScanFunctions(writeProject(t, ""))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"api": "x86_64", "legacy": "x86_64"}, architectures(functions))
+	})
+
+	t.Run("applies project default and function overrides", func(t *testing.T) {
+		functions, err := ScanFunctions(writeProject(t, `
+project {
+  name   = "app"
+  region = "us-east-1"
+}
+
+defaults {
+  architecture = "arm64"
+}
+
+function "legacy" {
+  architecture = "x86_64"
+}
+`))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"api": "arm64", "legacy": "x86_64"}, architectures(functions))
+	})
+
+	t.Run("fails on an invalid forge.hcl", func(t *testing.T) {
+		_, err := ScanFunctions(writeProject(t, `
+project {
+  name   = "app"
+  region = "us-east-1"
+}
+
+function "api" {
+  architecture = "ppc64"
+}
+`))
+		assert.ErrorContains(t, err, "unsupported architecture")
+	})
+}
+
+func TestScanner_ScanFunctionsFunctionFile(t *testing.T) {
+	writeFiles := func(t *testing.T, files map[string]string) string {
+		t.Helper()
+		tmpDir := t.TempDir()
+		for path, content := range files {
+			fullPath := filepath.Join(tmpDir, path)
+			require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+			require.NoError(t, os.WriteFile(fullPath, []byte(content), 0o644))
+		}
+		return tmpDir
+	}
+
+	findFunction := func(t *testing.T, functions []Function, name string) Function {
+		t.Helper()
+		for _, fn := range functions {
+			if fn.Name == name {
+				return fn
+			}
+		}
+		t.Fatalf("function %s not found", name)
+		return Function{}
+	}
+
+	t.Run("merges function.hcl over forge.hcl defaults", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"forge.hcl": `
+project {
+  name   = "app"
+  region = "us-east-1"
+}
+
+defaults {
+  memory  = 512
+  timeout = 20
+}
+`,
+			"src/functions/api/app.py": "def handler(e, c): pass",
+			"src/functions/api/function.hcl": `
+timeout = 60
+handler = "app.main"
+env = {
+  TABLE = "orders"
+}
+layers   = ["arn:aws:lambda:us-east-1:123456789012:layer:deps:1"]
+excludes = ["tests/"]
+`,
+			"src/functions/worker/main.go": "package main",
+		})
+
+		functions, err := ScanFunctions(dir)
+		require.NoError(t, err)
+
+		api := findFunction(t, functions, "api")
+		assert.Equal(t, RuntimePython, api.Runtime)
+		assert.Equal(t, "app.main", api.Handler)
+		assert.Equal(t, 512, api.Memory)
+		assert.Equal(t, 60, api.Timeout)
+		assert.Equal(t, map[string]string{"TABLE": "orders"}, api.Env)
+		assert.Equal(t, []string{"arn:aws:lambda:us-east-1:123456789012:layer:deps:1"}, api.Layers)
+		assert.Equal(t, []string{"tests/"}, api.Excludes)
+
+		worker := findFunction(t, functions, "worker")
+		assert.Equal(t, 512, worker.Memory)
+		assert.Equal(t, 20, worker.Timeout)
+		assert.Empty(t, worker.Handler)
+	})
+
+	t.Run("runtime override enables functions without a detected entry point", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"src/functions/billing/pom.xml":      "<project/>",
+			"src/functions/billing/function.hcl": `runtime = "java21"` + "\n" + `handler = "com.example.Handler::handleRequest"`,
+		})
+
+		functions, err := ScanFunctions(dir)
+		require.NoError(t, err)
+		require.Len(t, functions, 1)
+		assert.Equal(t, "java21", functions[0].Runtime)
+	})
+
+	t.Run("project default runtime pins detected functions of the same language", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"forge.hcl": `
+project {
+  name   = "app"
+  region = "us-east-1"
+}
+
+defaults {
+  runtime = "python3.12"
+}
+`,
+			"src/functions/py/app.py":     "",
+			"src/functions/node/index.js": "",
+		})
+
+		functions, err := ScanFunctions(dir)
+		require.NoError(t, err)
+		assert.Equal(t, "python3.12", findFunction(t, functions, "py").Runtime)
+		assert.Equal(t, RuntimeNode, findFunction(t, functions, "node").Runtime)
+	})
+
+	t.Run("fails on an invalid function.hcl", func(t *testing.T) {
+		dir := writeFiles(t, map[string]string{
+			"src/functions/api/main.go":      "package main",
+			"src/functions/api/function.hcl": `memory = 99999`,
+		})
+
+		_, err := ScanFunctions(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), filepath.Join("api", "function.hcl"))
+		assert.Contains(t, err.Error(), "memory must be between")
+	})
+}
+
 func TestFunction_ToBuildConfig(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -307,9 +484,33 @@ func TestFunction_ToBuildConfig(t *testing.T) {
 			assert.Equal(t, tt.expected["OutputPath"], cfg.OutputPath)
 			assert.Equal(t, tt.expected["Runtime"], cfg.Runtime)
 			assert.Equal(t, tt.expected["Handler"], cfg.Handler)
+			assert.Equal(t, "x86_64", cfg.Architecture)
 			assert.NotNil(t, cfg.Env)
 		})
 	}
+
+	t.Run("applies exclude overrides and keeps the build target", func(t *testing.T) {
+		fn := Function{
+			Name:     "api",
+			Path:     "/project/src/functions/api",
+			Runtime:  RuntimeGo,
+			Handler:  "main",
+			Excludes: []string{"testdata/"},
+		}
+
+		cfg := E.GetOrElse(func(error) build.Config { return build.Config{} })(ToBuildConfig(fn, "/project/.forge/build"))
+
+		assert.Equal(t, "bootstrap", cfg.Handler, "the Lambda handler override is not a Go package to build")
+		assert.Equal(t, []string{"function.hcl", "testdata/"}, cfg.Excludes)
+	})
+
+	t.Run("carries the function architecture", func(t *testing.T) {
+		fn := Function{Name: "api", Path: "/project/src/functions/api", Runtime: RuntimeGo, Architecture: "arm64"}
+
+		cfg := E.GetOrElse(func(error) build.Config { return build.Config{} })(ToBuildConfig(fn, "/project/.forge/build"))
+
+		assert.Equal(t, "arm64", cfg.Architecture)
+	})
 }
 
 func TestFunction_ToBuildConfig_ValidationErrors(t *testing.T) {
@@ -0,0 +1,85 @@
+package discovery
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	E "github.com/IBM/fp-go/either"
+
+	"github.com/lewis/forge/internal/build"
+	"github.com/lewis/forge/internal/tfmodules/lambda"
+)
+
+// ToLambdaModule maps a function's resolved settings onto the Terraform inputs of
+// terraform-aws-modules/lambda: runtime, handler, memory, timeout, architecture,
+// environment and layers. The module deploys the zip forge built at
+// <buildDir>/<name>.zip instead of packaging the source itself, and redeploys
+// whenever that zip's hash changes.
+// PURE: Calculation with validation.
+func ToLambdaModule(f Function, buildDir string) E.Either[error, *lambda.Module] {
+	if f.Name == "" {
+		return E.Left[*lambda.Module](errors.New("function name cannot be empty"))
+	}
+	if f.Runtime == "" {
+		return E.Left[*lambda.Module](errors.New("function runtime cannot be empty"))
+	}
+	if buildDir == "" {
+		return E.Left[*lambda.Module](errors.New("build directory cannot be empty"))
+	}
+
+	fn := lambda.NewModule(f.Name)
+	fn.WithRuntime(f.Runtime, lambdaHandler(f))
+
+	if f.Memory != 0 {
+		memory := f.Memory
+		fn.MemorySize = &memory
+	}
+	if f.Timeout != 0 {
+		timeout := f.Timeout
+		fn.Timeout = &timeout
+	}
+
+	fn.Architectures = []string{build.NormalizeArchitecture(f.Architecture)}
+
+	if len(f.Env) > 0 {
+		fn.WithEnvironment(f.Env)
+	}
+	if len(f.Layers) > 0 {
+		fn.WithLayers(f.Layers...)
+	}
+
+	// Deploy the artifact forge built instead of letting the module package source.
+	// Not filepath.Join: buildDir may be a Terraform template such as
+	// "${path.module}/../.forge/build", which must not be cleaned.
+	createPackage := false
+	fn.CreatePackage = &createPackage
+	packagePath := strings.TrimSuffix(buildDir, "/") + "/" + f.Name + ".zip"
+	fn.LocalExistingPackage = &packagePath
+
+	// Redeploy whenever the zip changes (source_code_hash = filebase64sha256 of the package)
+	ignoreSourceCodeHash := false
+	fn.IgnoreSourceCodeHash = &ignoreSourceCodeHash
+
+	return E.Right[error](fn)
+}
+
+// lambdaHandler returns the handler Lambda invokes: the function's override, or
+// the convention for its runtime and entry point (app.py -> "app.handler").
+// PURE: Calculation.
+func lambdaHandler(f Function) string {
+	if f.Handler != "" {
+		return f.Handler
+	}
+
+	module := strings.TrimSuffix(f.EntryPoint, filepath.Ext(f.EntryPoint))
+	switch {
+	case strings.HasPrefix(f.Runtime, "python"), strings.HasPrefix(f.Runtime, "nodejs"):
+		if module == "" || strings.Contains(module, "*") {
+			return determineHandler(f.Runtime)
+		}
+		return module + ".handler"
+	default:
+		return determineHandler(f.Runtime)
+	}
+}
@@ -0,0 +1,71 @@
+package discovery
+
+import (
+	"testing"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/lambda"
+)
+
+func TestToLambdaModule(t *testing.T) {
+	moduleOf := func(t *testing.T, f Function) *lambda.Module {
+		t.Helper()
+		result := ToLambdaModule(f, ".forge/build")
+		require.True(t, E.IsRight(result))
+		return E.GetOrElse(func(error) *lambda.Module { return nil })(result)
+	}
+
+	t.Run("maps resolved settings to module inputs", func(t *testing.T) {
+		fn := moduleOf(t, Function{
+			Name:         "api",
+			Path:         "/project/src/functions/api",
+			Runtime:      RuntimePython,
+			EntryPoint:   "app.py",
+			Architecture: "arm64",
+			Memory:       1024,
+			Timeout:      60,
+			Env:          map[string]string{"TABLE": "orders"},
+			Layers:       []string{"arn:layer:deps:1"},
+		})
+
+		assert.Equal(t, "api", *fn.FunctionName)
+		assert.Equal(t, RuntimePython, *fn.Runtime)
+		assert.Equal(t, "app.handler", *fn.Handler)
+		assert.Equal(t, 1024, *fn.MemorySize)
+		assert.Equal(t, 60, *fn.Timeout)
+		assert.Equal(t, []string{"arm64"}, fn.Architectures)
+		assert.Equal(t, map[string]string{"TABLE": "orders"}, fn.EnvironmentVariables)
+		assert.Equal(t, []string{"arn:layer:deps:1"}, fn.Layers)
+		assert.False(t, *fn.CreatePackage)
+		assert.Equal(t, ".forge/build/api.zip", *fn.LocalExistingPackage)
+	})
+
+	t.Run("derives handlers from runtime conventions", func(t *testing.T) {
+		cases := []struct {
+			fn   Function
+			want string
+		}{
+			{Function{Name: "a", Runtime: RuntimeGo, EntryPoint: "main.go"}, "bootstrap"},
+			{Function{Name: "b", Runtime: RuntimeNode, EntryPoint: "index.mjs"}, "index.handler"},
+			{Function{Name: "c", Runtime: RuntimePython, EntryPoint: "lambda_function.py"}, "lambda_function.handler"},
+			{Function{Name: "d", Runtime: RuntimePython, EntryPoint: "app.py", Handler: "app.main"}, "app.main"},
+		}
+		for _, tc := range cases {
+			assert.Equal(t, tc.want, *moduleOf(t, tc.fn).Handler, tc.fn.Name)
+		}
+	})
+
+	t.Run("defaults to x86_64", func(t *testing.T) {
+		fn := moduleOf(t, Function{Name: "api", Runtime: RuntimeGo})
+		assert.Equal(t, []string{"x86_64"}, fn.Architectures)
+	})
+
+	t.Run("rejects incomplete functions", func(t *testing.T) {
+		assert.True(t, E.IsLeft(ToLambdaModule(Function{Runtime: RuntimeGo}, ".forge/build")))
+		assert.True(t, E.IsLeft(ToLambdaModule(Function{Name: "api"}, ".forge/build")))
+		assert.True(t, E.IsLeft(ToLambdaModule(Function{Name: "api", Runtime: RuntimeGo}, "")))
+	})
+}
@@ -11,6 +11,8 @@ import (
 	E "github.com/IBM/fp-go/either"
 
 	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/dynamodb"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
 )
 
 const (
@@ -59,7 +61,8 @@ func (*Generator) Prompt(_ context.Context, intent generators.ResourceIntent, st
 	// If integrating with Lambda, add integration config
 	if intent.ToFunc != "" {
 		// Verify target function exists
-		if _, exists := state.Functions[intent.ToFunc]; !exists {
+		fn, exists := state.Functions[intent.ToFunc]
+		if !exists {
 			return E.Left[generators.ResourceConfig](
 				fmt.Errorf("target function '%s' not found", intent.ToFunc),
 			)
@@ -71,8 +74,9 @@ func (*Generator) Prompt(_ context.Context, intent generators.ResourceIntent, st
 
 		config.Integration = &generators.IntegrationConfig{
 			TargetFunction: intent.ToFunc,
+			Function:       fn,
 			EventSource: &generators.EventSourceConfig{
-				ARNExpression:         fmt.Sprintf("module.%s.stream_arn", sanitizeName(intent.Name)),
+				ARNExpression:         streamARNExpression(intent.Name, intent.UseModule),
 				BatchSize:             defaultBatchSize,
 				MaxBatchingWindowSecs: 0,
 				MaxConcurrency:        defaultMaxConcurrency,
@@ -87,7 +91,7 @@ func (*Generator) Prompt(_ context.Context, intent generators.ResourceIntent, st
 						"dynamodb:ListStreams",
 					},
 					Resources: []string{
-						fmt.Sprintf("module.%s.stream_arn", sanitizeName(intent.Name)),
+						streamARNExpression(intent.Name, intent.UseModule),
 					},
 				},
 				{
@@ -101,7 +105,7 @@ func (*Generator) Prompt(_ context.Context, intent generators.ResourceIntent, st
 						"dynamodb:DeleteItem",
 					},
 					Resources: []string{
-						fmt.Sprintf("module.%s.table_arn", sanitizeName(intent.Name)),
+						tableARNExpression(intent.Name, intent.UseModule),
 					},
 				},
 			},
@@ -119,9 +123,13 @@ func (gen *Generator) Generate(config generators.ResourceConfig, _ generators.Pr
 
 		// 1. Generate main DynamoDB resource file
 		if validConfig.Module {
+			content, err := generateModuleCode(validConfig)
+			if err != nil {
+				return E.Left[generators.GeneratedCode](err)
+			}
 			files = append(files, generators.FileToWrite{
 				Path:    "dynamodb.tf",
-				Content: generateModuleCode(validConfig),
+				Content: content,
 				Mode:    generators.WriteModeAppend,
 			})
 		} else {
@@ -180,11 +188,28 @@ func (*Generator) Validate(config generators.ResourceConfig) E.Either[error, gen
 	return E.Right[error](config)
 }
 
-// generateModuleCode creates Terraform module code (PURE).
-func generateModuleCode(config generators.ResourceConfig) string {
-	moduleName := sanitizeName(config.Name)
-	tableName := config.Name
+// generateModuleCode creates Terraform module code from the typed dynamodb
+// module (PURE).
+func generateModuleCode(config generators.ResourceConfig) (string, error) {
+	code, err := tableModule(config).Configuration()
+	if err != nil {
+		return "", err
+	}
 
+	var parts []string
+
+	parts = append(parts, "# Generated by forge add dynamodb "+config.Name)
+	parts = append(parts, "")
+	parts = append(parts, code)
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// tableModule configures a dynamodb module with the table named within the
+// namespace, its keys and attributes, and its stream and TTL when enabled.
+// Generated tables are left without deletion protection so that they can be
+// destroyed with the rest of the stack (PURE).
+func tableModule(config generators.ResourceConfig) *dynamodb.Module {
 	hashKey, ok := config.Variables["hash_key"].(string)
 	_ = ok
 	rangeKey, ok := config.Variables["range_key"].(string)
@@ -204,65 +229,30 @@ func generateModuleCode(config generators.ResourceConfig) string {
 	attributes, ok := config.Variables["attributes"].([]map[string]string)
 	_ = ok
 
-	var parts []string
-
-	parts = append(parts, "# Generated by forge add dynamodb "+config.Name)
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("module \"%s\" {", moduleName))
-	parts = append(parts, "  source  = \"terraform-aws-modules/dynamodb-table/aws\"")
-	parts = append(parts, "  version = \"~> 4.0\"")
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s\"", tableName))
-	parts = append(parts, fmt.Sprintf("  hash_key  = \"%s\"", hashKey))
-
+	namespace := hclgen.Ref("var.namespace")
+	module := dynamodb.NewModule(hclgen.Template(namespace, config.Name).String()).WithLocalName(sanitizeName(config.Name))
+	module.HashKey = &hashKey
 	if rangeKey != "" {
-		parts = append(parts, fmt.Sprintf("  range_key = \"%s\"", rangeKey))
+		module.RangeKey = &rangeKey
 	}
+	module.BillingMode = &billingMode
+	module.PointInTimeRecoveryEnabled = &pointInTimeRecovery
+	module.DeletionProtectionEnabled = nil
 
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("  billing_mode = \"%s\"", billingMode))
-
-	// Attributes
-	parts = append(parts, "")
-	parts = append(parts, "  attributes = [")
 	for _, attr := range attributes {
-		parts = append(parts, "    {")
-		parts = append(parts, fmt.Sprintf("      name = \"%s\"", attr["name"]))
-		parts = append(parts, fmt.Sprintf("      type = \"%s\"", attr["type"]))
-		parts = append(parts, "    },")
+		module.Attributes = append(module.Attributes, dynamodb.Attribute{Name: attr["name"], Type: attr["type"]})
 	}
-	parts = append(parts, "  ]")
-
-	// Streams
 	if streamEnabled {
-		parts = append(parts, "")
-		parts = append(parts, "  # DynamoDB Streams for Lambda triggers")
-		parts = append(parts, "  stream_enabled   = true")
-		parts = append(parts, fmt.Sprintf("  stream_view_type = \"%s\"", streamViewType))
+		module.WithStreams(streamViewType)
 	}
-
-	// TTL
 	if ttlEnabled && ttlAttribute != "" {
-		parts = append(parts, "")
-		parts = append(parts, "  # Time-to-Live configuration")
-		parts = append(parts, "  ttl_enabled        = true")
-		parts = append(parts, fmt.Sprintf("  ttl_attribute_name = \"%s\"", ttlAttribute))
+		module.WithTTL(ttlAttribute)
 	}
 
-	// Point-in-time recovery
-	parts = append(parts, "")
-	parts = append(parts, "  # Backup configuration")
-	parts = append(parts, fmt.Sprintf("  point_in_time_recovery_enabled = %t", pointInTimeRecovery))
-
-	parts = append(parts, "")
-	parts = append(parts, "  tags = {")
-	parts = append(parts, "    ManagedBy = \"forge\"")
-	parts = append(parts, "    Namespace = var.namespace")
-	parts = append(parts, "  }")
-	parts = append(parts, "}")
-	parts = append(parts, "")
-
-	return strings.Join(parts, "\n")
+	return module.WithTags(map[string]string{
+		"ManagedBy": "forge",
+		"Namespace": namespace.String(),
+	})
 }
 
 // generateRawResourceCode creates raw Terraform resource code (PURE).
@@ -343,14 +333,12 @@ func generateOutputs(config generators.ResourceConfig) string {
 	// Determine resource reference based on module vs raw resource
 	var tableIDRef, tableARNRef, streamARNRef string
 	if config.Module {
-		tableIDRef = fmt.Sprintf("module.%s.dynamodb_table_id", moduleName)
-		tableARNRef = fmt.Sprintf("module.%s.dynamodb_table_arn", moduleName)
-		streamARNRef = fmt.Sprintf("module.%s.dynamodb_table_stream_arn", moduleName)
+		tableIDRef = tableOutputs(config.Name).DynamodbTableID().Ref()
 	} else {
 		tableIDRef = fmt.Sprintf("aws_dynamodb_table.%s.id", moduleName)
-		tableARNRef = fmt.Sprintf("aws_dynamodb_table.%s.arn", moduleName)
-		streamARNRef = fmt.Sprintf("aws_dynamodb_table.%s.stream_arn", moduleName)
 	}
+	tableARNRef = tableARNExpression(config.Name, config.Module)
+	streamARNRef = streamARNExpression(config.Name, config.Module)
 
 	// Generate table ID output
 	parts = append(parts, generateOutputBlock(
@@ -390,6 +378,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 
 	tableName := sanitizeName(config.Name)
 	functionName := config.Integration.TargetFunction
+	target := config.Integration.TargetFunctionInfo()
 	eventSource := config.Integration.EventSource
 
 	var parts []string
@@ -399,7 +388,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 	parts = append(parts, fmt.Sprintf("resource \"aws_lambda_event_source_mapping\" \"%s_%s\" {",
 		functionName, tableName))
 	parts = append(parts, "  event_source_arn = "+eventSource.ARNExpression)
-	parts = append(parts, fmt.Sprintf("  function_name    = aws_lambda_function.%s.arn", functionName))
+	parts = append(parts, "  function_name    = "+target.ARNExpression())
 	parts = append(parts, "")
 	parts = append(parts, "  starting_position = \"LATEST\"")
 	parts = append(parts, fmt.Sprintf("  batch_size        = %d", eventSource.BatchSize))
@@ -417,7 +406,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 		parts = append(parts, fmt.Sprintf("# IAM policy for %s to access %s", functionName, config.Name))
 		parts = append(parts, fmt.Sprintf("resource \"aws_iam_role_policy\" \"%s\" {", policyName))
 		parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s\"", policyName))
-		parts = append(parts, fmt.Sprintf("  role = aws_iam_role.%s.id", functionName))
+		parts = append(parts, "  role = "+target.RoleExpression())
 		parts = append(parts, "")
 		parts = append(parts, "  policy = jsonencode({")
 		parts = append(parts, "    Version = \"2012-10-17\"")
@@ -442,13 +431,36 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 
 		parts = append(parts, "      }")
 		parts = append(parts, "    ]")
-		parts = append(parts, "  })}")
+		parts = append(parts, "  })")
+		parts = append(parts, "}")
 		parts = append(parts, "")
 	}
 
 	return strings.Join(parts, "\n")
 }
 
+// tableOutputs returns the outputs of the table's module call (PURE).
+func tableOutputs(name string) dynamodb.Outputs {
+	return dynamodb.NewModule(sanitizeName(name)).Outputs()
+}
+
+// tableARNExpression returns the Terraform expression for the table's ARN (PURE).
+func tableARNExpression(name string, module bool) string {
+	if module {
+		return tableOutputs(name).DynamodbTableARN().Ref()
+	}
+	return fmt.Sprintf("aws_dynamodb_table.%s.arn", sanitizeName(name))
+}
+
+// streamARNExpression returns the Terraform expression for the ARN of the
+// table's stream (PURE).
+func streamARNExpression(name string, module bool) string {
+	if module {
+		return tableOutputs(name).DynamodbTableStreamARN().Ref()
+	}
+	return fmt.Sprintf("aws_dynamodb_table.%s.stream_arn", sanitizeName(name))
+}
+
 // sanitizeName converts a name to a valid Terraform identifier (PURE).
 func sanitizeName(name string) string {
 	// Replace hyphens with underscores for Terraform identifiers
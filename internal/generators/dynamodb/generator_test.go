@@ -201,8 +201,9 @@ func TestGenerate(t *testing.T) {
 		assert.Equal(t, "dynamodb.tf", dynamoFile.Path)
 		assert.Contains(t, dynamoFile.Content, "module \"users\"")
 		assert.Contains(t, dynamoFile.Content, "terraform-aws-modules/dynamodb-table/aws")
-		assert.Contains(t, dynamoFile.Content, "hash_key  = \"id\"")
+		assert.Contains(t, dynamoFile.Content, "hash_key                       = \"id\"")
 		assert.Contains(t, dynamoFile.Content, "PAY_PER_REQUEST")
+		assert.NotContains(t, dynamoFile.Content, "deletion_protection_enabled", "Generated tables stay destroyable")
 	})
 
 	t.Run("generates with integration", func(t *testing.T) {
@@ -0,0 +1,581 @@
+// Package eventbridge provides EventBridge rule generation for forge add eventbridge command.
+// It follows functional programming principles with pure generation logic.
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	E "github.com/IBM/fp-go/either"
+
+	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/eventbridge"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+)
+
+type (
+	// Generator implements generators.Generator for EventBridge rules.
+	Generator struct{}
+)
+
+const (
+	// defaultBus is the account's default event bus, the only one schedules run on.
+	defaultBus = "default"
+
+	// defaultSchedule is the schedule of rules given neither a schedule nor a pattern.
+	defaultSchedule = "rate(1 day)"
+)
+
+// New creates a new EventBridge generator.
+func New() *Generator {
+	return &Generator{}
+}
+
+// Prompt gathers configuration from user (I/O ACTION).
+// Reads the schedule, pattern, bus and to-bus flags; rules without a schedule
+// or pattern run daily.
+func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent, state generators.ProjectState) E.Either[error, generators.ResourceConfig] {
+	schedule := intent.Flags["schedule"]
+	pattern := intent.Flags["pattern"]
+	if schedule == "" && pattern == "" {
+		schedule = defaultSchedule
+	}
+	bus := intent.Flags["bus"]
+	if bus == "" {
+		bus = defaultBus
+	}
+
+	config := generators.ResourceConfig{
+		Type:   generators.ResourceEventBridge,
+		Name:   intent.Name,
+		Module: intent.UseModule,
+		Variables: map[string]interface{}{
+			"schedule_expression":          schedule,
+			"event_pattern":                pattern,
+			"event_bus_name":               bus,
+			"target_bus_arn":               intent.Flags["to-bus"],
+			"maximum_event_age_in_seconds": 3600, // 1 hour
+			"maximum_retry_attempts":       3,
+		},
+	}
+
+	// If integrating with Lambda, add integration config
+	if intent.ToFunc != "" {
+		// Verify target function exists
+		fn, exists := state.Functions[intent.ToFunc]
+		if !exists {
+			return E.Left[generators.ResourceConfig](
+				fmt.Errorf("target function '%s' not found", intent.ToFunc),
+			)
+		}
+
+		config.Integration = &generators.IntegrationConfig{
+			TargetFunction: intent.ToFunc,
+			Function:       fn,
+		}
+	}
+
+	return E.Right[error](config)
+}
+
+// Generate creates Terraform code from configuration (PURE CALCULATION).
+func (g *Generator) Generate(config generators.ResourceConfig, state generators.ProjectState) E.Either[error, generators.GeneratedCode] {
+	// Validate first, then chain generation - automatic error short-circuiting
+	return E.Chain(func(validConfig generators.ResourceConfig) E.Either[error, generators.GeneratedCode] {
+		var files []generators.FileToWrite
+
+		// 1. Generate main EventBridge rule file
+		if validConfig.Module {
+			content, err := generateModuleCode(validConfig)
+			if err != nil {
+				return E.Left[generators.GeneratedCode](err)
+			}
+			files = append(files, generators.FileToWrite{
+				Path:    "eventbridge.tf",
+				Content: content,
+				Mode:    generators.WriteModeAppend,
+			})
+		} else {
+			files = append(files, generators.FileToWrite{
+				Path:    "eventbridge.tf",
+				Content: generateRawResourceCode(validConfig),
+				Mode:    generators.WriteModeAppend,
+			})
+		}
+
+		// 2. Generate outputs
+		files = append(files, generators.FileToWrite{
+			Path:    "outputs.tf",
+			Content: generateOutputs(validConfig),
+			Mode:    generators.WriteModeAppend,
+		})
+
+		// 3. If integration, update Lambda function file
+		if validConfig.Integration != nil {
+			lambdaFile := fmt.Sprintf("lambda_%s.tf", validConfig.Integration.TargetFunction)
+			files = append(files, generators.FileToWrite{
+				Path:    lambdaFile,
+				Content: generateIntegrationCode(validConfig),
+				Mode:    generators.WriteModeAppend,
+			})
+		}
+
+		return E.Right[error](generators.GeneratedCode{
+			Files: files,
+		})
+	})(g.Validate(config))
+}
+
+// Validate checks if configuration is valid (PURE CALCULATION).
+func (g *Generator) Validate(config generators.ResourceConfig) E.Either[error, generators.ResourceConfig] {
+	if config.Name == "" {
+		return E.Left[generators.ResourceConfig](
+			errors.New("rule name is required"),
+		)
+	}
+
+	if !isValidName(config.Name) {
+		return E.Left[generators.ResourceConfig](
+			errors.New("rule name must be alphanumeric with hyphens/underscores"),
+		)
+	}
+
+	schedule := stringVariable(config, "schedule_expression")
+	pattern := stringVariable(config, "event_pattern")
+	bus := stringVariable(config, "event_bus_name")
+	targetBus := stringVariable(config, "target_bus_arn")
+
+	switch {
+	case schedule != "" && pattern != "":
+		return E.Left[generators.ResourceConfig](
+			errors.New("use either --schedule or --pattern, not both"),
+		)
+	case schedule == "" && pattern == "":
+		return E.Left[generators.ResourceConfig](
+			errors.New("rule needs a --schedule or a --pattern"),
+		)
+	case schedule != "" && !isScheduleExpression(schedule):
+		return E.Left[generators.ResourceConfig](
+			fmt.Errorf("schedule %q must be a cron(...) or rate(...) expression", schedule),
+		)
+	case schedule != "" && bus != "" && bus != defaultBus:
+		return E.Left[generators.ResourceConfig](
+			errors.New("schedules only run on the default event bus: drop --bus"),
+		)
+	case targetBus != "" && !strings.HasPrefix(targetBus, "arn:"):
+		return E.Left[generators.ResourceConfig](
+			fmt.Errorf("target bus %q must be an event bus ARN", targetBus),
+		)
+	}
+
+	if pattern != "" {
+		if _, err := parsePattern(pattern); err != nil {
+			return E.Left[generators.ResourceConfig](err)
+		}
+	}
+
+	return E.Right[error](config)
+}
+
+// generateModuleCode creates Terraform module code from the typed
+// eventbridge module, plus the dead letter queue of its targets (PURE).
+func generateModuleCode(config generators.ResourceConfig) (string, error) {
+	module, err := ruleModule(config)
+	if err != nil {
+		return "", err
+	}
+	code, err := module.Configuration()
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+
+	parts = append(parts, "# Generated by forge add eventbridge "+config.Name)
+	parts = append(parts, "")
+	parts = append(parts, strings.TrimRight(code, "\n"))
+	parts = append(parts, "")
+	parts = append(parts, generateDLQCode(config))
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// ruleModule configures an eventbridge module with the rule and its targets:
+// the function wired with --to and the bus given with --to-bus, each with the
+// dead letter queue and retry policy. The module names rules after their key,
+// so the rule is keyed by its namespaced name, as raw rules are named (PURE).
+func ruleModule(config generators.ResourceConfig) (*eventbridge.Module, error) {
+	ruleKey := ruleModuleKey(config)
+	schedule := stringVariable(config, "schedule_expression")
+
+	createBus := false
+	createRole := false
+	appendPostfix := false
+	module := eventbridge.NewModule(stringVariable(config, "event_bus_name")).WithLocalName(sanitizeName(config.Name))
+	module.CreateBus = &createBus
+	module.CreateRole = &createRole
+	module.AppendRulePostfix = &appendPostfix
+
+	if schedule != "" {
+		module.WithScheduleRule(ruleKey, "Schedule for "+config.Name, schedule, true)
+	} else {
+		pattern, err := parsePattern(stringVariable(config, "event_pattern"))
+		if err != nil {
+			return nil, err
+		}
+		module.WithEventPatternRule(ruleKey, "Events for "+config.Name, hclgen.JSONEncode(pattern).String(), true)
+	}
+
+	if config.Integration != nil {
+		module.WithLambdaTarget(ruleKey, hclgen.Ref(config.Integration.TargetFunctionInfo().ARNExpression()).String())
+	}
+	if targetBus := stringVariable(config, "target_bus_arn"); targetBus != "" {
+		roleName := hclgen.Template(hclgen.Ref("var.namespace"), config.Name+"-events").String()
+		module.RoleName = &roleName
+		module.WithEventBusTarget(ruleKey, targetBus)
+	}
+
+	maxAge := intVariable(config, "maximum_event_age_in_seconds")
+	maxRetries := intVariable(config, "maximum_retry_attempts")
+	dlq := hclgen.Ref(dlqAddress(config) + ".arn").String()
+	targets := module.Targets[ruleKey]
+	for i := range targets {
+		name := targetName(config)
+		if targets[i].AttachRoleARN != nil {
+			name = "event_bus"
+		}
+		targets[i].Name = &name
+		targets[i].DeadLetterARN = &dlq
+		targets[i].RetryPolicy = &eventbridge.RetryPolicy{
+			MaximumEventAge:      &maxAge,
+			MaximumRetryAttempts: &maxRetries,
+		}
+	}
+
+	return module.WithTags(map[string]string{
+		"ManagedBy": "forge",
+		"Namespace": hclgen.Ref("var.namespace").String(),
+	}), nil
+}
+
+// generateRawResourceCode creates raw Terraform resource code (PURE).
+func generateRawResourceCode(config generators.ResourceConfig) string {
+	ruleName := sanitizeName(config.Name)
+	schedule := stringVariable(config, "schedule_expression")
+	bus := stringVariable(config, "event_bus_name")
+
+	var parts []string
+
+	parts = append(parts, "# Generated by forge add eventbridge "+config.Name+" --raw")
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("resource \"aws_cloudwatch_event_rule\" \"%s\" {", ruleName))
+	parts = append(parts, fmt.Sprintf("  name        = \"${var.namespace}%s\"", config.Name))
+
+	if schedule != "" {
+		parts = append(parts, fmt.Sprintf("  description = \"Schedule for %s\"", config.Name))
+		parts = append(parts, "")
+		parts = append(parts, fmt.Sprintf("  schedule_expression = \"%s\"", schedule))
+	} else {
+		parts = append(parts, fmt.Sprintf("  description = \"Events for %s\"", config.Name))
+		parts = append(parts, "")
+		if bus != defaultBus {
+			parts = append(parts, fmt.Sprintf("  event_bus_name = \"%s\"", bus))
+			parts = append(parts, "  event_pattern  = "+patternExpression(config))
+		} else {
+			parts = append(parts, "  event_pattern = "+patternExpression(config))
+		}
+	}
+
+	parts = append(parts, "")
+	parts = append(parts, "  tags = {")
+	parts = append(parts, "    ManagedBy = \"forge\"")
+	parts = append(parts, "    Namespace = var.namespace")
+	parts = append(parts, "  }")
+	parts = append(parts, "}")
+	parts = append(parts, "")
+
+	if targetBus := stringVariable(config, "target_bus_arn"); targetBus != "" {
+		parts = append(parts, generateEventBusTargetCode(config, targetBus))
+	}
+
+	parts = append(parts, generateDLQCode(config))
+
+	return strings.Join(parts, "\n")
+}
+
+// generateEventBusTargetCode creates the target forwarding a raw rule's events
+// to another event bus, with the IAM role EventBridge delivers them as (PURE).
+func generateEventBusTargetCode(config generators.ResourceConfig, targetBus string) string {
+	ruleName := sanitizeName(config.Name)
+
+	var parts []string
+
+	parts = append(parts, fmt.Sprintf("# Forward %s events to %s", config.Name, targetBus))
+	parts = append(parts, fmt.Sprintf("resource \"aws_cloudwatch_event_target\" \"%s_event_bus\" {", ruleName))
+	parts = append(parts, targetArguments(config, "event_bus", targetBus)...)
+	parts = append(parts, fmt.Sprintf("  role_arn       = aws_iam_role.%s_events.arn", ruleName))
+	parts = append(parts, deliveryBlocks(config)...)
+	parts = append(parts, "}")
+	parts = append(parts, "")
+
+	parts = append(parts, "# IAM role for EventBridge to put events on "+targetBus)
+	parts = append(parts, fmt.Sprintf("resource \"aws_iam_role\" \"%s_events\" {", ruleName))
+	parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s-events\"", config.Name))
+	parts = append(parts, "")
+	parts = append(parts, "  assume_role_policy = jsonencode({")
+	parts = append(parts, "    Version = \"2012-10-17\"")
+	parts = append(parts, "    Statement = [")
+	parts = append(parts, "      {")
+	parts = append(parts, "        Effect    = \"Allow\"")
+	parts = append(parts, "        Action    = \"sts:AssumeRole\"")
+	parts = append(parts, "        Principal = { Service = \"events.amazonaws.com\" }")
+	parts = append(parts, "      }")
+	parts = append(parts, "    ]")
+	parts = append(parts, "  })")
+	parts = append(parts, "}")
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("resource \"aws_iam_role_policy\" \"%s_events\" {", ruleName))
+	parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s-events\"", config.Name))
+	parts = append(parts, fmt.Sprintf("  role = aws_iam_role.%s_events.id", ruleName))
+	parts = append(parts, "")
+	parts = append(parts, "  policy = jsonencode({")
+	parts = append(parts, "    Version = \"2012-10-17\"")
+	parts = append(parts, "    Statement = [")
+	parts = append(parts, "      {")
+	parts = append(parts, "        Effect   = \"Allow\"")
+	parts = append(parts, "        Action   = \"events:PutEvents\"")
+	parts = append(parts, fmt.Sprintf("        Resource = \"%s\"", targetBus))
+	parts = append(parts, "      }")
+	parts = append(parts, "    ]")
+	parts = append(parts, "  })")
+	parts = append(parts, "}")
+	parts = append(parts, "")
+
+	return strings.Join(parts, "\n")
+}
+
+// generateDLQCode creates the dead letter queue of the rule's targets, which
+// only the rule may send to (PURE).
+func generateDLQCode(config generators.ResourceConfig) string {
+	queue := dlqAddress(config)
+	queueName := sanitizeName(config.Name) + "_dlq"
+
+	var parts []string
+
+	parts = append(parts, "# Dead letter queue for events "+config.Name+" failed to deliver")
+	parts = append(parts, fmt.Sprintf("resource \"aws_sqs_queue\" \"%s\" {", queueName))
+	parts = append(parts, fmt.Sprintf("  name                      = \"${var.namespace}%s-dlq\"", config.Name))
+	parts = append(parts, "  message_retention_seconds = 1209600 # 14 days")
+	parts = append(parts, "")
+	parts = append(parts, "  tags = {")
+	parts = append(parts, "    ManagedBy = \"forge\"")
+	parts = append(parts, "    Namespace = var.namespace")
+	parts = append(parts, "  }")
+	parts = append(parts, "}")
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("resource \"aws_sqs_queue_policy\" \"%s\" {", queueName))
+	parts = append(parts, fmt.Sprintf("  queue_url = %s.id", queue))
+	parts = append(parts, "")
+	parts = append(parts, "  policy = jsonencode({")
+	parts = append(parts, "    Version = \"2012-10-17\"")
+	parts = append(parts, "    Statement = [")
+	parts = append(parts, "      {")
+	parts = append(parts, "        Effect    = \"Allow\"")
+	parts = append(parts, "        Action    = \"sqs:SendMessage\"")
+	parts = append(parts, "        Principal = { Service = \"events.amazonaws.com\" }")
+	parts = append(parts, fmt.Sprintf("        Resource  = %s.arn", queue))
+	parts = append(parts, "        Condition = {")
+	parts = append(parts, fmt.Sprintf("          ArnEquals = { \"aws:SourceArn\" = %s }", ruleARNExpression(config)))
+	parts = append(parts, "        }")
+	parts = append(parts, "      }")
+	parts = append(parts, "    ]")
+	parts = append(parts, "  })")
+	parts = append(parts, "}")
+	parts = append(parts, "")
+
+	return strings.Join(parts, "\n")
+}
+
+// generateOutputs creates Terraform outputs (PURE).
+func generateOutputs(config generators.ResourceConfig) string {
+	ruleName := sanitizeName(config.Name)
+
+	var parts []string
+
+	parts = append(parts, "# Outputs for "+config.Name)
+	parts = append(parts, fmt.Sprintf("output \"%s_rule_arn\" {", ruleName))
+	parts = append(parts, fmt.Sprintf("  description = \"ARN of the %s rule\"", config.Name))
+	parts = append(parts, "  value       = "+ruleARNExpression(config))
+	parts = append(parts, "}")
+	parts = append(parts, "")
+	parts = append(parts, fmt.Sprintf("output \"%s_dlq_arn\" {", ruleName))
+	parts = append(parts, fmt.Sprintf("  description = \"ARN of the %s dead letter queue\"", config.Name))
+	parts = append(parts, fmt.Sprintf("  value       = %s.arn", dlqAddress(config)))
+	parts = append(parts, "}")
+	parts = append(parts, "")
+
+	return strings.Join(parts, "\n")
+}
+
+// generateIntegrationCode creates the Lambda permission for the rule and, for
+// raw resources, the rule's target (PURE).
+func generateIntegrationCode(config generators.ResourceConfig) string {
+	if config.Integration == nil {
+		return ""
+	}
+
+	ruleName := sanitizeName(config.Name)
+	functionName := config.Integration.TargetFunction
+	target := config.Integration.TargetFunctionInfo()
+
+	var parts []string
+
+	// Raw rules need a target resource; modules declare theirs
+	if !config.Module {
+		parts = append(parts, fmt.Sprintf("# EventBridge target for %s to %s", config.Name, functionName))
+		parts = append(parts, fmt.Sprintf("resource \"aws_cloudwatch_event_target\" \"%s_%s\" {", ruleName, functionName))
+		parts = append(parts, targetArguments(config, functionName, target.ARNExpression())...)
+		parts = append(parts, deliveryBlocks(config)...)
+		parts = append(parts, "}")
+		parts = append(parts, "")
+	}
+
+	// Lambda permission for EventBridge to invoke function
+	parts = append(parts, "# Permission for EventBridge to invoke "+functionName)
+	parts = append(parts, fmt.Sprintf("resource \"aws_lambda_permission\" \"%s_events_%s\" {",
+		functionName, ruleName))
+	parts = append(parts, fmt.Sprintf("  statement_id  = \"AllowExecutionFromEventBridge_%s\"", ruleName))
+	parts = append(parts, "  action        = \"lambda:InvokeFunction\"")
+	parts = append(parts, "  function_name = "+target.NameExpression())
+	parts = append(parts, "  principal     = \"events.amazonaws.com\"")
+	parts = append(parts, "  source_arn    = "+ruleARNExpression(config))
+	parts = append(parts, "}")
+	parts = append(parts, "")
+
+	return strings.Join(parts, "\n")
+}
+
+// targetArguments returns the arguments of a raw rule's target resource (PURE).
+func targetArguments(config generators.ResourceConfig, targetID, arn string) []string {
+	ruleName := sanitizeName(config.Name)
+
+	parts := []string{
+		fmt.Sprintf("  rule           = aws_cloudwatch_event_rule.%s.name", ruleName),
+		fmt.Sprintf("  event_bus_name = aws_cloudwatch_event_rule.%s.event_bus_name", ruleName),
+		fmt.Sprintf("  target_id      = \"%s\"", targetID),
+	}
+	if strings.HasPrefix(arn, "arn:") {
+		return append(parts, fmt.Sprintf("  arn            = \"%s\"", arn))
+	}
+	return append(parts, "  arn            = "+arn)
+}
+
+// deliveryBlocks returns the dead letter and retry configuration of a raw
+// rule's target resource (PURE).
+func deliveryBlocks(config generators.ResourceConfig) []string {
+	return []string{
+		"",
+		"  dead_letter_config {",
+		fmt.Sprintf("    arn = %s.arn", dlqAddress(config)),
+		"  }",
+		"",
+		"  retry_policy {",
+		fmt.Sprintf("    maximum_event_age_in_seconds = %d", intVariable(config, "maximum_event_age_in_seconds")),
+		fmt.Sprintf("    maximum_retry_attempts       = %d", intVariable(config, "maximum_retry_attempts")),
+		"  }",
+	}
+}
+
+// ruleARNExpression returns the Terraform expression for the rule's ARN (PURE).
+func ruleARNExpression(config generators.ResourceConfig) string {
+	ruleName := sanitizeName(config.Name)
+	if config.Module {
+		module := eventbridge.NewModule(defaultBus).WithLocalName(ruleName)
+		return fmt.Sprintf("%s[\"%s\"]", module.Outputs().EventbridgeRuleARNs().Ref(), ruleModuleKey(config))
+	}
+	return fmt.Sprintf("aws_cloudwatch_event_rule.%s.arn", ruleName)
+}
+
+// ruleModuleKey returns the key of the rule in the module's rules, its
+// namespaced name, e.g. "${var.namespace}nightly-report" (PURE).
+func ruleModuleKey(config generators.ResourceConfig) string {
+	return hclgen.Template(hclgen.Ref("var.namespace"), config.Name).String()
+}
+
+// dlqAddress returns the Terraform address of the rule's dead letter queue (PURE).
+func dlqAddress(config generators.ResourceConfig) string {
+	return fmt.Sprintf("aws_sqs_queue.%s_dlq", sanitizeName(config.Name))
+}
+
+// targetName returns the name of the rule's Lambda target (PURE).
+func targetName(config generators.ResourceConfig) string {
+	if config.Integration == nil {
+		return ""
+	}
+	return config.Integration.TargetFunction
+}
+
+// patternExpression returns the rule's event pattern as a jsonencode call,
+// indented for a top-level argument (PURE).
+func patternExpression(config generators.ResourceConfig) string {
+	pattern, err := parsePattern(stringVariable(config, "event_pattern"))
+	if err != nil {
+		return "null"
+	}
+	// A bare interpolation, "${jsonencode(...)}", is the expression itself
+	expr := hclgen.JSONEncode(pattern).String()
+	expr = strings.TrimSuffix(strings.TrimPrefix(expr, "${"), "}")
+	return strings.ReplaceAll(expr, "\n", "\n  ")
+}
+
+// parsePattern parses an event pattern, which must be a JSON object (PURE).
+func parsePattern(pattern string) (map[string]interface{}, error) {
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(pattern), &parsed); err != nil {
+		return nil, fmt.Errorf("event pattern must be a JSON object: %w", err)
+	}
+	return parsed, nil
+}
+
+// isScheduleExpression reports whether s is a cron(...) or rate(...) expression (PURE).
+func isScheduleExpression(s string) bool {
+	return (strings.HasPrefix(s, "cron(") || strings.HasPrefix(s, "rate(")) && strings.HasSuffix(s, ")")
+}
+
+// stringVariable returns a string configuration variable, or "" (PURE).
+func stringVariable(config generators.ResourceConfig, name string) string {
+	value, _ := config.Variables[name].(string)
+	return value
+}
+
+// intVariable returns an int configuration variable, or 0 (PURE).
+func intVariable(config generators.ResourceConfig, name string) int {
+	value, _ := config.Variables[name].(int)
+	return value
+}
+
+// sanitizeName converts a name to a valid Terraform identifier (PURE).
+func sanitizeName(name string) string {
+	// Replace hyphens with underscores for Terraform identifiers
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// isValidName checks if a name is valid (PURE).
+func isValidName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+
+	for _, r := range name {
+		if !((r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+			(r >= '0' && r <= '9') || r == '-' || r == '_') {
+
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,470 @@
+package eventbridge_test
+
+import (
+	"testing"
+
+	E "github.com/IBM/fp-go/either"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/generators/eventbridge"
+)
+
+// Helper function to extract Right value from Either.
+func extractConfig(result E.Either[error, generators.ResourceConfig]) generators.ResourceConfig {
+	return E.Fold(
+		func(error) generators.ResourceConfig { return generators.ResourceConfig{} },
+		func(c generators.ResourceConfig) generators.ResourceConfig { return c },
+	)(result)
+}
+
+// Helper function to extract error from Either.
+func extractError(result E.Either[error, generators.ResourceConfig]) error {
+	return E.Fold(
+		func(e error) error { return e },
+		func(generators.ResourceConfig) error { return nil },
+	)(result)
+}
+
+// Helper function to extract generated code.
+func extractCode(result E.Either[error, generators.GeneratedCode]) generators.GeneratedCode {
+	return E.Fold(
+		func(error) generators.GeneratedCode { return generators.GeneratedCode{} },
+		func(c generators.GeneratedCode) generators.GeneratedCode { return c },
+	)(result)
+}
+
+// Helper function to extract error from GeneratedCode Either.
+func extractCodeError(result E.Either[error, generators.GeneratedCode]) error {
+	return E.Fold(
+		func(e error) error { return e },
+		func(generators.GeneratedCode) error { return nil },
+	)(result)
+}
+
+// Helper function to find file by path.
+func findFile(files []generators.FileToWrite, path string) *generators.FileToWrite {
+	for i := range files {
+		if files[i].Path == path {
+			return &files[i]
+		}
+	}
+	return nil
+}
+
+// projectState returns a project with a "reporter" function.
+func projectState() generators.ProjectState {
+	return generators.ProjectState{
+		Functions: map[string]generators.FunctionInfo{
+			"reporter": {
+				Name:       "reporter",
+				Runtime:    "go1.x",
+				Handler:    "bootstrap",
+				TFResource: "module.reporter",
+			},
+		},
+	}
+}
+
+// generate prompts for intent and generates its code.
+func generate(t *testing.T, intent generators.ResourceIntent) generators.GeneratedCode {
+	t.Helper()
+	gen := eventbridge.New()
+	state := projectState()
+
+	result := E.Chain(func(config generators.ResourceConfig) E.Either[error, generators.GeneratedCode] {
+		return gen.Generate(config, state)
+	})(gen.Prompt(t.Context(), intent, state))
+
+	require.NoError(t, extractCodeError(result))
+	return extractCode(result)
+}
+
+// TestNew verifies generator creation.
+func TestNew(t *testing.T) {
+	gen := eventbridge.New()
+	assert.NotNil(t, gen)
+}
+
+// TestPrompt_Standalone tests rule configuration from flags.
+func TestPrompt_Standalone(t *testing.T) {
+	gen := eventbridge.New()
+	ctx := t.Context()
+
+	t.Run("schedule", func(t *testing.T) {
+		intent := generators.ResourceIntent{
+			Type:      generators.ResourceEventBridge,
+			Name:      "nightly-report",
+			UseModule: true,
+			Flags:     map[string]string{"schedule": "cron(0 3 * * ? *)"},
+		}
+
+		result := gen.Prompt(ctx, intent, generators.ProjectState{})
+
+		require.True(t, E.IsRight(result), "Prompt should succeed")
+		config := extractConfig(result)
+
+		assert.Equal(t, generators.ResourceEventBridge, config.Type)
+		assert.Equal(t, "nightly-report", config.Name)
+		assert.True(t, config.Module)
+		assert.Nil(t, config.Integration, "Standalone rule should have no integration")
+
+		assert.Equal(t, "cron(0 3 * * ? *)", config.Variables["schedule_expression"])
+		assert.Equal(t, "", config.Variables["event_pattern"])
+		assert.Equal(t, "default", config.Variables["event_bus_name"])
+		assert.Equal(t, 3600, config.Variables["maximum_event_age_in_seconds"])
+		assert.Equal(t, 3, config.Variables["maximum_retry_attempts"])
+	})
+
+	t.Run("pattern on a custom bus", func(t *testing.T) {
+		intent := generators.ResourceIntent{
+			Type: generators.ResourceEventBridge,
+			Name: "order-events",
+			Flags: map[string]string{
+				"pattern": `{"source":["orders"]}`,
+				"bus":     "orders",
+				"to-bus":  "arn:aws:events:us-east-1:123456789012:event-bus/audit",
+			},
+		}
+
+		config := extractConfig(gen.Prompt(ctx, intent, generators.ProjectState{}))
+
+		assert.Equal(t, "", config.Variables["schedule_expression"])
+		assert.Equal(t, `{"source":["orders"]}`, config.Variables["event_pattern"])
+		assert.Equal(t, "orders", config.Variables["event_bus_name"])
+		assert.Equal(t, "arn:aws:events:us-east-1:123456789012:event-bus/audit", config.Variables["target_bus_arn"])
+	})
+
+	t.Run("defaults to a daily schedule", func(t *testing.T) {
+		intent := generators.ResourceIntent{
+			Type: generators.ResourceEventBridge,
+			Name: "cleanup",
+		}
+
+		config := extractConfig(gen.Prompt(ctx, intent, generators.ProjectState{}))
+
+		assert.Equal(t, "rate(1 day)", config.Variables["schedule_expression"])
+	})
+}
+
+// TestPrompt_WithLambdaIntegration tests Lambda integration.
+func TestPrompt_WithLambdaIntegration(t *testing.T) {
+	gen := eventbridge.New()
+	ctx := t.Context()
+
+	intent := generators.ResourceIntent{
+		Type:      generators.ResourceEventBridge,
+		Name:      "nightly-report",
+		ToFunc:    "reporter",
+		UseModule: true,
+	}
+
+	result := gen.Prompt(ctx, intent, projectState())
+
+	require.True(t, E.IsRight(result), "Prompt should succeed")
+	config := extractConfig(result)
+
+	require.NotNil(t, config.Integration, "Should have integration config")
+	assert.Equal(t, "reporter", config.Integration.TargetFunction)
+	assert.Equal(t, "module.reporter", config.Integration.Function.TFResource)
+}
+
+// TestPrompt_FunctionNotFound tests error when target function doesn't exist.
+func TestPrompt_FunctionNotFound(t *testing.T) {
+	gen := eventbridge.New()
+	ctx := t.Context()
+
+	intent := generators.ResourceIntent{
+		Type:      generators.ResourceEventBridge,
+		Name:      "nightly-report",
+		ToFunc:    "nonexistent",
+		UseModule: true,
+	}
+
+	result := gen.Prompt(ctx, intent, projectState())
+
+	require.True(t, E.IsLeft(result), "Prompt should fail")
+	err := extractError(result)
+	assert.Contains(t, err.Error(), "target function 'nonexistent' not found")
+}
+
+// TestValidate tests configuration validation.
+func TestValidate(t *testing.T) {
+	gen := eventbridge.New()
+
+	tests := []struct {
+		name      string
+		ruleName  string
+		variables map[string]interface{}
+		expectErr bool
+		errMsg    string
+	}{
+		{
+			name:      "valid schedule",
+			ruleName:  "nightly-report",
+			variables: map[string]interface{}{"schedule_expression": "cron(0 3 * * ? *)"},
+		},
+		{
+			name:      "valid pattern on a custom bus",
+			ruleName:  "order-events",
+			variables: map[string]interface{}{"event_pattern": `{"source":["orders"]}`, "event_bus_name": "orders"},
+		},
+		{
+			name:      "missing name",
+			ruleName:  "",
+			variables: map[string]interface{}{"schedule_expression": "rate(1 day)"},
+			expectErr: true,
+			errMsg:    "rule name is required",
+		},
+		{
+			name:      "invalid name",
+			ruleName:  "nightly report",
+			variables: map[string]interface{}{"schedule_expression": "rate(1 day)"},
+			expectErr: true,
+			errMsg:    "alphanumeric",
+		},
+		{
+			name:      "schedule and pattern",
+			ruleName:  "nightly-report",
+			variables: map[string]interface{}{"schedule_expression": "rate(1 day)", "event_pattern": `{"source":["orders"]}`},
+			expectErr: true,
+			errMsg:    "not both",
+		},
+		{
+			name:      "neither schedule nor pattern",
+			ruleName:  "nightly-report",
+			variables: map[string]interface{}{},
+			expectErr: true,
+			errMsg:    "needs a --schedule or a --pattern",
+		},
+		{
+			name:      "invalid schedule",
+			ruleName:  "nightly-report",
+			variables: map[string]interface{}{"schedule_expression": "every night"},
+			expectErr: true,
+			errMsg:    "cron(...) or rate(...)",
+		},
+		{
+			name:      "schedule on a custom bus",
+			ruleName:  "nightly-report",
+			variables: map[string]interface{}{"schedule_expression": "rate(1 day)", "event_bus_name": "orders"},
+			expectErr: true,
+			errMsg:    "default event bus",
+		},
+		{
+			name:      "pattern that is not an object",
+			ruleName:  "order-events",
+			variables: map[string]interface{}{"event_pattern": `["orders"]`},
+			expectErr: true,
+			errMsg:    "event pattern must be a JSON object",
+		},
+		{
+			name:      "target bus that is not an ARN",
+			ruleName:  "order-events",
+			variables: map[string]interface{}{"event_pattern": `{"source":["orders"]}`, "target_bus_arn": "audit"},
+			expectErr: true,
+			errMsg:    "must be an event bus ARN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := gen.Validate(generators.ResourceConfig{
+				Type:      generators.ResourceEventBridge,
+				Name:      tt.ruleName,
+				Module:    true,
+				Variables: tt.variables,
+			})
+
+			if tt.expectErr {
+				require.True(t, E.IsLeft(result), "Should return error")
+				err := extractError(result)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.True(t, E.IsRight(result), "Should succeed")
+			}
+		})
+	}
+}
+
+// TestGenerate_ModuleMode tests module-based generation.
+func TestGenerate_ModuleMode(t *testing.T) {
+	code := generate(t, generators.ResourceIntent{
+		Type:      generators.ResourceEventBridge,
+		Name:      "nightly-report",
+		UseModule: true,
+		Flags:     map[string]string{"schedule": "cron(0 3 * * ? *)"},
+	})
+
+	require.Len(t, code.Files, 2, "Standalone rule should have eventbridge.tf and outputs.tf")
+
+	rule := findFile(code.Files, "eventbridge.tf")
+	require.NotNil(t, rule)
+	assert.Equal(t, generators.WriteModeAppend, rule.Mode)
+	assert.Contains(t, rule.Content, "# Generated by forge add eventbridge nightly-report\n")
+	assert.Contains(t, rule.Content, `module "nightly_report" {`)
+	assert.Contains(t, rule.Content, `source              = "terraform-aws-modules/eventbridge/aws"`)
+	assert.Contains(t, rule.Content, `bus_name            = "default"`)
+	assert.Contains(t, rule.Content, "create_bus          = false")
+	assert.Contains(t, rule.Content, `schedule_expression = "cron(0 3 * * ? *)"`)
+	assert.NotContains(t, rule.Content, "targets = {", "Standalone rule has no targets")
+	assert.Contains(t, rule.Content, `"${var.namespace}nightly-report" = {`, "Rules are named after their namespaced key")
+	assert.Contains(t, rule.Content, "append_rule_postfix = false")
+
+	outputs := findFile(code.Files, "outputs.tf")
+	require.NotNil(t, outputs)
+	assert.Contains(t, outputs.Content, `output "nightly_report_rule_arn"`)
+	assert.Contains(t, outputs.Content, `module.nightly_report.eventbridge_rule_arns["${var.namespace}nightly-report"]`)
+	assert.Contains(t, outputs.Content, `output "nightly_report_dlq_arn"`)
+}
+
+// TestGenerate_RawMode tests raw resource generation.
+func TestGenerate_RawMode(t *testing.T) {
+	code := generate(t, generators.ResourceIntent{
+		Type: generators.ResourceEventBridge,
+		Name: "order-events",
+		Flags: map[string]string{
+			"pattern": `{"source":["orders"],"detail-type":["OrderCreated"]}`,
+			"bus":     "orders",
+		},
+	})
+
+	rule := findFile(code.Files, "eventbridge.tf")
+	require.NotNil(t, rule)
+	assert.Contains(t, rule.Content, "# Generated by forge add eventbridge order-events --raw\n")
+	assert.Contains(t, rule.Content, `resource "aws_cloudwatch_event_rule" "order_events" {`)
+	assert.Contains(t, rule.Content, `name        = "${var.namespace}order-events"`)
+	assert.Contains(t, rule.Content, `event_bus_name = "orders"`)
+	assert.Contains(t, rule.Content, "  event_pattern  = jsonencode({\n"+
+		"    detail-type = [\"OrderCreated\"]\n"+
+		"    source      = [\"orders\"]\n"+
+		"  })\n")
+	assert.NotContains(t, rule.Content, "module ")
+
+	outputs := findFile(code.Files, "outputs.tf")
+	require.NotNil(t, outputs)
+	assert.Contains(t, outputs.Content, "value       = aws_cloudwatch_event_rule.order_events.arn")
+}
+
+// TestGenerate_WithIntegration tests generation with Lambda integration.
+func TestGenerate_WithIntegration(t *testing.T) {
+	t.Run("module", func(t *testing.T) {
+		code := generate(t, generators.ResourceIntent{
+			Type:      generators.ResourceEventBridge,
+			Name:      "nightly-report",
+			ToFunc:    "reporter",
+			UseModule: true,
+			Flags:     map[string]string{"schedule": "cron(0 3 * * ? *)"},
+		})
+
+		require.Len(t, code.Files, 3, "Should have eventbridge.tf, outputs.tf and lambda_reporter.tf")
+
+		rule := findFile(code.Files, "eventbridge.tf")
+		require.NotNil(t, rule)
+		assert.Contains(t, rule.Content, "      arn             = module.reporter.lambda_function_arn\n"+
+			"      dead_letter_arn = aws_sqs_queue.nightly_report_dlq.arn\n"+
+			"      name            = \"reporter\"\n"+
+			"      retry_policy = {\n"+
+			"        maximum_event_age_in_seconds = 3600\n"+
+			"        maximum_retry_attempts       = 3\n"+
+			"      }\n")
+
+		lambda := findFile(code.Files, "lambda_reporter.tf")
+		require.NotNil(t, lambda)
+		assert.Equal(t, generators.WriteModeAppend, lambda.Mode)
+		assert.Contains(t, lambda.Content, `resource "aws_lambda_permission" "reporter_events_nightly_report" {`)
+		assert.Contains(t, lambda.Content, "function_name = module.reporter.lambda_function_name")
+		assert.Contains(t, lambda.Content, `principal     = "events.amazonaws.com"`)
+		assert.Contains(t, lambda.Content, `source_arn    = module.nightly_report.eventbridge_rule_arns["${var.namespace}nightly-report"]`)
+		assert.NotContains(t, lambda.Content, "aws_cloudwatch_event_target", "The module declares the target")
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		code := generate(t, generators.ResourceIntent{
+			Type:   generators.ResourceEventBridge,
+			Name:   "nightly-report",
+			ToFunc: "reporter",
+			Flags:  map[string]string{"schedule": "cron(0 3 * * ? *)"},
+		})
+
+		lambda := findFile(code.Files, "lambda_reporter.tf")
+		require.NotNil(t, lambda)
+		assert.Contains(t, lambda.Content, `resource "aws_cloudwatch_event_target" "nightly_report_reporter" {`)
+		assert.Contains(t, lambda.Content, "rule           = aws_cloudwatch_event_rule.nightly_report.name")
+		assert.Contains(t, lambda.Content, "arn            = module.reporter.lambda_function_arn")
+		assert.Contains(t, lambda.Content, "  dead_letter_config {\n    arn = aws_sqs_queue.nightly_report_dlq.arn\n  }\n")
+		assert.Contains(t, lambda.Content, "maximum_retry_attempts       = 3")
+		assert.Contains(t, lambda.Content, "source_arn    = aws_cloudwatch_event_rule.nightly_report.arn")
+	})
+}
+
+// TestGenerate_EventBusTarget tests forwarding events to another bus.
+func TestGenerate_EventBusTarget(t *testing.T) {
+	busARN := "arn:aws:events:us-east-1:123456789012:event-bus/audit"
+
+	t.Run("module", func(t *testing.T) {
+		code := generate(t, generators.ResourceIntent{
+			Type:      generators.ResourceEventBridge,
+			Name:      "order-events",
+			UseModule: true,
+			Flags:     map[string]string{"pattern": `{"source":["orders"]}`, "to-bus": busARN},
+		})
+
+		rule := findFile(code.Files, "eventbridge.tf")
+		require.NotNil(t, rule)
+		assert.Contains(t, rule.Content, "create_role              = true")
+		assert.Contains(t, rule.Content, "attach_policy_statements = true")
+		assert.Contains(t, rule.Content, `"events:PutEvents"`)
+		assert.Contains(t, rule.Content, "attach_role_arn = true")
+		assert.Contains(t, rule.Content, `name            = "event_bus"`)
+		assert.Contains(t, rule.Content, `"${var.namespace}order-events" = [`, "Targets are keyed by the namespaced rule")
+		assert.Contains(t, rule.Content, `role_name = "${var.namespace}order-events-events"`)
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		code := generate(t, generators.ResourceIntent{
+			Type:  generators.ResourceEventBridge,
+			Name:  "order-events",
+			Flags: map[string]string{"pattern": `{"source":["orders"]}`, "to-bus": busARN},
+		})
+
+		rule := findFile(code.Files, "eventbridge.tf")
+		require.NotNil(t, rule)
+		assert.Contains(t, rule.Content, `resource "aws_cloudwatch_event_target" "order_events_event_bus" {`)
+		assert.Contains(t, rule.Content, `arn            = "`+busARN+`"`)
+		assert.Contains(t, rule.Content, "role_arn       = aws_iam_role.order_events_events.arn")
+		assert.Contains(t, rule.Content, `resource "aws_iam_role_policy" "order_events_events" {`)
+		assert.Contains(t, rule.Content, `Resource = "`+busARN+`"`)
+	})
+}
+
+// TestDLQConfiguration tests the dead letter queue of the rule's targets.
+func TestDLQConfiguration(t *testing.T) {
+	code := generate(t, generators.ResourceIntent{
+		Type:      generators.ResourceEventBridge,
+		Name:      "nightly-report",
+		UseModule: true,
+	})
+
+	rule := findFile(code.Files, "eventbridge.tf")
+	require.NotNil(t, rule)
+	assert.Contains(t, rule.Content, `resource "aws_sqs_queue" "nightly_report_dlq" {`)
+	assert.Contains(t, rule.Content, `name                      = "${var.namespace}nightly-report-dlq"`)
+	assert.Contains(t, rule.Content, `resource "aws_sqs_queue_policy" "nightly_report_dlq" {`)
+	assert.Contains(t, rule.Content, `Principal = { Service = "events.amazonaws.com" }`)
+	assert.Contains(t, rule.Content, `ArnEquals = { "aws:SourceArn" = module.nightly_report.eventbridge_rule_arns["${var.namespace}nightly-report"] }`)
+}
+
+// TestGenerate_InvalidConfig tests that Generate validates first.
+func TestGenerate_InvalidConfig(t *testing.T) {
+	gen := eventbridge.New()
+
+	result := gen.Generate(generators.ResourceConfig{
+		Type: generators.ResourceEventBridge,
+		Name: "",
+	}, generators.ProjectState{})
+
+	require.True(t, E.IsLeft(result), "Generate should fail for invalid config")
+	assert.Contains(t, extractCodeError(result).Error(), "rule name is required")
+}
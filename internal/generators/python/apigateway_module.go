@@ -37,36 +37,27 @@ func GenerateAPIGatewayModule(config ProjectConfig) *apigatewayv2.Module {
 	corsConfig.MaxAge = &maxAge
 	api.CORSConfiguration = corsConfig
 
-	// Create default stage with auto-deploy
+	// Create default stage (HTTP APIs auto-deploy it)
 	createStage := true
 	api.CreateStage = &createStage
 
 	stageName := "$default"
 	api.StageName = &stageName
 
-	autoDeploy := true
-	api.AutoDeploy = &autoDeploy
-
 	// Configure Lambda integration
 	lambdaFunctionName := fmt.Sprintf("%s-%s", config.ServiceName, "${var.environment}")
 	integrationURI := fmt.Sprintf("${module.%s.lambda_function_invoke_arn}", sanitizeModuleName(lambdaFunctionName))
 
-	// Define integration
-	api.Integrations = map[string]apigatewayv2.Integration{
-		"lambda": {
-			IntegrationType:      "AWS_PROXY",
-			IntegrationURI:       &integrationURI,
-			IntegrationMethod:    stringPtr("POST"),
-			PayloadFormatVersion: stringPtr("2.0"),
-		},
-	}
-
-	// Define route
+	// Define route and its integration
 	routeKey := fmt.Sprintf("%s %s", config.HTTPMethod, config.APIPath)
 	api.Routes = map[string]apigatewayv2.Route{
-		"main": {
-			RouteKey:       routeKey,
-			IntegrationKey: stringPtr("lambda"),
+		routeKey: {
+			Integration: apigatewayv2.Integration{
+				Type:                 stringPtr("AWS_PROXY"),
+				URI:                  &integrationURI,
+				Method:               stringPtr("POST"),
+				PayloadFormatVersion: stringPtr("2.0"),
+			},
 		},
 	}
 
@@ -82,22 +73,17 @@ func GenerateAPIGatewayModule(config ProjectConfig) *apigatewayv2.Module {
   "protocol": "$context.protocol",
   "responseLength": "$context.responseLength"
 }`
-	// Add throttling settings to prevent API abuse
-	throttleSettings := &apigatewayv2.ThrottleSettings{
-		BurstLimit: intPtr(100),
-		RateLimit:  float64Ptr(50.0),
+	createLogGroup := false
+	api.StageAccessLogSettings = &apigatewayv2.AccessLogSettings{
+		CreateLogGroup: &createLogGroup,
+		DestinationARN: &logDestination,
+		Format:         &logFormat,
 	}
 
-	api.Stages = map[string]apigatewayv2.Stage{
-		"$default": {
-			Name:             stringPtr("$default"),
-			AutoDeploy:       &autoDeploy,
-			ThrottleSettings: throttleSettings,
-			AccessLogSettings: &apigatewayv2.AccessLogSettings{
-				DestinationARN: logDestination,
-				Format:         logFormat,
-			},
-		},
+	// Add throttling settings to prevent API abuse
+	api.StageDefaultRouteSettings = &apigatewayv2.RouteSettings{
+		ThrottlingBurstLimit: intPtr(100),
+		ThrottlingRateLimit:  float64Ptr(50.0),
 	}
 
 	return api
@@ -111,11 +97,9 @@ func GenerateAPIGatewayModuleHCL(module *apigatewayv2.Module, lambdaModuleName s
 
 	// Get the route key from the module's Routes map
 	routeKey := "POST /"
-	if len(module.Routes) > 0 {
-		for _, route := range module.Routes {
-			routeKey = route.RouteKey
-			break // Use the first route key
-		}
+	for key := range module.Routes {
+		routeKey = key
+		break // Use the first route key
 	}
 
 	hcl := fmt.Sprintf(`# API Gateway HTTP API (v2) module
@@ -283,7 +283,7 @@ func TestGenerateAPIGatewayModule(t *testing.T) {
 		assert.Contains(t, module.CORSConfiguration.AllowHeaders, "content-type")
 	})
 
-	t.Run("configures default stage with auto-deploy", func(t *testing.T) {
+	t.Run("configures default stage with access logs and throttling", func(t *testing.T) {
 		config := python.ProjectConfig{
 			ServiceName: "test-service",
 			Description: "Test API",
@@ -298,8 +298,10 @@ func TestGenerateAPIGatewayModule(t *testing.T) {
 		assert.True(t, *module.CreateStage)
 		assert.NotNil(t, module.StageName)
 		assert.Equal(t, "$default", *module.StageName)
-		assert.NotNil(t, module.AutoDeploy)
-		assert.True(t, *module.AutoDeploy)
+		require.NotNil(t, module.StageAccessLogSettings)
+		assert.False(t, *module.StageAccessLogSettings.CreateLogGroup)
+		require.NotNil(t, module.StageDefaultRouteSettings)
+		assert.Equal(t, 100, *module.StageDefaultRouteSettings.ThrottlingBurstLimit)
 	})
 
 	t.Run("configures Lambda integration", func(t *testing.T) {
@@ -313,12 +315,12 @@ func TestGenerateAPIGatewayModule(t *testing.T) {
 		module := python.GenerateAPIGatewayModule(config)
 
 		require.NotNil(t, module)
-		require.NotEmpty(t, module.Integrations)
-		integration, exists := module.Integrations["lambda"]
-		assert.True(t, exists)
-		assert.Equal(t, "AWS_PROXY", integration.IntegrationType)
-		assert.NotNil(t, integration.IntegrationMethod)
-		assert.Equal(t, "POST", *integration.IntegrationMethod)
+		route, exists := module.Routes["POST /api/test"]
+		require.True(t, exists)
+		integration := route.Integration
+		assert.Equal(t, "AWS_PROXY", *integration.Type)
+		assert.NotNil(t, integration.Method)
+		assert.Equal(t, "POST", *integration.Method)
 	})
 
 	t.Run("configures route with correct HTTP method", func(t *testing.T) {
@@ -345,9 +347,8 @@ func TestGenerateAPIGatewayModule(t *testing.T) {
 
 				require.NotNil(t, module)
 				require.NotEmpty(t, module.Routes)
-				route, exists := module.Routes["main"]
+				_, exists := module.Routes[tt.expectedKey]
 				assert.True(t, exists)
-				assert.Equal(t, tt.expectedKey, route.RouteKey)
 			})
 		}
 	})
@@ -11,6 +11,8 @@ import (
 	E "github.com/IBM/fp-go/either"
 
 	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/s3"
 )
 
 type (
@@ -49,7 +51,8 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 	// If integrating with Lambda, add integration config
 	if intent.ToFunc != "" {
 		// Verify target function exists
-		if _, exists := state.Functions[intent.ToFunc]; !exists {
+		fn, exists := state.Functions[intent.ToFunc]
+		if !exists {
 			return E.Left[generators.ResourceConfig](
 				fmt.Errorf("target function '%s' not found", intent.ToFunc),
 			)
@@ -60,6 +63,7 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 
 		config.Integration = &generators.IntegrationConfig{
 			TargetFunction: intent.ToFunc,
+			Function:       fn,
 			IAMPermissions: []generators.IAMPermission{
 				{
 					Effect: "Allow",
@@ -68,13 +72,13 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 						"s3:ListBucket",
 					},
 					Resources: []string{
-						fmt.Sprintf("module.%s.s3_bucket_arn", sanitizeName(intent.Name)),
-						fmt.Sprintf("module.%s.s3_bucket_arn/*", sanitizeName(intent.Name)),
+						bucketARNExpression(intent.Name, intent.UseModule),
+						objectsARNExpression(intent.Name, intent.UseModule),
 					},
 				},
 			},
 			EnvVars: map[string]string{
-				strings.ToUpper(sanitizeName(intent.Name)) + "_BUCKET_NAME": fmt.Sprintf("module.%s.s3_bucket_id", sanitizeName(intent.Name)),
+				strings.ToUpper(sanitizeName(intent.Name)) + "_BUCKET_NAME": bucketIDExpression(intent.Name, intent.UseModule),
 			},
 		}
 	}
@@ -90,9 +94,13 @@ func (g *Generator) Generate(config generators.ResourceConfig, state generators.
 
 		// 1. Generate main S3 resource file
 		if validConfig.Module {
+			content, err := generateModuleCode(validConfig)
+			if err != nil {
+				return E.Left[generators.GeneratedCode](err)
+			}
 			files = append(files, generators.FileToWrite{
 				Path:    "s3.tf",
-				Content: generateModuleCode(validConfig),
+				Content: content,
 				Mode:    generators.WriteModeAppend,
 			})
 		} else {
@@ -144,11 +152,26 @@ func (g *Generator) Validate(config generators.ResourceConfig) E.Either[error, g
 	return E.Right[error](config)
 }
 
-// generateModuleCode creates Terraform module code (PURE).
-func generateModuleCode(config generators.ResourceConfig) string {
-	moduleName := sanitizeName(config.Name)
-	bucketName := config.Name
+// generateModuleCode creates Terraform module code from the typed s3
+// module (PURE).
+func generateModuleCode(config generators.ResourceConfig) (string, error) {
+	code, err := bucketModule(config).Configuration()
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+
+	parts = append(parts, "# Generated by forge add s3 "+config.Name)
+	parts = append(parts, "")
+	parts = append(parts, code)
+
+	return strings.Join(parts, "\n"), nil
+}
 
+// bucketModule configures an s3 module with the bucket named within the
+// namespace, its versioning, public access block and encryption (PURE).
+func bucketModule(config generators.ResourceConfig) *s3.Module {
 	versioningEnabled, ok := config.Variables["versioning_enabled"].(bool)
 	_ = ok
 	blockPublicACLs, ok := config.Variables["block_public_acls"].(bool)
@@ -158,56 +181,28 @@ func generateModuleCode(config generators.ResourceConfig) string {
 	encryption, ok := config.Variables["server_side_encryption"].(string)
 	_ = ok
 
-	var parts []string
-
-	parts = append(parts, "# Generated by forge add s3 "+config.Name)
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("module \"%s\" {", moduleName))
-	parts = append(parts, "  source  = \"terraform-aws-modules/s3-bucket/aws\"")
-	parts = append(parts, "  version = \"~> 4.0\"")
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("  bucket = \"${var.namespace}%s\"", bucketName))
-	parts = append(parts, fmt.Sprintf("  force_destroy = %t", forceDestroy))
+	namespace := hclgen.Ref("var.namespace")
+	module := s3.NewModule(hclgen.Template(namespace, config.Name).String()).WithLocalName(sanitizeName(config.Name))
+	module.ForceDestroy = &forceDestroy
 
-	// Versioning
-	if versioningEnabled {
-		parts = append(parts, "")
-		parts = append(parts, "  # Versioning configuration")
-		parts = append(parts, "  versioning = {")
-		parts = append(parts, "    enabled = true")
-		parts = append(parts, "  }")
+	if !versioningEnabled {
+		module.Versioning = nil
 	}
-
-	// Public access block
-	if blockPublicACLs {
-		parts = append(parts, "")
-		parts = append(parts, "  # Block all public access")
-		parts = append(parts, "  block_public_acls       = true")
-		parts = append(parts, "  block_public_policy     = true")
-		parts = append(parts, "  ignore_public_acls      = true")
-		parts = append(parts, "  restrict_public_buckets = true")
+	if !blockPublicACLs {
+		module.WithPublicAccess()
+	}
+	module.ServerSideEncryptionConfiguration = map[string]interface{}{
+		"rule": map[string]interface{}{
+			"apply_server_side_encryption_by_default": map[string]interface{}{
+				"sse_algorithm": encryption,
+			},
+		},
 	}
 
-	// Server-side encryption
-	parts = append(parts, "")
-	parts = append(parts, "  # Server-side encryption")
-	parts = append(parts, "  server_side_encryption_configuration = {")
-	parts = append(parts, "    rule = {")
-	parts = append(parts, "      apply_server_side_encryption_by_default = {")
-	parts = append(parts, fmt.Sprintf("        sse_algorithm = \"%s\"", encryption))
-	parts = append(parts, "      }")
-	parts = append(parts, "    }")
-	parts = append(parts, "  }")
-
-	parts = append(parts, "")
-	parts = append(parts, "  tags = {")
-	parts = append(parts, "    ManagedBy = \"forge\"")
-	parts = append(parts, "    Namespace = var.namespace")
-	parts = append(parts, "  }")
-	parts = append(parts, "}")
-	parts = append(parts, "")
-
-	return strings.Join(parts, "\n")
+	return module.WithTags(map[string]string{
+		"ManagedBy": "forge",
+		"Namespace": namespace.String(),
+	})
 }
 
 // generateRawResourceCode creates raw Terraform resource code (PURE).
@@ -270,19 +265,20 @@ func generateOutputs(config generators.ResourceConfig) string {
 	parts = append(parts, "# Outputs for "+config.Name)
 
 	if config.Module {
+		outputs := bucketOutputs(config.Name)
 		parts = append(parts, fmt.Sprintf("output \"%s_bucket_id\" {", moduleName))
 		parts = append(parts, fmt.Sprintf("  description = \"ID of %s\"", config.Name))
-		parts = append(parts, fmt.Sprintf("  value       = module.%s.s3_bucket_id", moduleName))
+		parts = append(parts, "  value       = "+outputs.S3BucketID().Ref())
 		parts = append(parts, "}")
 		parts = append(parts, "")
 		parts = append(parts, fmt.Sprintf("output \"%s_bucket_arn\" {", moduleName))
 		parts = append(parts, fmt.Sprintf("  description = \"ARN of %s\"", config.Name))
-		parts = append(parts, fmt.Sprintf("  value       = module.%s.s3_bucket_arn", moduleName))
+		parts = append(parts, "  value       = "+outputs.S3BucketARN().Ref())
 		parts = append(parts, "}")
 		parts = append(parts, "")
 		parts = append(parts, fmt.Sprintf("output \"%s_bucket_domain_name\" {", moduleName))
 		parts = append(parts, fmt.Sprintf("  description = \"Domain name of %s\"", config.Name))
-		parts = append(parts, fmt.Sprintf("  value       = module.%s.s3_bucket_bucket_domain_name", moduleName))
+		parts = append(parts, "  value       = "+outputs.S3BucketBucketDomainName().Ref())
 		parts = append(parts, "}")
 	} else {
 		parts = append(parts, fmt.Sprintf("output \"%s_bucket_id\" {", moduleName))
@@ -313,6 +309,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 
 	bucketName := sanitizeName(config.Name)
 	functionName := config.Integration.TargetFunction
+	target := config.Integration.TargetFunctionInfo()
 
 	var parts []string
 
@@ -322,15 +319,10 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 		functionName, bucketName))
 	parts = append(parts, "  statement_id  = \"AllowExecutionFromS3Bucket\"")
 	parts = append(parts, "  action        = \"lambda:InvokeFunction\"")
-	parts = append(parts, fmt.Sprintf("  function_name = aws_lambda_function.%s.arn", functionName))
+	parts = append(parts, "  function_name = "+target.ARNExpression())
 	parts = append(parts, "  principal     = \"s3.amazonaws.com\"")
 
-	if config.Module {
-		parts = append(parts, fmt.Sprintf("  source_arn    = module.%s.s3_bucket_arn", bucketName))
-	} else {
-		parts = append(parts, fmt.Sprintf("  source_arn    = aws_s3_bucket.%s.arn", bucketName))
-	}
-
+	parts = append(parts, "  source_arn    = "+bucketARNExpression(config.Name, config.Module))
 	parts = append(parts, "}")
 	parts = append(parts, "")
 
@@ -338,15 +330,11 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 	parts = append(parts, "# S3 bucket notification for "+config.Name)
 	parts = append(parts, fmt.Sprintf("resource \"aws_s3_bucket_notification\" \"%s\" {", bucketName))
 
-	if config.Module {
-		parts = append(parts, fmt.Sprintf("  bucket = module.%s.s3_bucket_id", bucketName))
-	} else {
-		parts = append(parts, fmt.Sprintf("  bucket = aws_s3_bucket.%s.id", bucketName))
-	}
+	parts = append(parts, "  bucket = "+bucketIDExpression(config.Name, config.Module))
 
 	parts = append(parts, "")
 	parts = append(parts, "  lambda_function {")
-	parts = append(parts, fmt.Sprintf("    lambda_function_arn = aws_lambda_function.%s.arn", functionName))
+	parts = append(parts, "    lambda_function_arn = "+target.ARNExpression())
 	parts = append(parts, "    events              = [\"s3:ObjectCreated:*\"]")
 	parts = append(parts, "  }")
 	parts = append(parts, "")
@@ -362,7 +350,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 		parts = append(parts, fmt.Sprintf("resource \"aws_iam_role_policy\" \"%s_s3_%s\" {",
 			functionName, bucketName))
 		parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s-s3-%s\"", functionName, bucketName))
-		parts = append(parts, fmt.Sprintf("  role = aws_iam_role.%s.id", functionName))
+		parts = append(parts, "  role = "+target.RoleExpression())
 		parts = append(parts, "")
 		parts = append(parts, "  policy = jsonencode({")
 		parts = append(parts, "    Version = \"2012-10-17\"")
@@ -393,7 +381,8 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 		parts = append(parts, "        ]")
 		parts = append(parts, "      }")
 		parts = append(parts, "    ]")
-		parts = append(parts, "  })}")
+		parts = append(parts, "  })")
+		parts = append(parts, "}")
 		parts = append(parts, "")
 	}
 
@@ -409,6 +398,33 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 	return strings.Join(parts, "\n")
 }
 
+// bucketOutputs returns the outputs of the bucket's module call (PURE).
+func bucketOutputs(name string) s3.Outputs {
+	return s3.NewModule(sanitizeName(name)).Outputs()
+}
+
+// bucketIDExpression returns the Terraform expression for the bucket's name (PURE).
+func bucketIDExpression(name string, module bool) string {
+	if module {
+		return bucketOutputs(name).S3BucketID().Ref()
+	}
+	return fmt.Sprintf("aws_s3_bucket.%s.id", sanitizeName(name))
+}
+
+// bucketARNExpression returns the Terraform expression for the bucket's ARN (PURE).
+func bucketARNExpression(name string, module bool) string {
+	if module {
+		return bucketOutputs(name).S3BucketARN().Ref()
+	}
+	return fmt.Sprintf("aws_s3_bucket.%s.arn", sanitizeName(name))
+}
+
+// objectsARNExpression returns the Terraform expression for the ARN of the
+// bucket's objects, e.g. "${module.uploads.s3_bucket_arn}/*" (PURE).
+func objectsARNExpression(name string, module bool) string {
+	return fmt.Sprintf("%q", hclgen.Template(hclgen.Ref(bucketARNExpression(name, module)), "/*").String())
+}
+
 // sanitizeName converts a name to a valid Terraform identifier (PURE).
 func sanitizeName(name string) string {
 	// Replace hyphens with underscores for Terraform identifiers
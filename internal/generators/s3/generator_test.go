@@ -137,7 +137,7 @@ func TestPrompt_WithLambdaIntegration(t *testing.T) {
 	assert.Contains(t, perm.Actions, "s3:GetObject")
 	assert.Contains(t, perm.Actions, "s3:ListBucket")
 	assert.Contains(t, perm.Resources, "module.uploads_bucket.s3_bucket_arn")
-	assert.Contains(t, perm.Resources, "module.uploads_bucket.s3_bucket_arn/*")
+	assert.Contains(t, perm.Resources, `"${module.uploads_bucket.s3_bucket_arn}/*"`)
 
 	// Verify environment variables
 	assert.NotNil(t, config.Integration.EnvVars)
@@ -330,11 +330,11 @@ func TestGenerate_ModuleMode(t *testing.T) {
 	require.NotNil(t, s3File)
 	assert.Equal(t, generators.WriteModeAppend, s3File.Mode)
 	assert.Contains(t, s3File.Content, `module "uploads_bucket"`)
-	assert.Contains(t, s3File.Content, `source  = "terraform-aws-modules/s3-bucket/aws"`)
-	assert.Contains(t, s3File.Content, `version = "~> 4.0"`)
-	assert.Contains(t, s3File.Content, `bucket = "${var.namespace}uploads-bucket"`)
-	assert.Contains(t, s3File.Content, "force_destroy = false")
-	assert.Contains(t, s3File.Content, "enabled = true") // versioning
+	assert.Contains(t, s3File.Content, `source                  = "terraform-aws-modules/s3-bucket/aws"`)
+	assert.Contains(t, s3File.Content, `version                 = "~> 5.0"`)
+	assert.Contains(t, s3File.Content, `bucket                  = "${var.namespace}uploads-bucket"`)
+	assert.Contains(t, s3File.Content, "force_destroy           = false")
+	assert.Contains(t, s3File.Content, `enabled = "true"`) // versioning
 	assert.Contains(t, s3File.Content, "block_public_acls")
 	assert.Contains(t, s3File.Content, "sse_algorithm = \"AES256\"")
 
@@ -572,7 +572,7 @@ func TestNamespaceSupport(t *testing.T) {
 	require.NotNil(t, s3File)
 
 	// All resources should use ${var.namespace} prefix
-	assert.Contains(t, s3File.Content, `bucket = "${var.namespace}uploads-bucket"`)
+	assert.Contains(t, s3File.Content, `bucket                  = "${var.namespace}uploads-bucket"`)
 }
 
 // TestGeneratedCodeFormat tests that generated code is well-formatted.
@@ -717,9 +717,9 @@ func TestForceDestroyConfiguration(t *testing.T) {
 			require.NotNil(t, s3File)
 
 			if tt.forceDestroy {
-				assert.Contains(t, s3File.Content, "force_destroy = true")
+				assert.Contains(t, s3File.Content, "force_destroy           = true")
 			} else {
-				assert.Contains(t, s3File.Content, "force_destroy = false")
+				assert.Contains(t, s3File.Content, "force_destroy           = false")
 			}
 		})
 	}
@@ -738,7 +738,7 @@ func TestVersioningConfiguration(t *testing.T) {
 		{
 			name:              "with versioning",
 			versioningEnabled: true,
-			expectedInModule:  "enabled = true",
+			expectedInModule:  `enabled = "true"`,
 			expectedInRaw:     true,
 		},
 		{
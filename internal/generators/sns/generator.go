@@ -11,6 +11,8 @@ import (
 	E "github.com/IBM/fp-go/either"
 
 	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/sns"
 )
 
 type (
@@ -45,7 +47,8 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 	// If integrating with Lambda, add integration config
 	if intent.ToFunc != "" {
 		// Verify target function exists
-		if _, exists := state.Functions[intent.ToFunc]; !exists {
+		fn, exists := state.Functions[intent.ToFunc]
+		if !exists {
 			return E.Left[generators.ResourceConfig](
 				fmt.Errorf("target function '%s' not found", intent.ToFunc),
 			)
@@ -53,6 +56,7 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 
 		config.Integration = &generators.IntegrationConfig{
 			TargetFunction: intent.ToFunc,
+			Function:       fn,
 			IAMPermissions: []generators.IAMPermission{
 				{
 					Effect: "Allow",
@@ -60,12 +64,12 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 						"sns:Publish",
 					},
 					Resources: []string{
-						fmt.Sprintf("module.%s.topic_arn", sanitizeName(intent.Name)),
+						topicARNExpression(intent.Name, intent.UseModule),
 					},
 				},
 			},
 			EnvVars: map[string]string{
-				strings.ToUpper(sanitizeName(intent.Name)) + "_TOPIC_ARN": fmt.Sprintf("module.%s.topic_arn", sanitizeName(intent.Name)),
+				strings.ToUpper(sanitizeName(intent.Name)) + "_TOPIC_ARN": topicARNExpression(intent.Name, intent.UseModule),
 			},
 		}
 	}
@@ -81,9 +85,13 @@ func (g *Generator) Generate(config generators.ResourceConfig, state generators.
 
 		// 1. Generate main SNS resource file
 		if validConfig.Module {
+			content, err := generateModuleCode(validConfig)
+			if err != nil {
+				return E.Left[generators.GeneratedCode](err)
+			}
 			files = append(files, generators.FileToWrite{
 				Path:    "sns.tf",
-				Content: generateModuleCode(validConfig),
+				Content: content,
 				Mode:    generators.WriteModeAppend,
 			})
 		} else {
@@ -134,51 +142,51 @@ func (g *Generator) Validate(config generators.ResourceConfig) E.Either[error, g
 	return E.Right[error](config)
 }
 
-// generateModuleCode creates Terraform module code (PURE).
-func generateModuleCode(config generators.ResourceConfig) string {
-	moduleName := sanitizeName(config.Name)
-	topicName := config.Name
+// generateModuleCode creates Terraform module code from the typed sns
+// module (PURE).
+func generateModuleCode(config generators.ResourceConfig) (string, error) {
+	code, err := topicModule(config).Configuration()
+	if err != nil {
+		return "", err
+	}
 
+	var parts []string
+
+	parts = append(parts, "# Generated by forge add sns "+config.Name)
+	parts = append(parts, "")
+	parts = append(parts, code)
+
+	return strings.Join(parts, "\n"), nil
+}
+
+// topicModule configures an sns module with the topic named within the
+// namespace (PURE).
+func topicModule(config generators.ResourceConfig) *sns.Module {
 	displayName, ok := config.Variables["display_name"].(string)
 	_ = ok
 	fifoTopic, ok := config.Variables["fifo_topic"].(bool)
 	_ = ok
+	contentBasedDedup, ok := config.Variables["content_based_deduplication"].(bool)
+	_ = ok
+	createTopicPolicy, hasTopicPolicy := config.Variables["create_topic_policy"].(bool)
 
-	var parts []string
-
-	parts = append(parts, "# Generated by forge add sns "+config.Name)
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("module \"%s\" {", moduleName))
-	parts = append(parts, "  source  = \"terraform-aws-modules/sns/aws\"")
-	parts = append(parts, "  version = \"~> 6.0\"")
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s\"", topicName))
+	namespace := hclgen.Ref("var.namespace")
+	module := sns.NewModule(hclgen.Template(namespace, config.Name).String()).WithLocalName(sanitizeName(config.Name))
 
 	if displayName != "" {
-		parts = append(parts, fmt.Sprintf("  display_name = \"%s\"", displayName))
+		module.DisplayName = &displayName
 	}
-
 	if fifoTopic {
-		parts = append(parts, "")
-		parts = append(parts, "  # FIFO topic configuration")
-		parts = append(parts, "  fifo_topic                  = true")
-
-		contentBasedDedup, ok := config.Variables["content_based_deduplication"].(bool)
-		_ = ok
-		if contentBasedDedup {
-			parts = append(parts, "  content_based_deduplication = true")
-		}
+		module.WithFIFO(contentBasedDedup)
+	}
+	if hasTopicPolicy {
+		module.CreateTopicPolicy = &createTopicPolicy
 	}
 
-	parts = append(parts, "")
-	parts = append(parts, "  tags = {")
-	parts = append(parts, "    ManagedBy = \"forge\"")
-	parts = append(parts, "    Namespace = var.namespace")
-	parts = append(parts, "  }")
-	parts = append(parts, "}")
-	parts = append(parts, "")
-
-	return strings.Join(parts, "\n")
+	return module.WithTags(map[string]string{
+		"ManagedBy": "forge",
+		"Namespace": namespace.String(),
+	})
 }
 
 // generateRawResourceCode creates raw Terraform resource code (PURE).
@@ -233,14 +241,15 @@ func generateOutputs(config generators.ResourceConfig) string {
 	parts = append(parts, "# Outputs for "+config.Name)
 
 	if config.Module {
+		outputs := topicOutputs(config.Name)
 		parts = append(parts, fmt.Sprintf("output \"%s_topic_arn\" {", moduleName))
 		parts = append(parts, fmt.Sprintf("  description = \"ARN of %s\"", config.Name))
-		parts = append(parts, fmt.Sprintf("  value       = module.%s.topic_arn", moduleName))
+		parts = append(parts, "  value       = "+outputs.TopicARN().Ref())
 		parts = append(parts, "}")
 		parts = append(parts, "")
 		parts = append(parts, fmt.Sprintf("output \"%s_topic_id\" {", moduleName))
 		parts = append(parts, fmt.Sprintf("  description = \"ID of %s\"", config.Name))
-		parts = append(parts, fmt.Sprintf("  value       = module.%s.topic_id", moduleName))
+		parts = append(parts, "  value       = "+outputs.TopicID().Ref())
 		parts = append(parts, "}")
 	} else {
 		parts = append(parts, fmt.Sprintf("output \"%s_topic_arn\" {", moduleName))
@@ -266,6 +275,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 
 	topicName := sanitizeName(config.Name)
 	functionName := config.Integration.TargetFunction
+	target := config.Integration.TargetFunctionInfo()
 
 	var parts []string
 
@@ -274,14 +284,10 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 	parts = append(parts, fmt.Sprintf("resource \"aws_sns_topic_subscription\" \"%s_%s\" {",
 		topicName, functionName))
 
-	if config.Module {
-		parts = append(parts, fmt.Sprintf("  topic_arn = module.%s.topic_arn", topicName))
-	} else {
-		parts = append(parts, fmt.Sprintf("  topic_arn = aws_sns_topic.%s.arn", topicName))
-	}
+	parts = append(parts, "  topic_arn = "+topicARNExpression(config.Name, config.Module))
 
 	parts = append(parts, "  protocol  = \"lambda\"")
-	parts = append(parts, fmt.Sprintf("  endpoint  = aws_lambda_function.%s.arn", functionName))
+	parts = append(parts, "  endpoint  = "+target.ARNExpression())
 	parts = append(parts, "}")
 	parts = append(parts, "")
 
@@ -291,15 +297,10 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 		functionName, topicName))
 	parts = append(parts, "  statement_id  = \"AllowExecutionFromSNS\"")
 	parts = append(parts, "  action        = \"lambda:InvokeFunction\"")
-	parts = append(parts, fmt.Sprintf("  function_name = aws_lambda_function.%s.function_name", functionName))
+	parts = append(parts, "  function_name = "+target.NameExpression())
 	parts = append(parts, "  principal     = \"sns.amazonaws.com\"")
 
-	if config.Module {
-		parts = append(parts, fmt.Sprintf("  source_arn    = module.%s.topic_arn", topicName))
-	} else {
-		parts = append(parts, fmt.Sprintf("  source_arn    = aws_sns_topic.%s.arn", topicName))
-	}
-
+	parts = append(parts, "  source_arn    = "+topicARNExpression(config.Name, config.Module))
 	parts = append(parts, "}")
 	parts = append(parts, "")
 
@@ -311,7 +312,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 		parts = append(parts, fmt.Sprintf("resource \"aws_iam_role_policy\" \"%s_sns_%s_publish\" {",
 			functionName, topicName))
 		parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s-sns-%s-publish\"", functionName, topicName))
-		parts = append(parts, fmt.Sprintf("  role = aws_iam_role.%s.id", functionName))
+		parts = append(parts, "  role = "+target.RoleExpression())
 		parts = append(parts, "")
 		parts = append(parts, "  policy = jsonencode({")
 		parts = append(parts, "    Version = \"2012-10-17\"")
@@ -332,7 +333,8 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 		parts = append(parts, "        Resource = "+perm.Resources[0])
 		parts = append(parts, "      }")
 		parts = append(parts, "    ]")
-		parts = append(parts, "  })}")
+		parts = append(parts, "  })")
+		parts = append(parts, "}")
 		parts = append(parts, "")
 	}
 
@@ -348,6 +350,19 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 	return strings.Join(parts, "\n")
 }
 
+// topicOutputs returns the outputs of the topic's module call (PURE).
+func topicOutputs(name string) sns.Outputs {
+	return sns.NewModule(sanitizeName(name)).Outputs()
+}
+
+// topicARNExpression returns the Terraform expression for the topic's ARN (PURE).
+func topicARNExpression(name string, module bool) string {
+	if module {
+		return topicOutputs(name).TopicARN().Ref()
+	}
+	return fmt.Sprintf("aws_sns_topic.%s.arn", sanitizeName(name))
+}
+
 // sanitizeName converts a name to a valid Terraform identifier (PURE).
 func sanitizeName(name string) string {
 	// Replace hyphens with underscores for Terraform identifiers
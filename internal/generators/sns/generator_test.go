@@ -277,10 +277,11 @@ func TestGenerate_ModuleMode(t *testing.T) {
 	require.NotNil(t, snsFile)
 	assert.Equal(t, generators.WriteModeAppend, snsFile.Mode)
 	assert.Contains(t, snsFile.Content, `module "notifications"`)
-	assert.Contains(t, snsFile.Content, `source  = "terraform-aws-modules/sns/aws"`)
-	assert.Contains(t, snsFile.Content, `version = "~> 6.0"`)
-	assert.Contains(t, snsFile.Content, `name = "${var.namespace}notifications"`)
-	assert.Contains(t, snsFile.Content, `display_name = "notifications"`)
+	assert.Contains(t, snsFile.Content, `source                      = "terraform-aws-modules/sns/aws"`)
+	assert.Contains(t, snsFile.Content, `version                     = "~> 7.0"`)
+	assert.Contains(t, snsFile.Content, `name                        = "${var.namespace}notifications"`)
+	assert.Contains(t, snsFile.Content, `display_name                = "notifications"`)
+	assert.Contains(t, snsFile.Content, "create_topic_policy         = false")
 
 	// Check outputs.tf
 	outputsFile := findFile(code.Files, "outputs.tf")
@@ -515,7 +516,7 @@ func TestNamespaceSupport(t *testing.T) {
 	require.NotNil(t, snsFile)
 
 	// All resources should use ${var.namespace} prefix
-	assert.Contains(t, snsFile.Content, `name = "${var.namespace}notifications"`)
+	assert.Contains(t, snsFile.Content, `name                        = "${var.namespace}notifications"`)
 }
 
 // TestGeneratedCodeFormat tests that generated code is well-formatted.
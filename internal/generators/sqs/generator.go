@@ -11,6 +11,8 @@ import (
 	E "github.com/IBM/fp-go/either"
 
 	"github.com/lewis/forge/internal/generators"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/sqs"
 )
 
 type (
@@ -42,7 +44,8 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 	// If integrating with Lambda, add integration config
 	if intent.ToFunc != "" {
 		// Verify target function exists
-		if _, exists := state.Functions[intent.ToFunc]; !exists {
+		fn, exists := state.Functions[intent.ToFunc]
+		if !exists {
 			return E.Left[generators.ResourceConfig](
 				fmt.Errorf("target function '%s' not found", intent.ToFunc),
 			)
@@ -50,8 +53,9 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 
 		config.Integration = &generators.IntegrationConfig{
 			TargetFunction: intent.ToFunc,
+			Function:       fn,
 			EventSource: &generators.EventSourceConfig{
-				ARNExpression:         fmt.Sprintf("module.%s.queue_arn", sanitizeName(intent.Name)),
+				ARNExpression:         queueARNExpression(intent.Name, intent.UseModule),
 				BatchSize:             10,
 				MaxBatchingWindowSecs: 5,
 				MaxConcurrency:        10,
@@ -65,7 +69,7 @@ func (g *Generator) Prompt(ctx context.Context, intent generators.ResourceIntent
 						"sqs:GetQueueAttributes",
 					},
 					Resources: []string{
-						fmt.Sprintf("module.%s.queue_arn", sanitizeName(intent.Name)),
+						queueARNExpression(intent.Name, intent.UseModule),
 					},
 				},
 			},
@@ -83,9 +87,13 @@ func (g *Generator) Generate(config generators.ResourceConfig, state generators.
 
 		// 1. Generate main SQS resource file
 		if validConfig.Module {
+			content, err := generateModuleCode(validConfig)
+			if err != nil {
+				return E.Left[generators.GeneratedCode](err)
+			}
 			files = append(files, generators.FileToWrite{
 				Path:    "sqs.tf",
-				Content: generateModuleCode(validConfig),
+				Content: content,
 				Mode:    generators.WriteModeAppend,
 			})
 		} else {
@@ -136,11 +144,26 @@ func (g *Generator) Validate(config generators.ResourceConfig) E.Either[error, g
 	return E.Right[error](config)
 }
 
-// generateModuleCode creates Terraform module code (PURE).
-func generateModuleCode(config generators.ResourceConfig) string {
-	moduleName := sanitizeName(config.Name)
-	queueName := config.Name
+// generateModuleCode creates Terraform module code from the typed sqs
+// module (PURE).
+func generateModuleCode(config generators.ResourceConfig) (string, error) {
+	code, err := queueModule(config).Configuration()
+	if err != nil {
+		return "", err
+	}
+
+	var parts []string
+
+	parts = append(parts, "# Generated by forge add sqs "+config.Name)
+	parts = append(parts, "")
+	parts = append(parts, code)
+
+	return strings.Join(parts, "\n"), nil
+}
 
+// queueModule configures an sqs module with the queue and, unless disabled,
+// its dead letter queue, both named within the namespace (PURE).
+func queueModule(config generators.ResourceConfig) *sqs.Module {
 	visibilityTimeout, ok := config.Variables["visibility_timeout_seconds"].(int)
 	_ = ok
 	messageRetention, ok := config.Variables["message_retention_seconds"].(int)
@@ -148,36 +171,22 @@ func generateModuleCode(config generators.ResourceConfig) string {
 	createDLQ, ok := config.Variables["create_dlq"].(bool)
 	_ = ok
 
-	var parts []string
-
-	parts = append(parts, "# Generated by forge add sqs "+config.Name)
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("module \"%s\" {", moduleName))
-	parts = append(parts, "  source  = \"terraform-aws-modules/sqs/aws\"")
-	parts = append(parts, "  version = \"~> 4.0\"")
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s\"", queueName))
-	parts = append(parts, "")
-	parts = append(parts, fmt.Sprintf("  visibility_timeout_seconds = %d", visibilityTimeout))
-	parts = append(parts, fmt.Sprintf("  message_retention_seconds  = %d", messageRetention))
+	namespace := hclgen.Ref("var.namespace")
+	module := sqs.NewModule(hclgen.Template(namespace, config.Name).String()).WithLocalName(sanitizeName(config.Name))
+	module.VisibilityTimeoutSeconds = &visibilityTimeout
+	module.MessageRetentionSeconds = &messageRetention
 
 	if createDLQ {
-		parts = append(parts, "")
-		parts = append(parts, "  # Dead letter queue for failed messages")
-		parts = append(parts, "  create_dlq                    = true")
-		parts = append(parts, fmt.Sprintf("  dlq_name                      = \"${var.namespace}%s-dlq\"", queueName))
-		parts = append(parts, "  dlq_message_retention_seconds = 1209600  # 14 days")
+		dlqName := hclgen.Template(namespace, config.Name+"-dlq").String()
+		module.DLQName = &dlqName
+	} else {
+		module.WithoutDLQ()
 	}
 
-	parts = append(parts, "")
-	parts = append(parts, "  tags = {")
-	parts = append(parts, "    ManagedBy = \"forge\"")
-	parts = append(parts, "    Namespace = var.namespace")
-	parts = append(parts, "  }")
-	parts = append(parts, "}")
-	parts = append(parts, "")
-
-	return strings.Join(parts, "\n")
+	return module.WithTags(map[string]string{
+		"ManagedBy": "forge",
+		"Namespace": namespace.String(),
+	})
 }
 
 // generateRawResourceCode creates raw Terraform resource code (PURE).
@@ -219,14 +228,15 @@ func generateOutputs(config generators.ResourceConfig) string {
 	parts = append(parts, "# Outputs for "+config.Name)
 
 	if config.Module {
+		outputs := queueOutputs(config.Name)
 		parts = append(parts, fmt.Sprintf("output \"%s_url\" {", moduleName))
 		parts = append(parts, fmt.Sprintf("  description = \"URL of %s\"", config.Name))
-		parts = append(parts, fmt.Sprintf("  value       = module.%s.queue_url", moduleName))
+		parts = append(parts, "  value       = "+outputs.QueueURL().Ref())
 		parts = append(parts, "}")
 		parts = append(parts, "")
 		parts = append(parts, fmt.Sprintf("output \"%s_arn\" {", moduleName))
 		parts = append(parts, fmt.Sprintf("  description = \"ARN of %s\"", config.Name))
-		parts = append(parts, fmt.Sprintf("  value       = module.%s.queue_arn", moduleName))
+		parts = append(parts, "  value       = "+outputs.QueueARN().Ref())
 		parts = append(parts, "}")
 	} else {
 		parts = append(parts, fmt.Sprintf("output \"%s_url\" {", moduleName))
@@ -252,6 +262,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 
 	queueName := sanitizeName(config.Name)
 	functionName := config.Integration.TargetFunction
+	target := config.Integration.TargetFunctionInfo()
 	eventSource := config.Integration.EventSource
 
 	var parts []string
@@ -261,7 +272,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 	parts = append(parts, fmt.Sprintf("resource \"aws_lambda_event_source_mapping\" \"%s_%s\" {",
 		functionName, queueName))
 	parts = append(parts, "  event_source_arn = "+eventSource.ARNExpression)
-	parts = append(parts, fmt.Sprintf("  function_name    = aws_lambda_function.%s.arn", functionName))
+	parts = append(parts, "  function_name    = "+target.ARNExpression())
 	parts = append(parts, "")
 	parts = append(parts, fmt.Sprintf("  batch_size                         = %d", eventSource.BatchSize))
 	parts = append(parts, fmt.Sprintf("  maximum_batching_window_in_seconds = %d", eventSource.MaxBatchingWindowSecs))
@@ -277,7 +288,7 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 	parts = append(parts, fmt.Sprintf("resource \"aws_iam_role_policy\" \"%s_sqs_%s\" {",
 		functionName, queueName))
 	parts = append(parts, fmt.Sprintf("  name = \"${var.namespace}%s-sqs-%s\"", functionName, queueName))
-	parts = append(parts, fmt.Sprintf("  role = aws_iam_role.%s.id", functionName))
+	parts = append(parts, "  role = "+target.RoleExpression())
 	parts = append(parts, "")
 	parts = append(parts, "  policy = jsonencode({")
 	parts = append(parts, "    Version = \"2012-10-17\"")
@@ -305,6 +316,19 @@ func generateIntegrationCode(config generators.ResourceConfig) string {
 	return strings.Join(parts, "\n")
 }
 
+// queueOutputs returns the outputs of the queue's module call (PURE).
+func queueOutputs(name string) sqs.Outputs {
+	return sqs.NewModule(sanitizeName(name)).Outputs()
+}
+
+// queueARNExpression returns the Terraform expression for the queue's ARN (PURE).
+func queueARNExpression(name string, module bool) string {
+	if module {
+		return queueOutputs(name).QueueARN().Ref()
+	}
+	return fmt.Sprintf("aws_sqs_queue.%s.arn", sanitizeName(name))
+}
+
 // sanitizeName converts a name to a valid Terraform identifier (PURE).
 func sanitizeName(name string) string {
 	// Replace hyphens with underscores for Terraform identifiers
@@ -267,13 +267,13 @@ func TestGenerate_ModuleMode(t *testing.T) {
 	require.NotNil(t, sqsFile)
 	assert.Equal(t, generators.WriteModeAppend, sqsFile.Mode)
 	assert.Contains(t, sqsFile.Content, `module "orders_queue"`)
-	assert.Contains(t, sqsFile.Content, `source  = "terraform-aws-modules/sqs/aws"`)
-	assert.Contains(t, sqsFile.Content, `version = "~> 4.0"`)
-	assert.Contains(t, sqsFile.Content, `name = "${var.namespace}orders-queue"`)
+	assert.Contains(t, sqsFile.Content, `source                          = "terraform-aws-modules/sqs/aws"`)
+	assert.Contains(t, sqsFile.Content, `version                         = "~> 5.0"`)
+	assert.Contains(t, sqsFile.Content, `name                            = "${var.namespace}orders-queue"`)
 	assert.Contains(t, sqsFile.Content, "visibility_timeout_seconds = 30")
-	assert.Contains(t, sqsFile.Content, "message_retention_seconds  = 345600")
-	assert.Contains(t, sqsFile.Content, "create_dlq                    = true")
-	assert.Contains(t, sqsFile.Content, `dlq_name                      = "${var.namespace}orders-queue-dlq"`)
+	assert.Contains(t, sqsFile.Content, "message_retention_seconds       = 345600")
+	assert.Contains(t, sqsFile.Content, "create_dlq                      = true")
+	assert.Contains(t, sqsFile.Content, `dlq_name                        = "${var.namespace}orders-queue-dlq"`)
 
 	// Check outputs.tf
 	outputsFile := findFile(code.Files, "outputs.tf")
@@ -468,8 +468,8 @@ func TestNamespaceSupport(t *testing.T) {
 	require.NotNil(t, sqsFile)
 
 	// All resources should use ${var.namespace} prefix
-	assert.Contains(t, sqsFile.Content, `name = "${var.namespace}orders-queue"`)
-	assert.Contains(t, sqsFile.Content, `dlq_name                      = "${var.namespace}orders-queue-dlq"`)
+	assert.Contains(t, sqsFile.Content, `name                            = "${var.namespace}orders-queue"`)
+	assert.Contains(t, sqsFile.Content, `dlq_name                        = "${var.namespace}orders-queue-dlq"`)
 }
 
 // TestDLQConfiguration tests DLQ settings.
@@ -505,11 +505,11 @@ func TestDLQConfiguration(t *testing.T) {
 			require.NotNil(t, sqsFile)
 
 			if tt.createDLQ {
-				assert.Contains(t, sqsFile.Content, "create_dlq                    = true")
+				assert.Contains(t, sqsFile.Content, "create_dlq                      = true")
 				assert.Contains(t, sqsFile.Content, "dlq_name")
-				assert.Contains(t, sqsFile.Content, "dlq_message_retention_seconds = 1209600")
+				assert.Contains(t, sqsFile.Content, "dlq_message_retention_seconds   = 1209600")
 			} else {
-				assert.NotContains(t, sqsFile.Content, "create_dlq")
+				assert.Contains(t, sqsFile.Content, "create_dlq                      = false")
 				assert.NotContains(t, sqsFile.Content, "dlq_name")
 			}
 		})
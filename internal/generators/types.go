@@ -5,8 +5,14 @@ package generators
 
 import (
 	"context"
+	"fmt"
+	"path"
+	"strings"
 
 	E "github.com/IBM/fp-go/either"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/modvendor"
 )
 
 type (
@@ -39,36 +45,37 @@ type (
 		Runtime    string // Runtime (go1.x, python3.13, etc.)
 		SourcePath string // Path to function source code
 		Handler    string // Handler name
-		TFResource string // Terraform resource name
+		TFResource string // Terraform address, e.g. module.api or aws_lambda_function.api
+		Role       string // Terraform address of a raw function's IAM role, if known
 	}
 
 	// QueueInfo describes an existing SQS queue.
 	QueueInfo struct {
 		Name       string // Queue name
-		URL        string // Queue URL (if known)
-		ARN        string // Queue ARN (if known)
-		TFResource string // Terraform resource/module name
+		URL        string // Terraform expression for the queue URL
+		ARN        string // Terraform expression for the queue ARN
+		TFResource string // Terraform address, e.g. module.orders or aws_sqs_queue.orders
 	}
 
 	// TableInfo describes an existing DynamoDB table.
 	TableInfo struct {
 		Name       string // Table name
-		ARN        string // Table ARN (if known)
-		TFResource string // Terraform resource/module name
+		ARN        string // Terraform expression for the table ARN
+		TFResource string // Terraform address, e.g. module.users or aws_dynamodb_table.users
 	}
 
 	// APIInfo describes an existing API Gateway.
 	APIInfo struct {
 		Name       string // API name
 		Type       string // HTTP, REST, or WebSocket
-		TFResource string // Terraform resource/module name
+		TFResource string // Terraform address, e.g. module.api or aws_apigatewayv2_api.api
 	}
 
 	// TopicInfo describes an existing SNS topic.
 	TopicInfo struct {
 		Name       string // Topic name
-		ARN        string // Topic ARN (if known)
-		TFResource string // Terraform resource/module name
+		ARN        string // Terraform expression for the topic ARN
+		TFResource string // Terraform address, e.g. module.events or aws_sns_topic.events
 	}
 
 	// ResourceConfig contains configuration for resource generation (PURE DATA).
@@ -83,6 +90,7 @@ type (
 	// IntegrationConfig defines how to wire resources together (PURE DATA).
 	IntegrationConfig struct {
 		TargetFunction string             // Lambda function to integrate with
+		Function       FunctionInfo       // Target function, as discovered in the project
 		EventSource    *EventSourceConfig // Event source mapping config
 		IAMPermissions []IAMPermission    // Required IAM permissions
 		EnvVars        map[string]string  // Environment variables to add
@@ -124,9 +132,17 @@ type (
 
 	// WrittenFiles tracks what was written (PURE DATA).
 	WrittenFiles struct {
-		Created []string // Newly created files
-		Updated []string // Modified files
-		Skipped []string // Skipped (already exist)
+		Created []string       // Newly created files
+		Updated []string       // Modified files
+		Skipped []string       // Skipped (already exist or up to date)
+		Blocks  []WrittenBlock // Top-level blocks merged into files
+	}
+
+	// WrittenBlock records what writing did to a top-level block (PURE DATA).
+	WrittenBlock struct {
+		File    string             // Relative path from infra/
+		Address string             // e.g. module.orders or output.orders_url
+		Action  hclgen.BlockAction // Created, updated or skipped
 	}
 
 	// Generator defines the interface for resource generators.
@@ -159,9 +175,9 @@ const (
 )
 
 const (
-	WriteModeCreate WriteMode = "create" // Create new file (error if exists)
-	WriteModeAppend WriteMode = "append" // Append to existing file
-	WriteModeUpdate WriteMode = "update" // Update existing resource in file
+	WriteModeCreate WriteMode = "create" // Create new file (skipped if exists)
+	WriteModeAppend WriteMode = "append" // Merge blocks into file, creating it if needed
+	WriteModeUpdate WriteMode = "update" // Merge blocks into file, as append does
 )
 
 // NewRegistry creates an empty registry.
@@ -193,6 +209,112 @@ func (r Registry) Get(resourceType ResourceType) (Generator, bool) {
 	return gen, ok
 }
 
+// ARNExpression returns the Terraform expression for the function's ARN (PURE).
+func (f FunctionInfo) ARNExpression() string {
+	if f.isModule() {
+		return f.TFResource + ".lambda_function_arn"
+	}
+	return f.address() + ".arn"
+}
+
+// NameExpression returns the Terraform expression for the function's name (PURE).
+func (f FunctionInfo) NameExpression() string {
+	if f.isModule() {
+		return f.TFResource + ".lambda_function_name"
+	}
+	return f.address() + ".function_name"
+}
+
+// RoleExpression returns the Terraform expression naming the function's IAM
+// role, for aws_iam_role_policy. Raw functions whose role is unknown are
+// assumed to use aws_iam_role.<name> (PURE).
+func (f FunctionInfo) RoleExpression() string {
+	switch {
+	case f.isModule():
+		return f.TFResource + ".lambda_role_name"
+	case f.Role != "":
+		return f.Role + ".id"
+	}
+	return "aws_iam_role." + f.Name + ".id"
+}
+
+// isModule reports whether the function is a terraform-aws-modules/lambda call (PURE).
+func (f FunctionInfo) isModule() bool {
+	return strings.HasPrefix(f.TFResource, "module.")
+}
+
+// address returns the function's Terraform address, assuming a raw
+// aws_lambda_function named after it when unknown (PURE).
+func (f FunctionInfo) address() string {
+	if f.TFResource != "" {
+		return f.TFResource
+	}
+	return "aws_lambda_function." + f.Name
+}
+
+// TargetFunctionInfo returns the target function, named TargetFunction when
+// it wasn't discovered (PURE).
+func (c IntegrationConfig) TargetFunctionInfo() FunctionInfo {
+	f := c.Function
+	if f.Name == "" {
+		f.Name = c.TargetFunction
+	}
+	return f
+}
+
+// ToTerraformJSON converts generated code to Terraform JSON syntax: every .tf
+// file becomes a .tf.json file declaring the same blocks, for projects whose
+// forge.hcl sets terraform_format = "json" (PURE CALCULATION).
+func ToTerraformJSON(code GeneratedCode) E.Either[error, GeneratedCode] {
+	files := make([]FileToWrite, 0, len(code.Files))
+	for _, file := range code.Files {
+		if !strings.HasSuffix(file.Path, ".tf") {
+			files = append(files, file)
+			continue
+		}
+		content, err := hclgen.HCLToJSON([]byte(file.Content), file.Path)
+		if err != nil {
+			return E.Left[GeneratedCode](fmt.Errorf("failed to convert %s to JSON: %w", file.Path, err))
+		}
+		files = append(files, FileToWrite{
+			Path:    file.Path + ".json",
+			Content: string(content),
+			Mode:    file.Mode,
+		})
+	}
+
+	converted := code
+	converted.Files = files
+	return E.Right[error](converted)
+}
+
+// ToVendoredSources points the module calls of generated .tf files at the
+// modules vendored in .forge/modules, for projects whose forge.hcl sets
+// module_source = "vendored". Paths are relative to infra/ (PURE CALCULATION).
+func ToVendoredSources(lock modvendor.Lock) func(GeneratedCode) E.Either[error, GeneratedCode] {
+	return func(code GeneratedCode) E.Either[error, GeneratedCode] {
+		files := make([]FileToWrite, 0, len(code.Files))
+		for _, file := range code.Files {
+			if !strings.HasSuffix(file.Path, ".tf") {
+				files = append(files, file)
+				continue
+			}
+			// infra/<dir>/<file>.tf reaches .forge/modules through one ".." per directory
+			modulesPath := strings.Repeat("../", strings.Count(path.Clean(file.Path), "/")+1) + modvendor.Dir
+			content, err := modvendor.RewriteSources([]byte(file.Content), file.Path, lock, modulesPath)
+			if err != nil {
+				return E.Left[GeneratedCode](err)
+			}
+			file.Content = string(content)
+			files = append(files, file)
+		}
+
+		rewritten := code
+		rewritten.Files = files
+		return E.Right[error](rewritten)
+	}
+}
+
 // DiscoverFunc scans project to find existing resources (I/O ACTION).
 type DiscoverFunc func(projectRoot string) E.Either[error, ProjectState]
 
@@ -6,6 +6,9 @@ import (
 
 	E "github.com/IBM/fp-go/either"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/modvendor"
 )
 
 // TestResourceTypes tests resource type constants.
@@ -363,6 +366,96 @@ func TestIntegrationConfig(t *testing.T) {
 	})
 }
 
+// TestToTerraformJSON tests converting generated files to .tf.json.
+func TestToTerraformJSON(t *testing.T) {
+	t.Run("converts .tf files and keeps their mode", func(t *testing.T) {
+		code := GeneratedCode{Files: []FileToWrite{
+			{Path: "sqs.tf", Content: "module \"orders\" {\n  source = \"terraform-aws-modules/sqs/aws\"\n  name   = \"${var.namespace}orders\"\n}\n", Mode: WriteModeAppend},
+			{Path: "README.md", Content: "# Orders", Mode: WriteModeCreate},
+		}}
+
+		converted, err := E.UnwrapError(ToTerraformJSON(code))
+		require.NoError(t, err)
+		require.Len(t, converted.Files, 2)
+
+		assert.Equal(t, "sqs.tf.json", converted.Files[0].Path)
+		assert.Equal(t, WriteModeAppend, converted.Files[0].Mode)
+		assert.JSONEq(t, `{"module": {"orders": {
+			"source": "terraform-aws-modules/sqs/aws",
+			"name": "${var.namespace}orders"
+		}}}`, converted.Files[0].Content)
+		assert.Equal(t, code.Files[1], converted.Files[1], "non-Terraform files are unchanged")
+		assert.Equal(t, "sqs.tf", code.Files[0].Path, "input is not mutated")
+	})
+
+	t.Run("fails on invalid HCL", func(t *testing.T) {
+		code := GeneratedCode{Files: []FileToWrite{{Path: "broken.tf", Content: "module \"x\" {"}}}
+
+		assert.True(t, E.IsLeft(ToTerraformJSON(code)))
+	})
+}
+
+// TestFunctionInfoExpressions tests referencing module and raw functions.
+func TestFunctionInfoExpressions(t *testing.T) {
+	tests := []struct {
+		name                     string
+		fn                       FunctionInfo
+		wantARN, wantN, wantRole string
+	}{
+		{
+			"lambda module",
+			FunctionInfo{Name: "api", TFResource: "module.api"},
+			"module.api.lambda_function_arn", "module.api.lambda_function_name", "module.api.lambda_role_name",
+		},
+		{
+			"raw function with a known role",
+			FunctionInfo{Name: "api", TFResource: "aws_lambda_function.api", Role: "aws_iam_role.exec"},
+			"aws_lambda_function.api.arn", "aws_lambda_function.api.function_name", "aws_iam_role.exec.id",
+		},
+		{
+			"undiscovered function",
+			IntegrationConfig{TargetFunction: "api"}.TargetFunctionInfo(),
+			"aws_lambda_function.api.arn", "aws_lambda_function.api.function_name", "aws_iam_role.api.id",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantARN, tc.fn.ARNExpression())
+			assert.Equal(t, tc.wantN, tc.fn.NameExpression())
+			assert.Equal(t, tc.wantRole, tc.fn.RoleExpression())
+		})
+	}
+}
+
+// TestToVendoredSources tests pointing generated module calls at .forge/modules.
+func TestToVendoredSources(t *testing.T) {
+	lock := modvendor.Lock{Modules: []modvendor.Module{{Name: "sqs"}}}
+	module := "module \"orders\" {\n  source  = \"terraform-aws-modules/sqs/aws\"\n  version = \"~> 5.0\"\n}\n"
+
+	t.Run("rewrites .tf files relative to their directory", func(t *testing.T) {
+		code := GeneratedCode{Files: []FileToWrite{
+			{Path: "sqs.tf", Content: module, Mode: WriteModeAppend},
+			{Path: "queues/sqs.tf", Content: module, Mode: WriteModeAppend},
+			{Path: "README.md", Content: "terraform-aws-modules/sqs/aws", Mode: WriteModeCreate},
+		}}
+
+		rewritten, err := E.UnwrapError(ToVendoredSources(lock)(code))
+		require.NoError(t, err)
+		require.Len(t, rewritten.Files, 3)
+
+		assert.Equal(t, "module \"orders\" {\n  source = \"../.forge/modules/sqs\"\n}\n", rewritten.Files[0].Content)
+		assert.Contains(t, rewritten.Files[1].Content, `source = "../../.forge/modules/sqs"`)
+		assert.Equal(t, code.Files[2], rewritten.Files[2], "non-Terraform files are unchanged")
+		assert.Equal(t, module, code.Files[0].Content, "input is not mutated")
+	})
+
+	t.Run("fails on modules that are not vendored", func(t *testing.T) {
+		code := GeneratedCode{Files: []FileToWrite{{Path: "sqs.tf", Content: module}}}
+
+		assert.True(t, E.IsLeft(ToVendoredSources(modvendor.Lock{})(code)))
+	})
+}
+
 // mockGenerator implements Generator for testing.
 type mockGenerator struct {
 	promptFunc   func(context.Context, ResourceIntent, ProjectState) E.Either[error, ResourceConfig]
@@ -239,12 +239,18 @@ func ConventionTerraformOutputs(exec TerraformExecutor) Stage {
 // TerraformInitFunc initializes Terraform in a directory.
 type TerraformInitFunc func(ctx context.Context, dir string) error
 
+// TerraformInitWithBackendFunc initializes Terraform with backend configuration overrides.
+type TerraformInitWithBackendFunc func(ctx context.Context, dir string, backendConfig map[string]string) error
+
 // TerraformPlanFunc plans infrastructure changes.
 type TerraformPlanFunc func(ctx context.Context, dir string) (bool, error)
 
 // TerraformPlanWithVarsFunc plans infrastructure changes with variables.
 type TerraformPlanWithVarsFunc func(ctx context.Context, dir string, vars map[string]string) (bool, error)
 
+// TerraformPlanDestroyFunc plans destruction of all resources and returns the addresses to be removed.
+type TerraformPlanDestroyFunc func(ctx context.Context, dir string, vars map[string]string) ([]string, error)
+
 // TerraformApplyFunc applies infrastructure changes.
 type TerraformApplyFunc func(ctx context.Context, dir string) error
 
@@ -253,9 +259,11 @@ type TerraformOutputFunc func(ctx context.Context, dir string) (map[string]inter
 
 // This follows functional programming - functions as first-class values.
 type TerraformExecutor struct {
-	Init         TerraformInitFunc
-	Plan         TerraformPlanFunc
-	PlanWithVars TerraformPlanWithVarsFunc
-	Apply        TerraformApplyFunc
-	Output       TerraformOutputFunc
+	Init            TerraformInitFunc
+	InitWithBackend TerraformInitWithBackendFunc
+	Plan            TerraformPlanFunc
+	PlanWithVars    TerraformPlanWithVarsFunc
+	PlanDestroy     TerraformPlanDestroyFunc
+	Apply           TerraformApplyFunc
+	Output          TerraformOutputFunc
 }
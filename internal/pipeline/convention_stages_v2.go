@@ -6,9 +6,7 @@ import (
 	"fmt"
 	"path/filepath"
 
-	A "github.com/IBM/fp-go/array"
 	E "github.com/IBM/fp-go/either"
-	O "github.com/IBM/fp-go/option"
 
 	"github.com/lewis/forge/internal/build"
 	"github.com/lewis/forge/internal/discovery"
@@ -82,16 +80,109 @@ func ConventionStubsV2() EventStage {
 	}
 }
 
-// BuildAccumulator holds the accumulated state during functional reduce.
-// This is pure data with no mutations - each iteration creates a new accumulator.
-type BuildAccumulator struct {
-	Artifacts map[string]Artifact
-	Events    []StageEvent
+// ConventionFunctionsTerraformV2 creates an event-based stage that regenerates
+// infra/functions.gen.tf with one Lambda module per discovered function.
+// Hand-written .tf files are never modified; functions they already declare are skipped.
+func ConventionFunctionsTerraformV2() EventStage {
+	return func(ctx context.Context, s State) E.Either[error, StageResult] {
+		// Extract functions from state
+		functions, ok := s.Config.([]discovery.Function)
+		if !ok {
+			return E.Left[StageResult](errors.New("invalid state: functions not found"))
+		}
+
+		result, err := discovery.WriteFunctionsTerraform(s.ProjectDir, functions)
+		if err != nil {
+			return E.Left[StageResult](fmt.Errorf("failed to generate function terraform: %w", err))
+		}
+
+		return E.Right[error](StageResult{
+			State:  s,
+			Events: functionsTerraformEvents(s.ProjectDir, result),
+		})
+	}
+}
+
+// functionsTerraformEvents describes a WriteFunctionsTerraform result.
+// PURE: Calculation.
+func functionsTerraformEvents(projectDir string, result discovery.FunctionsTerraformResult) []StageEvent {
+	path := result.Path
+	if rel, err := filepath.Rel(projectDir, result.Path); err == nil {
+		path = rel
+	}
+
+	events := []StageEvent{}
+	for _, name := range result.Skipped {
+		events = append(events, NewEvent(EventLevelInfo,
+			fmt.Sprintf("Skipping %s: module %q is declared in infra/", name, name)))
+	}
+
+	switch {
+	case result.Written && len(result.Generated) == 0:
+		events = append(events, NewEvent(EventLevelInfo, "Removed "+path))
+	case result.Written:
+		events = append(events, NewEventWithData(EventLevelSuccess,
+			fmt.Sprintf("Generated %s (%d function(s))", path, len(result.Generated)),
+			map[string]interface{}{"path": path, "functions": result.Generated}))
+	}
+
+	return events
+}
+
+// BuildStageConfig holds build stage settings.
+type BuildStageConfig struct {
+	Jobs     int
+	Registry build.Registry
+	Cache    build.Cache // Optional; nil disables caching
+}
+
+// BuildStageOption is a function that configures BuildStageConfig.
+type BuildStageOption func(*BuildStageConfig)
+
+// WithJobs sets how many functions are built concurrently (values below 1 mean one).
+func WithJobs(jobs int) BuildStageOption {
+	return func(cfg *BuildStageConfig) {
+		cfg.Jobs = jobs
+	}
 }
 
-// ConventionBuildV2 creates an event-based stage that builds all discovered functions.
-// PURE: Uses functional reduce pattern to avoid map mutations.
-func ConventionBuildV2() EventStage {
+// WithRegistry replaces the default builder registry.
+func WithRegistry(registry build.Registry) BuildStageOption {
+	return func(cfg *BuildStageConfig) {
+		cfg.Registry = registry
+	}
+}
+
+// WithBuildCache serves unchanged functions from cache instead of rebuilding them.
+func WithBuildCache(cache build.Cache) BuildStageOption {
+	return func(cfg *BuildStageConfig) {
+		cfg.Cache = cache
+	}
+}
+
+// applyBuildStageOptions applies all options and returns the config.
+func applyBuildStageOptions(opts ...BuildStageOption) BuildStageConfig {
+	cfg := BuildStageConfig{
+		Jobs:     build.DefaultJobs(),
+		Registry: build.NewRegistry(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.Cache != nil {
+		cfg.Registry = build.CachedRegistry(cfg.Registry, cfg.Cache)
+	}
+	return cfg
+}
+
+// ConventionBuildV2 creates an event-based stage that builds all discovered functions
+// on a bounded worker pool (one worker per CPU unless WithJobs says otherwise).
+// Per-function events are emitted in completion order; artifacts and failures are
+// collected in function order, and every failure is reported, not just the first.
+// With WithBuildCache, unchanged functions are restored from cache and reported as cached.
+func ConventionBuildV2(opts ...BuildStageOption) EventStage {
+	stageCfg := applyBuildStageOptions(opts...)
+
 	return func(ctx context.Context, s State) E.Either[error, StageResult] {
 		// Extract functions from state
 		functions, ok := s.Config.([]discovery.Function)
@@ -99,121 +190,102 @@ func ConventionBuildV2() EventStage {
 			return E.Left[StageResult](errors.New("invalid state: functions not found"))
 		}
 
-		registry := build.NewRegistry()
 		buildDir := filepath.Join(s.ProjectDir, ".forge", "build")
 
-		// Initial accumulator with existing artifacts and initial events
-		initialAcc := BuildAccumulator{
-			Artifacts: s.Artifacts, // Copy existing artifacts
-			Events: []StageEvent{
-				NewEvent(EventLevelInfo, "==> Building Lambda functions..."),
-			},
+		// PURE: Convert every function to a build config before starting any build
+		configs := make([]build.Config, 0, len(functions))
+		var configErrs []error
+		for _, fn := range functions {
+			E.Fold(
+				func(err error) any {
+					configErrs = append(configErrs, fmt.Errorf("invalid build config for %s: %w", fn.Name, err))
+					return nil
+				},
+				func(cfg build.Config) any {
+					configs = append(configs, cfg)
+					return nil
+				},
+			)(discovery.ToBuildConfig(fn, buildDir))
+		}
+		if len(configErrs) > 0 {
+			return E.Left[StageResult](errors.Join(configErrs...))
 		}
 
-		// PURE: Functional reduce over functions
-		// Each iteration creates a NEW accumulator (copy-on-write semantics)
-		buildResult := A.Reduce(
-			func(acc E.Either[error, BuildAccumulator], fn discovery.Function) E.Either[error, BuildAccumulator] {
-				// Chain the accumulator - short-circuits on first error (railway pattern)
-				return E.Chain(func(current BuildAccumulator) E.Either[error, BuildAccumulator] {
-					// Add "Building..." event
-					buildingEvent := NewEvent(EventLevelInfo, fmt.Sprintf("[%s] Building...", fn.Name))
-
-					// Get builder from registry (returns Option)
-					builderOpt := build.GetBuilder(registry, fn.Runtime)
-					if O.IsNone(builderOpt) {
-						return E.Left[BuildAccumulator](fmt.Errorf("unsupported runtime: %s", fn.Runtime))
-					}
-
-					// Extract builder using Fold
-					builder := O.Fold(
-						func() build.BuildFunc { return nil },
-						func(b build.BuildFunc) build.BuildFunc { return b },
-					)(builderOpt)
-
-					// Convert to build config with validation (returns Either)
-					cfgResult := discovery.ToBuildConfig(fn, buildDir)
-
-					// Handle config validation error
-					if E.IsLeft(cfgResult) {
-						err := E.Fold(
-							func(e error) error { return e },
-							func(c build.Config) error { return nil },
-						)(cfgResult)
-						return E.Left[BuildAccumulator](fmt.Errorf("invalid build config for %s: %w", fn.Name, err))
-					}
+		events := []StageEvent{
+			NewEvent(EventLevelInfo, "==> Building Lambda functions..."),
+		}
+		if len(functions) > 0 {
+			workers := max(1, min(stageCfg.Jobs, len(functions)))
+			events = append(events, NewEvent(EventLevelInfo, fmt.Sprintf("Building %d function(s) with %d worker(s)", len(functions), workers)))
+		}
 
-					// Extract config
-					cfg := E.Fold(
-						func(error) build.Config { return build.Config{} },
-						func(c build.Config) build.Config { return c },
-					)(cfgResult)
-
-					// Execute build (returns Either)
-					result := builder(ctx, cfg)
-
-					// Handle result using functional error handling
-					if E.IsLeft(result) {
-						err := E.Fold(
-							func(e error) error { return e },
-							func(a build.Artifact) error { return nil },
-						)(result)
-						return E.Left[BuildAccumulator](fmt.Errorf("failed to build %s: %w", fn.Name, err))
+		// Execute builds concurrently (I/O); events are appended as each build finishes
+		results := build.BuildConcurrent(ctx, configs, stageCfg.Registry, stageCfg.Jobs, func(r build.BuildResult) {
+			name := functions[r.Index].Name
+			events = append(events, E.Fold(
+				func(err error) StageEvent {
+					return NewEvent(EventLevelError, fmt.Sprintf("[%s] Failed: %v", name, err))
+				},
+				func(artifact build.Artifact) StageEvent {
+					sizeMB := float64(artifact.Size) / 1024 / 1024
+					verb := "Built"
+					if artifact.Cached {
+						verb = "Cached"
 					}
+					return NewEvent(EventLevelSuccess, fmt.Sprintf("[%s] %s: %s (%.2f MB)", name, verb, filepath.Base(artifact.Path), sizeMB))
+				},
+			)(r.Result))
+		})
 
-					// Extract artifact
-					artifact := E.Fold(
-						func(e error) build.Artifact { return build.Artifact{} },
-						func(a build.Artifact) build.Artifact { return a },
-					)(result)
-
-					// PURE: Create NEW artifacts map (copy-on-write)
-					newArtifacts := make(map[string]Artifact, len(current.Artifacts)+1)
-					for k, v := range current.Artifacts {
-						newArtifacts[k] = v
+		// PURE: Collect artifacts (copy-on-write) and failures in function order
+		artifacts := make(map[string]Artifact, len(s.Artifacts)+len(results))
+		for k, v := range s.Artifacts {
+			artifacts[k] = v
+		}
+		var failures []error
+		cached := 0
+		for _, r := range results {
+			name := functions[r.Index].Name
+			E.Fold(
+				func(err error) any {
+					failures = append(failures, fmt.Errorf("failed to build %s: %w", name, err))
+					return nil
+				},
+				func(artifact build.Artifact) any {
+					if artifact.Cached {
+						cached++
 					}
-					newArtifacts[fn.Name] = Artifact{
+					artifacts[name] = Artifact{
 						Path:     artifact.Path,
 						Checksum: artifact.Checksum,
 						Size:     artifact.Size,
 					}
+					return nil
+				},
+			)(r.Result)
+		}
 
-					// Create success event
-					sizeMB := float64(artifact.Size) / 1024 / 1024
-					successEvent := NewEvent(EventLevelSuccess, fmt.Sprintf("[%s] Built: %s (%.2f MB)", fn.Name, filepath.Base(artifact.Path), sizeMB))
-
-					// PURE: Create NEW events slice (copy-on-write)
-					newEvents := make([]StageEvent, 0, len(current.Events)+2)
-					newEvents = append(newEvents, current.Events...)
-					newEvents = append(newEvents, buildingEvent, successEvent)
-
-					// Return new accumulator (immutable)
-					return E.Right[error](BuildAccumulator{
-						Artifacts: newArtifacts,
-						Events:    newEvents,
-					})
-				})(acc)
+		if len(failures) > 0 {
+			return E.Left[StageResult](fmt.Errorf("%d of %d function(s) failed to build: %w",
+				len(failures), len(functions), errors.Join(failures...)))
+		}
+
+		if cached > 0 {
+			events = append(events, NewEventWithData(EventLevelInfo,
+				fmt.Sprintf("Restored %d of %d function(s) from build cache", cached, len(functions)),
+				map[string]interface{}{"cached": cached, "total": len(functions)}))
+		}
+		events = append(events, NewEvent(EventLevelInfo, ""))
+
+		// Return new State (immutable)
+		return E.Right[error](StageResult{
+			State: State{
+				ProjectDir: s.ProjectDir,
+				Artifacts:  artifacts,
+				Outputs:    s.Outputs,
+				Config:     s.Config,
 			},
-			E.Right[error](initialAcc), // Starting value
-		)(functions)
-
-		// Extract final accumulator or return error
-		return E.Chain(func(acc BuildAccumulator) E.Either[error, StageResult] {
-			// Add final empty line event
-			finalEvents := make([]StageEvent, 0, len(acc.Events)+1)
-			finalEvents = append(finalEvents, acc.Events...)
-			finalEvents = append(finalEvents, NewEvent(EventLevelInfo, ""))
-
-			// Return new State (immutable)
-			return E.Right[error](StageResult{
-				State: State{
-					ProjectDir: s.ProjectDir,
-					Artifacts:  acc.Artifacts,
-					Outputs:    s.Outputs,
-					Config:     s.Config,
-				},
-				Events: finalEvents,
-			})
-		})(buildResult)
+			Events: events,
+		})
 	}
 }
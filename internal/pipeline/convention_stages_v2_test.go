@@ -1,14 +1,18 @@
 package pipeline
 
 import (
+	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	E "github.com/IBM/fp-go/either"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/lewis/forge/internal/build"
 	"github.com/lewis/forge/internal/discovery"
 )
 
@@ -251,6 +255,63 @@ func TestConventionStubsV2(t *testing.T) {
 	})
 }
 
+// TestConventionFunctionsTerraformV2 tests the stage generating infra/functions.gen.tf.
+func TestConventionFunctionsTerraformV2(t *testing.T) {
+	functions := []discovery.Function{
+		{Name: "api", Runtime: "provided.al2023", EntryPoint: "main.go"},
+		{Name: "worker", Runtime: "python3.13", EntryPoint: "app.py"},
+	}
+
+	t.Run("generates the file and reports it", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		infraDir := filepath.Join(tmpDir, "infra")
+		require.NoError(t, os.MkdirAll(infraDir, 0o755))
+		mainTF := "module \"worker\" {\n  source = \"terraform-aws-modules/lambda/aws\"\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(infraDir, "main.tf"), []byte(mainTF), 0o644))
+
+		stage := ConventionFunctionsTerraformV2()
+		result := stage(t.Context(), State{ProjectDir: tmpDir, Config: functions})
+
+		require.True(t, E.IsRight(result))
+		stageResult := E.Fold(
+			func(e error) StageResult { return StageResult{} },
+			func(r StageResult) StageResult { return r },
+		)(result)
+
+		generated, err := os.ReadFile(filepath.Join(infraDir, discovery.FunctionsTerraformFile))
+		require.NoError(t, err)
+		assert.Contains(t, string(generated), `module "api"`)
+		assert.NotContains(t, string(generated), `module "worker"`)
+
+		handWritten, err := os.ReadFile(filepath.Join(infraDir, "main.tf"))
+		require.NoError(t, err)
+		assert.Equal(t, mainTF, string(handWritten))
+
+		messages := make([]string, 0, len(stageResult.Events))
+		for _, event := range stageResult.Events {
+			messages = append(messages, event.Message)
+		}
+		assert.Contains(t, messages, `Skipping worker: module "worker" is declared in infra/`)
+		assert.Contains(t, messages, "Generated infra/functions.gen.tf (1 function(s))")
+
+		// Unchanged output is not rewritten or reported again
+		again := stage(t.Context(), State{ProjectDir: tmpDir, Config: functions})
+		require.True(t, E.IsRight(again))
+		againResult := E.Fold(
+			func(e error) StageResult { return StageResult{} },
+			func(r StageResult) StageResult { return r },
+		)(again)
+		assert.Len(t, againResult.Events, 1, "only the skip notice should remain")
+	})
+
+	t.Run("returns error when Config is not []discovery.Function", func(t *testing.T) {
+		stage := ConventionFunctionsTerraformV2()
+		result := stage(t.Context(), State{ProjectDir: t.TempDir(), Config: "invalid"})
+
+		require.True(t, E.IsLeft(result))
+	})
+}
+
 // TestConventionBuildV2 tests the event-based build stage.
 func TestConventionBuildV2(t *testing.T) {
 	t.Run("returns error when Config is not []discovery.Function", func(t *testing.T) {
@@ -328,6 +389,139 @@ func TestConventionBuildV2(t *testing.T) {
 		// Should fail, but would have generated initial events
 		require.True(t, E.IsLeft(result))
 	})
+
+	t.Run("builds every function with a bounded worker pool", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		functions := []discovery.Function{
+			{Name: "api", Runtime: "go1.x", Path: filepath.Join(tmpDir, "api"), EntryPoint: "main.go"},
+			{Name: "worker", Runtime: "go1.x", Path: filepath.Join(tmpDir, "worker"), EntryPoint: "main.go"},
+			{Name: "cron", Runtime: "go1.x", Path: filepath.Join(tmpDir, "cron"), EntryPoint: "main.go"},
+		}
+
+		state := State{
+			ProjectDir: tmpDir,
+			Config:     functions,
+			Artifacts:  make(map[string]Artifact),
+		}
+
+		stage := ConventionBuildV2(WithJobs(2), WithRegistry(fakeBuildRegistry()))
+		result := stage(t.Context(), state)
+
+		require.True(t, E.IsRight(result))
+		stageResult := E.GetOrElse(func(error) StageResult { return StageResult{} })(result)
+
+		require.Len(t, stageResult.State.Artifacts, 3)
+		assert.Equal(t, filepath.Join(tmpDir, ".forge", "build", "worker.zip"), stageResult.State.Artifacts["worker"].Path)
+		assert.Empty(t, state.Artifacts, "input artifacts should be untouched")
+
+		messages := eventMessages(stageResult.Events)
+		assert.Contains(t, messages, "Building 3 function(s) with 2 worker(s)")
+		for _, fn := range functions {
+			assert.Equal(t, 1, countPrefix(messages, "["+fn.Name+"] Built:"), "one event per function")
+		}
+	})
+
+	t.Run("reports every failed function", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		functions := []discovery.Function{
+			{Name: "api", Runtime: "broken", Path: filepath.Join(tmpDir, "api"), EntryPoint: "main.go"},
+			{Name: "worker", Runtime: "go1.x", Path: filepath.Join(tmpDir, "worker"), EntryPoint: "main.go"},
+			{Name: "cron", Runtime: "broken", Path: filepath.Join(tmpDir, "cron"), EntryPoint: "main.go"},
+		}
+
+		state := State{
+			ProjectDir: tmpDir,
+			Config:     functions,
+			Artifacts:  make(map[string]Artifact),
+		}
+
+		stage := ConventionBuildV2(WithJobs(3), WithRegistry(fakeBuildRegistry()))
+		result := stage(t.Context(), state)
+
+		require.True(t, E.IsLeft(result))
+		err := E.Fold(
+			func(e error) error { return e },
+			func(StageResult) error { return nil },
+		)(result)
+		assert.Contains(t, err.Error(), "2 of 3 function(s) failed to build")
+		assert.Contains(t, err.Error(), "failed to build api")
+		assert.Contains(t, err.Error(), "failed to build cron")
+		assert.NotContains(t, err.Error(), "failed to build worker")
+	})
+
+	t.Run("reports functions restored from the build cache", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		functions := []discovery.Function{
+			{Name: "api", Runtime: "go1.x", Path: filepath.Join(tmpDir, "api"), EntryPoint: "main.go"},
+			{Name: "worker", Runtime: "go1.x", Path: filepath.Join(tmpDir, "worker"), EntryPoint: "main.go"},
+		}
+
+		state := State{
+			ProjectDir: tmpDir,
+			Config:     functions,
+			Artifacts:  make(map[string]Artifact),
+		}
+
+		cache := hitCache{hits: map[string]bool{filepath.Join(tmpDir, "api"): true}}
+		stage := ConventionBuildV2(WithRegistry(fakeBuildRegistry()), WithBuildCache(cache))
+		result := stage(t.Context(), state)
+
+		require.True(t, E.IsRight(result))
+		stageResult := E.GetOrElse(func(error) StageResult { return StageResult{} })(result)
+
+		messages := eventMessages(stageResult.Events)
+		assert.Equal(t, 1, countPrefix(messages, "[api] Cached:"))
+		assert.Equal(t, 1, countPrefix(messages, "[worker] Built:"))
+		assert.Contains(t, messages, "Restored 1 of 2 function(s) from build cache")
+		assert.Len(t, stageResult.State.Artifacts, 2)
+	})
+}
+
+// hitCache serves a fixed artifact for source dirs in hits and never stores anything.
+type hitCache struct {
+	hits map[string]bool
+}
+
+func (c hitCache) Get(cfg build.Config) (build.Artifact, bool) {
+	if !c.hits[cfg.SourceDir] {
+		return build.Artifact{}, false
+	}
+	return build.Artifact{Path: cfg.OutputPath, Checksum: "cached", Size: 2048}, true
+}
+
+func (c hitCache) Set(build.Config, build.Artifact) {}
+
+// fakeBuildRegistry returns builders that succeed for go1.x and fail for "broken".
+func fakeBuildRegistry() build.Registry {
+	return build.Registry{
+		"go1.x": func(_ context.Context, cfg build.Config) E.Either[error, build.Artifact] {
+			return E.Right[error](build.Artifact{Path: cfg.OutputPath, Checksum: "abc123", Size: 1024})
+		},
+		"broken": func(_ context.Context, cfg build.Config) E.Either[error, build.Artifact] {
+			return E.Left[build.Artifact](errors.New("compiler exploded"))
+		},
+	}
+}
+
+func eventMessages(events []StageEvent) []string {
+	messages := make([]string, 0, len(events))
+	for _, event := range events {
+		messages = append(messages, event.Message)
+	}
+	return messages
+}
+
+func countPrefix(messages []string, prefix string) int {
+	count := 0
+	for _, message := range messages {
+		if strings.HasPrefix(message, prefix) {
+			count++
+		}
+	}
+	return count
 }
 
 // TestRunWithEvents tests the event collection pipeline.
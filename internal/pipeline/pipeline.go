@@ -3,6 +3,7 @@ package pipeline
 import (
 	"context"
 	"errors"
+	"sync"
 
 	A "github.com/IBM/fp-go/array"
 	E "github.com/IBM/fp-go/either"
@@ -136,7 +137,7 @@ func Chain(pipelines ...Pipeline) Pipeline {
 	return Pipeline{stages: stages}
 }
 
-// NOTE: Future enhancement - true parallel execution with goroutines (see Parallel).
+// Sequential composes stages so each one receives the previous stage's state.
 func Sequential(stages ...Stage) Stage {
 	return func(ctx context.Context, s State) E.Either[error, State] {
 		// Use A.Reduce for functional sequential composition
@@ -152,9 +153,81 @@ func Sequential(stages ...Stage) Stage {
 	}
 }
 
-// Parallel is an alias for Sequential (true parallel execution not yet implemented)
-// DEPRECATED: Use Sequential for clarity. True parallel execution is a future enhancement.
-// See: https://github.com/lewis/forge/issues/XXX
+// Parallel runs independent stages concurrently against the same input state.
+// Each stage gets its own copy of Artifacts and Outputs, so stages never share a map.
+// Results are merged in stage order (later stages win on key conflicts); ProjectDir and
+// Config come from the last stage. Every stage runs to completion and all failures are joined.
 func Parallel(stages ...Stage) Stage {
-	return Sequential(stages...)
+	return func(ctx context.Context, s State) E.Either[error, State] {
+		results := make([]E.Either[error, State], len(stages))
+
+		var wg sync.WaitGroup
+		for i, stage := range stages {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = stage(ctx, copyState(s))
+			}()
+		}
+		wg.Wait()
+
+		return mergeResults(s, results)
+	}
+}
+
+// copyState returns a state whose maps can be mutated without affecting the original.
+// PURE: Shallow copy of map entries.
+func copyState(s State) State {
+	return State{
+		ProjectDir: s.ProjectDir,
+		Artifacts:  mergeMaps(nil, s.Artifacts),
+		Outputs:    mergeMaps(nil, s.Outputs),
+		Config:     s.Config,
+	}
+}
+
+// mergeResults folds stage results over the initial state in stage order.
+// PURE: Returns all errors joined if any stage failed.
+func mergeResults(initial State, results []E.Either[error, State]) E.Either[error, State] {
+	merged := copyState(initial)
+	var errs []error
+
+	for _, result := range results {
+		E.Fold(
+			func(err error) any {
+				errs = append(errs, err)
+				return nil
+			},
+			func(s State) any {
+				merged = State{
+					ProjectDir: s.ProjectDir,
+					Artifacts:  mergeMaps(merged.Artifacts, s.Artifacts),
+					Outputs:    mergeMaps(merged.Outputs, s.Outputs),
+					Config:     s.Config,
+				}
+				return nil
+			},
+		)(result)
+	}
+
+	if len(errs) > 0 {
+		return E.Left[State](errors.Join(errs...))
+	}
+	return E.Right[error](merged)
+}
+
+// mergeMaps returns a new map with the entries of base overlaid by overlay.
+// PURE: Returns nil when both inputs are nil.
+func mergeMaps[V any](base, overlay map[string]V) map[string]V {
+	if base == nil && overlay == nil {
+		return nil
+	}
+	merged := make(map[string]V, len(base)+len(overlay))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		merged[k] = v
+	}
+	return merged
 }
@@ -197,7 +197,7 @@ func TestParallelEdgeCases(t *testing.T) {
 		assert.Len(t, finalState.Outputs, 1)
 	})
 
-	t.Run("parallel stops on first error", func(t *testing.T) {
+	t.Run("parallel runs every stage and reports the error", func(t *testing.T) {
 		var stage1Called, stage2Called bool
 
 		stage1 := func(ctx context.Context, s State) E.Either[error, State] {
@@ -215,8 +215,8 @@ func TestParallelEdgeCases(t *testing.T) {
 
 		assert.True(t, E.IsLeft(result))
 		assert.True(t, stage1Called)
-		// Since we run sequentially now, stage2 won't be called
-		assert.False(t, stage2Called)
+		// Stages run concurrently, so a failure does not cancel its siblings
+		assert.True(t, stage2Called)
 	})
 
 	t.Run("parallel with single stage works", func(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	E "github.com/IBM/fp-go/either"
 	"github.com/stretchr/testify/assert"
@@ -385,6 +386,75 @@ func TestParallel(t *testing.T) {
 		assert.Contains(t, state.Artifacts, "api")
 		assert.Contains(t, state.Artifacts, "worker")
 	})
+
+	t.Run("runs stages concurrently", func(t *testing.T) {
+		// Arrange: each stage waits for the other, so a sequential run would time out
+		ctx := t.Context()
+		first := make(chan struct{})
+		second := make(chan struct{})
+		rendezvous := func(signal, wait chan struct{}) Stage {
+			return func(ctx context.Context, s State) E.Either[error, State] {
+				close(signal)
+				select {
+				case <-wait:
+					return E.Right[error](s)
+				case <-time.After(2 * time.Second):
+					return E.Left[State](fmt.Errorf("stage was not run concurrently"))
+				}
+			}
+		}
+		parallel := Parallel(rendezvous(first, second), rendezvous(second, first))
+
+		// Act
+		result := parallel(ctx, State{ProjectDir: "/project"})
+
+		// Assert
+		require.True(t, E.IsRight(result), "Parallel stages should overlap")
+	})
+
+	t.Run("reports every stage error", func(t *testing.T) {
+		// Arrange
+		ctx := t.Context()
+		parallel := Parallel(
+			errorStage("lint failed"),
+			successStage("build"),
+			errorStage("test failed"),
+		)
+
+		// Act
+		result := parallel(ctx, State{ProjectDir: "/project"})
+
+		// Assert
+		require.True(t, E.IsLeft(result))
+		err := E.Fold(
+			func(e error) error { return e },
+			func(State) error { return nil },
+		)(result)
+		assert.Contains(t, err.Error(), "lint failed")
+		assert.Contains(t, err.Error(), "test failed")
+	})
+
+	t.Run("does not mutate the input state", func(t *testing.T) {
+		// Arrange
+		ctx := t.Context()
+		initial := State{
+			ProjectDir: "/project",
+			Artifacts:  map[string]Artifact{"existing": {Path: "/build/existing.zip"}},
+		}
+		parallel := Parallel(
+			addArtifactStage("api", "/build/api.zip"),
+			addArtifactStage("worker", "/build/worker.zip"),
+		)
+
+		// Act
+		result := parallel(ctx, initial)
+
+		// Assert
+		require.True(t, E.IsRight(result))
+		state := E.GetOrElse(func(error) State { return State{} })(result)
+		assert.Len(t, state.Artifacts, 3)
+		assert.Len(t, initial.Artifacts, 1, "input artifacts should be untouched")
+	})
 }
 
 // Integration test combining New, Run, Chain, and Parallel.
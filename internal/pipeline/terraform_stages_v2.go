@@ -7,11 +7,20 @@ import (
 	"path/filepath"
 
 	E "github.com/IBM/fp-go/either"
+
+	"github.com/lewis/forge/internal/state"
 )
 
 // PURE: Returns events as data instead of printing to console.
-func ConventionTerraformInitV2(exec TerraformExecutor) EventStage {
+// A non-empty namespace re-initializes the backend against that namespace's own
+// state key under the key backend.tf configures, so preview environments never
+// share a terraform.tfstate. The default namespace keeps backend.tf as written.
+func ConventionTerraformInitV2(exec TerraformExecutor, namespace string) EventStage {
 	return func(ctx context.Context, s State) E.Either[error, StageResult] {
+		if err := state.ValidateNamespace(namespace); err != nil {
+			return E.Left[StageResult](err)
+		}
+
 		infraDir := filepath.Join(s.ProjectDir, "infra")
 
 		// Build events
@@ -20,8 +29,20 @@ func ConventionTerraformInitV2(exec TerraformExecutor) EventStage {
 		}
 
 		// Execute terraform init (I/O)
-		if err := exec.Init(ctx, infraDir); err != nil {
-			return E.Left[StageResult](fmt.Errorf("terraform init failed: %w", err))
+		if namespace == "" {
+			if err := exec.Init(ctx, infraDir); err != nil {
+				return E.Left[StageResult](fmt.Errorf("terraform init failed: %w", err))
+			}
+		} else {
+			backendKey, err := state.ReadBackendKey(infraDir)
+			if err != nil {
+				return E.Left[StageResult](err)
+			}
+			backendConfig := state.NamespaceBackendConfig(backendKey, namespace)
+			events = append(events, NewEvent(EventLevelInfo, "Using isolated state: "+backendConfig["key"]))
+			if err := exec.InitWithBackend(ctx, infraDir, backendConfig); err != nil {
+				return E.Left[StageResult](fmt.Errorf("terraform init failed for namespace %s: %w", namespace, err))
+			}
 		}
 
 		events = append(events, NewEvent(EventLevelSuccess, "[terraform] Initialized"))
@@ -71,6 +92,89 @@ func ConventionTerraformPlanV2(exec TerraformExecutor, namespace string) EventSt
 	}
 }
 
+// PURE: Returns events as data instead of printing to console.
+// Lists every resource address the destroy plan removes so the caller can show it before confirming.
+func ConventionTerraformPlanDestroyV2(exec TerraformExecutor, namespace string) EventStage {
+	return func(ctx context.Context, s State) E.Either[error, StageResult] {
+		infraDir := filepath.Join(s.ProjectDir, "infra")
+
+		// Build events
+		events := []StageEvent{
+			NewEvent(EventLevelInfo, "==> Planning infrastructure destruction..."),
+		}
+
+		// Set namespace variable if provided
+		var vars map[string]string
+		if namespace != "" {
+			vars = map[string]string{
+				"namespace": namespace + "-",
+			}
+			events = append(events, NewEvent(EventLevelInfo, "Destroying namespace: "+namespace))
+		}
+
+		// Execute terraform plan -destroy (I/O)
+		addresses, err := exec.PlanDestroy(ctx, infraDir, vars)
+		if err != nil {
+			return E.Left[StageResult](fmt.Errorf("terraform destroy plan failed: %w", err))
+		}
+
+		if len(addresses) == 0 {
+			events = append(events, NewEvent(EventLevelInfo, "[terraform] No resources to destroy"))
+		} else {
+			events = append(events, NewEventWithData(
+				EventLevelWarning,
+				fmt.Sprintf("[terraform] %d resource(s) will be destroyed:", len(addresses)),
+				map[string]interface{}{"addresses": addresses},
+			))
+			for _, address := range addresses {
+				events = append(events, NewEvent(EventLevelInfo, "  - "+address))
+			}
+		}
+
+		return E.Right[error](StageResult{
+			State:  s,
+			Events: events,
+		})
+	}
+}
+
+// PURE: Returns events as data instead of printing to console.
+// Removes the namespace's state object, the one ConventionTerraformInitV2 selected,
+// once its resources are gone. The default state is never deleted.
+func ConventionStateCleanupV2(deleteState state.DeleteStateFunc, namespace string) EventStage {
+	return func(ctx context.Context, s State) E.Either[error, StageResult] {
+		if namespace == "" {
+			return E.Right[error](StageResult{State: s, Events: []StageEvent{}})
+		}
+
+		if err := state.ValidateNamespace(namespace); err != nil {
+			return E.Left[StageResult](err)
+		}
+
+		backendKey, err := state.ReadBackendKey(filepath.Join(s.ProjectDir, "infra"))
+		if err != nil {
+			return E.Left[StageResult](err)
+		}
+
+		key := state.NamespaceStateKey(backendKey, namespace)
+		events := []StageEvent{
+			NewEvent(EventLevelInfo, "==> Removing namespace state..."),
+		}
+
+		// Delete state object (I/O)
+		if err := deleteState(ctx, key); err != nil {
+			return E.Left[StageResult](fmt.Errorf("failed to delete state for namespace %s: %w", namespace, err))
+		}
+
+		events = append(events, NewEvent(EventLevelSuccess, "[state] Deleted "+key))
+
+		return E.Right[error](StageResult{
+			State:  s,
+			Events: events,
+		})
+	}
+}
+
 // Returns true if approved, false if canceled.
 type ApprovalFunc func() bool
 
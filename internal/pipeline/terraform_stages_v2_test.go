@@ -3,6 +3,9 @@ package pipeline
 import (
 	"context"
 	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	E "github.com/IBM/fp-go/either"
@@ -10,6 +13,15 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// writeBackendTF writes an infra/backend.tf with an S3 backend using key.
+func writeBackendTF(t *testing.T, projectDir, key string) {
+	t.Helper()
+	infraDir := filepath.Join(projectDir, "infra")
+	require.NoError(t, os.MkdirAll(infraDir, 0o755))
+	backend := fmt.Sprintf("terraform {\n  backend \"s3\" {\n    bucket = \"state\"\n    key    = %q\n  }\n}\n", key)
+	require.NoError(t, os.WriteFile(filepath.Join(infraDir, "backend.tf"), []byte(backend), 0o644))
+}
+
 // TestConventionTerraformInitV2 tests the event-based Terraform init stage.
 func TestConventionTerraformInitV2(t *testing.T) {
 	t.Run("initializes Terraform successfully with events", func(t *testing.T) {
@@ -22,7 +34,7 @@ func TestConventionTerraformInitV2(t *testing.T) {
 		}
 
 		state := State{ProjectDir: "/test/project"}
-		stage := ConventionTerraformInitV2(exec)
+		stage := ConventionTerraformInitV2(exec, "")
 		result := stage(t.Context(), state)
 
 		require.True(t, E.IsRight(result), "Should successfully initialize")
@@ -57,7 +69,7 @@ func TestConventionTerraformInitV2(t *testing.T) {
 		}
 
 		state := State{ProjectDir: "/test/project"}
-		stage := ConventionTerraformInitV2(exec)
+		stage := ConventionTerraformInitV2(exec, "")
 		result := stage(t.Context(), state)
 
 		require.True(t, E.IsLeft(result), "Should return error")
@@ -84,7 +96,7 @@ func TestConventionTerraformInitV2(t *testing.T) {
 			},
 		}
 
-		stage := ConventionTerraformInitV2(exec)
+		stage := ConventionTerraformInitV2(exec, "")
 		result := stage(t.Context(), state)
 
 		require.True(t, E.IsRight(result))
@@ -98,6 +110,113 @@ func TestConventionTerraformInitV2(t *testing.T) {
 		assert.Equal(t, state.ProjectDir, stageResult.State.ProjectDir)
 		assert.Equal(t, state.Artifacts, stageResult.State.Artifacts)
 	})
+
+	t.Run("initializes namespace with isolated state key", func(t *testing.T) {
+		var receivedDir string
+		var receivedConfig map[string]string
+		exec := TerraformExecutor{
+			InitWithBackend: func(ctx context.Context, dir string, backendConfig map[string]string) error {
+				receivedDir = dir
+				receivedConfig = backendConfig
+				return nil
+			},
+		}
+
+		state := State{ProjectDir: "/test/project"}
+		stage := ConventionTerraformInitV2(exec, "pr-123")
+		result := stage(t.Context(), state)
+
+		require.True(t, E.IsRight(result))
+		assert.Equal(t, "/test/project/infra", receivedDir)
+		assert.Equal(t, map[string]string{"key": "pr-123/terraform.tfstate"}, receivedConfig)
+
+		stageResult := E.Fold(
+			func(e error) StageResult { return StageResult{} },
+			func(r StageResult) StageResult { return r },
+		)(result)
+
+		messages := make([]string, 0, len(stageResult.Events))
+		for _, event := range stageResult.Events {
+			messages = append(messages, event.Message)
+		}
+		assert.Contains(t, messages, "Using isolated state: pr-123/terraform.tfstate")
+	})
+
+	t.Run("keeps the backend.tf key for the default namespace", func(t *testing.T) {
+		called := false
+		exec := TerraformExecutor{
+			Init: func(ctx context.Context, dir string) error {
+				called = true
+				return nil
+			},
+			InitWithBackend: func(ctx context.Context, dir string, backendConfig map[string]string) error {
+				t.Fatal("the default namespace should not override the backend config")
+				return nil
+			},
+		}
+
+		result := ConventionTerraformInitV2(exec, "")(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsRight(result))
+		assert.True(t, called, "Init should be called")
+	})
+
+	t.Run("derives the namespace key from the backend.tf key", func(t *testing.T) {
+		projectDir := t.TempDir()
+		writeBackendTF(t, projectDir, "services/api.tfstate")
+
+		var receivedConfig map[string]string
+		exec := TerraformExecutor{
+			InitWithBackend: func(ctx context.Context, dir string, backendConfig map[string]string) error {
+				receivedConfig = backendConfig
+				return nil
+			},
+		}
+
+		result := ConventionTerraformInitV2(exec, "pr-123")(t.Context(), State{ProjectDir: projectDir})
+
+		require.True(t, E.IsRight(result))
+		assert.Equal(t, map[string]string{"key": "pr-123/services/api.tfstate"}, receivedConfig)
+	})
+
+	t.Run("rejects namespaces outside their state key", func(t *testing.T) {
+		exec := TerraformExecutor{
+			InitWithBackend: func(ctx context.Context, dir string, backendConfig map[string]string) error {
+				t.Fatal("init should not run for an invalid namespace")
+				return nil
+			},
+		}
+
+		result := ConventionTerraformInitV2(exec, "../prod")(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsLeft(result))
+		err := E.Fold(
+			func(e error) error { return e },
+			func(r StageResult) error { return nil },
+		)(result)
+		assert.Contains(t, err.Error(), `invalid namespace "../prod"`)
+	})
+
+	t.Run("returns namespaced error when backend init fails", func(t *testing.T) {
+		exec := TerraformExecutor{
+			InitWithBackend: func(ctx context.Context, dir string, backendConfig map[string]string) error {
+				return errors.New("access denied")
+			},
+		}
+
+		stage := ConventionTerraformInitV2(exec, "pr-7")
+		result := stage(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsLeft(result))
+
+		err := E.Fold(
+			func(e error) error { return e },
+			func(r StageResult) error { return nil },
+		)(result)
+
+		assert.Contains(t, err.Error(), "terraform init failed for namespace pr-7")
+		assert.Contains(t, err.Error(), "access denied")
+	})
 }
 
 // TestConventionTerraformPlanV2 tests the event-based Terraform plan stage.
@@ -221,6 +340,159 @@ func TestConventionTerraformPlanV2(t *testing.T) {
 	})
 }
 
+// TestConventionTerraformPlanDestroyV2 tests the event-based destroy plan stage.
+func TestConventionTerraformPlanDestroyV2(t *testing.T) {
+	t.Run("lists resources that will be destroyed", func(t *testing.T) {
+		var receivedDir string
+		var receivedVars map[string]string
+		exec := TerraformExecutor{
+			PlanDestroy: func(ctx context.Context, dir string, vars map[string]string) ([]string, error) {
+				receivedDir = dir
+				receivedVars = vars
+				return []string{"aws_lambda_function.api", "aws_iam_role.api"}, nil
+			},
+		}
+
+		stage := ConventionTerraformPlanDestroyV2(exec, "pr-123")
+		result := stage(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsRight(result))
+		assert.Equal(t, "/test/project/infra", receivedDir)
+		assert.Equal(t, map[string]string{"namespace": "pr-123-"}, receivedVars)
+
+		stageResult := E.Fold(
+			func(e error) StageResult { return StageResult{} },
+			func(r StageResult) StageResult { return r },
+		)(result)
+
+		messages := make([]string, 0, len(stageResult.Events))
+		for _, event := range stageResult.Events {
+			messages = append(messages, event.Message)
+		}
+		assert.Contains(t, messages, "Destroying namespace: pr-123")
+		assert.Contains(t, messages, "[terraform] 2 resource(s) will be destroyed:")
+		assert.Contains(t, messages, "  - aws_lambda_function.api")
+		assert.Contains(t, messages, "  - aws_iam_role.api")
+	})
+
+	t.Run("reports when nothing will be destroyed", func(t *testing.T) {
+		var receivedVars map[string]string
+		exec := TerraformExecutor{
+			PlanDestroy: func(ctx context.Context, dir string, vars map[string]string) ([]string, error) {
+				receivedVars = vars
+				return nil, nil
+			},
+		}
+
+		stage := ConventionTerraformPlanDestroyV2(exec, "")
+		result := stage(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsRight(result))
+		assert.Nil(t, receivedVars)
+
+		stageResult := E.Fold(
+			func(e error) StageResult { return StageResult{} },
+			func(r StageResult) StageResult { return r },
+		)(result)
+
+		lastEvent := stageResult.Events[len(stageResult.Events)-1]
+		assert.Equal(t, "[terraform] No resources to destroy", lastEvent.Message)
+	})
+
+	t.Run("returns error when destroy plan fails", func(t *testing.T) {
+		exec := TerraformExecutor{
+			PlanDestroy: func(ctx context.Context, dir string, vars map[string]string) ([]string, error) {
+				return nil, errors.New("state locked")
+			},
+		}
+
+		stage := ConventionTerraformPlanDestroyV2(exec, "pr-1")
+		result := stage(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsLeft(result))
+
+		err := E.Fold(
+			func(e error) error { return e },
+			func(r StageResult) error { return nil },
+		)(result)
+
+		assert.Contains(t, err.Error(), "terraform destroy plan failed")
+		assert.Contains(t, err.Error(), "state locked")
+	})
+}
+
+// TestConventionStateCleanupV2 tests the namespace state cleanup stage.
+func TestConventionStateCleanupV2(t *testing.T) {
+	t.Run("deletes the namespace state object", func(t *testing.T) {
+		var deletedKey string
+		deleteState := func(ctx context.Context, key string) error {
+			deletedKey = key
+			return nil
+		}
+
+		stage := ConventionStateCleanupV2(deleteState, "pr-123")
+		result := stage(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsRight(result))
+		assert.Equal(t, "pr-123/terraform.tfstate", deletedKey)
+
+		stageResult := E.Fold(
+			func(e error) StageResult { return StageResult{} },
+			func(r StageResult) StageResult { return r },
+		)(result)
+
+		lastEvent := stageResult.Events[len(stageResult.Events)-1]
+		assert.Equal(t, EventLevelSuccess, lastEvent.Level)
+		assert.Equal(t, "[state] Deleted pr-123/terraform.tfstate", lastEvent.Message)
+	})
+
+	t.Run("deletes the key init selected under the backend.tf key", func(t *testing.T) {
+		projectDir := t.TempDir()
+		writeBackendTF(t, projectDir, "services/api.tfstate")
+
+		var deletedKey string
+		deleteState := func(ctx context.Context, key string) error {
+			deletedKey = key
+			return nil
+		}
+
+		result := ConventionStateCleanupV2(deleteState, "pr-123")(t.Context(), State{ProjectDir: projectDir})
+
+		require.True(t, E.IsRight(result))
+		assert.Equal(t, "pr-123/services/api.tfstate", deletedKey)
+	})
+
+	t.Run("never deletes the default state", func(t *testing.T) {
+		deleteState := func(ctx context.Context, key string) error {
+			t.Fatal("default state must not be deleted")
+			return nil
+		}
+
+		stage := ConventionStateCleanupV2(deleteState, "")
+		result := stage(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsRight(result))
+	})
+
+	t.Run("returns error when deletion fails", func(t *testing.T) {
+		deleteState := func(ctx context.Context, key string) error {
+			return errors.New("access denied")
+		}
+
+		stage := ConventionStateCleanupV2(deleteState, "pr-9")
+		result := stage(t.Context(), State{ProjectDir: "/test/project"})
+
+		require.True(t, E.IsLeft(result))
+
+		err := E.Fold(
+			func(e error) error { return e },
+			func(r StageResult) error { return nil },
+		)(result)
+
+		assert.Contains(t, err.Error(), "failed to delete state for namespace pr-9")
+	})
+}
+
 // TestConventionTerraformApplyV2 tests the event-based Terraform apply stage.
 func TestConventionTerraformApplyV2(t *testing.T) {
 	t.Run("executes apply successfully with auto-approve", func(t *testing.T) {
@@ -479,7 +751,7 @@ func TestTerraformPipelineV2Integration(t *testing.T) {
 		}
 
 		pipeline := NewEventPipeline(
-			ConventionTerraformInitV2(exec),
+			ConventionTerraformInitV2(exec, ""),
 			ConventionTerraformPlanV2(exec, ""),
 			ConventionTerraformApplyV2(exec, nil), // nil = auto-approve (no approval func)
 			ConventionTerraformOutputsV2(exec),
@@ -551,7 +823,7 @@ func TestTerraformPipelineV2Integration(t *testing.T) {
 		}
 
 		pipeline := NewEventPipeline(
-			ConventionTerraformInitV2(exec),
+			ConventionTerraformInitV2(exec, ""),
 			ConventionTerraformPlanV2(exec, ""),
 		)
 
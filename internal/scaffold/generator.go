@@ -5,19 +5,23 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/lewis/forge/internal/build"
 )
 
 // ProjectOptions configures project generation.
 type ProjectOptions struct {
-	Name   string
-	Region string
+	Name         string
+	Region       string
+	Architecture string // Default function architecture; empty means x86_64
 }
 
 // StackOptions configures stack generation.
 type StackOptions struct {
-	Name        string
-	Runtime     string
-	Description string
+	Name         string
+	Runtime      string
+	Description  string
+	Architecture string // Lambda architecture (x86_64 or arm64); empty means x86_64
 }
 
 // Pure function - no methods, takes projectRoot as parameter.
@@ -179,11 +183,21 @@ func generateJavaStack(stackDir string, opts *StackOptions) error {
 // Code generation functions
 
 func generateForgeHCL(opts *ProjectOptions) string {
-	return fmt.Sprintf(`project {
+	hcl := fmt.Sprintf(`project {
   name   = "%s"
   region = "%s"
 }
 `, opts.Name, opts.Region)
+
+	// Only non-default architectures need to be spelled out
+	if arch := build.NormalizeArchitecture(opts.Architecture); arch != build.ArchitectureX86_64 {
+		hcl += fmt.Sprintf(`
+defaults {
+  architecture = "%s"
+}
+`, arch)
+	}
+	return hcl
 }
 
 func generateGitignore() string {
@@ -232,11 +246,17 @@ func generateStackHCL(opts *StackOptions) string {
 		desc = opts.Name + " stack"
 	}
 	return fmt.Sprintf(`stack {
-  name        = "%s"
-  runtime     = "%s"
-  description = "%s"
+  name         = "%s"
+  runtime      = "%s"
+  architecture = "%s"
+  description  = "%s"
+}
+`, opts.Name, opts.Runtime, stackArchitecture(opts), desc)
 }
-`, opts.Name, opts.Runtime, desc)
+
+// stackArchitecture returns the stack's Lambda architecture, defaulting to x86_64.
+func stackArchitecture(opts *StackOptions) string {
+	return build.NormalizeArchitecture(opts.Architecture)
 }
 
 func generateGoMain(opts *StackOptions) string {
@@ -297,6 +317,7 @@ resource "aws_lambda_function" "%s" {
   role          = aws_iam_role.lambda.arn
   handler       = "bootstrap"
   runtime       = "%s"
+  architectures = ["%s"]
   filename      = "bootstrap.zip"
 
   environment {
@@ -336,7 +357,7 @@ output "function_name" {
 output "function_arn" {
   value = aws_lambda_function.%s.arn
 }
-`, opts.Name, opts.Name, opts.Runtime, opts.Name, opts.Name, opts.Name)
+`, opts.Name, opts.Name, opts.Runtime, stackArchitecture(opts), opts.Name, opts.Name, opts.Name)
 }
 
 func generatePythonHandler(opts *StackOptions) string {
@@ -379,6 +400,7 @@ resource "aws_lambda_function" "%s" {
   role          = aws_iam_role.lambda.arn
   handler       = "handler.handler"
   runtime       = "%s"
+  architectures = ["%s"]
   filename      = "function.zip"
 
   environment {
@@ -418,7 +440,7 @@ output "function_name" {
 output "function_arn" {
   value = aws_lambda_function.%s.arn
 }
-`, opts.Name, opts.Name, opts.Runtime, opts.Name, opts.Name, opts.Name)
+`, opts.Name, opts.Name, opts.Runtime, stackArchitecture(opts), opts.Name, opts.Name, opts.Name)
 }
 
 func generateNodeIndex(opts *StackOptions) string {
@@ -460,6 +482,7 @@ resource "aws_lambda_function" "%s" {
   role          = aws_iam_role.lambda.arn
   handler       = "index.handler"
   runtime       = "%s"
+  architectures = ["%s"]
   filename      = "function.zip"
 
   environment {
@@ -499,7 +522,7 @@ output "function_name" {
 output "function_arn" {
   value = aws_lambda_function.%s.arn
 }
-`, opts.Name, opts.Name, opts.Runtime, opts.Name, opts.Name, opts.Name)
+`, opts.Name, opts.Name, opts.Runtime, stackArchitecture(opts), opts.Name, opts.Name, opts.Name)
 }
 
 func generateJavaHandler(opts *StackOptions) string {
@@ -599,6 +622,7 @@ resource "aws_lambda_function" "%s" {
   role          = aws_iam_role.lambda.arn
   handler       = "com.example.Handler::handleRequest"
   runtime       = "%s"
+  architectures = ["%s"]
   filename      = "function.jar"
 
   environment {
@@ -638,5 +662,5 @@ output "function_name" {
 output "function_arn" {
   value = aws_lambda_function.%s.arn
 }
-`, opts.Name, opts.Name, opts.Runtime, opts.Name, opts.Name, opts.Name)
+`, opts.Name, opts.Name, opts.Runtime, stackArchitecture(opts), opts.Name, opts.Name, opts.Name)
 }
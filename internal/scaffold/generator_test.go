@@ -1055,3 +1055,35 @@ func TestGenerateHelperFunctions(t *testing.T) {
 		assert.Contains(t, pom, "maven-shade-plugin")
 	})
 }
+
+// TestArchitecture tests that the Lambda architecture reaches generated config and Terraform.
+func TestArchitecture(t *testing.T) {
+	t.Run("Terraform declares the architecture for every runtime", func(t *testing.T) {
+		generators := map[string]func(*StackOptions) string{
+			"go":     generateGoTerraform,
+			"python": generatePythonTerraform,
+			"node":   generateNodeTerraform,
+			"java":   generateJavaTerraform,
+		}
+		for name, generate := range generators {
+			arm := generate(&StackOptions{Name: "fn", Runtime: "x", Architecture: "arm64"})
+			assert.Contains(t, arm, `architectures = ["arm64"]`, name)
+
+			def := generate(&StackOptions{Name: "fn", Runtime: "x"})
+			assert.Contains(t, def, `architectures = ["x86_64"]`, name)
+		}
+	})
+
+	t.Run("stack config records the architecture", func(t *testing.T) {
+		hcl := generateStackHCL(&StackOptions{Name: "api", Runtime: "go1.x", Architecture: "aarch64"})
+		assert.Contains(t, hcl, `architecture = "arm64"`)
+	})
+
+	t.Run("forge.hcl only writes non-default architectures", func(t *testing.T) {
+		def := generateForgeHCL(&ProjectOptions{Name: "app", Region: "us-east-1"})
+		assert.NotContains(t, def, "defaults")
+
+		arm := generateForgeHCL(&ProjectOptions{Name: "app", Region: "us-east-1", Architecture: "arm64"})
+		assert.Contains(t, arm, "defaults {\n  architecture = \"arm64\"\n}")
+	})
+}
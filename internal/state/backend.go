@@ -2,7 +2,13 @@ package state
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // BackendConfig represents Terraform backend configuration (immutable data).
@@ -57,12 +63,96 @@ func GenerateLockTableName(projectName string) string {
 	return "forge_locks_" + normalized
 }
 
+// DefaultStateKey is the state key of the default namespace when backend.tf sets none.
+const DefaultStateKey = "terraform.tfstate"
+
 // Without namespace: terraform.tfstate.
 func GenerateStateKey(namespace string) string {
+	return NamespaceStateKey(DefaultStateKey, namespace)
+}
+
+// NamespaceStateKey returns the state key of a namespace under the backend's
+// configured key: {namespace}/{backendKey}, or backendKey itself without one.
+// PURE: Calculation.
+func NamespaceStateKey(backendKey, namespace string) string {
 	if namespace != "" {
-		return namespace + "/terraform.tfstate"
+		return namespace + "/" + backendKey
+	}
+	return backendKey
+}
+
+// ValidateNamespace rejects namespaces that would reach outside their own state key.
+// PURE: Calculation.
+func ValidateNamespace(namespace string) error {
+	if strings.Contains(namespace, "/") || strings.Contains(namespace, "..") {
+		return fmt.Errorf("invalid namespace %q: must not contain '/' or '..'", namespace)
+	}
+	return nil
+}
+
+// NamespaceBackendConfig returns the partial backend settings that isolate a namespace's state.
+// PURE: Returns nil for the default namespace so callers keep the backend.tf key.
+func NamespaceBackendConfig(backendKey, namespace string) map[string]string {
+	if namespace == "" {
+		return nil
+	}
+	return map[string]string{"key": NamespaceStateKey(backendKey, namespace)}
+}
+
+// ReadBackendKey returns the key the backend block in infraDir's .tf files
+// configures, or DefaultStateKey when there is no backend block or it leaves
+// the key to -backend-config.
+// ACTION: Performs I/O (reads infraDir).
+func ReadBackendKey(infraDir string) (string, error) {
+	entries, err := os.ReadDir(infraDir)
+	if os.IsNotExist(err) {
+		return DefaultStateKey, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", infraDir, err)
+	}
+
+	parser := hclparse.NewParser()
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tf" {
+			continue
+		}
+		file, diags := parser.ParseHCLFile(filepath.Join(infraDir, entry.Name()))
+		if diags.HasErrors() {
+			return "", fmt.Errorf("failed to parse %s: %w", entry.Name(), diags)
+		}
+		if key, ok := backendKey(file.Body); ok {
+			return key, nil
+		}
+	}
+	return DefaultStateKey, nil
+}
+
+// backendKey returns the literal key argument of a terraform { backend "..." }
+// block in body.
+// PURE: Calculation.
+func backendKey(body hcl.Body) (string, bool) {
+	content, _, _ := body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+	})
+	for _, terraform := range content.Blocks {
+		settings, _, _ := terraform.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "backend", LabelNames: []string{"type"}}},
+		})
+		for _, backend := range settings.Blocks {
+			attrs, _ := backend.Body.JustAttributes()
+			attr, ok := attrs["key"]
+			if !ok {
+				continue
+			}
+			value, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+				continue
+			}
+			return value.AsString(), true
+		}
 	}
-	return "terraform.tfstate"
+	return "", false
 }
 
 // GenerateS3BucketSpec creates S3 bucket specification (PURE calculation).
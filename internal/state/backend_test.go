@@ -2,6 +2,8 @@ package state
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -118,6 +120,85 @@ func TestGenerateStateKey(t *testing.T) {
 	}
 }
 
+// TestNamespaceBackendConfig tests partial backend config generation (PURE function).
+func TestNamespaceBackendConfig(t *testing.T) {
+	if got := NamespaceBackendConfig("terraform.tfstate", ""); got != nil {
+		t.Errorf("NamespaceBackendConfig(\"\") = %v, want nil", got)
+	}
+
+	got := NamespaceBackendConfig("terraform.tfstate", "pr-123")
+	if len(got) != 1 || got["key"] != "pr-123/terraform.tfstate" {
+		t.Errorf("NamespaceBackendConfig(\"pr-123\") = %v, want key=pr-123/terraform.tfstate", got)
+	}
+
+	got = NamespaceBackendConfig("services/api.tfstate", "pr-123")
+	if got["key"] != "pr-123/services/api.tfstate" {
+		t.Errorf("NamespaceBackendConfig(\"pr-123\") = %v, want key=pr-123/services/api.tfstate", got)
+	}
+}
+
+// TestReadBackendKey tests reading the configured state key from infra/.
+func TestReadBackendKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{
+			name:  "no backend block",
+			files: map[string]string{"main.tf": "resource \"null_resource\" \"x\" {}\n"},
+			want:  "terraform.tfstate",
+		},
+		{
+			name:  "partial backend config",
+			files: map[string]string{"backend.tf": "terraform {\n  backend \"s3\" {\n    bucket = \"state\"\n  }\n}\n"},
+			want:  "terraform.tfstate",
+		},
+		{
+			name:  "configured key",
+			files: map[string]string{"backend.tf": "terraform {\n  backend \"s3\" {\n    key = \"services/api.tfstate\"\n  }\n}\n"},
+			want:  "services/api.tfstate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := ReadBackendKey(dir)
+			if err != nil {
+				t.Fatalf("ReadBackendKey() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ReadBackendKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got, err := ReadBackendKey(filepath.Join(t.TempDir(), "missing")); err != nil || got != "terraform.tfstate" {
+		t.Errorf("ReadBackendKey(missing) = %v, %v, want terraform.tfstate", got, err)
+	}
+}
+
+// TestValidateNamespace tests namespace validation (PURE function).
+func TestValidateNamespace(t *testing.T) {
+	for _, namespace := range []string{"", "pr-123", "staging"} {
+		if err := ValidateNamespace(namespace); err != nil {
+			t.Errorf("ValidateNamespace(%q) = %v, want nil", namespace, err)
+		}
+	}
+	for _, namespace := range []string{"pr/123", "../prod", "pr-1..2"} {
+		if err := ValidateNamespace(namespace); err == nil {
+			t.Errorf("ValidateNamespace(%q) = nil, want error", namespace)
+		}
+	}
+}
+
 // TestGenerateS3BucketSpec tests S3 bucket specification generation (PURE function).
 func TestGenerateS3BucketSpec(t *testing.T) {
 	projectName := "test-app"
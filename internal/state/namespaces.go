@@ -0,0 +1,202 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NamespaceInfo describes one namespaced environment found in the state backend (immutable data).
+type NamespaceInfo struct {
+	Name          string
+	Key           string
+	LastModified  time.Time
+	ResourceCount int
+	Tags          map[string]string
+	// Err is why the state object could not be read or parsed, in which case
+	// ResourceCount and Tags are unknown.
+	Err error
+}
+
+// StateSummary is what Forge reads out of a terraform.tfstate file (immutable data).
+type StateSummary struct {
+	ResourceCount int
+	Tags          map[string]string
+}
+
+// GCPolicy decides which namespaces are stale (immutable data).
+// A zero TTL disables age-based collection.
+type GCPolicy struct {
+	TTL       time.Duration
+	ClosedPRs []int
+	Now       time.Time
+}
+
+// prNamespacePattern matches namespaces created for pull requests: pr-123 or pr123.
+var prNamespacePattern = regexp.MustCompile(`(?i)^pr-?(\d+)$`)
+
+// NamespaceFromKey extracts the namespace from a state key.
+// Inverse of GenerateStateKey: {namespace}/terraform.tfstate → namespace.
+// Returns false for the default key and for anything that is not a namespace state file.
+func NamespaceFromKey(key string) (string, bool) {
+	namespace, ok := strings.CutSuffix(key, "/terraform.tfstate")
+	if !ok || namespace == "" || strings.Contains(namespace, "/") {
+		return "", false
+	}
+	return namespace, true
+}
+
+// PRNumber returns the pull request number encoded in a namespace name.
+func PRNumber(namespace string) (int, bool) {
+	match := prNamespacePattern.FindStringSubmatch(namespace)
+	if match == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// tfState is the subset of the Terraform v4 state format Forge inspects.
+type tfState struct {
+	Resources []struct {
+		Mode      string `json:"mode"`
+		Instances []struct {
+			Attributes map[string]json.RawMessage `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// SummarizeState counts managed resource instances and finds the owning tags.
+// PURE: Owning tags are the tags every tagged resource instance agrees on.
+func SummarizeState(data []byte) (StateSummary, error) {
+	var st tfState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return StateSummary{}, fmt.Errorf("failed to parse state: %w", err)
+	}
+
+	count := 0
+	var owning map[string]string
+	for _, resource := range st.Resources {
+		if resource.Mode != "managed" {
+			continue
+		}
+		for _, instance := range resource.Instances {
+			count++
+
+			raw, ok := instance.Attributes["tags"]
+			if !ok {
+				continue
+			}
+			var tags map[string]string
+			if err := json.Unmarshal(raw, &tags); err != nil || len(tags) == 0 {
+				continue
+			}
+			owning = intersectTags(owning, tags)
+		}
+	}
+
+	if owning == nil {
+		owning = map[string]string{}
+	}
+
+	return StateSummary{ResourceCount: count, Tags: owning}, nil
+}
+
+// intersectTags keeps the entries present with the same value in both maps.
+// PURE: A nil accumulator means no tagged resource has been seen yet.
+func intersectTags(acc, tags map[string]string) map[string]string {
+	if acc == nil {
+		result := make(map[string]string, len(tags))
+		for k, v := range tags {
+			result[k] = v
+		}
+		return result
+	}
+
+	result := make(map[string]string, len(acc))
+	for k, v := range acc {
+		if tags[k] == v {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// ListNamespaces reads every namespace state object from the store (ACTION - I/O).
+// Results are sorted by namespace name. A state object that cannot be read or
+// parsed is listed with its error in Err; only failing to list the store is fatal.
+func ListNamespaces(ctx context.Context, store Store) ([]NamespaceInfo, error) {
+	objects, err := store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := []NamespaceInfo{}
+	for _, obj := range objects {
+		name, ok := NamespaceFromKey(obj.Key)
+		if !ok {
+			continue
+		}
+
+		info := NamespaceInfo{Name: name, Key: obj.Key, LastModified: obj.LastModified}
+		summary, err := readStateSummary(ctx, store, obj.Key)
+		if err != nil {
+			info.Err = err
+		} else {
+			info.ResourceCount = summary.ResourceCount
+			info.Tags = summary.Tags
+		}
+		namespaces = append(namespaces, info)
+	}
+
+	sort.Slice(namespaces, func(i, j int) bool { return namespaces[i].Name < namespaces[j].Name })
+	return namespaces, nil
+}
+
+// readStateSummary reads and summarizes one state object (ACTION - I/O).
+func readStateSummary(ctx context.Context, store Store, key string) (StateSummary, error) {
+	data, err := store.Read(ctx, key)
+	if err != nil {
+		return StateSummary{}, err
+	}
+	return SummarizeState(data)
+}
+
+// SelectStaleNamespaces returns the namespaces a GC run should destroy, with the reason for each.
+// PURE: A namespace is stale when it is older than the TTL or belongs to a closed pull request.
+// Namespaces whose state could not be read are never selected.
+func SelectStaleNamespaces(namespaces []NamespaceInfo, policy GCPolicy) ([]NamespaceInfo, map[string]string) {
+	closed := make(map[int]bool, len(policy.ClosedPRs))
+	for _, pr := range policy.ClosedPRs {
+		closed[pr] = true
+	}
+
+	stale := []NamespaceInfo{}
+	reasons := map[string]string{}
+	for _, ns := range namespaces {
+		if ns.Err != nil {
+			continue
+		}
+		if pr, ok := PRNumber(ns.Name); ok && closed[pr] {
+			stale = append(stale, ns)
+			reasons[ns.Name] = fmt.Sprintf("PR #%d is closed", pr)
+			continue
+		}
+		if policy.TTL > 0 {
+			if age := policy.Now.Sub(ns.LastModified); age > policy.TTL {
+				stale = append(stale, ns)
+				reasons[ns.Name] = fmt.Sprintf("last modified %s ago (ttl %s)", age.Round(time.Minute), policy.TTL)
+			}
+		}
+	}
+
+	return stale, reasons
+}
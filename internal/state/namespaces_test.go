@@ -0,0 +1,205 @@
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const taggedState = `{
+  "version": 4,
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_lambda_function",
+      "name": "api",
+      "instances": [
+        {"attributes": {"tags": {"Project": "app", "Namespace": "pr-1", "Owner": "alice"}}}
+      ]
+    },
+    {
+      "mode": "managed",
+      "type": "aws_sqs_queue",
+      "name": "jobs",
+      "instances": [
+        {"attributes": {"tags": {"Project": "app", "Namespace": "pr-1", "Owner": "bob"}}},
+        {"attributes": {"tags": null}}
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "aws_caller_identity",
+      "name": "current",
+      "instances": [{"attributes": {}}]
+    }
+  ]
+}`
+
+// TestNamespaceFromKey tests extracting namespaces from state keys (PURE function).
+func TestNamespaceFromKey(t *testing.T) {
+	tests := []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{key: "pr-123/terraform.tfstate", want: "pr-123", wantOK: true},
+		{key: "staging/terraform.tfstate", want: "staging", wantOK: true},
+		{key: "terraform.tfstate", wantOK: false},
+		{key: "a/b/terraform.tfstate", wantOK: false},
+		{key: "pr-123/other.json", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := NamespaceFromKey(tt.key)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("round-trips GenerateStateKey", func(t *testing.T) {
+		got, ok := NamespaceFromKey(GenerateStateKey("pr-42"))
+		assert.True(t, ok)
+		assert.Equal(t, "pr-42", got)
+	})
+}
+
+// TestPRNumber tests PR number extraction from namespace names (PURE function).
+func TestPRNumber(t *testing.T) {
+	n, ok := PRNumber("pr-123")
+	assert.True(t, ok)
+	assert.Equal(t, 123, n)
+
+	n, ok = PRNumber("PR77")
+	assert.True(t, ok)
+	assert.Equal(t, 77, n)
+
+	_, ok = PRNumber("staging-2")
+	assert.False(t, ok)
+
+	_, ok = PRNumber("pr-abc")
+	assert.False(t, ok)
+}
+
+// TestSummarizeState tests resource counting and owning tag detection (PURE function).
+func TestSummarizeState(t *testing.T) {
+	t.Run("counts managed instances and intersects tags", func(t *testing.T) {
+		summary, err := SummarizeState([]byte(taggedState))
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, summary.ResourceCount)
+		assert.Equal(t, map[string]string{"Project": "app", "Namespace": "pr-1"}, summary.Tags)
+	})
+
+	t.Run("handles empty state", func(t *testing.T) {
+		summary, err := SummarizeState([]byte(`{"version": 4, "resources": []}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, summary.ResourceCount)
+		assert.Empty(t, summary.Tags)
+	})
+
+	t.Run("rejects invalid JSON", func(t *testing.T) {
+		_, err := SummarizeState([]byte("not json"))
+		assert.Error(t, err)
+	})
+}
+
+// TestListNamespaces tests listing namespaces against a filesystem-backed store.
+func TestListNamespaces(t *testing.T) {
+	t.Run("lists namespaces sorted by name and skips default state", func(t *testing.T) {
+		root := t.TempDir()
+		writeStateFile(t, root, "terraform.tfstate", taggedState)
+		writeStateFile(t, root, "pr-2/terraform.tfstate", `{"version": 4, "resources": []}`)
+		writeStateFile(t, root, "pr-1/terraform.tfstate", taggedState)
+
+		modified := time.Date(2025, 1, 2, 3, 4, 0, 0, time.UTC)
+		require.NoError(t, os.Chtimes(filepath.Join(root, "pr-1", "terraform.tfstate"), modified, modified))
+
+		namespaces, err := ListNamespaces(t.Context(), NewFileStore(root))
+
+		require.NoError(t, err)
+		require.Len(t, namespaces, 2)
+		assert.Equal(t, "pr-1", namespaces[0].Name)
+		assert.Equal(t, "pr-1/terraform.tfstate", namespaces[0].Key)
+		assert.Equal(t, 3, namespaces[0].ResourceCount)
+		assert.True(t, modified.Equal(namespaces[0].LastModified))
+		assert.Equal(t, "app", namespaces[0].Tags["Project"])
+		assert.Equal(t, "pr-2", namespaces[1].Name)
+		assert.Equal(t, 0, namespaces[1].ResourceCount)
+	})
+
+	t.Run("reports unreadable state per namespace and keeps going", func(t *testing.T) {
+		root := t.TempDir()
+		writeStateFile(t, root, "pr-1/terraform.tfstate", "garbage")
+		writeStateFile(t, root, "pr-2/terraform.tfstate", taggedState)
+
+		namespaces, err := ListNamespaces(t.Context(), NewFileStore(root))
+
+		require.NoError(t, err)
+		require.Len(t, namespaces, 2)
+		assert.Equal(t, "pr-1", namespaces[0].Name)
+		require.Error(t, namespaces[0].Err)
+		assert.Contains(t, namespaces[0].Err.Error(), "failed to parse state")
+		assert.Equal(t, "pr-2", namespaces[1].Name)
+		assert.NoError(t, namespaces[1].Err)
+		assert.Equal(t, 3, namespaces[1].ResourceCount)
+	})
+}
+
+// TestSelectStaleNamespaces tests GC selection (PURE function).
+func TestSelectStaleNamespaces(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	namespaces := []NamespaceInfo{
+		{Name: "pr-1", LastModified: now.Add(-1 * time.Hour)},
+		{Name: "pr-2", LastModified: now.Add(-10 * 24 * time.Hour)},
+		{Name: "staging", LastModified: now.Add(-30 * 24 * time.Hour)},
+		{Name: "pr-3", LastModified: now.Add(-2 * time.Hour)},
+	}
+
+	t.Run("selects by TTL", func(t *testing.T) {
+		stale, reasons := SelectStaleNamespaces(namespaces, GCPolicy{TTL: 7 * 24 * time.Hour, Now: now})
+
+		require.Len(t, stale, 2)
+		assert.Equal(t, "pr-2", stale[0].Name)
+		assert.Equal(t, "staging", stale[1].Name)
+		assert.Contains(t, reasons["pr-2"], "ttl 168h0m0s")
+	})
+
+	t.Run("selects closed PRs regardless of age", func(t *testing.T) {
+		stale, reasons := SelectStaleNamespaces(namespaces, GCPolicy{ClosedPRs: []int{3, 99}, Now: now})
+
+		require.Len(t, stale, 1)
+		assert.Equal(t, "pr-3", stale[0].Name)
+		assert.Equal(t, "PR #3 is closed", reasons["pr-3"])
+	})
+
+	t.Run("combines TTL and closed PRs without duplicates", func(t *testing.T) {
+		stale, _ := SelectStaleNamespaces(namespaces, GCPolicy{TTL: 7 * 24 * time.Hour, ClosedPRs: []int{1, 2}, Now: now})
+
+		names := make([]string, 0, len(stale))
+		for _, ns := range stale {
+			names = append(names, ns.Name)
+		}
+		assert.Equal(t, []string{"pr-1", "pr-2", "staging"}, names)
+	})
+
+	t.Run("never selects namespaces it could not read", func(t *testing.T) {
+		unreadable := []NamespaceInfo{
+			{Name: "pr-4", LastModified: now.Add(-30 * 24 * time.Hour), Err: errors.New("access denied")},
+		}
+
+		stale, _ := SelectStaleNamespaces(unreadable, GCPolicy{TTL: time.Hour, ClosedPRs: []int{4}, Now: now})
+		assert.Empty(t, stale)
+	})
+
+	t.Run("selects nothing with an empty policy", func(t *testing.T) {
+		stale, _ := SelectStaleNamespaces(namespaces, GCPolicy{Now: now})
+		assert.Empty(t, stale)
+	})
+}
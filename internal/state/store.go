@@ -0,0 +1,178 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	dynamotypes "github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// StateObject describes a state file stored in the backend (immutable data).
+type StateObject struct {
+	Key          string
+	LastModified time.Time
+	Size         int64
+}
+
+// ListStateFunc lists every state object in the backend.
+type ListStateFunc func(ctx context.Context) ([]StateObject, error)
+
+// ReadStateFunc reads the raw contents of a state object.
+type ReadStateFunc func(ctx context.Context, key string) ([]byte, error)
+
+// DeleteStateFunc removes a state object from the backend.
+type DeleteStateFunc func(ctx context.Context, key string) error
+
+// Store is a collection of state backend operations (functions as fields, no methods).
+type Store struct {
+	List   ListStateFunc
+	Read   ReadStateFunc
+	Delete DeleteStateFunc
+}
+
+// NewS3Store creates a Store backed by the project's S3 bucket and lock table (ACTION - I/O).
+func NewS3Store(ctx context.Context, backend BackendConfig) (Store, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(backend.Region))
+	if err != nil {
+		return Store{}, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	s3Client := s3.NewFromConfig(awsCfg)
+	dynamoClient := dynamodb.NewFromConfig(awsCfg)
+
+	return Store{
+		List: func(ctx context.Context) ([]StateObject, error) {
+			objects := []StateObject{}
+			paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+				Bucket: aws.String(backend.Bucket),
+			})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list s3://%s: %w", backend.Bucket, err)
+				}
+				for _, obj := range page.Contents {
+					objects = append(objects, StateObject{
+						Key:          aws.ToString(obj.Key),
+						LastModified: aws.ToTime(obj.LastModified),
+						Size:         aws.ToInt64(obj.Size),
+					})
+				}
+			}
+			return objects, nil
+		},
+
+		Read: func(ctx context.Context, key string) ([]byte, error) {
+			resp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: aws.String(backend.Bucket),
+				Key:    aws.String(key),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read s3://%s/%s: %w", backend.Bucket, key, err)
+			}
+			defer resp.Body.Close()
+			return io.ReadAll(resp.Body)
+		},
+
+		Delete: func(ctx context.Context, key string) error {
+			if _, err := s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+				Bucket: aws.String(backend.Bucket),
+				Key:    aws.String(key),
+			}); err != nil {
+				return fmt.Errorf("failed to delete s3://%s/%s: %w", backend.Bucket, key, err)
+			}
+
+			if backend.DynamoDBTable == "" {
+				return nil
+			}
+
+			// Terraform keeps an MD5 digest of each state object in the lock table.
+			// A stale digest makes the next init of the same namespace fail.
+			if _, err := dynamoClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+				TableName: aws.String(backend.DynamoDBTable),
+				Key: map[string]dynamotypes.AttributeValue{
+					"LockID": &dynamotypes.AttributeValueMemberS{Value: StateDigestLockID(backend.Bucket, key)},
+				},
+			}); err != nil {
+				return fmt.Errorf("failed to delete state digest for %s: %w", key, err)
+			}
+
+			return nil
+		},
+	}, nil
+}
+
+// NewFileStore creates a Store that treats a local directory as the state bucket (ACTION - I/O).
+// Object keys are paths relative to root, so {root}/pr-123/terraform.tfstate is key pr-123/terraform.tfstate.
+// Useful for tests and for inspecting a synced copy of the bucket offline.
+func NewFileStore(root string) Store {
+	return Store{
+		List: func(ctx context.Context) ([]StateObject, error) {
+			objects := []StateObject{}
+			err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() {
+					return nil
+				}
+				info, err := d.Info()
+				if err != nil {
+					return err
+				}
+				rel, err := filepath.Rel(root, path)
+				if err != nil {
+					return err
+				}
+				objects = append(objects, StateObject{
+					Key:          filepath.ToSlash(rel),
+					LastModified: info.ModTime(),
+					Size:         info.Size(),
+				})
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list %s: %w", root, err)
+			}
+			sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+			return objects, nil
+		},
+
+		Read: func(ctx context.Context, key string) ([]byte, error) {
+			data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(key)))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", key, err)
+			}
+			return data, nil
+		},
+
+		Delete: func(ctx context.Context, key string) error {
+			path := filepath.Join(root, filepath.FromSlash(key))
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to delete %s: %w", key, err)
+			}
+			// Drop the namespace directory once it is empty, like an S3 prefix would disappear
+			if dir := filepath.Dir(path); dir != filepath.Clean(root) {
+				_ = os.Remove(dir)
+			}
+			return nil
+		},
+	}
+}
+
+// StateDigestLockID returns the lock table item Terraform uses for a state object's digest.
+// Pattern: {bucket}/{key}-md5.
+func StateDigestLockID(bucket, key string) string {
+	return bucket + "/" + key + "-md5"
+}
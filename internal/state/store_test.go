@@ -0,0 +1,74 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStateDigestLockID tests lock table digest ID generation (PURE function).
+func TestStateDigestLockID(t *testing.T) {
+	got := StateDigestLockID("forge-state-my-app", "pr-123/terraform.tfstate")
+	want := "forge-state-my-app/pr-123/terraform.tfstate-md5"
+	if got != want {
+		t.Errorf("StateDigestLockID() = %v, want %v", got, want)
+	}
+}
+
+// writeStateFile writes a state object into a filesystem-backed bucket.
+func writeStateFile(t *testing.T, root, key, content string) {
+	t.Helper()
+	path := filepath.Join(root, filepath.FromSlash(key))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// TestNewFileStore tests the filesystem-backed state store.
+func TestNewFileStore(t *testing.T) {
+	t.Run("lists objects with keys relative to root", func(t *testing.T) {
+		root := t.TempDir()
+		writeStateFile(t, root, "terraform.tfstate", "{}")
+		writeStateFile(t, root, "pr-2/terraform.tfstate", "{}")
+		writeStateFile(t, root, "pr-1/terraform.tfstate", "{}")
+
+		objects, err := NewFileStore(root).List(t.Context())
+
+		require.NoError(t, err)
+		keys := make([]string, 0, len(objects))
+		for _, obj := range objects {
+			keys = append(keys, obj.Key)
+			assert.False(t, obj.LastModified.IsZero())
+			assert.Equal(t, int64(2), obj.Size)
+		}
+		assert.Equal(t, []string{"pr-1/terraform.tfstate", "pr-2/terraform.tfstate", "terraform.tfstate"}, keys)
+	})
+
+	t.Run("reads object contents", func(t *testing.T) {
+		root := t.TempDir()
+		writeStateFile(t, root, "pr-1/terraform.tfstate", `{"version":4}`)
+
+		data, err := NewFileStore(root).Read(t.Context(), "pr-1/terraform.tfstate")
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"version":4}`, string(data))
+	})
+
+	t.Run("deletes object and its empty namespace directory", func(t *testing.T) {
+		root := t.TempDir()
+		writeStateFile(t, root, "pr-1/terraform.tfstate", "{}")
+
+		err := NewFileStore(root).Delete(t.Context(), "pr-1/terraform.tfstate")
+
+		require.NoError(t, err)
+		assert.NoDirExists(t, filepath.Join(root, "pr-1"))
+		assert.DirExists(t, root)
+	})
+
+	t.Run("deleting a missing object is not an error", func(t *testing.T) {
+		err := NewFileStore(t.TempDir()).Delete(t.Context(), "pr-404/terraform.tfstate")
+		assert.NoError(t, err)
+	})
+}
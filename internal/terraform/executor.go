@@ -12,8 +12,30 @@ type (
 	DestroyFunc  func(ctx context.Context, dir string, opts ...DestroyOption) error
 	OutputFunc   func(ctx context.Context, dir string) (map[string]interface{}, error)
 	ValidateFunc func(ctx context.Context, dir string) error
+	ShowPlanFunc func(ctx context.Context, dir, planFile string) ([]PlannedChange, error)
 )
 
+// PlannedChange is a single resource change recorded in a saved plan (immutable data).
+type PlannedChange struct {
+	Address string
+	Actions []string
+}
+
+// DeletedAddresses returns the addresses of resources a plan removes, including replacements.
+// PURE: Preserves plan order.
+func DeletedAddresses(changes []PlannedChange) []string {
+	addresses := []string{}
+	for _, change := range changes {
+		for _, action := range change.Actions {
+			if action == "delete" {
+				addresses = append(addresses, change.Address)
+				break
+			}
+		}
+	}
+	return addresses
+}
+
 // Executor is a collection of terraform operation functions.
 type Executor struct {
 	Init     InitFunc
@@ -22,6 +44,7 @@ type Executor struct {
 	Destroy  DestroyFunc
 	Output   OutputFunc
 	Validate ValidateFunc
+	ShowPlan ShowPlanFunc
 }
 
 // NewExecutor creates a real terraform executor using terraform-exec.
@@ -33,6 +56,7 @@ func NewExecutor(tfPath string) Executor {
 		Destroy:  makeDestroyFunc(tfPath),
 		Output:   makeOutputFunc(tfPath),
 		Validate: makeValidateFunc(tfPath),
+		ShowPlan: makeShowPlanFunc(tfPath),
 	}
 }
 
@@ -57,5 +81,8 @@ func NewMockExecutor() Executor {
 		Validate: func(ctx context.Context, dir string) error {
 			return nil
 		},
+		ShowPlan: func(ctx context.Context, dir, planFile string) ([]PlannedChange, error) {
+			return nil, nil
+		},
 	}
 }
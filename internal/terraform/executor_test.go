@@ -48,6 +48,30 @@ func TestMockExecutor(t *testing.T) {
 		err := exec.Validate(t.Context(), "/tmp/test")
 		assert.NoError(t, err)
 	})
+
+	t.Run("ShowPlan should return no changes by default", func(t *testing.T) {
+		exec := NewMockExecutor()
+		changes, err := exec.ShowPlan(t.Context(), "/tmp/test", "tfplan")
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
+
+// TestDeletedAddresses tests filtering planned changes down to removals.
+func TestDeletedAddresses(t *testing.T) {
+	t.Run("returns deletes and replacements in plan order", func(t *testing.T) {
+		changes := []PlannedChange{
+			{Address: "aws_lambda_function.api", Actions: []string{"delete"}},
+			{Address: "aws_iam_role.lambda", Actions: []string{"update"}},
+			{Address: "aws_sqs_queue.jobs", Actions: []string{"create", "delete"}},
+		}
+
+		assert.Equal(t, []string{"aws_lambda_function.api", "aws_sqs_queue.jobs"}, DeletedAddresses(changes))
+	})
+
+	t.Run("returns empty slice when nothing is removed", func(t *testing.T) {
+		assert.Empty(t, DeletedAddresses(nil))
+	})
 }
 
 // TestMockExecutorCustomBehavior tests customizing mock behavior.
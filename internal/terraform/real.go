@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-exec/tfexec"
+	tfjson "github.com/hashicorp/terraform-json"
 )
 
 // makeInitFunc returns a closure that executes terraform init.
@@ -28,6 +30,9 @@ func makeInitFunc(tfPath string) InitFunc {
 		if cfg.Reconfigure {
 			tfOpts = append(tfOpts, tfexec.Reconfigure(true))
 		}
+		for _, bc := range cfg.BackendConfig {
+			tfOpts = append(tfOpts, tfexec.BackendConfig(bc))
+		}
 
 		return tf.Init(ctx, tfOpts...)
 	}
@@ -53,6 +58,9 @@ func makePlanFunc(tfPath string) PlanFunc {
 		if cfg.VarFile != "" {
 			tfOpts = append(tfOpts, tfexec.VarFile(cfg.VarFile))
 		}
+		for _, v := range varAssignments(cfg.Vars) {
+			tfOpts = append(tfOpts, tfexec.Var(v))
+		}
 
 		return tf.Plan(ctx, tfOpts...)
 	}
@@ -79,7 +87,7 @@ func makeApplyFunc(tfPath string) ApplyFunc {
 			tfOpts = append(tfOpts, tfexec.VarFile(cfg.VarFile))
 		}
 		if cfg.PlanFile != "" {
-			_, _, _, _ = tfOpts, tfOpts, tfexec.DirOrPlan, cfg.PlanFile
+			tfOpts = append(tfOpts, tfexec.DirOrPlan(cfg.PlanFile))
 		}
 
 		return tf.Apply(ctx, tfOpts...)
@@ -150,3 +158,52 @@ func makeValidateFunc(tfPath string) ValidateFunc {
 		return err
 	}
 }
+
+// makeShowPlanFunc returns a closure that reads the resource changes from a saved plan.
+func makeShowPlanFunc(tfPath string) ShowPlanFunc {
+	return func(ctx context.Context, dir, planFile string) ([]PlannedChange, error) {
+		tf, err := tfexec.NewTerraform(dir, tfPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create terraform: %w", err)
+		}
+
+		plan, err := tf.ShowPlanFile(ctx, planFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read plan %s: %w", planFile, err)
+		}
+
+		return plannedChanges(plan), nil
+	}
+}
+
+// plannedChanges converts terraform-json resource changes to PlannedChange values.
+// PURE: No-op changes are dropped.
+func plannedChanges(plan *tfjson.Plan) []PlannedChange {
+	if plan == nil {
+		return nil
+	}
+
+	changes := make([]PlannedChange, 0, len(plan.ResourceChanges))
+	for _, rc := range plan.ResourceChanges {
+		if rc == nil || rc.Change == nil || rc.Change.Actions.NoOp() {
+			continue
+		}
+		actions := make([]string, 0, len(rc.Change.Actions))
+		for _, action := range rc.Change.Actions {
+			actions = append(actions, string(action))
+		}
+		changes = append(changes, PlannedChange{Address: rc.Address, Actions: actions})
+	}
+	return changes
+}
+
+// varAssignments converts a variable map to sorted "key=value" assignments.
+// PURE: Sorted for deterministic command lines.
+func varAssignments(vars map[string]string) []string {
+	assignments := make([]string, 0, len(vars))
+	for k, v := range vars {
+		assignments = append(assignments, k+"="+v)
+	}
+	sort.Strings(assignments)
+	return assignments
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,6 +22,7 @@ func TestNewExecutor(t *testing.T) {
 		assert.NotNil(t, exec.Destroy, "Destroy function should be set")
 		assert.NotNil(t, exec.Output, "Output function should be set")
 		assert.NotNil(t, exec.Validate, "Validate function should be set")
+		assert.NotNil(t, exec.ShowPlan, "ShowPlan function should be set")
 	})
 
 	t.Run("creates executor with custom terraform path", func(t *testing.T) {
@@ -772,3 +774,39 @@ output "test_output" {
 		// Just verify the function accepts all options
 	})
 }
+
+// TestMakeShowPlanFunc tests the makeShowPlanFunc closure.
+func TestMakeShowPlanFunc(t *testing.T) {
+	t.Run("returns error for non-existent directory", func(t *testing.T) {
+		showPlanFunc := makeShowPlanFunc("terraform")
+
+		_, err := showPlanFunc(t.Context(), "/nonexistent/directory/path/12345", "tfplan")
+
+		assert.Error(t, err, "Should error for non-existent directory")
+		assert.Contains(t, err.Error(), "failed to create terraform")
+	})
+}
+
+// TestPlannedChanges tests conversion of terraform-json plans.
+func TestPlannedChanges(t *testing.T) {
+	t.Run("returns nil for nil plan", func(t *testing.T) {
+		assert.Nil(t, plannedChanges(nil))
+	})
+
+	t.Run("keeps actionable changes in plan order", func(t *testing.T) {
+		plan := &tfjson.Plan{
+			ResourceChanges: []*tfjson.ResourceChange{
+				{Address: "aws_lambda_function.api", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete}}},
+				{Address: "aws_iam_role.lambda", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}}},
+				{Address: "aws_sqs_queue.jobs", Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}}},
+				{Address: "aws_s3_bucket.missing"},
+			},
+		}
+
+		changes := plannedChanges(plan)
+
+		require.Len(t, changes, 2)
+		assert.Equal(t, PlannedChange{Address: "aws_lambda_function.api", Actions: []string{"delete"}}, changes[0])
+		assert.Equal(t, PlannedChange{Address: "aws_sqs_queue.jobs", Actions: []string{"delete", "create"}}, changes[1])
+	})
+}
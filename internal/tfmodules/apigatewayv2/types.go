@@ -1,7 +1,18 @@
 // Package apigatewayv2 provides type-safe Terraform module definitions for terraform-aws-modules/apigateway-v2/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-apigateway-v2 v5.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/apigateway-v2 (see variables_gen.go).
 package apigatewayv2
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module apigateway-v2 -source terraform-aws-modules/apigateway-v2/aws
+
+import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
+
 // Module represents the terraform-aws-modules/apigateway-v2/aws module.
 // All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
 type Module struct {
@@ -29,7 +40,7 @@ type Module struct {
 
 	// ProtocolType is the API protocol
 	// Valid values: "HTTP" | "WEBSOCKET"
-	ProtocolType *string `json:"protocol_type,omitempty" hcl:"protocol_type,attr"`
+	ProtocolType *string `json:"protocol_type,omitempty" validate:"oneof=HTTP WEBSOCKET" hcl:"protocol_type,attr"`
 
 	// APIVersion is a version identifier (1-64 characters)
 	APIVersion *string `json:"api_version,omitempty" hcl:"api_version,attr"`
@@ -104,37 +115,37 @@ type Module struct {
 	MutualTLSAuthentication *MutualTLSAuthentication `json:"mutual_tls_authentication,omitempty" hcl:"mutual_tls_authentication,attr"`
 
 	// ================================
-	// Stages
+	// Stage
 	// ================================
 
-	// Stages is a map of stage configurations
-	Stages map[string]Stage `json:"stages,omitempty" hcl:"stages,attr"`
-
-	// CreateStage controls whether to create default stage
+	// CreateStage controls whether to create the stage
 	CreateStage *bool `json:"create_stage,omitempty" hcl:"create_stage,attr"`
 
-	// StageName is the default stage name
+	// StageName is the stage name
 	StageName *string `json:"stage_name,omitempty" hcl:"stage_name,attr"`
 
-	// StageDescription is the default stage description
+	// StageDescription is the stage description
 	StageDescription *string `json:"stage_description,omitempty" hcl:"stage_description,attr"`
 
-	// AutoDeploy automatically deploys changes to default stage
-	AutoDeploy *bool `json:"auto_deploy,omitempty" hcl:"auto_deploy,attr"`
+	// DeployStage controls whether to deploy the stage (HTTP APIs auto-deploy by default)
+	DeployStage *bool `json:"deploy_stage,omitempty" hcl:"deploy_stage,attr"`
+
+	// StageDefaultRouteSettings are the default route settings for the stage
+	StageDefaultRouteSettings *RouteSettings `json:"stage_default_route_settings,omitempty" hcl:"stage_default_route_settings,attr"`
 
-	// DefaultRouteSettings for default stage
-	DefaultRouteSettings *RouteSettings `json:"default_route_settings,omitempty" hcl:"default_route_settings,attr"`
+	// StageAccessLogSettings configures access logging for the stage
+	StageAccessLogSettings *AccessLogSettings `json:"stage_access_log_settings,omitempty" hcl:"stage_access_log_settings,attr"`
+
+	// StageVariables are stage-specific variables
+	StageVariables map[string]string `json:"stage_variables,omitempty" hcl:"stage_variables,attr"`
 
 	// ================================
 	// Routes & Integrations
 	// ================================
 
-	// Routes is a map of route configurations
+	// Routes maps route keys (e.g. "GET /orders") to the route and its integration
 	Routes map[string]Route `json:"routes,omitempty" hcl:"routes,attr"`
 
-	// Integrations is a map of integration configurations
-	Integrations map[string]Integration `json:"integrations,omitempty" hcl:"integrations,attr"`
-
 	// ================================
 	// VPC Links
 	// ================================
@@ -183,7 +194,7 @@ type Authorizer struct {
 	AuthorizerPayloadFormatVersion *string `json:"authorizer_payload_format_version,omitempty" hcl:"authorizer_payload_format_version,attr"`
 
 	// AuthorizerResultTTLInSeconds is the TTL for cached results (0-3600)
-	AuthorizerResultTTLInSeconds *int `json:"authorizer_result_ttl_in_seconds,omitempty" hcl:"authorizer_result_ttl_in_seconds,attr"`
+	AuthorizerResultTTLInSeconds *int `json:"authorizer_result_ttl_in_seconds,omitempty" validate:"min=0,max=3600" hcl:"authorizer_result_ttl_in_seconds,attr"`
 
 	// EnableSimpleResponses enables simple boolean responses
 	EnableSimpleResponses *bool `json:"enable_simple_responses,omitempty" hcl:"enable_simple_responses,attr"`
@@ -213,33 +224,6 @@ type MutualTLSAuthentication struct {
 	TruststoreVersion *string `json:"truststore_version,omitempty" hcl:"truststore_version,attr"`
 }
 
-// Stage represents an API Gateway stage.
-type Stage struct {
-	// Name is the stage name
-	Name *string `json:"name,omitempty" hcl:"name,attr"`
-
-	// Description of the stage
-	Description *string `json:"description,omitempty" hcl:"description,attr"`
-
-	// AutoDeploy automatically deploys changes
-	AutoDeploy *bool `json:"auto_deploy,omitempty" hcl:"auto_deploy,attr"`
-
-	// DefaultRouteSettings for the stage
-	DefaultRouteSettings *RouteSettings `json:"default_route_settings,omitempty" hcl:"default_route_settings,attr"`
-
-	// AccessLogSettings configures access logging
-	AccessLogSettings *AccessLogSettings `json:"access_log_settings,omitempty" hcl:"access_log_settings,attr"`
-
-	// ThrottleSettings configures throttling
-	ThrottleSettings *ThrottleSettings `json:"throttle_settings,omitempty" hcl:"throttle_settings,attr"`
-
-	// StageVariables are stage-specific variables
-	StageVariables map[string]string `json:"stage_variables,omitempty" hcl:"stage_variables,attr"`
-
-	// Tags for the stage
-	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
-}
-
 // RouteSettings represents route-level settings.
 type RouteSettings struct {
 	// DataTraceEnabled enables data trace logging
@@ -261,55 +245,52 @@ type RouteSettings struct {
 
 // AccessLogSettings represents access logging configuration.
 type AccessLogSettings struct {
+	// CreateLogGroup controls whether the module creates the log group (default: true)
+	CreateLogGroup *bool `json:"create_log_group,omitempty" hcl:"create_log_group,attr"`
+
 	// DestinationARN is the CloudWatch Logs group or Kinesis Data Firehose ARN
-	DestinationARN string `json:"destination_arn" hcl:"destination_arn,attr"`
+	DestinationARN *string `json:"destination_arn,omitempty" hcl:"destination_arn,attr"`
 
 	// Format is the log format
-	Format string `json:"format" hcl:"format,attr"`
-}
+	Format *string `json:"format,omitempty" hcl:"format,attr"`
 
-// ThrottleSettings represents throttling configuration.
-type ThrottleSettings struct {
-	// BurstLimit is the burst limit
-	BurstLimit *int `json:"burst_limit,omitempty" hcl:"burst_limit,attr"`
-
-	// RateLimit is the rate limit
-	RateLimit *float64 `json:"rate_limit,omitempty" hcl:"rate_limit,attr"`
+	// LogGroupRetentionInDays is the retention of the created log group (default: 30)
+	LogGroupRetentionInDays *int `json:"log_group_retention_in_days,omitempty" hcl:"log_group_retention_in_days,attr"`
 }
 
-// Route represents an API Gateway route.
+// Route represents an API Gateway route and the integration it invokes.
 type Route struct {
-	// RouteKey is the route key
-	RouteKey string `json:"route_key" hcl:"route_key,attr"`
-
-	// IntegrationKey references an integration
-	IntegrationKey *string `json:"integration_key,omitempty" hcl:"integration_key,attr"`
-
-	// AuthorizationKey references an authorizer
-	AuthorizationKey *string `json:"authorization_key,omitempty" hcl:"authorization_key,attr"`
+	// AuthorizerKey references an authorizer in Authorizers
+	AuthorizerKey *string `json:"authorizer_key,omitempty" hcl:"authorizer_key,attr"`
 
 	// AuthorizationType is the authorization type
 	// Valid values: "NONE" | "AWS_IAM" | "CUSTOM" | "JWT"
 	AuthorizationType *string `json:"authorization_type,omitempty" hcl:"authorization_type,attr"`
 
+	// AuthorizationScopes are the scopes a JWT authorizer requires
+	AuthorizationScopes []string `json:"authorization_scopes,omitempty" hcl:"authorization_scopes,attr"`
+
 	// APIKeyRequired indicates if API key is required
 	APIKeyRequired *bool `json:"api_key_required,omitempty" hcl:"api_key_required,attr"`
 
 	// OperationName is a friendly operation name
 	OperationName *string `json:"operation_name,omitempty" hcl:"operation_name,attr"`
+
+	// Integration is the integration the route invokes
+	Integration Integration `json:"integration" hcl:"integration,attr"`
 }
 
 // Integration represents an API Gateway integration.
 type Integration struct {
-	// IntegrationType is the type of integration
+	// Type is the type of integration (default: "AWS_PROXY")
 	// Valid values: "AWS" | "AWS_PROXY" | "HTTP" | "HTTP_PROXY" | "MOCK"
-	IntegrationType string `json:"integration_type" hcl:"integration_type,attr"`
+	Type *string `json:"type,omitempty" hcl:"type,attr"`
 
-	// IntegrationURI is the URI of the integration
-	IntegrationURI *string `json:"integration_uri,omitempty" hcl:"integration_uri,attr"`
+	// URI is the URI of the integration, e.g. a Lambda invoke ARN
+	URI *string `json:"uri,omitempty" hcl:"uri,attr"`
 
-	// IntegrationMethod is the HTTP method for the integration
-	IntegrationMethod *string `json:"integration_method,omitempty" hcl:"integration_method,attr"`
+	// Method is the HTTP method for the integration
+	Method *string `json:"method,omitempty" hcl:"method,attr"`
 
 	// ConnectionType is the connection type
 	// Valid values: "INTERNET" | "VPC_LINK"
@@ -318,11 +299,14 @@ type Integration struct {
 	// ConnectionID is the VPC link ID
 	ConnectionID *string `json:"connection_id,omitempty" hcl:"connection_id,attr"`
 
+	// CredentialsARN is the IAM role API Gateway assumes to invoke the integration
+	CredentialsARN *string `json:"credentials_arn,omitempty" hcl:"credentials_arn,attr"`
+
 	// PayloadFormatVersion is the payload format version
 	PayloadFormatVersion *string `json:"payload_format_version,omitempty" hcl:"payload_format_version,attr"`
 
 	// TimeoutMilliseconds is the integration timeout (50-30000)
-	TimeoutMilliseconds *int `json:"timeout_milliseconds,omitempty" hcl:"timeout_milliseconds,attr"`
+	TimeoutMilliseconds *int `json:"timeout_milliseconds,omitempty" validate:"min=50,max=30000" hcl:"timeout_milliseconds,attr"`
 }
 
 // VPCLink represents a VPC link.
@@ -343,12 +327,11 @@ type VPCLink struct {
 // NewModule creates a new API Gateway V2 module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/apigateway-v2/aws"
-	version := "~> 5.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	protocolType := "HTTP"
 	createStage := true
 	stageName := "default"
-	autoDeploy := true
 
 	return &Module{
 		Source:       source,
@@ -358,7 +341,6 @@ func NewModule(name string) *Module {
 		ProtocolType: &protocolType,
 		CreateStage:  &createStage,
 		StageName:    &stageName,
-		AutoDeploy:   &autoDeploy,
 	}
 }
 
@@ -413,21 +395,12 @@ func (m *Module) WithLambdaAuthorizer(name, lambdaURI string, identitySources []
 	return m
 }
 
-// WithRoute adds a route.
-func (m *Module) WithRoute(key string, route Route) *Module {
+// WithRoute adds a route under its route key, e.g. "GET /orders" or "$default".
+func (m *Module) WithRoute(routeKey string, route Route) *Module {
 	if m.Routes == nil {
 		m.Routes = make(map[string]Route)
 	}
-	m.Routes[key] = route
-	return m
-}
-
-// WithIntegration adds an integration.
-func (m *Module) WithIntegration(key string, integration Integration) *Module {
-	if m.Integrations == nil {
-		m.Integrations = make(map[string]Integration)
-	}
-	m.Integrations[key] = integration
+	m.Routes[routeKey] = route
 	return m
 }
 
@@ -450,8 +423,17 @@ func (m *Module) LocalName() string {
 	return "api_gateway"
 }
 
-// Configuration generates the HCL configuration for this module.
-func (_ *Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
+func (m *Module) Configuration() (string, error) {
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid apigatewayv2 module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
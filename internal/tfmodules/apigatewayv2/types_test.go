@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -30,9 +32,6 @@ func TestNewModule(t *testing.T) {
 
 		assert.NotNil(t, module.StageName)
 		assert.Equal(t, "default", *module.StageName)
-
-		assert.NotNil(t, module.AutoDeploy)
-		assert.True(t, *module.AutoDeploy)
 	})
 
 	t.Run("creates module with different names", func(t *testing.T) {
@@ -170,64 +169,36 @@ func TestModule_WithLambdaAuthorizer(t *testing.T) {
 }
 
 func TestModule_WithRoute(t *testing.T) {
-	t.Run("adds a route", func(t *testing.T) {
+	t.Run("adds a route under its route key", func(t *testing.T) {
 		route := Route{
-			RouteKey: "GET /users",
+			Integration: Integration{URI: ptr("arn:aws:lambda:us-east-1:123456789012:function:api")},
 		}
 
 		module := NewModule("test_api")
-		result := module.WithRoute("get_users", route)
+		result := module.WithRoute("GET /users", route)
 
 		assert.Equal(t, module, result)
 		assert.NotNil(t, module.Routes)
 		assert.Len(t, module.Routes, 1)
-		assert.Equal(t, "GET /users", module.Routes["get_users"].RouteKey)
+		assert.Equal(t, route, module.Routes["GET /users"])
 	})
 
 	t.Run("adds multiple routes", func(t *testing.T) {
 		module := NewModule("test_api")
 
-		module.WithRoute("get", Route{RouteKey: "GET /users"})
-		module.WithRoute("post", Route{RouteKey: "POST /users"})
+		module.WithRoute("GET /users", Route{})
+		module.WithRoute("POST /users", Route{})
 
 		assert.Len(t, module.Routes, 2)
 	})
 
-	t.Run("supports ANY method", func(t *testing.T) {
-		module := NewModule("test_api")
-		module.WithRoute("catch_all", Route{RouteKey: "ANY /proxy"})
-
-		assert.Equal(t, "ANY /proxy", module.Routes["catch_all"].RouteKey)
-	})
-}
-
-func TestModule_WithIntegration(t *testing.T) {
-	t.Run("adds Lambda integration", func(t *testing.T) {
-		integration := Integration{
-			IntegrationType: "AWS_PROXY",
-			IntegrationURI:  ptr("arn:aws:lambda:us-east-1:123456789012:function:api"),
-		}
-
-		module := NewModule("test_api")
-		result := module.WithIntegration("lambda", integration)
-
-		assert.Equal(t, module, result)
-		assert.NotNil(t, module.Integrations)
-		assert.Len(t, module.Integrations, 1)
-		assert.Equal(t, "AWS_PROXY", module.Integrations["lambda"].IntegrationType)
-	})
-
-	t.Run("adds HTTP integration", func(t *testing.T) {
-		uri := "https://api.example.com"
-		integration := Integration{
-			IntegrationType: "HTTP_PROXY",
-			IntegrationURI:  &uri,
-		}
-
+	t.Run("supports HTTP integrations", func(t *testing.T) {
 		module := NewModule("test_api")
-		module.WithIntegration("http", integration)
+		module.WithRoute("ANY /proxy", Route{
+			Integration: Integration{Type: ptr("HTTP_PROXY"), URI: ptr("https://api.example.com"), Method: ptr("ANY")},
+		})
 
-		assert.Equal(t, "HTTP_PROXY", module.Integrations["http"].IntegrationType)
+		assert.Equal(t, "HTTP_PROXY", *module.Routes["ANY /proxy"].Integration.Type)
 	})
 }
 
@@ -274,13 +245,33 @@ func TestModule_LocalName(t *testing.T) {
 }
 
 func TestModule_Configuration(t *testing.T) {
-	t.Run("returns empty string and nil error as placeholder", func(t *testing.T) {
+	t.Run("generates valid HCL for basic API", func(t *testing.T) {
 		module := NewModule("test_api")
 
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.Empty(t, config)
+		golden.Assert(t, "basic_api", config)
+	})
+
+	t.Run("generates HCL with routes and authorizers", func(t *testing.T) {
+		module := NewModule("http_api").
+			WithCORS([]string{"*"}, []string{"GET", "POST"}, []string{"Content-Type"}).
+			WithJWTAuthorizer("cognito", "https://cognito-idp.us-east-1.amazonaws.com/pool", []string{"client"}).
+			WithRoute("GET /orders", Route{
+				AuthorizerKey:     ptr("cognito"),
+				AuthorizationType: ptr("JWT"),
+				Integration: Integration{
+					URI:                  ptr("${module.orders.lambda_function_invoke_arn}"),
+					PayloadFormatVersion: ptr("2.0"),
+				},
+			}).
+			WithTags(map[string]string{"Team": "platform"})
+
+		config, err := module.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "routes", config)
 	})
 }
 
@@ -290,7 +281,7 @@ func TestModule_FluentAPI(t *testing.T) {
 			WithCORS([]string{"*"}, []string{"GET", "POST"}, []string{"Content-Type"}).
 			WithDomainName("api.example.com", "cert-arn").
 			WithJWTAuthorizer("auth", "issuer", []string{"aud"}).
-			WithRoute("get", Route{RouteKey: "GET /users"}).
+			WithRoute("GET /users", Route{}).
 			WithTags(map[string]string{"Team": "platform"})
 
 		assert.NotNil(t, module.Name)
@@ -322,21 +313,18 @@ func TestCORSConfiguration(t *testing.T) {
 
 func TestRoute(t *testing.T) {
 	t.Run("creates route with authorization", func(t *testing.T) {
-		integrationKey := "lambda"
 		authKey := "jwt-auth"
 		authType := "JWT"
 		opName := "GetUsers"
 
 		route := Route{
-			RouteKey:          "GET /users",
-			IntegrationKey:    &integrationKey,
-			AuthorizationKey:  &authKey,
-			AuthorizationType: &authType,
-			OperationName:     &opName,
+			AuthorizerKey:       &authKey,
+			AuthorizationType:   &authType,
+			AuthorizationScopes: []string{"users.read"},
+			OperationName:       &opName,
 		}
 
-		assert.Equal(t, "GET /users", route.RouteKey)
-		assert.Equal(t, "lambda", *route.IntegrationKey)
+		assert.Equal(t, "jwt-auth", *route.AuthorizerKey)
 		assert.Equal(t, "JWT", *route.AuthorizationType)
 	})
 }
@@ -347,14 +335,24 @@ func TestIntegration(t *testing.T) {
 		timeout := 5000
 
 		integration := Integration{
-			IntegrationType:     "AWS_PROXY",
-			IntegrationURI:      &uri,
+			URI:                 &uri,
 			TimeoutMilliseconds: &timeout,
 		}
 
-		assert.Equal(t, "AWS_PROXY", integration.IntegrationType)
+		assert.Equal(t, uri, *integration.URI)
 		assert.Equal(t, 5000, *integration.TimeoutMilliseconds)
 	})
+
+	t.Run("rejects a timeout out of range", func(t *testing.T) {
+		timeout := 40000
+		module := NewModule("test_api").WithRoute("GET /", Route{
+			Integration: Integration{TimeoutMilliseconds: &timeout},
+		})
+
+		_, err := module.Configuration()
+
+		assert.Error(t, err)
+	})
 }
 
 // Helper function to create pointer to string.
@@ -374,7 +372,7 @@ func BenchmarkFluentAPI(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = NewModule("bench_api").
 			WithCORS([]string{"*"}, []string{"GET"}, []string{"Content-Type"}).
-			WithRoute("test", Route{RouteKey: "GET /test"}).
+			WithRoute("GET /test", Route{}).
 			WithTags(map[string]string{"Environment": "production"})
 	}
 }
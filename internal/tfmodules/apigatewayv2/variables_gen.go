@@ -0,0 +1,301 @@
+// Code generated by tfmodules-gen from .forge/modules/apigateway-v2. DO NOT EDIT.
+
+package apigatewayv2
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/apigateway-v2/aws these types were generated from.
+const ModuleVersion = "5.4.1"
+
+// Variables holds every input declared by terraform-aws-modules/apigateway-v2/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Controls if resources should be created
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// A mapping of tags to assign to API gateway resources
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// An API key selection expression. Valid values: `$context.authorizer.usageIdentifierKey`, `$request.header.x-api-key`. Defaults to `$request.header.x-api-key`. Applicable for WebSocket APIs
+	APIKeySelectionExpression *string `json:"api_key_selection_expression,omitempty" hcl:"api_key_selection_expression,attr"`
+
+	// The cross-origin resource sharing (CORS) configuration. Applicable for HTTP APIs
+	CORSConfiguration map[string]interface{} `json:"cors_configuration,omitempty" hcl:"cors_configuration,attr"`
+
+	// Part of quick create. Specifies any credentials required for the integration. Applicable for HTTP APIs
+	CredentialsARN *string `json:"credentials_arn,omitempty" hcl:"credentials_arn,attr"`
+
+	// The description of the API. Must be less than or equal to 1024 characters in length
+	Description *string `json:"description,omitempty" hcl:"description,attr"`
+
+	// Whether clients can invoke the API by using the default execute-api endpoint. By default, clients can invoke the API with the default `{api_id}.execute-api.{region}.amazonaws.com endpoint`. To require that clients use a custom domain name to invoke the API, disable the default endpoint
+	DisableExecuteAPIEndpoint *bool `json:"disable_execute_api_endpoint,omitempty" hcl:"disable_execute_api_endpoint,attr"`
+
+	// Whether warnings should return an error while API Gateway is creating or updating the resource using an OpenAPI specification. Defaults to `false`. Applicable for HTTP APIs
+	FailOnWarnings *bool `json:"fail_on_warnings,omitempty" hcl:"fail_on_warnings,attr"`
+
+	// The IP address types that can invoke the API. Valid values: ipv4, dualstack. Use ipv4 to allow only IPv4 addresses to invoke your API, or use dualstack to allow both IPv4 and IPv6 addresses to invoke your API. Defaults to ipv4.
+	IPAddressType *string `json:"ip_address_type,omitempty" hcl:"ip_address_type,attr"`
+
+	// The name of the API. Must be less than or equal to 128 characters in length
+	//
+	// Default: ""
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// An OpenAPI specification that defines the set of routes and integrations to create as part of the HTTP APIs. Supported only for HTTP APIs
+	Body *string `json:"body,omitempty" hcl:"body,attr"`
+
+	// The API protocol. Valid values: `HTTP`, `WEBSOCKET`
+	//
+	// Default: "HTTP"
+	ProtocolType *string `json:"protocol_type,omitempty" hcl:"protocol_type,attr"`
+
+	// Part of quick create. Specifies any route key. Applicable for HTTP APIs
+	RouteKey *string `json:"route_key,omitempty" hcl:"route_key,attr"`
+
+	// The route selection expression for the API. Defaults to `$request.method $request.path`
+	RouteSelectionExpression *string `json:"route_selection_expression,omitempty" hcl:"route_selection_expression,attr"`
+
+	// Part of quick create. Quick create produces an API with an integration, a default catch-all route, and a default stage which is configured to automatically deploy changes. For HTTP integrations, specify a fully qualified URL. For Lambda integrations, specify a function ARN. The type of the integration will be HTTP_PROXY or AWS_PROXY, respectively. Applicable for HTTP APIs
+	Target *string `json:"target,omitempty" hcl:"target,attr"`
+
+	// A version identifier for the API. Must be between 1 and 64 characters in length
+	APIVersion *string `json:"api_version,omitempty" hcl:"api_version,attr"`
+
+	// The [API mapping key](https://docs.aws.amazon.com/apigateway/latest/developerguide/apigateway-websocket-api-mapping-template-reference.html)
+	APIMappingKey *string `json:"api_mapping_key,omitempty" hcl:"api_mapping_key,attr"`
+
+	// Map of API gateway authorizers to create
+	//
+	// Default: {}
+	Authorizers map[string]map[string]interface{} `json:"authorizers,omitempty" hcl:"authorizers,attr"`
+
+	// Whether to create API domain name resource
+	//
+	// Default: true
+	CreateDomainName *bool `json:"create_domain_name,omitempty" hcl:"create_domain_name,attr"`
+
+	// The domain name to use for API gateway
+	//
+	// Default: ""
+	DomainName *string `json:"domain_name,omitempty" hcl:"domain_name,attr"`
+
+	// Optional domain name of the Hosted Zone where the domain should be created
+	HostedZoneName *string `json:"hosted_zone_name,omitempty" hcl:"hosted_zone_name,attr"`
+
+	// The ARN of an AWS-managed certificate that will be used by the endpoint for the domain name. AWS Certificate Manager is the only supported source
+	DomainNameCertificateARN *string `json:"domain_name_certificate_arn,omitempty" hcl:"domain_name_certificate_arn,attr"`
+
+	// ARN of the AWS-issued certificate used to validate custom domain ownership (when certificate_arn is issued via an ACM Private CA or mutual_tls_authentication is configured with an ACM-imported certificate.)
+	DomainNameOwnershipVerificationCertificateARN *string `json:"domain_name_ownership_verification_certificate_arn,omitempty" hcl:"domain_name_ownership_verification_certificate_arn,attr"`
+
+	// The mutual TLS authentication configuration for the domain name
+	//
+	// Default: {}
+	MutualTLSAuthentication map[string]string `json:"mutual_tls_authentication,omitempty" hcl:"mutual_tls_authentication,attr"`
+
+	// Whether to create Route53 records for the domain name
+	//
+	// Default: true
+	CreateDomainRecords *bool `json:"create_domain_records,omitempty" hcl:"create_domain_records,attr"`
+
+	// An optional list of subdomains to use for API gateway
+	//
+	// Default: []
+	Subdomains []string `json:"subdomains,omitempty" hcl:"subdomains,attr"`
+
+	// A list of record types to create for the subdomain(s)
+	//
+	// Default: ["A", "AAAA"]
+	SubdomainRecordTypes []string `json:"subdomain_record_types,omitempty" hcl:"subdomain_record_types,attr"`
+
+	// Whether to create a certificate for the domain
+	//
+	// Default: true
+	CreateCertificate *bool `json:"create_certificate,omitempty" hcl:"create_certificate,attr"`
+
+	// Whether to create routes and integrations resources
+	//
+	// Default: true
+	CreateRoutesAndIntegrations *bool `json:"create_routes_and_integrations,omitempty" hcl:"create_routes_and_integrations,attr"`
+
+	// Map of API gateway routes with integrations
+	//
+	// Default: {}
+	Routes map[string]map[string]interface{} `json:"routes,omitempty" hcl:"routes,attr"`
+
+	// Whether to create default stage
+	//
+	// Default: true
+	CreateStage *bool `json:"create_stage,omitempty" hcl:"create_stage,attr"`
+
+	// Settings for logging access in this stage. Use the aws_api_gateway_account resource to configure [permissions for CloudWatch Logging](https://docs.aws.amazon.com/apigateway/latest/developerguide/set-up-logging.html#set-up-access-logging-permissions)
+	//
+	// Default: {}
+	StageAccessLogSettings map[string]interface{} `json:"stage_access_log_settings,omitempty" hcl:"stage_access_log_settings,attr"`
+
+	// The identifier of a client certificate for the stage. Use the `aws_api_gateway_client_certificate` resource to configure a client certificate. Supported only for WebSocket APIs
+	StageClientCertificateID *string `json:"stage_client_certificate_id,omitempty" hcl:"stage_client_certificate_id,attr"`
+
+	// The default route settings for the stage
+	//
+	// Default: {}
+	StageDefaultRouteSettings map[string]interface{} `json:"stage_default_route_settings,omitempty" hcl:"stage_default_route_settings,attr"`
+
+	// The description for the stage. Must be less than or equal to 1024 characters in length
+	StageDescription *string `json:"stage_description,omitempty" hcl:"stage_description,attr"`
+
+	// The name of the stage. Must be between 1 and 128 characters in length
+	//
+	// Default: "$default"
+	StageName *string `json:"stage_name,omitempty" hcl:"stage_name,attr"`
+
+	// A map that defines the stage variables for the stage
+	//
+	// Default: {}
+	StageVariables map[string]string `json:"stage_variables,omitempty" hcl:"stage_variables,attr"`
+
+	// A mapping of tags to assign to the stage resource
+	//
+	// Default: {}
+	StageTags map[string]string `json:"stage_tags,omitempty" hcl:"stage_tags,attr"`
+
+	// Whether to deploy the stage. `HTTP` APIs are auto-deployed by default
+	//
+	// Default: true
+	DeployStage *bool `json:"deploy_stage,omitempty" hcl:"deploy_stage,attr"`
+
+	// Map of VPC Link definitions to create
+	//
+	// Default: {}
+	VPCLinks map[string]map[string]interface{} `json:"vpc_links,omitempty" hcl:"vpc_links,attr"`
+
+	// A map of tags to add to the VPC Links created
+	//
+	// Default: {}
+	VPCLinkTags map[string]string `json:"vpc_link_tags,omitempty" hcl:"vpc_link_tags,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/apigateway-v2/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// APIID references api_id: The API identifier
+func (o Outputs) APIID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "api_id")
+}
+
+// APIEndpoint references api_endpoint: URI of the API, of the form `https://{api-id}.execute-api.{region}.amazonaws.com` for HTTP APIs and `wss://{api-id}.execute-api.{region}.amazonaws.com` for WebSocket APIs
+func (o Outputs) APIEndpoint() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "api_endpoint")
+}
+
+// APIARN references api_arn: The ARN of the API
+func (o Outputs) APIARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "api_arn")
+}
+
+// APIExecutionARN references api_execution_arn: The ARN prefix to be used in an `aws_lambda_permission`'s `source_arn` attribute or in an `aws_iam_policy` to authorize access to the `@connections` API
+func (o Outputs) APIExecutionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "api_execution_arn")
+}
+
+// Authorizers references authorizers: Map of API Gateway Authorizer(s) created and their attributes
+func (o Outputs) Authorizers() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "authorizers")
+}
+
+// DomainNameID references domain_name_id: The domain name identifier
+func (o Outputs) DomainNameID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "domain_name_id")
+}
+
+// DomainNameARN references domain_name_arn: The ARN of the domain name
+func (o Outputs) DomainNameARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "domain_name_arn")
+}
+
+// DomainNameAPIMappingSelectionExpression references domain_name_api_mapping_selection_expression: The API mapping selection expression for the domain name
+func (o Outputs) DomainNameAPIMappingSelectionExpression() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "domain_name_api_mapping_selection_expression")
+}
+
+// DomainNameConfiguration references domain_name_configuration: The domain name configuration
+func (o Outputs) DomainNameConfiguration() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "domain_name_configuration")
+}
+
+// DomainNameTargetDomainName references domain_name_target_domain_name: The target domain name
+func (o Outputs) DomainNameTargetDomainName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "domain_name_target_domain_name")
+}
+
+// DomainNameHostedZoneID references domain_name_hosted_zone_id: The Amazon Route 53 Hosted Zone ID of the endpoint
+func (o Outputs) DomainNameHostedZoneID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "domain_name_hosted_zone_id")
+}
+
+// ACMCertificateARN references acm_certificate_arn: The ARN of the certificate
+func (o Outputs) ACMCertificateARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "acm_certificate_arn")
+}
+
+// Integrations references integrations: Map of the integrations created and their attributes
+func (o Outputs) Integrations() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "integrations")
+}
+
+// Routes references routes: Map of the routes created and their attributes
+func (o Outputs) Routes() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "routes")
+}
+
+// StageID references stage_id: The stage identifier
+func (o Outputs) StageID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "stage_id")
+}
+
+// StageDomainName references stage_domain_name: Domain name of the stage (useful for CloudFront distribution)
+func (o Outputs) StageDomainName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "stage_domain_name")
+}
+
+// StageARN references stage_arn: The stage ARN
+func (o Outputs) StageARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "stage_arn")
+}
+
+// StageExecutionARN references stage_execution_arn: The ARN prefix to be used in an aws_lambda_permission's source_arn attribute or in an aws_iam_policy to authorize access to the @connections API
+func (o Outputs) StageExecutionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "stage_execution_arn")
+}
+
+// StageInvokeURL references stage_invoke_url: The URL to invoke the API pointing to the stage
+func (o Outputs) StageInvokeURL() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "stage_invoke_url")
+}
+
+// StageAccessLogsCloudwatchLogGroupName references stage_access_logs_cloudwatch_log_group_name: Name of cloudwatch log group created
+func (o Outputs) StageAccessLogsCloudwatchLogGroupName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "stage_access_logs_cloudwatch_log_group_name")
+}
+
+// StageAccessLogsCloudwatchLogGroupARN references stage_access_logs_cloudwatch_log_group_arn: Arn of cloudwatch log group created
+func (o Outputs) StageAccessLogsCloudwatchLogGroupARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "stage_access_logs_cloudwatch_log_group_arn")
+}
+
+// VPCLinks references vpc_links: Map of VPC links created and their attributes
+func (o Outputs) VPCLinks() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "vpc_links")
+}
@@ -1,7 +1,18 @@
 // Package appconfig provides type-safe Terraform module definitions for terraform-aws-modules/appconfig/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-appconfig v2.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/appconfig (see variables_gen.go).
 package appconfig
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module appconfig -source terraform-aws-modules/appconfig/aws
+
+import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
+
 // Module represents the terraform-aws-modules/appconfig/aws module.
 // All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
 type Module struct {
@@ -89,11 +100,11 @@ type Module struct {
 	// SSMDocumentConfigurationARN is the SSM document ARN
 	SSMDocumentConfigurationARN *string `json:"ssm_document_configuration_arn,omitempty" hcl:"ssm_document_configuration_arn,attr"`
 
-	// S3ConfigurationSourceARN is the S3 object ARN
-	S3ConfigurationSourceARN *string `json:"s3_configuration_source_arn,omitempty" hcl:"s3_configuration_source_arn,attr"`
+	// S3ConfigurationBucketARN is the ARN of the configuration S3 bucket
+	S3ConfigurationBucketARN *string `json:"s3_configuration_bucket_arn,omitempty" hcl:"s3_configuration_bucket_arn,attr"`
 
-	// SecretsManagerSecretARN is the Secrets Manager secret ARN
-	SecretsManagerSecretARN *string `json:"secrets_manager_secret_arn,omitempty" hcl:"secrets_manager_secret_arn,attr"`
+	// S3ConfigurationObjectKey is the key of the configuration S3 object
+	S3ConfigurationObjectKey *string `json:"s3_configuration_object_key,omitempty" hcl:"s3_configuration_object_key,attr"`
 
 	// ================================
 	// Deployment Strategy
@@ -109,56 +120,37 @@ type Module struct {
 	DeploymentStrategyDescription *string `json:"deployment_strategy_description,omitempty" hcl:"deployment_strategy_description,attr"`
 
 	// DeploymentDurationInMinutes is the deployment duration (0-1440)
-	DeploymentDurationInMinutes *int `json:"deployment_duration_in_minutes,omitempty" validate:"min=0,max=1440" hcl:"deployment_duration_in_minutes,attr"`
+	DeploymentDurationInMinutes *int `json:"deployment_strategy_deployment_duration_in_minutes,omitempty" validate:"min=0,max=1440" hcl:"deployment_strategy_deployment_duration_in_minutes,attr"`
 
 	// GrowthFactor is the percentage of targets to receive deployment (1-100)
-	GrowthFactor *float64 `json:"growth_factor,omitempty" validate:"min=1,max=100" hcl:"growth_factor,attr"`
+	GrowthFactor *float64 `json:"deployment_strategy_growth_factor,omitempty" validate:"min=1,max=100" hcl:"deployment_strategy_growth_factor,attr"`
 
 	// GrowthType is the growth type
 	// Valid values: "LINEAR" | "EXPONENTIAL"
-	GrowthType *string `json:"growth_type,omitempty" hcl:"growth_type,attr"`
+	GrowthType *string `json:"deployment_strategy_growth_type,omitempty" hcl:"deployment_strategy_growth_type,attr"`
 
 	// FinalBakeTimeInMinutes is the bake time after deployment (0-1440)
-	FinalBakeTimeInMinutes *int `json:"final_bake_time_in_minutes,omitempty" validate:"min=0,max=1440" hcl:"final_bake_time_in_minutes,attr"`
+	FinalBakeTimeInMinutes *int `json:"deployment_strategy_final_bake_time_in_minutes,omitempty" validate:"min=0,max=1440" hcl:"deployment_strategy_final_bake_time_in_minutes,attr"`
 
 	// ReplicateTo replicates configuration
 	// Valid values: "NONE" | "SSM_DOCUMENT"
-	ReplicateTo *string `json:"replicate_to,omitempty" hcl:"replicate_to,attr"`
+	ReplicateTo *string `json:"deployment_strategy_replicate_to,omitempty" hcl:"deployment_strategy_replicate_to,attr"`
 
 	// ================================
 	// Hosted Configuration Version
 	// ================================
 
-	// CreateHostedConfigurationVersion creates hosted configuration version
-	CreateHostedConfigurationVersion *bool `json:"create_hosted_configuration_version,omitempty" hcl:"create_hosted_configuration_version,attr"`
+	// UseHostedConfiguration stores the configuration in AppConfig as a hosted configuration version
+	UseHostedConfiguration *bool `json:"use_hosted_configuration,omitempty" hcl:"use_hosted_configuration,attr"`
 
 	// HostedConfigurationVersionContent is the configuration content
-	HostedConfigurationVersionContent *string `json:"hosted_configuration_version_content,omitempty" hcl:"hosted_configuration_version_content,attr"`
+	HostedConfigurationVersionContent *string `json:"hosted_config_version_content,omitempty" hcl:"hosted_config_version_content,attr"`
 
 	// HostedConfigurationVersionContentType is the content type
-	HostedConfigurationVersionContentType *string `json:"hosted_configuration_version_content_type,omitempty" hcl:"hosted_configuration_version_content_type,attr"`
+	HostedConfigurationVersionContentType *string `json:"hosted_config_version_content_type,omitempty" hcl:"hosted_config_version_content_type,attr"`
 
 	// HostedConfigurationVersionDescription describes the version
-	HostedConfigurationVersionDescription *string `json:"hosted_configuration_version_description,omitempty" hcl:"hosted_configuration_version_description,attr"`
-
-	// ================================
-	// Extension
-	// ================================
-
-	// CreateExtension creates an AppConfig extension
-	CreateExtension *bool `json:"create_extension,omitempty" hcl:"create_extension,attr"`
-
-	// ExtensionName is the extension name
-	ExtensionName *string `json:"extension_name,omitempty" hcl:"extension_name,attr"`
-
-	// ExtensionDescription describes the extension
-	ExtensionDescription *string `json:"extension_description,omitempty" hcl:"extension_description,attr"`
-
-	// ExtensionActions defines extension action points
-	ExtensionActions map[string][]ExtensionAction `json:"extension_actions,omitempty" hcl:"extension_actions,attr"`
-
-	// ExtensionParameters are extension parameters
-	ExtensionParameters map[string]ExtensionParameter `json:"extension_parameters,omitempty" hcl:"extension_parameters,attr"`
+	HostedConfigurationVersionDescription *string `json:"hosted_config_version_description,omitempty" hcl:"hosted_config_version_description,attr"`
 }
 
 // Environment represents an AppConfig environment.
@@ -195,34 +187,10 @@ type Validator struct {
 	Content string `json:"content" hcl:"content,attr"`
 }
 
-// ExtensionAction represents an extension action.
-type ExtensionAction struct {
-	// Name of the action
-	Name string `json:"name" hcl:"name,attr"`
-
-	// URI of the action (Lambda ARN, SNS topic, SQS queue, EventBridge)
-	URI string `json:"uri" hcl:"uri,attr"`
-
-	// RoleARN is the IAM role ARN for the action
-	RoleARN *string `json:"role_arn,omitempty" hcl:"role_arn,attr"`
-
-	// Description of the action
-	Description *string `json:"description,omitempty" hcl:"description,attr"`
-}
-
-// ExtensionParameter represents an extension parameter.
-type ExtensionParameter struct {
-	// Required indicates if the parameter is required
-	Required *bool `json:"required,omitempty" hcl:"required,attr"`
-
-	// Description of the parameter
-	Description *string `json:"description,omitempty" hcl:"description,attr"`
-}
-
 // NewModule creates a new AppConfig module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/appconfig/aws"
-	version := "~> 2.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	locationURI := "hosted"
 
@@ -248,10 +216,10 @@ func (m *Module) WithEnvironment(name string, env Environment) *Module {
 func (m *Module) WithFeatureFlags(content string) *Module {
 	profileType := "AWS.AppConfig.FeatureFlags"
 	contentType := "application/json"
-	createHosted := true
+	useHosted := true
 
 	m.ConfigProfileType = &profileType
-	m.CreateHostedConfigurationVersion = &createHosted
+	m.UseHostedConfiguration = &useHosted
 	m.HostedConfigurationVersionContent = &content
 	m.HostedConfigurationVersionContentType = &contentType
 	return m
@@ -260,10 +228,10 @@ func (m *Module) WithFeatureFlags(content string) *Module {
 // WithFreeformConfig configures as a freeform configuration.
 func (m *Module) WithFreeformConfig(content, contentType string) *Module {
 	profileType := "AWS.Freeform"
-	createHosted := true
+	useHosted := true
 
 	m.ConfigProfileType = &profileType
-	m.CreateHostedConfigurationVersion = &createHosted
+	m.UseHostedConfiguration = &useHosted
 	m.HostedConfigurationVersionContent = &content
 	m.HostedConfigurationVersionContentType = &contentType
 	return m
@@ -310,8 +278,17 @@ func (m *Module) LocalName() string {
 	return "appconfig"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 func (m *Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid appconfig module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
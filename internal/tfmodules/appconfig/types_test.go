@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -99,8 +101,8 @@ func TestModule_WithFeatureFlags(t *testing.T) {
 		assert.NotNil(t, module.ConfigProfileType)
 		assert.Equal(t, "AWS.AppConfig.FeatureFlags", *module.ConfigProfileType)
 
-		assert.NotNil(t, module.CreateHostedConfigurationVersion)
-		assert.True(t, *module.CreateHostedConfigurationVersion)
+		assert.NotNil(t, module.UseHostedConfiguration)
+		assert.True(t, *module.UseHostedConfiguration)
 
 		assert.NotNil(t, module.HostedConfigurationVersionContent)
 		assert.Equal(t, content, *module.HostedConfigurationVersionContent)
@@ -132,8 +134,8 @@ func TestModule_WithFreeformConfig(t *testing.T) {
 		assert.NotNil(t, module.ConfigProfileType)
 		assert.Equal(t, "AWS.Freeform", *module.ConfigProfileType)
 
-		assert.NotNil(t, module.CreateHostedConfigurationVersion)
-		assert.True(t, *module.CreateHostedConfigurationVersion)
+		assert.NotNil(t, module.UseHostedConfiguration)
+		assert.True(t, *module.UseHostedConfiguration)
 
 		assert.NotNil(t, module.HostedConfigurationVersionContent)
 		assert.Equal(t, content, *module.HostedConfigurationVersionContent)
@@ -318,14 +320,31 @@ func TestModule_LocalName(t *testing.T) {
 }
 
 func TestModule_Configuration(t *testing.T) {
-	t.Run("returns empty string and nil error as placeholder", func(t *testing.T) {
+	t.Run("generates valid HCL for basic application", func(t *testing.T) {
 		module := NewModule("test_app")
 
 		config, err := module.Configuration()
 
-		// Current implementation is a placeholder
 		require.NoError(t, err)
-		assert.Empty(t, config)
+		golden.Assert(t, "basic_app", config)
+	})
+
+	t.Run("generates HCL with environments and feature flags", func(t *testing.T) {
+		description := "Production"
+		module := NewModule("checkout").
+			WithEnvironment("prod", Environment{
+				Name:        "prod",
+				Description: &description,
+				Monitors:    []Monitor{{AlarmARN: "${module.alarms.arn}"}},
+			}).
+			WithFeatureFlags(`{"flags":{"new_checkout":{"name":"new_checkout"}}}`).
+			WithDeploymentStrategy(10, 20, 5).
+			WithTags(map[string]string{"Team": "platform"})
+
+		config, err := module.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "feature_flags", config)
 	})
 }
 
@@ -422,60 +441,6 @@ func TestValidator(t *testing.T) {
 	})
 }
 
-func TestExtensionAction(t *testing.T) {
-	t.Run("creates Lambda extension action", func(t *testing.T) {
-		roleARN := "arn:aws:iam::123456789012:role/AppConfigExtension"
-		desc := "Sends notifications on deployment"
-		action := ExtensionAction{
-			Name:        "notify-deployment",
-			URI:         "arn:aws:lambda:us-east-1:123456789012:function:notify",
-			RoleARN:     &roleARN,
-			Description: &desc,
-		}
-
-		assert.Equal(t, "notify-deployment", action.Name)
-		assert.Contains(t, action.URI, "arn:aws:lambda")
-		assert.NotNil(t, action.RoleARN)
-		assert.Equal(t, "Sends notifications on deployment", *action.Description)
-	})
-
-	t.Run("creates SNS extension action", func(t *testing.T) {
-		action := ExtensionAction{
-			Name: "sns-notify",
-			URI:  "arn:aws:sns:us-east-1:123456789012:appconfig-events",
-		}
-
-		assert.Equal(t, "sns-notify", action.Name)
-		assert.Contains(t, action.URI, "arn:aws:sns")
-	})
-}
-
-func TestExtensionParameter(t *testing.T) {
-	t.Run("creates required parameter", func(t *testing.T) {
-		required := true
-		desc := "API endpoint URL"
-		param := ExtensionParameter{
-			Required:    &required,
-			Description: &desc,
-		}
-
-		assert.NotNil(t, param.Required)
-		assert.True(t, *param.Required)
-		assert.Equal(t, "API endpoint URL", *param.Description)
-	})
-
-	t.Run("creates optional parameter", func(t *testing.T) {
-		required := false
-		param := ExtensionParameter{
-			Required: &required,
-		}
-
-		assert.NotNil(t, param.Required)
-		assert.False(t, *param.Required)
-	})
-}
-
-// BenchmarkNewModule benchmarks module creation.
 func BenchmarkNewModule(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = NewModule("bench_app")
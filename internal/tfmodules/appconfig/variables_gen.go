@@ -0,0 +1,302 @@
+// Code generated by tfmodules-gen from .forge/modules/appconfig. DO NOT EDIT.
+
+package appconfig
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/appconfig/aws these types were generated from.
+const ModuleVersion = "2.0.2"
+
+// Variables holds every input declared by terraform-aws-modules/appconfig/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Determines whether resources are created
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// A list of tag blocks. Each element should have keys named key, value, and propagate_at_launch
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// The name for the application. Must be between 1 and 64 characters in length
+	//
+	// Default: ""
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// The description of the application. Can be at most 1024 characters
+	Description *string `json:"description,omitempty" hcl:"description,attr"`
+
+	// Map of attributes for AppConfig environment resource(s)
+	//
+	// Default: {}
+	Environments map[string]interface{} `json:"environments,omitempty" hcl:"environments,attr"`
+
+	// The name for the configuration profile. Must be between 1 and 64 characters in length
+	ConfigProfileName *string `json:"config_profile_name,omitempty" hcl:"config_profile_name,attr"`
+
+	// The description of the configuration profile. Can be at most 1024 characters
+	ConfigProfileDescription *string `json:"config_profile_description,omitempty" hcl:"config_profile_description,attr"`
+
+	// Type of configurations contained in the profile. Valid values: `AWS.AppConfig.FeatureFlags` and `AWS.Freeform`
+	ConfigProfileType *string `json:"config_profile_type,omitempty" hcl:"config_profile_type,attr"`
+
+	// A URI to locate the configuration. You can specify the AWS AppConfig hosted configuration store, Systems Manager (SSM) document, an SSM Parameter Store parameter, or an Amazon S3 object
+	//
+	// Default: "hosted"
+	ConfigProfileLocationURI *string `json:"config_profile_location_uri,omitempty" hcl:"config_profile_location_uri,attr"`
+
+	// The ARN of an IAM role with permission to access the configuration at the specified `location_uri`. A retrieval role ARN is not required for configurations stored in the AWS AppConfig `hosted` configuration store. It is required for all other sources that store your configuration
+	ConfigProfileRetrievalRoleARN *string `json:"config_profile_retrieval_role_arn,omitempty" hcl:"config_profile_retrieval_role_arn,attr"`
+
+	// A set of methods for validating the configuration. Maximum of 2
+	//
+	// Default: []
+	ConfigProfileValidator []map[string]interface{} `json:"config_profile_validator,omitempty" hcl:"config_profile_validator,attr"`
+
+	// A map of additional tags to apply to the configuration profile
+	//
+	// Default: {}
+	ConfigProfileTags map[string]string `json:"config_profile_tags,omitempty" hcl:"config_profile_tags,attr"`
+
+	// Determines whether configuration retrieval IAM role is created
+	//
+	// Default: true
+	CreateRetrievalRole *bool `json:"create_retrieval_role,omitempty" hcl:"create_retrieval_role,attr"`
+
+	// The name for the configuration retrieval role
+	//
+	// Default: ""
+	RetrievalRoleName *string `json:"retrieval_role_name,omitempty" hcl:"retrieval_role_name,attr"`
+
+	// Determines whether to a name or name-prefix strategy is used on the role
+	//
+	// Default: true
+	RetrievalRoleUseNamePrefix *bool `json:"retrieval_role_use_name_prefix,omitempty" hcl:"retrieval_role_use_name_prefix,attr"`
+
+	// Description of the configuration retrieval role
+	RetrievalRoleDescription *string `json:"retrieval_role_description,omitempty" hcl:"retrieval_role_description,attr"`
+
+	// Path to the configuration retrieval role
+	RetrievalRolePath *string `json:"retrieval_role_path,omitempty" hcl:"retrieval_role_path,attr"`
+
+	// ARN of the policy that is used to set the permissions boundary for the configuration retrieval role
+	RetrievalRolePermissionsBoundary *string `json:"retrieval_role_permissions_boundary,omitempty" hcl:"retrieval_role_permissions_boundary,attr"`
+
+	// ARN of the configuration SSM parameter
+	SsmParameterConfigurationARN *string `json:"ssm_parameter_configuration_arn,omitempty" hcl:"ssm_parameter_configuration_arn,attr"`
+
+	// ARN of the configuration SSM document
+	SsmDocumentConfigurationARN *string `json:"ssm_document_configuration_arn,omitempty" hcl:"ssm_document_configuration_arn,attr"`
+
+	// The ARN of the configuration S3 bucket
+	S3ConfigurationBucketARN *string `json:"s3_configuration_bucket_arn,omitempty" hcl:"s3_configuration_bucket_arn,attr"`
+
+	// Name of the configuration object/file stored in the S3 bucket
+	//
+	// Default: "*"
+	S3ConfigurationObjectKey *string `json:"s3_configuration_object_key,omitempty" hcl:"s3_configuration_object_key,attr"`
+
+	// A map of additional tags to apply to the configuration retrieval role
+	//
+	// Default: {}
+	RetrievalRoleTags map[string]string `json:"retrieval_role_tags,omitempty" hcl:"retrieval_role_tags,attr"`
+
+	// Determines whether a hosted configuration is used
+	//
+	// Default: false
+	UseHostedConfiguration *bool `json:"use_hosted_configuration,omitempty" hcl:"use_hosted_configuration,attr"`
+
+	// Determines whether an SSM parameter configuration is used
+	//
+	// Default: false
+	UseSsmParameterConfiguration *bool `json:"use_ssm_parameter_configuration,omitempty" hcl:"use_ssm_parameter_configuration,attr"`
+
+	// Determines whether an SSM document configuration is used
+	//
+	// Default: false
+	UseSsmDocumentConfiguration *bool `json:"use_ssm_document_configuration,omitempty" hcl:"use_ssm_document_configuration,attr"`
+
+	// Determines whether an S3 configuration is used
+	//
+	// Default: false
+	UseS3Configuration *bool `json:"use_s3_configuration,omitempty" hcl:"use_s3_configuration,attr"`
+
+	// A description of the configuration
+	HostedConfigVersionDescription *string `json:"hosted_config_version_description,omitempty" hcl:"hosted_config_version_description,attr"`
+
+	// The content of the configuration or the configuration data
+	HostedConfigVersionContent *string `json:"hosted_config_version_content,omitempty" hcl:"hosted_config_version_content,attr"`
+
+	// A standard MIME type describing the format of the configuration content. For more information, see [Content-Type](https://www.w3.org/Protocols/rfc2616/rfc2616-sec14.html#sec14.17)
+	HostedConfigVersionContentType *string `json:"hosted_config_version_content_type,omitempty" hcl:"hosted_config_version_content_type,attr"`
+
+	// Determines whether a deployment strategy is created
+	//
+	// Default: true
+	CreateDeploymentStrategy *bool `json:"create_deployment_strategy,omitempty" hcl:"create_deployment_strategy,attr"`
+
+	// An existing AppConfig deployment strategy ID
+	DeploymentStrategyID *string `json:"deployment_strategy_id,omitempty" hcl:"deployment_strategy_id,attr"`
+
+	// A name for the deployment strategy. Must be between 1 and 64 characters in length
+	DeploymentStrategyName *string `json:"deployment_strategy_name,omitempty" hcl:"deployment_strategy_name,attr"`
+
+	// A description of the deployment strategy. Can be at most 1024 characters
+	DeploymentStrategyDescription *string `json:"deployment_strategy_description,omitempty" hcl:"deployment_strategy_description,attr"`
+
+	// Total amount of time for a deployment to last. Minimum value of 0, maximum value of 1440
+	//
+	// Default: 0
+	DeploymentStrategyDeploymentDurationInMinutes *int `json:"deployment_strategy_deployment_duration_in_minutes,omitempty" hcl:"deployment_strategy_deployment_duration_in_minutes,attr"`
+
+	// Total amount of time for a deployment to last. Minimum value of 0, maximum value of 1440
+	//
+	// Default: 0
+	DeploymentStrategyFinalBakeTimeInMinutes *int `json:"deployment_strategy_final_bake_time_in_minutes,omitempty" hcl:"deployment_strategy_final_bake_time_in_minutes,attr"`
+
+	// The percentage of targets to receive a deployed configuration during each interval. Minimum value of 1, maximum value of 100
+	//
+	// Default: 100
+	DeploymentStrategyGrowthFactor *int `json:"deployment_strategy_growth_factor,omitempty" hcl:"deployment_strategy_growth_factor,attr"`
+
+	// The algorithm used to define how percentage grows over time. Valid value: `LINEAR` and `EXPONENTIAL`. Defaults to `LINEAR`
+	DeploymentStrategyGrowthType *string `json:"deployment_strategy_growth_type,omitempty" hcl:"deployment_strategy_growth_type,attr"`
+
+	// Where to save the deployment strategy. Valid values: `NONE` and `SSM_DOCUMENT`
+	//
+	// Default: "NONE"
+	DeploymentStrategyReplicateTo *string `json:"deployment_strategy_replicate_to,omitempty" hcl:"deployment_strategy_replicate_to,attr"`
+
+	// A map of additional tags to apply to the deployment strategy
+	//
+	// Default: {}
+	DeploymentStrategyTags map[string]string `json:"deployment_strategy_tags,omitempty" hcl:"deployment_strategy_tags,attr"`
+
+	// A description of the deployment. Can be at most 1024 characters
+	DeploymentDescription *string `json:"deployment_description,omitempty" hcl:"deployment_description,attr"`
+
+	// The configuration version to deploy. Can be at most 1024 characters
+	DeploymentConfigurationVersion *string `json:"deployment_configuration_version,omitempty" hcl:"deployment_configuration_version,attr"`
+
+	// A map of additional tags to apply to the deployment
+	//
+	// Default: {}
+	DeploymentTags map[string]string `json:"deployment_tags,omitempty" hcl:"deployment_tags,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/appconfig/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// ApplicationARN references application_arn: The Amazon Resource Name (ARN) of the AppConfig Application
+func (o Outputs) ApplicationARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "application_arn")
+}
+
+// ApplicationID references application_id: The AppConfig application ID
+func (o Outputs) ApplicationID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "application_id")
+}
+
+// Environments references environments: The AppConfig environments
+func (o Outputs) Environments() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "environments")
+}
+
+// ConfigurationProfileARN references configuration_profile_arn: The Amazon Resource Name (ARN) of the AppConfig Configuration Profile
+func (o Outputs) ConfigurationProfileARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "configuration_profile_arn")
+}
+
+// ConfigurationProfileConfigurationProfileID references configuration_profile_configuration_profile_id: The configuration profile ID
+func (o Outputs) ConfigurationProfileConfigurationProfileID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "configuration_profile_configuration_profile_id")
+}
+
+// ConfigurationProfileID references configuration_profile_id: The AppConfig configuration profile ID and application ID separated by a colon (:)
+func (o Outputs) ConfigurationProfileID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "configuration_profile_id")
+}
+
+// HostedConfigurationVersionARN references hosted_configuration_version_arn: The Amazon Resource Name (ARN) of the AppConfig hosted configuration version
+func (o Outputs) HostedConfigurationVersionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "hosted_configuration_version_arn")
+}
+
+// HostedConfigurationVersionID references hosted_configuration_version_id: The AppConfig application ID, configuration profile ID, and version number separated by a slash (/)
+func (o Outputs) HostedConfigurationVersionID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "hosted_configuration_version_id")
+}
+
+// HostedConfigurationVersionVersionNumber references hosted_configuration_version_version_number: The version number of the hosted configuration
+func (o Outputs) HostedConfigurationVersionVersionNumber() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "hosted_configuration_version_version_number")
+}
+
+// DeploymentStrategyARN references deployment_strategy_arn: The Amazon Resource Name (ARN) of the AppConfig Deployment Strategy
+func (o Outputs) DeploymentStrategyARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "deployment_strategy_arn")
+}
+
+// DeploymentStrategyID references deployment_strategy_id: The AppConfig deployment strategy ID
+func (o Outputs) DeploymentStrategyID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "deployment_strategy_id")
+}
+
+// Deployments references deployments: The AppConfig deployments
+func (o Outputs) Deployments() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "deployments")
+}
+
+// RetrievalRoleARN references retrieval_role_arn: Amazon Resource Name (ARN) specifying the retrieval role
+func (o Outputs) RetrievalRoleARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_arn")
+}
+
+// RetrievalRoleID references retrieval_role_id: Name of the retrieval role
+func (o Outputs) RetrievalRoleID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_id")
+}
+
+// RetrievalRoleUniqueID references retrieval_role_unique_id: Stable and unique string identifying the retrieval role
+func (o Outputs) RetrievalRoleUniqueID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_unique_id")
+}
+
+// RetrievalRoleName references retrieval_role_name: Name of the retrieval role
+func (o Outputs) RetrievalRoleName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_name")
+}
+
+// RetrievalRolePolicyARN references retrieval_role_policy_arn: The ARN assigned by AWS to the retrieval role policy
+func (o Outputs) RetrievalRolePolicyARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_policy_arn")
+}
+
+// RetrievalRolePolicyID references retrieval_role_policy_id: The ARN assigned by AWS to the retrieval role policy
+func (o Outputs) RetrievalRolePolicyID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_policy_id")
+}
+
+// RetrievalRolePolicyName references retrieval_role_policy_name: The name of the policy
+func (o Outputs) RetrievalRolePolicyName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_policy_name")
+}
+
+// RetrievalRolePolicyPolicy references retrieval_role_policy_policy: The retrieval role policy document
+func (o Outputs) RetrievalRolePolicyPolicy() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_policy_policy")
+}
+
+// RetrievalRolePolicyPolicyID references retrieval_role_policy_policy_id: The retrieval role policy ID
+func (o Outputs) RetrievalRolePolicyPolicyID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "retrieval_role_policy_policy_id")
+}
@@ -1,8 +1,18 @@
 // Package appsync provides type-safe Terraform module definitions for terraform-aws-modules/appsync/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-appsync v2.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/appsync (see variables_gen.go).
 package appsync
 
-import "strconv"
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module appsync -source terraform-aws-modules/appsync/aws
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
 
 // Module represents the terraform-aws-modules/appsync/aws module.
 // All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
@@ -422,7 +432,7 @@ type Function struct {
 // NewModule creates a new AppSync module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/appsync/aws"
-	version := "~> 2.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	authType := "API_KEY"
 	createLogsRole := true
@@ -590,8 +600,17 @@ func (m *Module) LocalName() string {
 	return "graphql_api"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 func (m *Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid appsync module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
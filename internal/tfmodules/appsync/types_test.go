@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 // TestNewModule tests module creation with defaults.
@@ -15,7 +17,7 @@ func TestNewModule(t *testing.T) {
 
 		require.NotNil(t, m)
 		assert.Equal(t, "terraform-aws-modules/appsync/aws", m.Source)
-		assert.Equal(t, "~> 2.0", m.Version)
+		assert.Equal(t, "~> 4.0", m.Version)
 		assert.Equal(t, name, *m.Name)
 		assert.True(t, *m.CreateGraphQLAPI)
 		assert.Equal(t, "API_KEY", *m.AuthenticationType)
@@ -451,13 +453,32 @@ func TestModuleLocalName(t *testing.T) {
 
 // TestModuleConfiguration tests HCL configuration generation.
 func TestModuleConfiguration(t *testing.T) {
-	t.Run("returns empty string currently", func(t *testing.T) {
+	t.Run("generates valid HCL for basic API", func(t *testing.T) {
 		m := NewModule("api")
 
 		config, err := m.Configuration()
 
-		assert.NoError(t, err)
-		assert.Empty(t, config)
+		require.NoError(t, err)
+		golden.Assert(t, "basic_api", config)
+	})
+
+	t.Run("generates HCL with data sources and resolvers", func(t *testing.T) {
+		dataSource := "orders"
+		m := NewModule("orders_api").
+			WithSchema("type Query { order(id: ID!): Order }").
+			WithIAMAuth().
+			WithLambdaDataSource("orders", "${module.orders.lambda_function_arn}").
+			WithResolver("Query.order", Resolver{
+				Type:       "Query",
+				Field:      "order",
+				DataSource: &dataSource,
+			}).
+			WithTags(map[string]string{"Team": "platform"})
+
+		config, err := m.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "resolvers", config)
 	})
 }
 
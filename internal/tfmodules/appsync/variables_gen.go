@@ -0,0 +1,346 @@
+// Code generated by tfmodules-gen from .forge/modules/appsync. DO NOT EDIT.
+
+package appsync
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/appsync/aws these types were generated from.
+const ModuleVersion = "4.0.1"
+
+// Variables holds every input declared by terraform-aws-modules/appsync/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Whether to create GraphQL API
+	//
+	// Default: true
+	CreateGraphqlAPI *bool `json:"create_graphql_api,omitempty" hcl:"create_graphql_api,attr"`
+
+	// Whether to enable Cloudwatch logging on GraphQL API
+	//
+	// Default: false
+	LoggingEnabled *bool `json:"logging_enabled,omitempty" hcl:"logging_enabled,attr"`
+
+	// Whether to enable domain name association on GraphQL API
+	//
+	// Default: false
+	DomainNameAssociationEnabled *bool `json:"domain_name_association_enabled,omitempty" hcl:"domain_name_association_enabled,attr"`
+
+	// Whether caching with Elasticache is enabled.
+	//
+	// Default: false
+	CachingEnabled *bool `json:"caching_enabled,omitempty" hcl:"caching_enabled,attr"`
+
+	// Whether tracing with X-ray is enabled.
+	//
+	// Default: false
+	XrayEnabled *bool `json:"xray_enabled,omitempty" hcl:"xray_enabled,attr"`
+
+	// Name of GraphQL API
+	//
+	// Default: ""
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// The schema definition, in GraphQL schema language format. Terraform cannot perform drift detection of this configuration.
+	//
+	// Default: ""
+	Schema *string `json:"schema,omitempty" hcl:"schema,attr"`
+
+	// The API visibility. Valid values: GLOBAL, PRIVATE.
+	Visibility *string `json:"visibility,omitempty" hcl:"visibility,attr"`
+
+	// The authentication type to use by GraphQL API
+	//
+	// Default: "API_KEY"
+	AuthenticationType *string `json:"authentication_type,omitempty" hcl:"authentication_type,attr"`
+
+	// Whether to create service role for Cloudwatch logs
+	//
+	// Default: true
+	CreateLogsRole *bool `json:"create_logs_role,omitempty" hcl:"create_logs_role,attr"`
+
+	// Name of IAM role to create for Cloudwatch logs
+	LogsRoleName *string `json:"logs_role_name,omitempty" hcl:"logs_role_name,attr"`
+
+	// Description for the IAM role to create for Cloudwatch logs
+	LogsRoleDescription *string `json:"logs_role_description,omitempty" hcl:"logs_role_description,attr"`
+
+	// Amazon Resource Name of the service role that AWS AppSync will assume to publish to Amazon CloudWatch logs in your account.
+	LogCloudwatchLogsRoleARN *string `json:"log_cloudwatch_logs_role_arn,omitempty" hcl:"log_cloudwatch_logs_role_arn,attr"`
+
+	// Field logging level. Valid values: ALL, ERROR, NONE.
+	LogFieldLogLevel *string `json:"log_field_log_level,omitempty" hcl:"log_field_log_level,attr"`
+
+	// Set to TRUE to exclude sections that contain information such as headers, context, and evaluated mapping templates, regardless of logging level.
+	//
+	// Default: false
+	LogExcludeVerboseContent *bool `json:"log_exclude_verbose_content,omitempty" hcl:"log_exclude_verbose_content,attr"`
+
+	// Nested argument containing Lambda authorizer configuration.
+	//
+	// Default: {}
+	LambdaAuthorizerConfig map[string]string `json:"lambda_authorizer_config,omitempty" hcl:"lambda_authorizer_config,attr"`
+
+	// Nested argument containing OpenID Connect configuration.
+	//
+	// Default: {}
+	OpenidConnectConfig map[string]string `json:"openid_connect_config,omitempty" hcl:"openid_connect_config,attr"`
+
+	// The Amazon Cognito User Pool configuration.
+	//
+	// Default: {}
+	UserPoolConfig map[string]string `json:"user_pool_config,omitempty" hcl:"user_pool_config,attr"`
+
+	// One or more additional authentication providers for the GraphqlApi.
+	//
+	// Default: {}
+	AdditionalAuthenticationProvider interface{} `json:"additional_authentication_provider,omitempty" hcl:"additional_authentication_provider,attr"`
+
+	// Nested argument containing Lambda Ehanced metrics configuration.
+	//
+	// Default: {}
+	EnhancedMetricsConfig map[string]string `json:"enhanced_metrics_config,omitempty" hcl:"enhanced_metrics_config,attr"`
+
+	// Map of tags to add to GraphQL API
+	//
+	// Default: {}
+	GraphqlAPITags map[string]string `json:"graphql_api_tags,omitempty" hcl:"graphql_api_tags,attr"`
+
+	// Map of tags to add to Cloudwatch logs IAM role
+	//
+	// Default: {}
+	LogsRoleTags map[string]string `json:"logs_role_tags,omitempty" hcl:"logs_role_tags,attr"`
+
+	// Map of tags to add to all GraphQL resources created by this module
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// The domain name that AppSync gets associated with.
+	//
+	// Default: ""
+	DomainName *string `json:"domain_name,omitempty" hcl:"domain_name,attr"`
+
+	// A description of the Domain Name.
+	DomainNameDescription *string `json:"domain_name_description,omitempty" hcl:"domain_name_description,attr"`
+
+	// The Amazon Resource Name (ARN) of the certificate.
+	//
+	// Default: ""
+	CertificateARN *string `json:"certificate_arn,omitempty" hcl:"certificate_arn,attr"`
+
+	// Caching behavior.
+	//
+	// Default: "FULL_REQUEST_CACHING"
+	CachingBehavior *string `json:"caching_behavior,omitempty" hcl:"caching_behavior,attr"`
+
+	// The cache instance type.
+	//
+	// Default: "SMALL"
+	CacheType *string `json:"cache_type,omitempty" hcl:"cache_type,attr"`
+
+	// TTL in seconds for cache entries
+	//
+	// Default: 1
+	CacheTTL *int `json:"cache_ttl,omitempty" hcl:"cache_ttl,attr"`
+
+	// At-rest encryption flag for cache.
+	//
+	// Default: false
+	CacheAtRestEncryptionEnabled *bool `json:"cache_at_rest_encryption_enabled,omitempty" hcl:"cache_at_rest_encryption_enabled,attr"`
+
+	// Transit encryption flag when connecting to cache.
+	//
+	// Default: false
+	CacheTransitEncryptionEnabled *bool `json:"cache_transit_encryption_enabled,omitempty" hcl:"cache_transit_encryption_enabled,attr"`
+
+	// Map of API keys to create
+	//
+	// Default: {}
+	APIKeys map[string]string `json:"api_keys,omitempty" hcl:"api_keys,attr"`
+
+	// List of allowed IAM actions for datasources type AWS_LAMBDA
+	//
+	// Default: ["lambda:invokeFunction"]
+	LambdaAllowedActions []string `json:"lambda_allowed_actions,omitempty" hcl:"lambda_allowed_actions,attr"`
+
+	// List of allowed IAM actions for datasources type AMAZON_DYNAMODB
+	//
+	// Default: ["dynamodb:GetItem", "dynamodb:PutItem", "dynamodb:DeleteItem", "dynamodb:UpdateItem", "dynamodb:Query", "dynamodb:Scan", "dynamodb:BatchGetItem", "dynamodb:BatchWriteItem"]
+	DynamodbAllowedActions []string `json:"dynamodb_allowed_actions,omitempty" hcl:"dynamodb_allowed_actions,attr"`
+
+	// List of allowed IAM actions for datasources type AMAZON_ELASTICSEARCH
+	//
+	// Default: ["es:ESHttpDelete", "es:ESHttpHead", "es:ESHttpGet", "es:ESHttpPost", "es:ESHttpPut"]
+	ElasticsearchAllowedActions []string `json:"elasticsearch_allowed_actions,omitempty" hcl:"elasticsearch_allowed_actions,attr"`
+
+	// List of allowed IAM actions for datasources type AMAZON_OPENSEARCH_SERVICE
+	//
+	// Default: ["es:ESHttpDelete", "es:ESHttpHead", "es:ESHttpGet", "es:ESHttpPost", "es:ESHttpPut"]
+	OpensearchserviceAllowedActions []string `json:"opensearchservice_allowed_actions,omitempty" hcl:"opensearchservice_allowed_actions,attr"`
+
+	// List of allowed IAM actions for datasources type AMAZON_EVENTBRIDGE
+	//
+	// Default: ["events:PutEvents"]
+	EventbridgeAllowedActions []string `json:"eventbridge_allowed_actions,omitempty" hcl:"eventbridge_allowed_actions,attr"`
+
+	// List of allowed IAM actions for datasources type RELATIONAL_DATABASE
+	//
+	// Default: ["rds-data:BatchExecuteStatement", "rds-data:BeginTransaction", "rds-data:CommitTransaction", "rds-data:ExecuteStatement", "rds-data:RollbackTransaction"]
+	RelationalDatabaseAllowedActions []string `json:"relational_database_allowed_actions,omitempty" hcl:"relational_database_allowed_actions,attr"`
+
+	// List of allowed IAM actions for secrets manager datasources type RELATIONAL_DATABASE
+	//
+	// Default: ["secretsmanager:GetSecretValue"]
+	SecretsManagerAllowedActions []string `json:"secrets_manager_allowed_actions,omitempty" hcl:"secrets_manager_allowed_actions,attr"`
+
+	// ARN for iam permissions boundary
+	IAMPermissionsBoundary *string `json:"iam_permissions_boundary,omitempty" hcl:"iam_permissions_boundary,attr"`
+
+	// VTL request template for the direct lambda integrations
+	//
+	// Default:
+	//
+	//	<<-EOF
+	//	{
+	//	  "version" : "2017-02-28",
+	//	  "operation": "Invoke",
+	//	  "payload": {
+	//	    "arguments": $util.toJson($ctx.arguments),
+	//	    "identity": $util.toJson($ctx.identity),
+	//	    "source": $util.toJson($ctx.source),
+	//	    "request": $util.toJson($ctx.request),
+	//	    "prev": $util.toJson($ctx.prev),
+	//	    "info": {
+	//	        "selectionSetList": $util.toJson($ctx.info.selectionSetList),
+	//	        "selectionSetGraphQL": $util.toJson($ctx.info.selectionSetGraphQL),
+	//	        "parentTypeName": $util.toJson($ctx.info.parentTypeName),
+	//	        "fieldName": $util.toJson($ctx.info.fieldName),
+	//	        "variables": $util.toJson($ctx.info.variables)
+	//	    },
+	//	    "stash": $util.toJson($ctx.stash)
+	//	  }
+	//	}
+	//	EOF
+	DirectLambdaRequestTemplate *string `json:"direct_lambda_request_template,omitempty" hcl:"direct_lambda_request_template,attr"`
+
+	// VTL response template for the direct lambda integrations
+	//
+	// Default:
+	//
+	//	<<-EOF
+	//	$util.toJson($ctx.result)
+	//	EOF
+	DirectLambdaResponseTemplate *string `json:"direct_lambda_response_template,omitempty" hcl:"direct_lambda_response_template,attr"`
+
+	// Default caching TTL for resolvers when caching is enabled
+	//
+	// Default: 60
+	ResolverCachingTTL *int `json:"resolver_caching_ttl,omitempty" hcl:"resolver_caching_ttl,attr"`
+
+	// Map of datasources to create
+	//
+	// Default: {}
+	Datasources interface{} `json:"datasources,omitempty" hcl:"datasources,attr"`
+
+	// Map of resolvers to create
+	//
+	// Default: {}
+	Resolvers interface{} `json:"resolvers,omitempty" hcl:"resolvers,attr"`
+
+	// Map of functions to create
+	//
+	// Default: {}
+	Functions interface{} `json:"functions,omitempty" hcl:"functions,attr"`
+
+	// Whether to enable or disable introspection of the GraphQL API.
+	IntrospectionConfig *string `json:"introspection_config,omitempty" hcl:"introspection_config,attr"`
+
+	// The maximum depth a query can have in a single request.
+	QueryDepthLimit *int `json:"query_depth_limit,omitempty" hcl:"query_depth_limit,attr"`
+
+	// The maximum number of resolvers that can be invoked in a single request.
+	ResolverCountLimit *int `json:"resolver_count_limit,omitempty" hcl:"resolver_count_limit,attr"`
+
+	// Region where the resource(s) will be managed. Defaults to the region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/appsync/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// AppsyncGraphqlAPIID references appsync_graphql_api_id: ID of GraphQL API
+func (o Outputs) AppsyncGraphqlAPIID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_graphql_api_id")
+}
+
+// AppsyncGraphqlAPIARN references appsync_graphql_api_arn: ARN of GraphQL API
+func (o Outputs) AppsyncGraphqlAPIARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_graphql_api_arn")
+}
+
+// AppsyncGraphqlAPIUris references appsync_graphql_api_uris: Map of URIs associated with the API
+func (o Outputs) AppsyncGraphqlAPIUris() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_graphql_api_uris")
+}
+
+// AppsyncAPIKeyID references appsync_api_key_id: Map of API Key ID (Formatted as ApiId:Key)
+func (o Outputs) AppsyncAPIKeyID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_api_key_id")
+}
+
+// AppsyncAPIKeyKey references appsync_api_key_key: Map of API Keys
+func (o Outputs) AppsyncAPIKeyKey() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_api_key_key")
+}
+
+// AppsyncDatasourceARN references appsync_datasource_arn: Map of ARNs of datasources
+func (o Outputs) AppsyncDatasourceARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_datasource_arn")
+}
+
+// AppsyncResolverARN references appsync_resolver_arn: Map of ARNs of resolvers
+func (o Outputs) AppsyncResolverARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_resolver_arn")
+}
+
+// AppsyncFunctionARN references appsync_function_arn: Map of ARNs of functions
+func (o Outputs) AppsyncFunctionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_function_arn")
+}
+
+// AppsyncFunctionID references appsync_function_id: Map of IDs of functions
+func (o Outputs) AppsyncFunctionID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_function_id")
+}
+
+// AppsyncFunctionFunctionID references appsync_function_function_id: Map of function IDs of functions
+func (o Outputs) AppsyncFunctionFunctionID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_function_function_id")
+}
+
+// AppsyncDomainID references appsync_domain_id: The Appsync Domain Name.
+func (o Outputs) AppsyncDomainID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_domain_id")
+}
+
+// AppsyncDomainName references appsync_domain_name: The domain name that AppSync provides.
+func (o Outputs) AppsyncDomainName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_domain_name")
+}
+
+// AppsyncDomainHostedZoneID references appsync_domain_hosted_zone_id: The ID of your Amazon Route 53 hosted zone.
+func (o Outputs) AppsyncDomainHostedZoneID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_domain_hosted_zone_id")
+}
+
+// AppsyncGraphqlAPIFqdns references appsync_graphql_api_fqdns: Map of FQDNs associated with the API (no protocol and path)
+func (o Outputs) AppsyncGraphqlAPIFqdns() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "appsync_graphql_api_fqdns")
+}
@@ -0,0 +1,126 @@
+// Package catalog maps Terraform module sources to forge's typed tfmodules
+// packages, so module calls in existing .tf files can be decoded back into
+// typed structs, edited, and rendered again.
+package catalog
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/apigatewayv2"
+	"github.com/lewis/forge/internal/tfmodules/appconfig"
+	"github.com/lewis/forge/internal/tfmodules/appsync"
+	"github.com/lewis/forge/internal/tfmodules/cloudfront"
+	"github.com/lewis/forge/internal/tfmodules/dynamodb"
+	"github.com/lewis/forge/internal/tfmodules/eventbridge"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/lambda"
+	"github.com/lewis/forge/internal/tfmodules/s3"
+	"github.com/lewis/forge/internal/tfmodules/secretsmanager"
+	"github.com/lewis/forge/internal/tfmodules/sns"
+	"github.com/lewis/forge/internal/tfmodules/sqs"
+	"github.com/lewis/forge/internal/tfmodules/ssm"
+	"github.com/lewis/forge/internal/tfmodules/stepfunctions"
+)
+
+// registryHost is the default registry; "registry.terraform.io/x/y/aws" and "x/y/aws" are the same source.
+const registryHost = "registry.terraform.io/"
+
+// modules returns an empty typed module for each supported registry source.
+var modules = map[string]func() tfmodules.Module{
+	"terraform-aws-modules/apigateway-v2/aws":   func() tfmodules.Module { return &apigatewayv2.Module{} },
+	"terraform-aws-modules/appconfig/aws":       func() tfmodules.Module { return &appconfig.Module{} },
+	"terraform-aws-modules/appsync/aws":         func() tfmodules.Module { return &appsync.Module{} },
+	"terraform-aws-modules/cloudfront/aws":      func() tfmodules.Module { return &cloudfront.Module{} },
+	"terraform-aws-modules/dynamodb-table/aws":  func() tfmodules.Module { return &dynamodb.Module{} },
+	"terraform-aws-modules/eventbridge/aws":     func() tfmodules.Module { return &eventbridge.Module{} },
+	"terraform-aws-modules/lambda/aws":          func() tfmodules.Module { return &lambda.Module{} },
+	"terraform-aws-modules/s3-bucket/aws":       func() tfmodules.Module { return &s3.Module{} },
+	"terraform-aws-modules/secrets-manager/aws": func() tfmodules.Module { return &secretsmanager.Module{} },
+	"terraform-aws-modules/sns/aws":             func() tfmodules.Module { return &sns.Module{} },
+	"terraform-aws-modules/sqs/aws":             func() tfmodules.Module { return &sqs.Module{} },
+	"terraform-aws-modules/ssm-parameter/aws":   func() tfmodules.Module { return &ssm.Module{} },
+	"terraform-aws-modules/step-functions/aws":  func() tfmodules.Module { return &stepfunctions.Module{} },
+}
+
+// ParsedModule is a module call decoded from Terraform source. It implements
+// tfmodules.Module, so parsed modules can be edited and added to a Stack.
+type ParsedModule struct {
+	// Block holds the call's label, source, version and the arguments the
+	// typed module has no field for.
+	Block hclgen.ModuleBlock
+
+	// Module is the typed module the call decoded into (e.g. *sqs.Module),
+	// or nil when forge has no package for the source.
+	Module tfmodules.Module
+}
+
+// LocalName returns the module block's label.
+func (p *ParsedModule) LocalName() string {
+	return p.Block.LocalName
+}
+
+// Validate validates the typed module, if any.
+// PURE: Calculation.
+func (p *ParsedModule) Validate() error {
+	if p.Module == nil {
+		return nil
+	}
+	return tfmodules.WithValidation(p.Module)
+}
+
+// Configuration validates the typed module and renders the module block again
+// from it and the arguments it couldn't hold.
+// PURE: Same module configuration always produces the same HCL output.
+func (p *ParsedModule) Configuration() (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", fmt.Errorf("invalid module %s: %w", p.LocalName(), err)
+	}
+	return p.Block.ToHCLWrite(p.Module)
+}
+
+// New returns an empty typed module for a registry source, ignoring the
+// default registry host.
+// PURE: Calculation.
+func New(source string) (tfmodules.Module, bool) {
+	newModule, ok := modules[strings.TrimPrefix(source, registryHost)]
+	if !ok {
+		return nil, false
+	}
+	return newModule(), true
+}
+
+// Sources returns the registry sources with a typed package, sorted.
+// PURE: Calculation.
+func Sources() []string {
+	sources := make([]string, 0, len(modules))
+	for source := range modules {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// Parse decodes every module block in src, in file order. Calls to sources
+// without a typed package are kept with a nil Module and all their arguments
+// in Block.Extra.
+// PURE: Calculation.
+func Parse(src []byte, filename string) ([]*ParsedModule, error) {
+	headers, err := hclgen.ParseModuleBlocks(src, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make([]*ParsedModule, 0, len(headers))
+	for _, header := range headers {
+		module, _ := New(header.Source)
+		block, err := hclgen.FromHCL(src, filename, header.LocalName, module)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode module %s: %w", header.LocalName, err)
+		}
+		parsed = append(parsed, &ParsedModule{Block: block, Module: module})
+	}
+	return parsed, nil
+}
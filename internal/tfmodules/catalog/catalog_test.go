@@ -0,0 +1,338 @@
+package catalog
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/apigatewayv2"
+	"github.com/lewis/forge/internal/tfmodules/appconfig"
+	"github.com/lewis/forge/internal/tfmodules/appsync"
+	"github.com/lewis/forge/internal/tfmodules/cloudfront"
+	"github.com/lewis/forge/internal/tfmodules/dynamodb"
+	"github.com/lewis/forge/internal/tfmodules/eventbridge"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/lambda"
+	"github.com/lewis/forge/internal/tfmodules/s3"
+	"github.com/lewis/forge/internal/tfmodules/secretsmanager"
+	"github.com/lewis/forge/internal/tfmodules/sns"
+	"github.com/lewis/forge/internal/tfmodules/sqs"
+	"github.com/lewis/forge/internal/tfmodules/ssm"
+	"github.com/lewis/forge/internal/tfmodules/stepfunctions"
+)
+
+// generatedVariables holds the generated Variables struct of every module in the catalog.
+var generatedVariables = map[string]any{
+	"terraform-aws-modules/apigateway-v2/aws":   apigatewayv2.Variables{},
+	"terraform-aws-modules/appconfig/aws":       appconfig.Variables{},
+	"terraform-aws-modules/appsync/aws":         appsync.Variables{},
+	"terraform-aws-modules/cloudfront/aws":      cloudfront.Variables{},
+	"terraform-aws-modules/dynamodb-table/aws":  dynamodb.Variables{},
+	"terraform-aws-modules/eventbridge/aws":     eventbridge.Variables{},
+	"terraform-aws-modules/lambda/aws":          lambda.Variables{},
+	"terraform-aws-modules/s3-bucket/aws":       s3.Variables{},
+	"terraform-aws-modules/secrets-manager/aws": secretsmanager.Variables{},
+	"terraform-aws-modules/sns/aws":             sns.Variables{},
+	"terraform-aws-modules/sqs/aws":             sqs.Variables{},
+	"terraform-aws-modules/ssm-parameter/aws":   ssm.Variables{},
+	"terraform-aws-modules/step-functions/aws":  stepfunctions.Variables{},
+}
+
+// hclArguments returns the argument names of v's hcl struct tags.
+func hclArguments(v any) map[string]bool {
+	args := make(map[string]bool)
+	typ := reflect.Indirect(reflect.ValueOf(v)).Type()
+	for i := range typ.NumField() {
+		if name, _, _ := strings.Cut(typ.Field(i).Tag.Get("hcl"), ","); name != "" {
+			args[name] = true
+		}
+	}
+	return args
+}
+
+// TestModulesMatchVariables checks that every argument a hand-written Module
+// can render is a variable of the vendored release its package was generated from.
+func TestModulesMatchVariables(t *testing.T) {
+	require.Len(t, generatedVariables, len(Sources()))
+
+	for _, source := range Sources() {
+		t.Run(source, func(t *testing.T) {
+			module, ok := New(source)
+			require.True(t, ok)
+			variables, ok := generatedVariables[source]
+			require.True(t, ok, "no generated Variables for %s", source)
+
+			declared := hclArguments(variables)
+			for name := range hclArguments(module) {
+				if name == "source" || name == "version" {
+					continue
+				}
+				assert.True(t, declared[name], "Module renders %q, which %s does not declare", name, source)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Run("returns an empty typed module for a known source", func(t *testing.T) {
+		module, ok := New("terraform-aws-modules/sqs/aws")
+		require.True(t, ok)
+		assert.Equal(t, &sqs.Module{}, module)
+	})
+
+	t.Run("ignores the default registry host", func(t *testing.T) {
+		_, ok := New("registry.terraform.io/terraform-aws-modules/sqs/aws")
+		assert.True(t, ok)
+	})
+
+	t.Run("rejects unknown sources", func(t *testing.T) {
+		_, ok := New("./modules/custom")
+		assert.False(t, ok)
+	})
+
+	t.Run("lists every source", func(t *testing.T) {
+		assert.Len(t, Sources(), 13)
+		assert.Contains(t, Sources(), "terraform-aws-modules/lambda/aws")
+	})
+}
+
+func TestParse(t *testing.T) {
+	src := `
+module "orders" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "~> 4.0"
+
+  name                       = "${var.namespace}orders"
+  visibility_timeout_seconds = 60
+  fifo_queue                 = true
+  kms_master_key_id          = module.keys.key_arn
+  some_future_input          = 42
+}
+
+module "custom" {
+  source = "./modules/custom"
+  queue  = module.orders.queue_arn
+}
+`
+
+	parsed, err := Parse([]byte(src), "main.tf")
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+
+	t.Run("decodes known sources into typed modules", func(t *testing.T) {
+		queue, ok := parsed[0].Module.(*sqs.Module)
+		require.True(t, ok)
+
+		assert.Equal(t, "orders", parsed[0].LocalName())
+		assert.Equal(t, "~> 4.0", queue.Version)
+		require.NotNil(t, queue.VisibilityTimeoutSeconds)
+		assert.Equal(t, 60, *queue.VisibilityTimeoutSeconds)
+		require.NotNil(t, queue.KmsMasterKeyID)
+		assert.Equal(t, "${module.keys.key_arn}", *queue.KmsMasterKeyID)
+		require.NotNil(t, queue.Name)
+		assert.Equal(t, "${var.namespace}orders", *queue.Name)
+		assert.Equal(t, map[string]string{"some_future_input": "42"}, parsed[0].Block.Extra)
+	})
+
+	t.Run("keeps calls to unknown sources", func(t *testing.T) {
+		assert.Nil(t, parsed[1].Module)
+		assert.Equal(t, "custom", parsed[1].LocalName())
+		assert.Equal(t, map[string]string{"queue": "module.orders.queue_arn"}, parsed[1].Block.Extra)
+
+		config, err := parsed[1].Configuration()
+		require.NoError(t, err)
+		assert.Contains(t, config, "queue  = module.orders.queue_arn")
+	})
+
+	t.Run("renders edits to the typed module", func(t *testing.T) {
+		queue := parsed[0].Module.(*sqs.Module)
+		queue.WithoutDLQ()
+
+		config, err := parsed[0].Configuration()
+		require.NoError(t, err)
+		assert.Contains(t, config, "create_dlq")
+		assert.Contains(t, config, `name                       = "${var.namespace}orders"`)
+		assert.Contains(t, config, "kms_master_key_id          = module.keys.key_arn")
+	})
+
+	t.Run("validates the typed module", func(t *testing.T) {
+		invalid, err := Parse([]byte(`module "q" {
+  source        = "terraform-aws-modules/sqs/aws"
+  delay_seconds = 9000
+}`), "main.tf")
+		require.NoError(t, err)
+
+		_, err = invalid[0].Configuration()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "delay_seconds: must be at most 900, got 9000")
+	})
+
+	t.Run("fails on invalid HCL", func(t *testing.T) {
+		_, err := Parse([]byte(`module "x" {`), "main.tf")
+		assert.Error(t, err)
+	})
+}
+
+// TestRoundTrip_GoldenFiles checks that every module package's golden output
+// parses back into its typed module and renders byte for byte the same.
+func TestRoundTrip_GoldenFiles(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "*", "testdata", "*.golden"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		t.Run(filepath.Base(filepath.Dir(filepath.Dir(path)))+"/"+filepath.Base(path), func(t *testing.T) {
+			src, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			parsed, err := Parse(src, path)
+			require.NoError(t, err)
+			require.Len(t, parsed, 1)
+			require.NotNil(t, parsed[0].Module, "no typed package for %s", parsed[0].Block.Source)
+			assert.Empty(t, parsed[0].Block.Extra, "every rendered argument should decode into a field")
+
+			rendered, err := parsed[0].Configuration()
+			require.NoError(t, err)
+			assert.Equal(t, string(src), rendered)
+		})
+	}
+}
+
+// TestRoundTrip_Property checks that render -> parse -> render is stable for
+// randomly populated modules of every package.
+func TestRoundTrip_Property(t *testing.T) {
+	const iterations = 25
+
+	for _, source := range Sources() {
+		t.Run(source, func(t *testing.T) {
+			for seed := int64(0); seed < iterations; seed++ {
+				module, ok := New(source)
+				require.True(t, ok)
+				fill(rand.New(rand.NewSource(seed)), reflect.ValueOf(module).Elem(), 0)
+
+				block := hclgen.ModuleBlock{LocalName: "subject", Source: source, Version: "~> 1.0"}
+				first, err := block.ToHCLWrite(module)
+				require.NoError(t, err, "seed %d", seed)
+
+				parsed, err := Parse([]byte(first), "main.tf")
+				require.NoError(t, err, "seed %d:\n%s", seed, first)
+				require.Len(t, parsed, 1)
+				assert.Empty(t, parsed[0].Block.Extra, "seed %d: arguments left undecoded", seed)
+
+				// Random values ignore the validate tags, so render without validating
+				second, err := parsed[0].Block.ToHCLWrite(parsed[0].Module)
+				require.NoError(t, err, "seed %d", seed)
+				require.Equal(t, first, second, "seed %d", seed)
+			}
+		})
+	}
+}
+
+// randomStrings covers plain text, characters HCL must escape, literals that
+// look like references, and the string templates hclgen writes as expressions.
+var randomStrings = []string{
+	"orders", "Hello, world", "with \"quotes\"", "back\\slash", "multi\nline",
+	"tab\there", "price: $5", "not $${interpolated}", "%%{ directive }", "unicode ✓",
+	"module.docs", "${module.orders.queue_arn}", "${var.namespace}", "${local.tags}",
+	"${data.aws_region.current.name}", "${var.namespace}-orders", "${upper(var.name)}",
+	"heredoc\nwith ${var.stage}\n",
+	"arn:aws:sqs:us-east-1:123456789012:orders", "",
+}
+
+// randomKeys covers identifier keys and keys that must be quoted.
+var randomKeys = []string{"Name", "team", "cost-center", "with space", "a.b", "x1"}
+
+// fill populates v with random values. Collections always get at least one
+// element since empty ones are not rendered.
+func fill(r *rand.Rand, v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() || field.Tag.Get("hcl") == "" || field.Tag.Get("hcl") == "-" {
+				continue
+			}
+			if field.Name == "Source" || field.Name == "Version" || field.Name == "Region" {
+				continue
+			}
+			if r.Intn(2) == 0 {
+				fill(r, v.Field(i), depth+1)
+			}
+		}
+
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		fill(r, elem.Elem(), depth)
+		v.Set(elem)
+
+	case reflect.String:
+		v.SetString(randomStrings[r.Intn(len(randomStrings))])
+
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 0)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(r.Intn(2000) - 1000))
+
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(r.Intn(10000)) / 100)
+
+	case reflect.Slice:
+		n := 1 + r.Intn(3)
+		slice := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			fill(r, slice.Index(i), depth+1)
+		}
+		v.Set(slice)
+
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for i := 0; i < 1+r.Intn(3); i++ {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			fill(r, elem, depth+1)
+			m.SetMapIndex(reflect.ValueOf(randomKeys[r.Intn(len(randomKeys))]), elem)
+		}
+		v.Set(m)
+
+	case reflect.Interface:
+		v.Set(reflect.ValueOf(randomDynamic(r, depth)))
+	}
+}
+
+// randomDynamic returns a random value for an interface{} field, such as a
+// policy document.
+func randomDynamic(r *rand.Rand, depth int) interface{} {
+	choice := r.Intn(6)
+	if depth > 3 {
+		choice %= 4
+	}
+	switch choice {
+	case 0:
+		return randomStrings[r.Intn(len(randomStrings))]
+	case 1:
+		return r.Intn(2000) - 1000
+	case 2:
+		return r.Intn(2) == 0
+	case 3:
+		return float64(r.Intn(10000))/100 + 0.25
+	case 4:
+		list := make([]interface{}, 1+r.Intn(3))
+		for i := range list {
+			list[i] = randomDynamic(r, depth+1)
+		}
+		return list
+	default:
+		obj := make(map[string]interface{})
+		for i := 0; i < 1+r.Intn(3); i++ {
+			obj[strings.ToUpper(randomKeys[r.Intn(len(randomKeys))])] = randomDynamic(r, depth+1)
+		}
+		return obj
+	}
+}
@@ -1,7 +1,18 @@
 // Package cloudfront provides type-safe Terraform module definitions for terraform-aws-modules/cloudfront/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-cloudfront v3.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/cloudfront (see variables_gen.go).
 package cloudfront
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module cloudfront -source terraform-aws-modules/cloudfront/aws
+
+import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
+
 // Module represents the terraform-aws-modules/cloudfront/aws module.
 // All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
 type Module struct {
@@ -359,7 +370,7 @@ type OriginSSLProtocols struct {
 // NewModule creates a new CloudFront module with sensible defaults.
 func NewModule(comment string) *Module {
 	source := "terraform-aws-modules/cloudfront/aws"
-	version := "~> 3.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	enabled := true
 	httpVersion := "http2"
@@ -532,8 +543,17 @@ func (m *Module) LocalName() string {
 	return "distribution"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 func (m *Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid cloudfront module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -15,7 +17,7 @@ func TestNewModule(t *testing.T) {
 		// Verify basic properties
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/cloudfront/aws", module.Source)
-		assert.Equal(t, "~> 3.0", module.Version)
+		assert.Equal(t, "~> 5.0", module.Version)
 		assert.NotNil(t, module.Comment)
 		assert.Equal(t, comment, *module.Comment)
 
@@ -755,14 +757,28 @@ func TestModule_LocalName(t *testing.T) {
 }
 
 func TestModule_Configuration(t *testing.T) {
-	t.Run("returns empty string and nil error as placeholder", func(t *testing.T) {
+	t.Run("generates valid HCL for basic distribution", func(t *testing.T) {
 		module := NewModule("test")
 
 		config, err := module.Configuration()
 
-		// Current implementation is a placeholder
 		require.NoError(t, err)
-		assert.Empty(t, config)
+		golden.Assert(t, "basic_distribution", config)
+	})
+
+	t.Run("generates HCL with origins, cache behavior and certificate", func(t *testing.T) {
+		module := NewModule("website").
+			WithS3Origin("assets", "${module.assets.s3_bucket_bucket_regional_domain_name}", "origin-access-identity/cloudfront/E2QWRUHEXAMPLE").
+			WithDefaultCacheBehavior("assets", "redirect-to-https").
+			WithCertificate("arn:aws:acm:us-east-1:123456789012:certificate/abc", "TLSv1.2_2021").
+			WithAliases("www.example.com").
+			WithGeoRestriction("whitelist", []string{"US", "CA"}).
+			WithTags(map[string]string{"Team": "platform"})
+
+		config, err := module.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "website", config)
 	})
 }
 
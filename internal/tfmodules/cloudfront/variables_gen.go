@@ -0,0 +1,261 @@
+// Code generated by tfmodules-gen from .forge/modules/cloudfront. DO NOT EDIT.
+
+package cloudfront
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/cloudfront/aws these types were generated from.
+const ModuleVersion = "5.0.1"
+
+// Variables holds every input declared by terraform-aws-modules/cloudfront/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Controls if CloudFront distribution should be created
+	//
+	// Default: true
+	CreateDistribution *bool `json:"create_distribution,omitempty" hcl:"create_distribution,attr"`
+
+	// Controls if CloudFront origin access identity should be created
+	//
+	// Default: false
+	CreateOriginAccessIdentity *bool `json:"create_origin_access_identity,omitempty" hcl:"create_origin_access_identity,attr"`
+
+	// Map of CloudFront origin access identities (value as a comment)
+	//
+	// Default: {}
+	OriginAccessIdentities map[string]string `json:"origin_access_identities,omitempty" hcl:"origin_access_identities,attr"`
+
+	// Controls if CloudFront origin access control should be created
+	//
+	// Default: false
+	CreateOriginAccessControl *bool `json:"create_origin_access_control,omitempty" hcl:"create_origin_access_control,attr"`
+
+	// Map of CloudFront origin access control
+	//
+	// Default:
+	//
+	//	{
+	//	  s3 = {
+	//	    description      = "",
+	//	    origin_type      = "s3",
+	//	    signing_behavior = "always",
+	//	    signing_protocol = "sigv4"
+	//	  }
+	//	}
+	OriginAccessControl map[string]map[string]interface{} `json:"origin_access_control,omitempty" hcl:"origin_access_control,attr"`
+
+	// Extra CNAMEs (alternate domain names), if any, for this distribution.
+	Aliases []string `json:"aliases,omitempty" hcl:"aliases,attr"`
+
+	// Any comments you want to include about the distribution.
+	Comment *string `json:"comment,omitempty" hcl:"comment,attr"`
+
+	// Identifier of a continuous deployment policy. This argument should only be set on a production distribution.
+	ContinuousDeploymentPolicyID *string `json:"continuous_deployment_policy_id,omitempty" hcl:"continuous_deployment_policy_id,attr"`
+
+	// The object that you want CloudFront to return (for example, index.html) when an end user requests the root URL.
+	DefaultRootObject *string `json:"default_root_object,omitempty" hcl:"default_root_object,attr"`
+
+	// Whether the distribution is enabled to accept end user requests for content.
+	//
+	// Default: true
+	Enabled *bool `json:"enabled,omitempty" hcl:"enabled,attr"`
+
+	// The maximum HTTP version to support on the distribution. Allowed values are http1.1, http2, http2and3, and http3. The default is http2.
+	//
+	// Default: "http2"
+	HTTPVersion *string `json:"http_version,omitempty" hcl:"http_version,attr"`
+
+	// Whether the IPv6 is enabled for the distribution.
+	IsIpv6Enabled *bool `json:"is_ipv6_enabled,omitempty" hcl:"is_ipv6_enabled,attr"`
+
+	// The price class for this distribution. One of PriceClass_All, PriceClass_200, PriceClass_100
+	PriceClass *string `json:"price_class,omitempty" hcl:"price_class,attr"`
+
+	// Disables the distribution instead of deleting it when destroying the resource through Terraform. If this is set, the distribution needs to be deleted manually afterwards.
+	//
+	// Default: false
+	RetainOnDelete *bool `json:"retain_on_delete,omitempty" hcl:"retain_on_delete,attr"`
+
+	// If enabled, the resource will wait for the distribution status to change from InProgress to Deployed. Setting this to false will skip the process.
+	//
+	// Default: true
+	WaitForDeployment *bool `json:"wait_for_deployment,omitempty" hcl:"wait_for_deployment,attr"`
+
+	// If you're using AWS WAF to filter CloudFront requests, the Id of the AWS WAF web ACL that is associated with the distribution. The WAF Web ACL must exist in the WAF Global (CloudFront) region and the credentials configuring this argument must have waf:GetWebACL permissions assigned. If using WAFv2, provide the ARN of the web ACL.
+	WebACLID *string `json:"web_acl_id,omitempty" hcl:"web_acl_id,attr"`
+
+	// Whether the distribution is a staging distribution.
+	//
+	// Default: false
+	Staging *bool `json:"staging,omitempty" hcl:"staging,attr"`
+
+	// A map of tags to assign to the resource.
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// One or more origins for this distribution (multiples allowed).
+	Origin interface{} `json:"origin,omitempty" hcl:"origin,attr"`
+
+	// One or more origin_group for this distribution (multiples allowed).
+	//
+	// Default: {}
+	OriginGroup interface{} `json:"origin_group,omitempty" hcl:"origin_group,attr"`
+
+	// The SSL configuration for this distribution
+	//
+	// Default:
+	//
+	//	{
+	//	  cloudfront_default_certificate = true
+	//	  minimum_protocol_version       = "TLSv1"
+	//	}
+	ViewerCertificate interface{} `json:"viewer_certificate,omitempty" hcl:"viewer_certificate,attr"`
+
+	// The restriction configuration for this distribution (geo_restrictions)
+	//
+	// Default: {}
+	GeoRestriction interface{} `json:"geo_restriction,omitempty" hcl:"geo_restriction,attr"`
+
+	// The logging configuration that controls how logs are written to your distribution (maximum one).
+	//
+	// Default: {}
+	LoggingConfig interface{} `json:"logging_config,omitempty" hcl:"logging_config,attr"`
+
+	// One or more custom error response elements
+	//
+	// Default: {}
+	CustomErrorResponse interface{} `json:"custom_error_response,omitempty" hcl:"custom_error_response,attr"`
+
+	// The default cache behavior for this distribution
+	DefaultCacheBehavior interface{} `json:"default_cache_behavior,omitempty" hcl:"default_cache_behavior,attr"`
+
+	// An ordered list of cache behaviors resource for this distribution. List from top to bottom in order of precedence. The topmost cache behavior will have precedence 0.
+	//
+	// Default: []
+	OrderedCacheBehavior interface{} `json:"ordered_cache_behavior,omitempty" hcl:"ordered_cache_behavior,attr"`
+
+	// If enabled, the resource for monitoring subscription will created.
+	//
+	// Default: false
+	CreateMonitoringSubscription *bool `json:"create_monitoring_subscription,omitempty" hcl:"create_monitoring_subscription,attr"`
+
+	// A flag that indicates whether additional CloudWatch metrics are enabled for a given CloudFront distribution. Valid values are `Enabled` and `Disabled`.
+	//
+	// Default: "Enabled"
+	RealtimeMetricsSubscriptionStatus *string `json:"realtime_metrics_subscription_status,omitempty" hcl:"realtime_metrics_subscription_status,attr"`
+
+	// If enabled, the resource for VPC origin will be created.
+	//
+	// Default: false
+	CreateVPCOrigin *bool `json:"create_vpc_origin,omitempty" hcl:"create_vpc_origin,attr"`
+
+	// Map of CloudFront VPC origin
+	//
+	// Default: {}
+	VPCOrigin map[string]map[string]interface{} `json:"vpc_origin,omitempty" hcl:"vpc_origin,attr"`
+
+	// Create, update, and delete timeout configurations for vpc origin
+	//
+	// Default: {}
+	VPCOriginTimeouts map[string]string `json:"vpc_origin_timeouts,omitempty" hcl:"vpc_origin_timeouts,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/cloudfront/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// CloudfrontDistributionID references cloudfront_distribution_id: The identifier for the distribution.
+func (o Outputs) CloudfrontDistributionID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_id")
+}
+
+// CloudfrontDistributionARN references cloudfront_distribution_arn: The ARN (Amazon Resource Name) for the distribution.
+func (o Outputs) CloudfrontDistributionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_arn")
+}
+
+// CloudfrontDistributionCallerReference references cloudfront_distribution_caller_reference: Internal value used by CloudFront to allow future updates to the distribution configuration.
+func (o Outputs) CloudfrontDistributionCallerReference() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_caller_reference")
+}
+
+// CloudfrontDistributionStatus references cloudfront_distribution_status: The current status of the distribution. Deployed if the distribution's information is fully propagated throughout the Amazon CloudFront system.
+func (o Outputs) CloudfrontDistributionStatus() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_status")
+}
+
+// CloudfrontDistributionTrustedSigners references cloudfront_distribution_trusted_signers: List of nested attributes for active trusted signers, if the distribution is set up to serve private content with signed URLs
+func (o Outputs) CloudfrontDistributionTrustedSigners() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_trusted_signers")
+}
+
+// CloudfrontDistributionDomainName references cloudfront_distribution_domain_name: The domain name corresponding to the distribution.
+func (o Outputs) CloudfrontDistributionDomainName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_domain_name")
+}
+
+// CloudfrontDistributionLastModifiedTime references cloudfront_distribution_last_modified_time: The date and time the distribution was last modified.
+func (o Outputs) CloudfrontDistributionLastModifiedTime() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_last_modified_time")
+}
+
+// CloudfrontDistributionInProgressValidationBatches references cloudfront_distribution_in_progress_validation_batches: The number of invalidation batches currently in progress.
+func (o Outputs) CloudfrontDistributionInProgressValidationBatches() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_in_progress_validation_batches")
+}
+
+// CloudfrontDistributionEtag references cloudfront_distribution_etag: The current version of the distribution's information.
+func (o Outputs) CloudfrontDistributionEtag() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_etag")
+}
+
+// CloudfrontDistributionHostedZoneID references cloudfront_distribution_hosted_zone_id: The CloudFront Route 53 zone ID that can be used to route an Alias Resource Record Set to.
+func (o Outputs) CloudfrontDistributionHostedZoneID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_hosted_zone_id")
+}
+
+// CloudfrontOriginAccessIdentities references cloudfront_origin_access_identities: The origin access identities created
+func (o Outputs) CloudfrontOriginAccessIdentities() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_origin_access_identities")
+}
+
+// CloudfrontOriginAccessIdentityIDs references cloudfront_origin_access_identity_ids: The IDS of the origin access identities created
+func (o Outputs) CloudfrontOriginAccessIdentityIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_origin_access_identity_ids")
+}
+
+// CloudfrontOriginAccessIdentityIAMARNs references cloudfront_origin_access_identity_iam_arns: The IAM arns of the origin access identities created
+func (o Outputs) CloudfrontOriginAccessIdentityIAMARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_origin_access_identity_iam_arns")
+}
+
+// CloudfrontMonitoringSubscriptionID references cloudfront_monitoring_subscription_id: The ID of the CloudFront monitoring subscription, which corresponds to the `distribution_id`.
+func (o Outputs) CloudfrontMonitoringSubscriptionID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_monitoring_subscription_id")
+}
+
+// CloudfrontDistributionTags references cloudfront_distribution_tags: Tags of the distribution's
+func (o Outputs) CloudfrontDistributionTags() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_distribution_tags")
+}
+
+// CloudfrontOriginAccessControls references cloudfront_origin_access_controls: The origin access controls created
+func (o Outputs) CloudfrontOriginAccessControls() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_origin_access_controls")
+}
+
+// CloudfrontOriginAccessControlsIDs references cloudfront_origin_access_controls_ids: The IDS of the origin access identities created
+func (o Outputs) CloudfrontOriginAccessControlsIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_origin_access_controls_ids")
+}
+
+// CloudfrontVPCOriginIDs references cloudfront_vpc_origin_ids: The IDS of the VPC origin created
+func (o Outputs) CloudfrontVPCOriginIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudfront_vpc_origin_ids")
+}
@@ -0,0 +1,207 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestParseModule(t *testing.T) {
+	schema, err := ParseModule(filepath.Join("testdata", "queue"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.3", schema.Version)
+	require.Len(t, schema.Variables, 9)
+
+	t.Run("reads required variables", func(t *testing.T) {
+		name := schema.Variables[0]
+		assert.Equal(t, "name", name.Name)
+		assert.Equal(t, "Name of the queue", name.Description)
+		assert.Equal(t, cty.String, name.Type)
+		assert.True(t, name.Required())
+	})
+
+	t.Run("reads defaults as source and value", func(t *testing.T) {
+		create := schema.Variables[1]
+		assert.False(t, create.Required())
+		assert.Equal(t, "true", create.Default)
+		assert.Equal(t, cty.True, create.DefaultValue)
+
+		delay := schema.Variables[2]
+		assert.False(t, delay.Required(), "a null default makes a variable optional")
+		assert.True(t, delay.DefaultValue.IsNull())
+	})
+
+	t.Run("trims heredoc descriptions", func(t *testing.T) {
+		assert.Equal(t, "The redrive policy of the queue.\nSee the AWS docs.", schema.Variables[6].Description)
+	})
+
+	t.Run("reads optional object attributes", func(t *testing.T) {
+		statements := schema.Variables[7].Type
+		require.True(t, statements.IsMapType())
+		assert.True(t, statements.ElementType().IsObjectType())
+	})
+
+	t.Run("infers the type of untyped variables from the default", func(t *testing.T) {
+		assert.Equal(t, cty.String, schema.Variables[8].Type)
+	})
+
+	t.Run("reads outputs", func(t *testing.T) {
+		assert.Equal(t, []Output{
+			{Name: "queue_arn", Description: "The ARN of the queue"},
+			{Name: "queue_url"},
+		}, schema.Outputs)
+	})
+
+	t.Run("fails without variables.tf", func(t *testing.T) {
+		_, err := ParseModule(t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func TestParseSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables string
+		outputs   string
+		wantErr   string
+	}{
+		{"invalid syntax", `variable "x" {`, "", "variables.tf"},
+		{"invalid type", `variable "x" { type = strin }`, "", `variable "x": invalid type`},
+		{"computed default", `variable "x" { default = var.y }`, "", `variable "x": default must be a constant`},
+		{"computed description", `variable "x" { description = local.d }`, "", "description must be a literal string"},
+		{"unlabelled output", "", `output { value = 1 }`, "outputs.tf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchema([]byte(tt.variables), []byte(tt.outputs))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	schema, err := ParseModule(filepath.Join("testdata", "queue"))
+	require.NoError(t, err)
+
+	src, err := Generate(schema, Config{Package: "queue", Source: "example/queue/aws", Dir: "testdata/queue"})
+	require.NoError(t, err)
+	got := string(src)
+
+	assert.Contains(t, got, "// Code generated by tfmodules-gen from testdata/queue. DO NOT EDIT.\n\npackage queue\n")
+	assert.Contains(t, got, `const ModuleVersion = "1.2.3"`)
+
+	t.Run("maps Terraform types to pointer and collection fields", func(t *testing.T) {
+		for _, field := range []string{
+			"Name *string `json:\"name,omitempty\" hcl:\"name,attr\"`",
+			"Create *bool `json:\"create,omitempty\" hcl:\"create,attr\"`",
+			"DelaySeconds *int `",
+			"SamplingRate *float64 `",
+			"SubnetIDs []string `",
+			"Tags map[string]string `",
+			"RedrivePolicy interface{} `",
+			"Statements map[string]map[string]interface{} `",
+			"KMSMasterKeyID *string `",
+		} {
+			assert.Regexp(t, `(?m)^\t`+regexp.QuoteMeta(field), got)
+		}
+	})
+
+	t.Run("documents descriptions and defaults", func(t *testing.T) {
+		assert.Contains(t, got, "\t// Name of the queue\n\t//\n\t// Required.\n\tName ")
+		assert.Contains(t, got, "\t// Whether to create the queue\n\t//\n\t// Default: true\n\tCreate ")
+		assert.Contains(t, got, "\t// DelaySeconds sets delay_seconds.\n\tDelaySeconds ")
+		assert.Contains(t, got, "\t// The redrive policy of the queue.\n\t// See the AWS docs.\n\t//\n"+
+			"\t// Default:\n\t//\n\t//\t{\n\t//\t  maxReceiveCount = 5\n\t//\t}\n\tRedrivePolicy ")
+	})
+
+	t.Run("generates output accessors", func(t *testing.T) {
+		assert.Contains(t, got, "func (m *Module) Outputs() Outputs {")
+		assert.Contains(t, got, "// QueueARN references queue_arn: The ARN of the queue\n"+
+			"func (o Outputs) QueueARN() tfmodules.Output {\n\treturn tfmodules.NewOutput(o.module, \"queue_arn\")\n}")
+		assert.Contains(t, got, "// QueueURL references queue_url\nfunc (o Outputs) QueueURL() tfmodules.Output {")
+	})
+
+	t.Run("rejects names that collide in Go", func(t *testing.T) {
+		_, err := Generate(Schema{Variables: []Variable{
+			{Name: "queue_id", Type: cty.String},
+			{Name: "queue-id", Type: cty.String},
+		}}, Config{Package: "queue"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "both map to field QueueID")
+	})
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{
+		"name":                    "Name",
+		"kms_master_key_id":       "KMSMasterKeyID",
+		"sqs_managed_sse_enabled": "SQSManagedSSEEnabled",
+		"vpc_subnet_ids":          "VPCSubnetIDs",
+		"layer_arns":              "LayerARNs",
+		"cors_configuration":      "CORSConfiguration",
+		"step-functions":          "StepFunctions",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, GoName(in), in)
+	}
+}
+
+func TestChangelogVersion(t *testing.T) {
+	tests := map[string]string{
+		"## [5.1.0](https://github.com/x/compare/v5.0.0...v5.1.0) (2025-01-01)\n\n## [5.0.0]": "5.1.0",
+		"# Changelog\n\n# [4.2.0](https://github.com/x) (2024-06-01)":                         "4.2.0",
+		"# Changelog\n\nNo releases yet.":                                                     "",
+	}
+	for changelog, want := range tests {
+		assert.Equal(t, want, ChangelogVersion([]byte(changelog)), changelog)
+	}
+}
+
+// generateDirective matches the go:generate line of a tfmodules package.
+var generateDirective = regexp.MustCompile(`//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module (\S+) -source (\S+)`)
+
+// TestGenerated_UpToDate checks that every package's variables_gen.go matches
+// its vendored module. Run go generate ./internal/tfmodules/... after
+// updating .forge/modules.
+func TestGenerated_UpToDate(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "*", "types.go"))
+	require.NoError(t, err)
+
+	checked := 0
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		require.NoError(t, err)
+		m := generateDirective.FindSubmatch(src)
+		if m == nil {
+			continue
+		}
+		pkgDir := filepath.Dir(path)
+		checked++
+
+		t.Run(filepath.Base(pkgDir), func(t *testing.T) {
+			dir := filepath.Join(".forge", "modules", string(m[1]))
+			schema, err := ParseModule(filepath.Join("..", "..", "..", dir))
+			require.NoError(t, err)
+
+			want, err := Generate(schema, Config{
+				Package: filepath.Base(pkgDir),
+				Source:  string(m[2]),
+				Dir:     filepath.ToSlash(dir),
+			})
+			require.NoError(t, err)
+
+			got, err := os.ReadFile(filepath.Join(pkgDir, "variables_gen.go"))
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got), "stale %s/variables_gen.go, run go generate", pkgDir)
+		})
+	}
+	assert.Equal(t, 13, checked, "packages with a go:generate directive")
+}
@@ -0,0 +1,232 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Config names the Go package a schema is generated into.
+type Config struct {
+	// Package is the Go package name, e.g. sqs.
+	Package string
+	// Source is the registry source of the module, e.g. terraform-aws-modules/sqs/aws.
+	Source string
+	// Dir is the vendored module directory, quoted in the file header.
+	Dir string
+}
+
+// Generate renders the Go source for a module schema: a Variables struct with
+// one pointer or collection field per variable, and an Outputs type with one
+// accessor per output, reachable from the package's Module through Outputs().
+// PURE: Calculation.
+func Generate(schema Schema, cfg Config) ([]byte, error) {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by tfmodules-gen from %s. DO NOT EDIT.\n\n", cfg.Dir)
+	fmt.Fprintf(&b, "package %s\n\n", cfg.Package)
+	if len(schema.Outputs) > 0 {
+		b.WriteString("import \"github.com/lewis/forge/internal/tfmodules\"\n\n")
+	}
+
+	if schema.Version != "" {
+		fmt.Fprintf(&b, "// ModuleVersion is the version of %s these types were generated from.\n", cfg.Source)
+		fmt.Fprintf(&b, "const ModuleVersion = %q\n\n", schema.Version)
+	}
+
+	if err := writeVariables(&b, schema.Variables, cfg); err != nil {
+		return nil, err
+	}
+	if err := writeOutputs(&b, schema.Outputs, cfg); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid Go for %s: %w", cfg.Package, err)
+	}
+	return src, nil
+}
+
+// writeVariables writes the Variables struct.
+// PURE: Calculation.
+func writeVariables(b *bytes.Buffer, variables []Variable, cfg Config) error {
+	fmt.Fprintf(b, "// Variables holds every input declared by %s.\n", cfg.Source)
+	b.WriteString("// Unset (nil or empty) fields keep the module default.\n")
+	b.WriteString("type Variables struct {\n")
+
+	seen := make(map[string]string, len(variables))
+	for i, v := range variables {
+		name := GoName(v.Name)
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("variables %q and %q both map to field %s", other, v.Name, name)
+		}
+		seen[name] = v.Name
+
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeComment(b, "\t", variableDoc(v))
+		fmt.Fprintf(b, "\t%s %s `json:\"%s,omitempty\" hcl:\"%s,attr\"`\n", name, fieldType(v), v.Name, v.Name)
+	}
+
+	b.WriteString("}\n\n")
+	return nil
+}
+
+// writeOutputs writes the Outputs type and its accessors.
+// PURE: Calculation.
+func writeOutputs(b *bytes.Buffer, outputs []Output, cfg Config) error {
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(b, "// Outputs references the outputs of a %s module call.\n", cfg.Source)
+	b.WriteString("type Outputs struct {\n\tmodule tfmodules.Module\n}\n\n")
+	b.WriteString("// Outputs returns references to the outputs of m, for use as inputs of other modules.\n")
+	b.WriteString("func (m *Module) Outputs() Outputs {\n\treturn Outputs{module: m}\n}\n")
+
+	seen := make(map[string]string, len(outputs))
+	for _, o := range outputs {
+		name := GoName(o.Name)
+		if other, ok := seen[name]; ok {
+			return fmt.Errorf("outputs %q and %q both map to method %s", other, o.Name, name)
+		}
+		seen[name] = o.Name
+
+		b.WriteString("\n")
+		doc := name + " references " + o.Name
+		if o.Description != "" {
+			doc += ": " + o.Description
+		}
+		writeComment(b, "", doc)
+		fmt.Fprintf(b, "func (o Outputs) %s() tfmodules.Output {\n\treturn tfmodules.NewOutput(o.module, %q)\n}\n", name, o.Name)
+	}
+	return nil
+}
+
+// variableDoc returns the doc comment of a variable's field: its description
+// followed by its default, or a note that it is required.
+// PURE: Calculation.
+func variableDoc(v Variable) string {
+	doc := v.Description
+	if doc == "" {
+		doc = GoName(v.Name) + " sets " + v.Name + "."
+	}
+
+	switch {
+	case v.Required():
+		return doc + "\n\nRequired."
+	case v.DefaultValue.IsNull():
+		return doc
+	case strings.Contains(v.Default, "\n"):
+		return doc + "\n\nDefault:\n\n" + indent(v.Default)
+	default:
+		return doc + "\n\nDefault: " + v.Default
+	}
+}
+
+// indent turns multi-line source into a doc comment code block. Lines after
+// the first are dedented by the indentation of the last, which closes the
+// expression at the column of its attribute.
+// PURE: Calculation.
+func indent(src string) string {
+	lines := strings.Split(src, "\n")
+	last := lines[len(lines)-1]
+	margin := last[:len(last)-len(strings.TrimLeft(last, " \t"))]
+	for i, line := range lines {
+		lines[i] = "\t" + strings.TrimPrefix(line, margin)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// writeComment writes text as a // comment, one line per line of text.
+// PURE: Calculation.
+func writeComment(b *bytes.Buffer, prefix, text string) {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" || strings.HasPrefix(line, "\t") {
+			fmt.Fprintf(b, "%s//%s\n", prefix, line)
+			continue
+		}
+		fmt.Fprintf(b, "%s// %s\n", prefix, line)
+	}
+}
+
+// fieldType returns the Go type of a variable's field. Primitives are pointers
+// so that an explicit zero value is distinguishable from unset.
+// PURE: Calculation.
+func fieldType(v Variable) string {
+	typ := GoType(v.Type, hasFraction(v.DefaultValue))
+	if v.Type.IsPrimitiveType() {
+		return "*" + typ
+	}
+	return typ
+}
+
+// GoType maps a Terraform type constraint to a Go type. Numbers are int
+// unless fractional is set, objects become maps since their attributes are
+// often optional, and any becomes interface{}.
+// PURE: Calculation.
+func GoType(ty cty.Type, fractional bool) string {
+	switch {
+	case ty == cty.String:
+		return "string"
+	case ty == cty.Bool:
+		return "bool"
+	case ty == cty.Number:
+		if fractional {
+			return "float64"
+		}
+		return "int"
+	case ty.IsListType(), ty.IsSetType():
+		return "[]" + GoType(ty.ElementType(), false)
+	case ty.IsMapType():
+		return "map[string]" + GoType(ty.ElementType(), false)
+	case ty.IsObjectType():
+		return "map[string]interface{}"
+	case ty.IsTupleType():
+		return "[]interface{}"
+	default:
+		return "interface{}"
+	}
+}
+
+// hasFraction reports whether a default is a number with a fractional part.
+// PURE: Calculation.
+func hasFraction(v cty.Value) bool {
+	if v == cty.NilVal || v.IsNull() || !v.IsKnown() || v.Type() != cty.Number {
+		return false
+	}
+	return !v.AsBigFloat().IsInt()
+}
+
+// initialisms are name parts written in upper case, following Go naming.
+var initialisms = map[string]bool{
+	"acl": true, "acm": true, "api": true, "arn": true, "cidr": true, "cors": true,
+	"cpu": true, "dlq": true, "dns": true, "ecr": true, "efs": true, "http": true,
+	"https": true, "iam": true, "id": true, "ip": true, "json": true, "kms": true,
+	"mtls": true, "sns": true, "sqs": true, "sse": true, "ssl": true, "tls": true,
+	"ttl": true, "uri": true, "url": true, "vpc": true, "waf": true, "xml": true,
+}
+
+// GoName converts a Terraform snake_case name to an exported Go name, e.g.
+// kms_master_key_id to KMSMasterKeyID.
+// PURE: Calculation.
+func GoName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		switch {
+		case initialisms[part]:
+			b.WriteString(strings.ToUpper(part))
+		case part == "arns", part == "ids", part == "urls":
+			b.WriteString(strings.ToUpper(part[:len(part)-1]) + "s")
+		default:
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
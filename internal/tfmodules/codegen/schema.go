@@ -0,0 +1,205 @@
+// Package codegen derives tfmodules Go types from a vendored Terraform
+// module's variables.tf and outputs.tf.
+package codegen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+type (
+	// Schema is the interface of a Terraform module: its inputs and outputs
+	// in declaration order.
+	Schema struct {
+		Version   string
+		Variables []Variable
+		Outputs   []Output
+	}
+
+	// Variable is a variable block from variables.tf.
+	Variable struct {
+		Name        string
+		Description string
+		// Type is the type constraint, cty.DynamicPseudoType for any. Untyped
+		// variables take the type of their default.
+		Type cty.Type
+		// Default is the source of the default expression, empty when the
+		// variable is required.
+		Default string
+		// DefaultValue is the evaluated default, cty.NilVal when required.
+		DefaultValue cty.Value
+	}
+
+	// Output is an output block from outputs.tf.
+	Output struct {
+		Name        string
+		Description string
+	}
+)
+
+// Required reports whether callers must set the variable.
+// PURE: Calculation.
+func (v Variable) Required() bool {
+	return v.Default == ""
+}
+
+// changelogVersion matches the latest release heading of a release-please
+// CHANGELOG.md, e.g. "## [5.1.0](https://...)".
+var changelogVersion = regexp.MustCompile(`(?m)^##? \[(\d+\.\d+\.\d+)\]`)
+
+// ChangelogVersion returns the latest release of a release-please
+// CHANGELOG.md, or "" when it has none.
+// PURE: Calculation.
+func ChangelogVersion(changelog []byte) string {
+	if m := changelogVersion.FindSubmatch(changelog); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+// ParseModule reads the schema of the module vendored in dir. outputs.tf and
+// CHANGELOG.md are optional.
+// ACTION: Performs I/O (reads files).
+func ParseModule(dir string) (Schema, error) {
+	variables, err := os.ReadFile(filepath.Join(dir, "variables.tf"))
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to read variables: %w", err)
+	}
+
+	outputs, err := readOptional(filepath.Join(dir, "outputs.tf"))
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to read outputs: %w", err)
+	}
+
+	changelog, err := readOptional(filepath.Join(dir, "CHANGELOG.md"))
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to read changelog: %w", err)
+	}
+
+	schema, err := ParseSchema(variables, outputs)
+	if err != nil {
+		return Schema{}, fmt.Errorf("module %s: %w", dir, err)
+	}
+	schema.Version = ChangelogVersion(changelog)
+	return schema, nil
+}
+
+// readOptional reads a file, returning nil when it does not exist.
+// ACTION: Performs I/O (reads a file).
+func readOptional(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// ParseSchema parses the contents of variables.tf and outputs.tf.
+// PURE: Calculation.
+func ParseSchema(variables, outputs []byte) (Schema, error) {
+	var schema Schema
+
+	varBlocks, err := parseBlocks(variables, "variables.tf", "variable")
+	if err != nil {
+		return Schema{}, err
+	}
+	for _, block := range varBlocks {
+		v, err := parseVariable(block, variables)
+		if err != nil {
+			return Schema{}, err
+		}
+		schema.Variables = append(schema.Variables, v)
+	}
+
+	outBlocks, err := parseBlocks(outputs, "outputs.tf", "output")
+	if err != nil {
+		return Schema{}, err
+	}
+	for _, block := range outBlocks {
+		description, err := stringAttr(block, "description")
+		if err != nil {
+			return Schema{}, err
+		}
+		schema.Outputs = append(schema.Outputs, Output{Name: block.Labels[0], Description: description})
+	}
+
+	return schema, nil
+}
+
+// parseBlocks returns the top-level blocks of the given type with one label.
+// PURE: Calculation.
+func parseBlocks(src []byte, filename, blockType string) ([]*hclsyntax.Block, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, diags)
+	}
+
+	var blocks []*hclsyntax.Block
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != blockType {
+			continue
+		}
+		if len(block.Labels) != 1 {
+			return nil, fmt.Errorf("%s: %s block must have one label", block.DefRange(), blockType)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// parseVariable reads the description, type and default of a variable block.
+// PURE: Calculation.
+func parseVariable(block *hclsyntax.Block, src []byte) (Variable, error) {
+	v := Variable{Name: block.Labels[0], Type: cty.DynamicPseudoType}
+
+	description, err := stringAttr(block, "description")
+	if err != nil {
+		return Variable{}, err
+	}
+	v.Description = description
+
+	if attr, ok := block.Body.Attributes["type"]; ok {
+		ty, _, diags := typeexpr.TypeConstraintWithDefaults(attr.Expr)
+		if diags.HasErrors() {
+			return Variable{}, fmt.Errorf("variable %q: invalid type: %w", v.Name, diags)
+		}
+		v.Type = ty
+	}
+
+	if attr, ok := block.Body.Attributes["default"]; ok {
+		value, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() {
+			return Variable{}, fmt.Errorf("variable %q: default must be a constant: %w", v.Name, diags)
+		}
+		rng := attr.Expr.Range()
+		v.Default = string(src[rng.Start.Byte:rng.End.Byte])
+		v.DefaultValue = value
+		if _, typed := block.Body.Attributes["type"]; !typed && !value.IsNull() {
+			v.Type = value.Type()
+		}
+	}
+
+	return v, nil
+}
+
+// stringAttr evaluates an optional literal string attribute of a block.
+// PURE: Calculation.
+func stringAttr(block *hclsyntax.Block, name string) (string, error) {
+	attr, ok := block.Body.Attributes[name]
+	if !ok {
+		return "", nil
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+		return "", fmt.Errorf("%s %q: %s must be a literal string", block.Type, block.Labels[0], name)
+	}
+	return strings.TrimSpace(value.AsString()), nil
+}
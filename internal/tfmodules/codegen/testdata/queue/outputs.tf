@@ -0,0 +1,8 @@
+output "queue_arn" {
+  description = "The ARN of the queue"
+  value       = try(aws_sqs_queue.this[0].arn, null)
+}
+
+output "queue_url" {
+  value = try(aws_sqs_queue.this[0].url, null)
+}
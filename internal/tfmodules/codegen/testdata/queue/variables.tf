@@ -0,0 +1,58 @@
+variable "name" {
+  description = "Name of the queue"
+  type        = string
+}
+
+variable "create" {
+  description = "Whether to create the queue"
+  type        = bool
+  default     = true
+}
+
+variable "delay_seconds" {
+  type    = number
+  default = null
+}
+
+variable "sampling_rate" {
+  description = "Fraction of messages to sample"
+  type        = number
+  default     = 0.5
+}
+
+variable "subnet_ids" {
+  description = "Subnets the consumer runs in"
+  type        = list(string)
+  default     = []
+}
+
+variable "tags" {
+  description = "Tags to assign to all resources"
+  type        = map(string)
+  default     = {}
+}
+
+variable "redrive_policy" {
+  description = <<-EOT
+    The redrive policy of the queue.
+    See the AWS docs.
+  EOT
+  type        = any
+  default = {
+    maxReceiveCount = 5
+  }
+}
+
+variable "statements" {
+  description = "Queue policy statements"
+  type = map(object({
+    sid     = optional(string)
+    actions = list(string)
+  }))
+  default = null
+}
+
+variable "kms_master_key_id" {
+  description = "KMS key for encryption"
+  default     = "alias/aws/sqs"
+}
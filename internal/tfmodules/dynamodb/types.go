@@ -1,9 +1,16 @@
 // Package dynamodb provides type-safe Terraform module definitions for terraform-aws-modules/dynamodb-table/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-dynamodb-table v4.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/dynamodb (see variables_gen.go).
 package dynamodb
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module dynamodb -source terraform-aws-modules/dynamodb-table/aws
+
 import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
 	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
 )
 
 // Module represents the terraform-aws-modules/dynamodb-table/aws module.
@@ -33,11 +40,11 @@ type Module struct {
 
 	// BillingMode controls how you are billed for read/write throughput
 	// Valid values: "PROVISIONED" | "PAY_PER_REQUEST"
-	BillingMode *string `json:"billing_mode,omitempty" hcl:"billing_mode,attr"`
+	BillingMode *string `json:"billing_mode,omitempty" validate:"oneof=PROVISIONED PAY_PER_REQUEST" hcl:"billing_mode,attr"`
 
 	// TableClass is the storage class of the table
 	// Valid values: "STANDARD" | "STANDARD_INFREQUENT_ACCESS"
-	TableClass *string `json:"table_class,omitempty" hcl:"table_class,attr"`
+	TableClass *string `json:"table_class,omitempty" validate:"oneof=STANDARD STANDARD_INFREQUENT_ACCESS" hcl:"table_class,attr"`
 
 	// DeletionProtectionEnabled enables deletion protection for table
 	DeletionProtectionEnabled *bool `json:"deletion_protection_enabled,omitempty" hcl:"deletion_protection_enabled,attr"`
@@ -109,7 +116,7 @@ type Module struct {
 
 	// StreamViewType determines what information is written to the stream
 	// Valid values: "KEYS_ONLY" | "NEW_IMAGE" | "OLD_IMAGE" | "NEW_AND_OLD_IMAGES"
-	StreamViewType *string `json:"stream_view_type,omitempty" hcl:"stream_view_type,attr"`
+	StreamViewType *string `json:"stream_view_type,omitempty" validate:"oneof=KEYS_ONLY NEW_IMAGE OLD_IMAGE NEW_AND_OLD_IMAGES" hcl:"stream_view_type,attr"`
 
 	// ================================
 	// Backup & Recovery
@@ -176,6 +183,9 @@ type Module struct {
 
 	// Timeouts for Terraform resource management
 	Timeouts map[string]string `json:"timeouts,omitempty" hcl:"timeouts,attr"`
+
+	// localName overrides the local identifier derived from Name.
+	localName string
 }
 
 // Attribute represents a DynamoDB table attribute.
@@ -244,7 +254,7 @@ type ReplicaRegion struct {
 // NewModule creates a new DynamoDB module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/dynamodb-table/aws"
-	version := "~> 4.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	billingMode := "PAY_PER_REQUEST"
 	pitrEnabled := true
 	create := true
@@ -321,6 +331,13 @@ func (m *Module) WithProvisioned(readCapacity, writeCapacity int) *Module {
 	return m
 }
 
+// WithLocalName sets the local identifier of the module call, for tables
+// whose name is not a valid Terraform identifier.
+func (m *Module) WithLocalName(name string) *Module {
+	m.localName = name
+	return m
+}
+
 // WithTags adds tags to the table.
 func (m *Module) WithTags(tags map[string]string) *Module {
 	if m.Tags == nil {
@@ -334,14 +351,27 @@ func (m *Module) WithTags(tags map[string]string) *Module {
 
 // LocalName returns the local identifier for this module instance.
 func (m *Module) LocalName() string {
+	if m.localName != "" {
+		return m.localName
+	}
 	if m.Name != nil {
 		return *m.Name
 	}
 	return "dynamodb_table"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 // PURE: Same module configuration always produces the same HCL output.
 func (m *Module) Configuration() (string, error) {
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid dynamodb module %s: %w", m.LocalName(), err)
+	}
 	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
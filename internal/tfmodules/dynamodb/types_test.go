@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -14,7 +16,7 @@ func TestNewModule(t *testing.T) {
 
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/dynamodb-table/aws", module.Source)
-		assert.Equal(t, "~> 4.0", module.Version)
+		assert.Equal(t, "~> 5.0", module.Version)
 		assert.NotNil(t, module.Name)
 		assert.Equal(t, name, *module.Name)
 
@@ -310,6 +312,39 @@ func TestModule_LocalName(t *testing.T) {
 
 		assert.Equal(t, "dynamodb_table", module.LocalName())
 	})
+
+	t.Run("returns local name when set", func(t *testing.T) {
+		module := NewModule("${var.namespace}users").WithLocalName("users")
+
+		assert.Equal(t, "users", module.LocalName())
+		assert.Equal(t, "module.users.dynamodb_table_arn", module.Outputs().DynamodbTableARN().Ref())
+	})
+}
+
+func TestModule_Validate(t *testing.T) {
+	t.Run("accepts the defaults", func(t *testing.T) {
+		assert.NoError(t, NewModule("orders").Validate())
+	})
+
+	t.Run("rejects unknown enum values", func(t *testing.T) {
+		module := NewModule("orders").WithStreams("EVERYTHING")
+		billingMode := "ON_DEMAND"
+		module.BillingMode = &billingMode
+
+		err := module.Validate()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `billing_mode: must be one of PROVISIONED, PAY_PER_REQUEST, got "ON_DEMAND"`)
+		assert.Contains(t, err.Error(), `stream_view_type: must be one of KEYS_ONLY, NEW_IMAGE, OLD_IMAGE, NEW_AND_OLD_IMAGES, got "EVERYTHING"`)
+	})
+
+	t.Run("allows references", func(t *testing.T) {
+		module := NewModule("orders")
+		billingMode := "${var.billing_mode}"
+		module.BillingMode = &billingMode
+
+		assert.NoError(t, module.Validate())
+	})
 }
 
 func TestModule_Configuration(t *testing.T) {
@@ -319,15 +354,7 @@ func TestModule_Configuration(t *testing.T) {
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.NotEmpty(t, config)
-
-		// Verify basic structure
-		assert.Contains(t, config, `module "test_table" {`)
-		assert.Contains(t, config, `source  = "terraform-aws-modules/dynamodb-table/aws"`)
-		assert.Contains(t, config, `version = "~> 4.0"`)
-		assert.Contains(t, config, `name = "test_table"`)
-		assert.Contains(t, config, `billing_mode = "PAY_PER_REQUEST"`)
-		assert.Contains(t, config, `point_in_time_recovery_enabled = true`)
+		golden.Assert(t, "basic_table", config)
 	})
 
 	t.Run("generates HCL with all options", func(t *testing.T) {
@@ -342,12 +369,7 @@ func TestModule_Configuration(t *testing.T) {
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.Contains(t, config, `name = "users"`)
-		assert.Contains(t, config, `hash_key = "userId"`)
-		assert.Contains(t, config, `range_key = "timestamp"`)
-		assert.Contains(t, config, `stream_enabled = true`)
-		assert.Contains(t, config, `stream_view_type = "NEW_AND_OLD_IMAGES"`)
-		assert.Contains(t, config, `Environment = "production"`)
+		golden.Assert(t, "all_options", config)
 	})
 }
 
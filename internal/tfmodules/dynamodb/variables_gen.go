@@ -0,0 +1,212 @@
+// Code generated by tfmodules-gen from .forge/modules/dynamodb. DO NOT EDIT.
+
+package dynamodb
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/dynamodb-table/aws these types were generated from.
+const ModuleVersion = "5.2.0"
+
+// Variables holds every input declared by terraform-aws-modules/dynamodb-table/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Controls if DynamoDB table and associated resources are created
+	//
+	// Default: true
+	CreateTable *bool `json:"create_table,omitempty" hcl:"create_table,attr"`
+
+	// Name of the DynamoDB table
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// List of nested attribute definitions. Only required for hash_key and range_key attributes. Each attribute has two properties: name - (Required) The name of the attribute, type - (Required) Attribute type, which must be a scalar type: S, N, or B for (S)tring, (N)umber or (B)inary data
+	//
+	// Default: []
+	Attributes []map[string]string `json:"attributes,omitempty" hcl:"attributes,attr"`
+
+	// The attribute to use as the hash (partition) key. Must also be defined as an attribute
+	HashKey *string `json:"hash_key,omitempty" hcl:"hash_key,attr"`
+
+	// The attribute to use as the range (sort) key. Must also be defined as an attribute
+	RangeKey *string `json:"range_key,omitempty" hcl:"range_key,attr"`
+
+	// Controls how you are billed for read/write throughput and how you manage capacity. The valid values are PROVISIONED or PAY_PER_REQUEST
+	//
+	// Default: "PAY_PER_REQUEST"
+	BillingMode *string `json:"billing_mode,omitempty" hcl:"billing_mode,attr"`
+
+	// The number of write units for this table. If the billing_mode is PROVISIONED, this field should be greater than 0
+	WriteCapacity *int `json:"write_capacity,omitempty" hcl:"write_capacity,attr"`
+
+	// The number of read units for this table. If the billing_mode is PROVISIONED, this field should be greater than 0
+	ReadCapacity *int `json:"read_capacity,omitempty" hcl:"read_capacity,attr"`
+
+	// Whether to enable point-in-time recovery
+	//
+	// Default: false
+	PointInTimeRecoveryEnabled *bool `json:"point_in_time_recovery_enabled,omitempty" hcl:"point_in_time_recovery_enabled,attr"`
+
+	// Number of preceding days for which continuous backups are taken and maintained. Default 35
+	PointInTimeRecoveryPeriodInDays *int `json:"point_in_time_recovery_period_in_days,omitempty" hcl:"point_in_time_recovery_period_in_days,attr"`
+
+	// Indicates whether ttl is enabled
+	//
+	// Default: false
+	TTLEnabled *bool `json:"ttl_enabled,omitempty" hcl:"ttl_enabled,attr"`
+
+	// The name of the table attribute to store the TTL timestamp in
+	//
+	// Default: ""
+	TTLAttributeName *string `json:"ttl_attribute_name,omitempty" hcl:"ttl_attribute_name,attr"`
+
+	// Describe a GSI for the table; subject to the normal limits on the number of GSIs, projected attributes, etc.
+	//
+	// Default: []
+	GlobalSecondaryIndexes interface{} `json:"global_secondary_indexes,omitempty" hcl:"global_secondary_indexes,attr"`
+
+	// Describe an LSI on the table; these can only be allocated at creation so you cannot change this definition after you have created the resource.
+	//
+	// Default: []
+	LocalSecondaryIndexes interface{} `json:"local_secondary_indexes,omitempty" hcl:"local_secondary_indexes,attr"`
+
+	// Region names for creating replicas for a global DynamoDB table.
+	//
+	// Default: []
+	ReplicaRegions interface{} `json:"replica_regions,omitempty" hcl:"replica_regions,attr"`
+
+	// Indicates whether Streams are to be enabled (true) or disabled (false).
+	//
+	// Default: false
+	StreamEnabled *bool `json:"stream_enabled,omitempty" hcl:"stream_enabled,attr"`
+
+	// When an item in the table is modified, StreamViewType determines what information is written to the table's stream. Valid values are KEYS_ONLY, NEW_IMAGE, OLD_IMAGE, NEW_AND_OLD_IMAGES.
+	StreamViewType *string `json:"stream_view_type,omitempty" hcl:"stream_view_type,attr"`
+
+	// Whether or not to enable encryption at rest using an AWS managed KMS customer master key (CMK)
+	//
+	// Default: false
+	ServerSideEncryptionEnabled *bool `json:"server_side_encryption_enabled,omitempty" hcl:"server_side_encryption_enabled,attr"`
+
+	// The ARN of the CMK that should be used for the AWS KMS encryption. This attribute should only be specified if the key is different from the default DynamoDB CMK, alias/aws/dynamodb.
+	ServerSideEncryptionKMSKeyARN *string `json:"server_side_encryption_kms_key_arn,omitempty" hcl:"server_side_encryption_kms_key_arn,attr"`
+
+	// A map of tags to add to all resources
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// Updated Terraform resource management timeouts
+	//
+	// Default:
+	//
+	//	{
+	//	  create = "10m"
+	//	  update = "60m"
+	//	  delete = "10m"
+	//	}
+	Timeouts map[string]string `json:"timeouts,omitempty" hcl:"timeouts,attr"`
+
+	// Whether or not to enable autoscaling. See note in README about this setting
+	//
+	// Default: false
+	AutoscalingEnabled *bool `json:"autoscaling_enabled,omitempty" hcl:"autoscaling_enabled,attr"`
+
+	// A map of default autoscaling settings
+	//
+	// Default:
+	//
+	//	{
+	//	  scale_in_cooldown  = 0
+	//	  scale_out_cooldown = 0
+	//	  target_value       = 70
+	//	}
+	AutoscalingDefaults map[string]string `json:"autoscaling_defaults,omitempty" hcl:"autoscaling_defaults,attr"`
+
+	// A map of read autoscaling settings. `max_capacity` is the only required key. See example in examples/autoscaling
+	//
+	// Default: {}
+	AutoscalingRead map[string]string `json:"autoscaling_read,omitempty" hcl:"autoscaling_read,attr"`
+
+	// A map of write autoscaling settings. `max_capacity` is the only required key. See example in examples/autoscaling
+	//
+	// Default: {}
+	AutoscalingWrite map[string]string `json:"autoscaling_write,omitempty" hcl:"autoscaling_write,attr"`
+
+	// A map of index autoscaling configurations. See example in examples/autoscaling
+	//
+	// Default: {}
+	AutoscalingIndexes map[string]map[string]string `json:"autoscaling_indexes,omitempty" hcl:"autoscaling_indexes,attr"`
+
+	// The storage class of the table. Valid values are STANDARD and STANDARD_INFREQUENT_ACCESS
+	TableClass *string `json:"table_class,omitempty" hcl:"table_class,attr"`
+
+	// Enables deletion protection for table
+	DeletionProtectionEnabled *bool `json:"deletion_protection_enabled,omitempty" hcl:"deletion_protection_enabled,attr"`
+
+	// Configurations for importing s3 data into a new table.
+	//
+	// Default: {}
+	ImportTable interface{} `json:"import_table,omitempty" hcl:"import_table,attr"`
+
+	// Whether to ignore changes lifecycle to global secondary indices, useful for provisioned tables with scaling
+	//
+	// Default: false
+	IgnoreChangesGlobalSecondaryIndex *bool `json:"ignore_changes_global_secondary_index,omitempty" hcl:"ignore_changes_global_secondary_index,attr"`
+
+	// Sets the maximum number of read and write units for the specified on-demand table
+	//
+	// Default: {}
+	OnDemandThroughput interface{} `json:"on_demand_throughput,omitempty" hcl:"on_demand_throughput,attr"`
+
+	// Sets the number of warm read and write units for the specified table
+	//
+	// Default: {}
+	WarmThroughput interface{} `json:"warm_throughput,omitempty" hcl:"warm_throughput,attr"`
+
+	// Time of the point-in-time recovery point to restore.
+	RestoreDateTime *string `json:"restore_date_time,omitempty" hcl:"restore_date_time,attr"`
+
+	// Name of the table to restore. Must match the name of an existing table.
+	RestoreSourceName *string `json:"restore_source_name,omitempty" hcl:"restore_source_name,attr"`
+
+	// ARN of the source table to restore. Must be supplied for cross-region restores.
+	RestoreSourceTableARN *string `json:"restore_source_table_arn,omitempty" hcl:"restore_source_table_arn,attr"`
+
+	// If set, restores table to the most recent point-in-time recovery point.
+	RestoreToLatestTime *bool `json:"restore_to_latest_time,omitempty" hcl:"restore_to_latest_time,attr"`
+
+	// The JSON definition of the resource-based policy.
+	ResourcePolicy *string `json:"resource_policy,omitempty" hcl:"resource_policy,attr"`
+
+	// Region where this resource will be managed. Defaults to the Region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/dynamodb-table/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// DynamodbTableARN references dynamodb_table_arn: ARN of the DynamoDB table
+func (o Outputs) DynamodbTableARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dynamodb_table_arn")
+}
+
+// DynamodbTableID references dynamodb_table_id: ID of the DynamoDB table
+func (o Outputs) DynamodbTableID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dynamodb_table_id")
+}
+
+// DynamodbTableStreamARN references dynamodb_table_stream_arn: The ARN of the Table Stream. Only available when var.stream_enabled is true
+func (o Outputs) DynamodbTableStreamARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dynamodb_table_stream_arn")
+}
+
+// DynamodbTableStreamLabel references dynamodb_table_stream_label: A timestamp, in ISO 8601 format of the Table Stream. Only available when var.stream_enabled is true
+func (o Outputs) DynamodbTableStreamLabel() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dynamodb_table_stream_label")
+}
@@ -1,7 +1,18 @@
 // Package eventbridge provides type-safe Terraform module definitions for terraform-aws-modules/eventbridge/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-eventbridge v3.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/eventbridge (see variables_gen.go).
 package eventbridge
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module eventbridge -source terraform-aws-modules/eventbridge/aws
+
+import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
+
 // Module represents the terraform-aws-modules/eventbridge/aws module.
 // All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
 //
@@ -38,6 +49,8 @@ package eventbridge
 // ScheduleTarget represents a schedule target.
 //
 // Pipe represents an EventBridge pipe.
+//
+// PolicyStatement represents an additional statement for the module's IAM role.
 type (
 	Module struct {
 		// Source is the Terraform module source.
@@ -52,6 +65,9 @@ type (
 		// CreateRole controls whether IAM roles should be created.
 		CreateRole *bool `json:"create_role,omitempty" hcl:"create_role,attr"`
 
+		// RoleName is the name of the module's IAM role, by default BusName.
+		RoleName *string `json:"role_name,omitempty" hcl:"role_name,attr"`
+
 		// Region where the resource(s) will be managed.
 		Region *string `json:"region,omitempty" hcl:"region,attr"`
 
@@ -147,6 +163,17 @@ type (
 
 		// CreateSchemasDiscoverer controls whether default schemas discoverer should be created.
 		CreateSchemasDiscoverer *bool `json:"create_schemas_discoverer,omitempty" hcl:"create_schemas_discoverer,attr"`
+
+		// ================================.
+
+		// AttachPolicyStatements controls whether PolicyStatements are added to the module's IAM role.
+		AttachPolicyStatements *bool `json:"attach_policy_statements,omitempty" hcl:"attach_policy_statements,attr"`
+
+		// PolicyStatements is a map of additional IAM policy statements.
+		PolicyStatements map[string]PolicyStatement `json:"policy_statements,omitempty" hcl:"policy_statements,attr"`
+
+		// localName overrides the local identifier derived from BusName.
+		localName string
 	}
 
 	Rule struct {
@@ -179,8 +206,9 @@ type (
 		// ARN is the target ARN.
 		ARN string `json:"arn" hcl:"arn,attr"`
 
-		// RoleARN is the IAM role ARN for the target.
-		RoleARN *string `json:"role_arn,omitempty" hcl:"role_arn,attr"`
+		// AttachRoleARN is the IAM role EventBridge assumes to deliver to the
+		// target: an IAM role ARN, or true for the module's role.
+		AttachRoleARN interface{} `json:"attach_role_arn,omitempty" hcl:"attach_role_arn,attr"`
 
 		// Input is the input JSON.
 		Input *string `json:"input,omitempty" hcl:"input,attr"`
@@ -211,10 +239,10 @@ type (
 
 	RetryPolicy struct {
 		// MaximumEventAge is the maximum age in seconds (60-86400).
-		MaximumEventAge *int `json:"maximum_event_age,omitempty" hcl:"maximum_event_age,attr"`
+		MaximumEventAge *int `json:"maximum_event_age_in_seconds,omitempty" validate:"min=60,max=86400" hcl:"maximum_event_age_in_seconds,attr"`
 
 		// MaximumRetryAttempts is the maximum number of retries (0-185).
-		MaximumRetryAttempts *int `json:"maximum_retry_attempts,omitempty" hcl:"maximum_retry_attempts,attr"`
+		MaximumRetryAttempts *int `json:"maximum_retry_attempts,omitempty" validate:"min=0,max=185" hcl:"maximum_retry_attempts,attr"`
 	}
 
 	ECSTarget struct {
@@ -317,7 +345,7 @@ type (
 		HTTPMethod string `json:"http_method" hcl:"http_method,attr"`
 
 		// InvocationRateLimitPerSecond is the rate limit (1-300).
-		InvocationRateLimitPerSecond *int `json:"invocation_rate_limit_per_second,omitempty" hcl:"invocation_rate_limit_per_second,attr"`
+		InvocationRateLimitPerSecond *int `json:"invocation_rate_limit_per_second,omitempty" validate:"min=1,max=300" hcl:"invocation_rate_limit_per_second,attr"`
 
 		// ConnectionARN is the connection ARN.
 		ConnectionARN string `json:"connection_arn" hcl:"connection_arn,attr"`
@@ -359,7 +387,7 @@ type (
 		Mode string `json:"mode" hcl:"mode,attr"`
 
 		// MaximumWindowInMinutes is the max window in minutes (1-1440).
-		MaximumWindowInMinutes *int `json:"maximum_window_in_minutes,omitempty" hcl:"maximum_window_in_minutes,attr"`
+		MaximumWindowInMinutes *int `json:"maximum_window_in_minutes,omitempty" validate:"min=1,max=1440" hcl:"maximum_window_in_minutes,attr"`
 	}
 
 	ScheduleTarget struct {
@@ -398,12 +426,23 @@ type (
 		// TargetParameters configures target-specific settings.
 		TargetParameters map[string]interface{} `json:"target_parameters,omitempty" hcl:"target_parameters,attr"`
 	}
+
+	PolicyStatement struct {
+		// Valid values: "Allow" | "Deny".
+		Effect string `json:"effect" validate:"oneof=Allow Deny" hcl:"effect,attr"`
+
+		// Actions is the list of IAM actions.
+		Actions []string `json:"actions" hcl:"actions,attr"`
+
+		// Resources is the list of resource ARNs.
+		Resources []string `json:"resources" hcl:"resources,attr"`
+	}
 )
 
 // NewModule creates a new EventBridge module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/eventbridge/aws"
-	version := "~> 3.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	createBus := true
 	createRules := true
@@ -464,6 +503,13 @@ func (m *Module) WithPipe(key string, pipe Pipe) *Module {
 	return m
 }
 
+// WithLocalName sets the local identifier of the module call, for modules
+// that attach rules to an existing bus such as "default".
+func (m *Module) WithLocalName(name string) *Module {
+	m.localName = name
+	return m
+}
+
 // WithTags adds tags to the event bus.
 func (m *Module) WithTags(tags map[string]string) *Module {
 	if m.Tags == nil {
@@ -559,6 +605,30 @@ func (m *Module) WithScheduleRule(key, description, scheduleExpression string, e
 	return m.WithRule(key, rule)
 }
 
+// WithEventBusTarget forwards the events a rule matches to another event bus,
+// possibly in another account or region. Delivery uses the module's IAM role,
+// which is allowed to put events on that bus.
+func (m *Module) WithEventBusTarget(ruleKey, busARN string) *Module {
+	createRole := true
+	attach := true
+	m.CreateRole = &createRole
+	m.AttachPolicyStatements = &attach
+	if m.PolicyStatements == nil {
+		m.PolicyStatements = make(map[string]PolicyStatement)
+	}
+	statement := m.PolicyStatements["put_events"]
+	statement.Effect = "Allow"
+	statement.Actions = []string{"events:PutEvents"}
+	statement.Resources = append(statement.Resources, busARN)
+	m.PolicyStatements["put_events"] = statement
+
+	target := Target{
+		ARN:           busARN,
+		AttachRoleARN: true,
+	}
+	return m.WithTarget(ruleKey, target)
+}
+
 // WithAPIDestinationTarget adds an API destination as a target for a rule.
 func (m *Module) WithAPIDestinationTarget(ruleKey, destinationARN string) *Module {
 	target := Target{
@@ -569,14 +639,26 @@ func (m *Module) WithAPIDestinationTarget(ruleKey, destinationARN string) *Modul
 
 // LocalName returns the local identifier for this module instance.
 func (m *Module) LocalName() string {
+	if m.localName != "" {
+		return m.localName
+	}
 	if m.BusName != nil {
 		return *m.BusName
 	}
 	return "eventbridge"
 }
 
-// Configuration generates the HCL configuration for this module.
-func (*Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
+func (m *Module) Configuration() (string, error) {
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid eventbridge module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
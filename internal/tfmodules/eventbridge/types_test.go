@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -14,7 +16,7 @@ func TestNewModule(t *testing.T) {
 
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/eventbridge/aws", module.Source)
-		assert.Equal(t, "~> 3.0", module.Version)
+		assert.Equal(t, "~> 4.0", module.Version)
 		assert.NotNil(t, module.BusName)
 		assert.Equal(t, name, *module.BusName)
 
@@ -241,16 +243,43 @@ func TestModule_LocalName(t *testing.T) {
 
 		assert.Equal(t, "eventbridge", module.LocalName())
 	})
+
+	t.Run("returns local name when set", func(t *testing.T) {
+		module := NewModule("default").WithLocalName("nightly_report")
+
+		assert.Equal(t, "nightly_report", module.LocalName())
+		assert.Equal(t, "module.nightly_report.eventbridge_rule_arns", module.Outputs().EventbridgeRuleARNs().Ref())
+	})
 }
 
 func TestModule_Configuration(t *testing.T) {
-	t.Run("returns empty string and nil error as placeholder", func(t *testing.T) {
+	t.Run("generates valid HCL for basic bus", func(t *testing.T) {
 		module := NewModule("test_bus")
 
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.Empty(t, config)
+		golden.Assert(t, "basic_bus", config)
+	})
+
+	t.Run("generates HCL for schedule and pattern rules with targets", func(t *testing.T) {
+		retries := 3
+		module := NewModule("app_events").
+			WithScheduleRule("nightly", "Nightly report", "cron(0 3 * * ? *)", true).
+			WithLambdaTarget("nightly", "${module.reporter.lambda_function_arn}").
+			WithEventPatternRule("orders", "Order events", `{"source":["app.orders"]}`, true).
+			WithTarget("orders", Target{
+				Name:          ptr("orders-queue"),
+				ARN:           "${module.orders_queue.queue_arn}",
+				DeadLetterARN: ptr("${module.orders_dlq.queue_arn}"),
+				RetryPolicy:   &RetryPolicy{MaximumRetryAttempts: &retries},
+			}).
+			WithTags(map[string]string{"Team": "platform"})
+
+		config, err := module.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "rules_and_targets", config)
 	})
 }
 
@@ -343,6 +372,10 @@ func TestTarget(t *testing.T) {
 		assert.NotNil(t, target.RetryPolicy)
 		assert.Equal(t, 3600, *target.RetryPolicy.MaximumEventAge)
 		assert.Equal(t, 2, *target.RetryPolicy.MaximumRetryAttempts)
+
+		config, err := NewModule("bus").WithTarget("rule", target).Configuration()
+		require.NoError(t, err)
+		assert.Contains(t, config, "maximum_event_age_in_seconds = 3600", "named as the module reads it")
 	})
 
 	t.Run("creates target with DLQ", func(t *testing.T) {
@@ -678,6 +711,30 @@ func TestModule_WithScheduleRule(t *testing.T) {
 	})
 }
 
+// TestModule_WithEventBusTarget tests forwarding events to another bus.
+func TestModule_WithEventBusTarget(t *testing.T) {
+	busARN := "arn:aws:events:us-east-1:123456789012:event-bus/central"
+	module := NewModule("default").
+		WithEventPatternRule("orders", "Order events", `{"source":["app.orders"]}`, true).
+		WithEventBusTarget("orders", busARN)
+
+	require.Len(t, module.Targets["orders"], 1)
+	assert.Equal(t, busARN, module.Targets["orders"][0].ARN)
+	assert.Equal(t, true, module.Targets["orders"][0].AttachRoleARN)
+	assert.True(t, *module.CreateRole)
+	assert.True(t, *module.AttachPolicyStatements)
+	assert.Equal(t, PolicyStatement{
+		Effect:    "Allow",
+		Actions:   []string{"events:PutEvents"},
+		Resources: []string{busARN},
+	}, module.PolicyStatements["put_events"])
+
+	config, err := module.Configuration()
+	require.NoError(t, err)
+	assert.Contains(t, config, "attach_role_arn = true")
+	assert.Contains(t, config, `actions   = ["events:PutEvents"]`)
+}
+
 // TestModule_WithAPIDestinationTarget tests API destination target helper.
 func TestModule_WithAPIDestinationTarget(t *testing.T) {
 	t.Run("adds API destination target to rule", func(t *testing.T) {
@@ -0,0 +1,581 @@
+// Code generated by tfmodules-gen from .forge/modules/eventbridge. DO NOT EDIT.
+
+package eventbridge
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/eventbridge/aws these types were generated from.
+const ModuleVersion = "4.2.2"
+
+// Variables holds every input declared by terraform-aws-modules/eventbridge/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Controls whether resources should be created
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// Controls whether IAM roles should be created
+	//
+	// Default: true
+	CreateRole *bool `json:"create_role,omitempty" hcl:"create_role,attr"`
+
+	// Controls whether an IAM role should be created for the pipes only
+	//
+	// Default: false
+	CreatePipeRoleOnly *bool `json:"create_pipe_role_only,omitempty" hcl:"create_pipe_role_only,attr"`
+
+	// Controls whether to append '-rule' to the name of the rule
+	//
+	// Default: true
+	AppendRulePostfix *bool `json:"append_rule_postfix,omitempty" hcl:"append_rule_postfix,attr"`
+
+	// Controls whether to append '-connection' to the name of the connection
+	//
+	// Default: true
+	AppendConnectionPostfix *bool `json:"append_connection_postfix,omitempty" hcl:"append_connection_postfix,attr"`
+
+	// Controls whether to append '-destination' to the name of the destination
+	//
+	// Default: true
+	AppendDestinationPostfix *bool `json:"append_destination_postfix,omitempty" hcl:"append_destination_postfix,attr"`
+
+	// Controls whether to append '-group' to the name of the schedule group
+	//
+	// Default: true
+	AppendScheduleGroupPostfix *bool `json:"append_schedule_group_postfix,omitempty" hcl:"append_schedule_group_postfix,attr"`
+
+	// Controls whether to append '-schedule' to the name of the schedule
+	//
+	// Default: true
+	AppendSchedulePostfix *bool `json:"append_schedule_postfix,omitempty" hcl:"append_schedule_postfix,attr"`
+
+	// Controls whether to append '-pipe' to the name of the pipe
+	//
+	// Default: true
+	AppendPipePostfix *bool `json:"append_pipe_postfix,omitempty" hcl:"append_pipe_postfix,attr"`
+
+	// Controls whether EventBridge Bus resource should be created
+	//
+	// Default: true
+	CreateBus *bool `json:"create_bus,omitempty" hcl:"create_bus,attr"`
+
+	// Controls whether EventBridge Rule resources should be created
+	//
+	// Default: true
+	CreateRules *bool `json:"create_rules,omitempty" hcl:"create_rules,attr"`
+
+	// Controls whether EventBridge Target resources should be created
+	//
+	// Default: true
+	CreateTargets *bool `json:"create_targets,omitempty" hcl:"create_targets,attr"`
+
+	// Controls whether EventBridge Permission resources should be created
+	//
+	// Default: true
+	CreatePermissions *bool `json:"create_permissions,omitempty" hcl:"create_permissions,attr"`
+
+	// Controls whether EventBridge Archive resources should be created
+	//
+	// Default: false
+	CreateArchives *bool `json:"create_archives,omitempty" hcl:"create_archives,attr"`
+
+	// Controls whether EventBridge Connection resources should be created
+	//
+	// Default: false
+	CreateConnections *bool `json:"create_connections,omitempty" hcl:"create_connections,attr"`
+
+	// Controls whether EventBridge Destination resources should be created
+	//
+	// Default: false
+	CreateAPIDestinations *bool `json:"create_api_destinations,omitempty" hcl:"create_api_destinations,attr"`
+
+	// Controls whether default schemas discoverer should be created
+	//
+	// Default: false
+	CreateSchemasDiscoverer *bool `json:"create_schemas_discoverer,omitempty" hcl:"create_schemas_discoverer,attr"`
+
+	// Controls whether EventBridge Schedule Group resources should be created
+	//
+	// Default: true
+	CreateScheduleGroups *bool `json:"create_schedule_groups,omitempty" hcl:"create_schedule_groups,attr"`
+
+	// Controls whether EventBridge Schedule resources should be created
+	//
+	// Default: true
+	CreateSchedules *bool `json:"create_schedules,omitempty" hcl:"create_schedules,attr"`
+
+	// Controls whether EventBridge Pipes resources should be created
+	//
+	// Default: true
+	CreatePipes *bool `json:"create_pipes,omitempty" hcl:"create_pipes,attr"`
+
+	// Controls whether EventBridge log delivery source resource should be created
+	//
+	// Default: true
+	CreateLogDeliverySource *bool `json:"create_log_delivery_source,omitempty" hcl:"create_log_delivery_source,attr"`
+
+	// Controls whether EventBridge log delivery resources should be created
+	//
+	// Default: true
+	CreateLogDelivery *bool `json:"create_log_delivery,omitempty" hcl:"create_log_delivery,attr"`
+
+	// Region where the resource(s) will be managed. Defaults to the region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+
+	// A unique name for your EventBridge Bus
+	//
+	// Default: "default"
+	BusName *string `json:"bus_name,omitempty" hcl:"bus_name,attr"`
+
+	// Event bus description
+	BusDescription *string `json:"bus_description,omitempty" hcl:"bus_description,attr"`
+
+	// The configuration block for the EventBridge bus log config settings
+	LogConfig map[string]interface{} `json:"log_config,omitempty" hcl:"log_config,attr"`
+
+	// Map of the configuration block for the EventBridge bus log delivery settings (key is the type of log delivery: cloudwatch_logs, s3, firehose)
+	//
+	// Default: {}
+	LogDelivery map[string]map[string]interface{} `json:"log_delivery,omitempty" hcl:"log_delivery,attr"`
+
+	// Name of log delivery source
+	LogDeliverySourceName *string `json:"log_delivery_source_name,omitempty" hcl:"log_delivery_source_name,attr"`
+
+	// The partner event source that the new event bus will be matched with. Must match name.
+	EventSourceName *string `json:"event_source_name,omitempty" hcl:"event_source_name,attr"`
+
+	// The identifier of the AWS KMS customer managed key for EventBridge to use, if you choose to use a customer managed key to encrypt events on this event bus. The identifier can be the key Amazon Resource Name (ARN), KeyId, key alias, or key alias ARN.
+	KMSKeyIdentifier *string `json:"kms_key_identifier,omitempty" hcl:"kms_key_identifier,attr"`
+
+	// Configuration details of the Amazon SQS queue for EventBridge to use as a dead-letter queue (DLQ)
+	//
+	// Default: {}
+	DeadLetterConfig interface{} `json:"dead_letter_config,omitempty" hcl:"dead_letter_config,attr"`
+
+	// Default schemas discoverer description
+	//
+	// Default: "Auto schemas discoverer event"
+	SchemasDiscovererDescription *string `json:"schemas_discoverer_description,omitempty" hcl:"schemas_discoverer_description,attr"`
+
+	// A map of objects with EventBridge Rule definitions.
+	//
+	// Default: {}
+	Rules map[string]interface{} `json:"rules,omitempty" hcl:"rules,attr"`
+
+	// A map of objects with EventBridge Target definitions.
+	//
+	// Default: {}
+	Targets interface{} `json:"targets,omitempty" hcl:"targets,attr"`
+
+	// A map of objects with the EventBridge Archive definitions.
+	//
+	// Default: {}
+	Archives map[string]interface{} `json:"archives,omitempty" hcl:"archives,attr"`
+
+	// A map of objects with EventBridge Permission definitions.
+	//
+	// Default: {}
+	Permissions map[string]interface{} `json:"permissions,omitempty" hcl:"permissions,attr"`
+
+	// A map of objects with EventBridge Connection definitions.
+	//
+	// Default: {}
+	Connections interface{} `json:"connections,omitempty" hcl:"connections,attr"`
+
+	// A map of objects with EventBridge Destination definitions.
+	//
+	// Default: {}
+	APIDestinations map[string]interface{} `json:"api_destinations,omitempty" hcl:"api_destinations,attr"`
+
+	// A map of objects with EventBridge Schedule Group definitions.
+	//
+	// Default: {}
+	ScheduleGroups interface{} `json:"schedule_groups,omitempty" hcl:"schedule_groups,attr"`
+
+	// A map of objects with EventBridge Schedule definitions.
+	//
+	// Default: {}
+	Schedules map[string]interface{} `json:"schedules,omitempty" hcl:"schedules,attr"`
+
+	// A map of objects with EventBridge Pipe definitions.
+	//
+	// Default: {}
+	Pipes interface{} `json:"pipes,omitempty" hcl:"pipes,attr"`
+
+	// A map of tags to assign to resources.
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// A map of objects with EventBridge Schedule Group create and delete timeouts.
+	//
+	// Default: {}
+	ScheduleGroupTimeouts map[string]string `json:"schedule_group_timeouts,omitempty" hcl:"schedule_group_timeouts,attr"`
+
+	// Name of IAM role to use for EventBridge
+	RoleName *string `json:"role_name,omitempty" hcl:"role_name,attr"`
+
+	// Description of IAM role to use for EventBridge
+	RoleDescription *string `json:"role_description,omitempty" hcl:"role_description,attr"`
+
+	// Path of IAM role to use for EventBridge
+	RolePath *string `json:"role_path,omitempty" hcl:"role_path,attr"`
+
+	// Path of IAM policy to use for EventBridge
+	PolicyPath *string `json:"policy_path,omitempty" hcl:"policy_path,attr"`
+
+	// Specifies to force detaching any policies the IAM role has before destroying it.
+	//
+	// Default: true
+	RoleForceDetachPolicies *bool `json:"role_force_detach_policies,omitempty" hcl:"role_force_detach_policies,attr"`
+
+	// The ARN of the policy that is used to set the permissions boundary for the IAM role used by EventBridge
+	RolePermissionsBoundary *string `json:"role_permissions_boundary,omitempty" hcl:"role_permissions_boundary,attr"`
+
+	// A map of tags to assign to IAM role
+	//
+	// Default: {}
+	RoleTags map[string]string `json:"role_tags,omitempty" hcl:"role_tags,attr"`
+
+	// List of approved roles to be passed
+	//
+	// Default: []
+	EcsPassRoleResources []string `json:"ecs_pass_role_resources,omitempty" hcl:"ecs_pass_role_resources,attr"`
+
+	// Controls whether the Kinesis policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachKinesisPolicy *bool `json:"attach_kinesis_policy,omitempty" hcl:"attach_kinesis_policy,attr"`
+
+	// Controls whether the Kinesis Firehose policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachKinesisFirehosePolicy *bool `json:"attach_kinesis_firehose_policy,omitempty" hcl:"attach_kinesis_firehose_policy,attr"`
+
+	// Controls whether the SQS policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachSQSPolicy *bool `json:"attach_sqs_policy,omitempty" hcl:"attach_sqs_policy,attr"`
+
+	// Controls whether the SNS policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachSNSPolicy *bool `json:"attach_sns_policy,omitempty" hcl:"attach_sns_policy,attr"`
+
+	// Controls whether the ECS policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachEcsPolicy *bool `json:"attach_ecs_policy,omitempty" hcl:"attach_ecs_policy,attr"`
+
+	// Controls whether the Lambda Function policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachLambdaPolicy *bool `json:"attach_lambda_policy,omitempty" hcl:"attach_lambda_policy,attr"`
+
+	// Controls whether the StepFunction policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachSfnPolicy *bool `json:"attach_sfn_policy,omitempty" hcl:"attach_sfn_policy,attr"`
+
+	// Controls whether the Cloudwatch policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachCloudwatchPolicy *bool `json:"attach_cloudwatch_policy,omitempty" hcl:"attach_cloudwatch_policy,attr"`
+
+	// Controls whether the API Destination policy should be added to IAM role for EventBridge Target
+	//
+	// Default: false
+	AttachAPIDestinationPolicy *bool `json:"attach_api_destination_policy,omitempty" hcl:"attach_api_destination_policy,attr"`
+
+	// Controls whether X-Ray tracing policy should be added to IAM role for EventBridge
+	//
+	// Default: false
+	AttachTracingPolicy *bool `json:"attach_tracing_policy,omitempty" hcl:"attach_tracing_policy,attr"`
+
+	// The Amazon Resource Name (ARN) of the Kinesis Streams you want to use as EventBridge targets
+	//
+	// Default: []
+	KinesisTargetARNs []string `json:"kinesis_target_arns,omitempty" hcl:"kinesis_target_arns,attr"`
+
+	// The Amazon Resource Name (ARN) of the Kinesis Firehose Delivery Streams you want to use as EventBridge targets
+	//
+	// Default: []
+	KinesisFirehoseTargetARNs []string `json:"kinesis_firehose_target_arns,omitempty" hcl:"kinesis_firehose_target_arns,attr"`
+
+	// The Amazon Resource Name (ARN) of the AWS SQS Queues you want to use as EventBridge targets
+	//
+	// Default: []
+	SQSTargetARNs []string `json:"sqs_target_arns,omitempty" hcl:"sqs_target_arns,attr"`
+
+	// The Amazon Resource Name (ARN) of the AWS SNS's you want to use as EventBridge targets
+	//
+	// Default: []
+	SNSTargetARNs []string `json:"sns_target_arns,omitempty" hcl:"sns_target_arns,attr"`
+
+	// The Amazon Resource Name (ARN) of the AWS KMS's configured for AWS SNS you want Decrypt/GenerateDataKey for
+	//
+	// Default: ["*"]
+	SNSKMSARNs []string `json:"sns_kms_arns,omitempty" hcl:"sns_kms_arns,attr"`
+
+	// The Amazon Resource Name (ARN) of the AWS ECS Tasks you want to use as EventBridge targets
+	//
+	// Default: []
+	EcsTargetARNs []string `json:"ecs_target_arns,omitempty" hcl:"ecs_target_arns,attr"`
+
+	// The Amazon Resource Name (ARN) of the Lambda Functions you want to use as EventBridge targets
+	//
+	// Default: []
+	LambdaTargetARNs []string `json:"lambda_target_arns,omitempty" hcl:"lambda_target_arns,attr"`
+
+	// The Amazon Resource Name (ARN) of the StepFunctions you want to use as EventBridge targets
+	//
+	// Default: []
+	SfnTargetARNs []string `json:"sfn_target_arns,omitempty" hcl:"sfn_target_arns,attr"`
+
+	// The Amazon Resource Name (ARN) of the Cloudwatch Log Streams you want to use as EventBridge targets
+	//
+	// Default: []
+	CloudwatchTargetARNs []string `json:"cloudwatch_target_arns,omitempty" hcl:"cloudwatch_target_arns,attr"`
+
+	// Controls whether policy_json should be added to IAM role
+	//
+	// Default: false
+	AttachPolicyJSON *bool `json:"attach_policy_json,omitempty" hcl:"attach_policy_json,attr"`
+
+	// Controls whether policy_jsons should be added to IAM role
+	//
+	// Default: false
+	AttachPolicyJsons *bool `json:"attach_policy_jsons,omitempty" hcl:"attach_policy_jsons,attr"`
+
+	// Controls whether policy should be added to IAM role
+	//
+	// Default: false
+	AttachPolicy *bool `json:"attach_policy,omitempty" hcl:"attach_policy,attr"`
+
+	// Controls whether list of policies should be added to IAM role
+	//
+	// Default: false
+	AttachPolicies *bool `json:"attach_policies,omitempty" hcl:"attach_policies,attr"`
+
+	// Number of policies JSON to attach to IAM role
+	//
+	// Default: 0
+	NumberOfPolicyJsons *int `json:"number_of_policy_jsons,omitempty" hcl:"number_of_policy_jsons,attr"`
+
+	// Number of policies to attach to IAM role
+	//
+	// Default: 0
+	NumberOfPolicies *int `json:"number_of_policies,omitempty" hcl:"number_of_policies,attr"`
+
+	// Controls whether policy_statements should be added to IAM role
+	//
+	// Default: false
+	AttachPolicyStatements *bool `json:"attach_policy_statements,omitempty" hcl:"attach_policy_statements,attr"`
+
+	// Additional trusted entities for assuming roles (trust relationship)
+	//
+	// Default: []
+	TrustedEntities []string `json:"trusted_entities,omitempty" hcl:"trusted_entities,attr"`
+
+	// An additional policy document as JSON to attach to IAM role
+	PolicyJSON *string `json:"policy_json,omitempty" hcl:"policy_json,attr"`
+
+	// List of additional policy documents as JSON to attach to IAM role
+	//
+	// Default: []
+	PolicyJsons []string `json:"policy_jsons,omitempty" hcl:"policy_jsons,attr"`
+
+	// An additional policy document ARN to attach to IAM role
+	Policy *string `json:"policy,omitempty" hcl:"policy,attr"`
+
+	// List of policy statements ARN to attach to IAM role
+	//
+	// Default: []
+	Policies []string `json:"policies,omitempty" hcl:"policies,attr"`
+
+	// Map of dynamic policy statements to attach to IAM role
+	//
+	// Default: {}
+	PolicyStatements interface{} `json:"policy_statements,omitempty" hcl:"policy_statements,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/eventbridge/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// EventbridgeBusName references eventbridge_bus_name: The EventBridge Bus Name
+func (o Outputs) EventbridgeBusName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_bus_name")
+}
+
+// EventbridgeBusARN references eventbridge_bus_arn: The EventBridge Bus ARN
+func (o Outputs) EventbridgeBusARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_bus_arn")
+}
+
+// EventbridgeArchiveARNs references eventbridge_archive_arns: The EventBridge Archive ARNs
+func (o Outputs) EventbridgeArchiveARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_archive_arns")
+}
+
+// EventbridgePermissionIDs references eventbridge_permission_ids: The EventBridge Permission IDs
+func (o Outputs) EventbridgePermissionIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_permission_ids")
+}
+
+// EventbridgeConnectionIDs references eventbridge_connection_ids: The EventBridge Connection IDs
+func (o Outputs) EventbridgeConnectionIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_connection_ids")
+}
+
+// EventbridgeConnectionARNs references eventbridge_connection_arns: The EventBridge Connection Arns
+func (o Outputs) EventbridgeConnectionARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_connection_arns")
+}
+
+// EventbridgeAPIDestinationARNs references eventbridge_api_destination_arns: The EventBridge API Destination ARNs
+func (o Outputs) EventbridgeAPIDestinationARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_api_destination_arns")
+}
+
+// EventbridgeRuleIDs references eventbridge_rule_ids: The EventBridge Rule IDs
+func (o Outputs) EventbridgeRuleIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_rule_ids")
+}
+
+// EventbridgeRuleARNs references eventbridge_rule_arns: The EventBridge Rule ARNs
+func (o Outputs) EventbridgeRuleARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_rule_arns")
+}
+
+// EventbridgeScheduleGroupIDs references eventbridge_schedule_group_ids: The EventBridge Schedule Group IDs
+func (o Outputs) EventbridgeScheduleGroupIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_schedule_group_ids")
+}
+
+// EventbridgeScheduleGroupARNs references eventbridge_schedule_group_arns: The EventBridge Schedule Group ARNs
+func (o Outputs) EventbridgeScheduleGroupARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_schedule_group_arns")
+}
+
+// EventbridgeScheduleGroupStates references eventbridge_schedule_group_states: The EventBridge Schedule Group states
+func (o Outputs) EventbridgeScheduleGroupStates() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_schedule_group_states")
+}
+
+// EventbridgeScheduleIDs references eventbridge_schedule_ids: The EventBridge Schedule IDs created
+func (o Outputs) EventbridgeScheduleIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_schedule_ids")
+}
+
+// EventbridgeScheduleARNs references eventbridge_schedule_arns: The EventBridge Schedule ARNs created
+func (o Outputs) EventbridgeScheduleARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_schedule_arns")
+}
+
+// EventbridgeRoleARN references eventbridge_role_arn: The ARN of the IAM role created for EventBridge
+func (o Outputs) EventbridgeRoleARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_role_arn")
+}
+
+// EventbridgeRoleName references eventbridge_role_name: The name of the IAM role created for EventBridge
+func (o Outputs) EventbridgeRoleName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_role_name")
+}
+
+// EventbridgePipeIDs references eventbridge_pipe_ids: The EventBridge Pipes IDs
+func (o Outputs) EventbridgePipeIDs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_pipe_ids")
+}
+
+// EventbridgePipeARNs references eventbridge_pipe_arns: The EventBridge Pipes ARNs
+func (o Outputs) EventbridgePipeARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_pipe_arns")
+}
+
+// EventbridgePipeRoleARNs references eventbridge_pipe_role_arns: The ARNs of the IAM role created for EventBridge Pipes
+func (o Outputs) EventbridgePipeRoleARNs() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_pipe_role_arns")
+}
+
+// EventbridgePipeRoleNames references eventbridge_pipe_role_names: The names of the IAM role created for EventBridge Pipes
+func (o Outputs) EventbridgePipeRoleNames() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_pipe_role_names")
+}
+
+// EventbridgeBus references eventbridge_bus: The EventBridge Bus created and their attributes
+func (o Outputs) EventbridgeBus() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_bus")
+}
+
+// EventbridgeArchives references eventbridge_archives: The EventBridge Archives created and their attributes
+func (o Outputs) EventbridgeArchives() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_archives")
+}
+
+// EventbridgePermissions references eventbridge_permissions: The EventBridge Permissions created and their attributes
+func (o Outputs) EventbridgePermissions() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_permissions")
+}
+
+// EventbridgeConnections references eventbridge_connections: The EventBridge Connections created and their attributes
+func (o Outputs) EventbridgeConnections() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_connections")
+}
+
+// EventbridgeAPIDestinations references eventbridge_api_destinations: The EventBridge API Destinations created and their attributes
+func (o Outputs) EventbridgeAPIDestinations() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_api_destinations")
+}
+
+// EventbridgeTargets references eventbridge_targets: The EventBridge Targets created and their attributes
+func (o Outputs) EventbridgeTargets() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_targets")
+}
+
+// EventbridgeRules references eventbridge_rules: The EventBridge Rules created and their attributes
+func (o Outputs) EventbridgeRules() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_rules")
+}
+
+// EventbridgeScheduleGroups references eventbridge_schedule_groups: The EventBridge Schedule Groups created and their attributes
+func (o Outputs) EventbridgeScheduleGroups() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_schedule_groups")
+}
+
+// EventbridgeSchedules references eventbridge_schedules: The EventBridge Schedules created and their attributes
+func (o Outputs) EventbridgeSchedules() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_schedules")
+}
+
+// EventbridgePipes references eventbridge_pipes: The EventBridge Pipes created and their attributes
+func (o Outputs) EventbridgePipes() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_pipes")
+}
+
+// EventbridgeLogDeliverySourceARN references eventbridge_log_delivery_source_arn: The EventBridge Bus CloudWatch Log Delivery Source ARN
+func (o Outputs) EventbridgeLogDeliverySourceARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_log_delivery_source_arn")
+}
+
+// EventbridgeLogDeliverySourceName references eventbridge_log_delivery_source_name: The EventBridge Bus CloudWatch Log Delivery Source Name
+func (o Outputs) EventbridgeLogDeliverySourceName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_log_delivery_source_name")
+}
+
+// EventbridgePipesIAMRoles references eventbridge_pipes_iam_roles: The EventBridge Pipes IAM roles created and their attributes
+func (o Outputs) EventbridgePipesIAMRoles() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_pipes_iam_roles")
+}
+
+// EventbridgeIAMRoles references eventbridge_iam_roles: The EventBridge IAM roles created and their attributes
+func (o Outputs) EventbridgeIAMRoles() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "eventbridge_iam_roles")
+}
@@ -0,0 +1,37 @@
+// Package golden compares rendered Terraform with expected files in a package's testdata/.
+// It is a test helper shared by the tfmodules packages.
+package golden
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata/ with the current output")
+
+// Assert checks that got is valid HCL and equals testdata/<name>.golden.
+// Run the tests with -update to (re)write the golden file instead.
+func Assert(t testing.TB, name, got string) {
+	t.Helper()
+
+	_, diags := hclsyntax.ParseConfig([]byte(got), name, hcl.InitialPos)
+	require.False(t, diags.HasErrors(), "rendered HCL does not parse: %s\n%s", diags.Error(), got)
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file (run go test with -update to create it)")
+	assert.Equal(t, string(want), got)
+}
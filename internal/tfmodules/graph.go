@@ -0,0 +1,270 @@
+package tfmodules
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+)
+
+// SortedModules returns the stack's modules in dependency order: every module comes
+// after the modules it depends on, either explicitly (AddDependency) or implicitly by
+// referencing their outputs. Independent modules keep their insertion order.
+// PURE: Calculation.
+func (s *Stack) SortedModules() ([]Module, error) {
+	graph, err := s.dependencyGraph()
+	if err != nil {
+		return nil, err
+	}
+
+	// Kahn's algorithm, always taking the earliest-added ready module
+	remaining := make(map[string]int, len(s.Modules))
+	for _, m := range s.Modules {
+		remaining[m.LocalName()] = len(graph[m.LocalName()])
+	}
+	dependents := make(map[string][]string, len(s.Modules))
+	for _, m := range s.Modules {
+		for _, dep := range graph[m.LocalName()] {
+			dependents[dep] = append(dependents[dep], m.LocalName())
+		}
+	}
+
+	sorted := make([]Module, 0, len(s.Modules))
+	emitted := make(map[string]bool, len(s.Modules))
+	for len(sorted) < len(s.Modules) {
+		progressed := false
+		for _, m := range s.Modules {
+			name := m.LocalName()
+			if emitted[name] || remaining[name] > 0 {
+				continue
+			}
+			sorted = append(sorted, m)
+			emitted[name] = true
+			for _, dependent := range dependents[name] {
+				remaining[dependent]--
+			}
+			progressed = true
+			break
+		}
+		if !progressed {
+			return nil, fmt.Errorf("dependency cycle: %s", strings.Join(findCycle(s.Modules, graph, emitted), " -> "))
+		}
+	}
+
+	return sorted, nil
+}
+
+// dependencyGraph maps each module to the modules it depends on, explicit
+// dependencies first, without duplicates. References to modules outside the
+// stack are ignored; explicit dependencies on unknown modules are an error.
+// PURE: Calculation.
+func (s *Stack) dependencyGraph() (map[string][]string, error) {
+	known := make(map[string]bool, len(s.Modules))
+	for _, m := range s.Modules {
+		name := m.LocalName()
+		if known[name] {
+			return nil, fmt.Errorf("duplicate module name %q", name)
+		}
+		known[name] = true
+	}
+
+	graph := make(map[string][]string, len(s.Modules))
+	for _, m := range s.Modules {
+		name := m.LocalName()
+		seen := make(map[string]bool)
+		for _, dep := range s.Dependencies[name] {
+			if !known[dep] {
+				return nil, fmt.Errorf("module %q depends on unknown module %q", name, dep)
+			}
+			if !seen[dep] {
+				seen[dep] = true
+				graph[name] = append(graph[name], dep)
+			}
+		}
+		for _, dep := range referencedModules(m) {
+			if known[dep] && dep != name && !seen[dep] {
+				seen[dep] = true
+				graph[name] = append(graph[name], dep)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// findCycle returns a dependency cycle among the modules not yet emitted,
+// starting and ending with the same module (e.g. [a b c a]).
+// PURE: Calculation.
+func findCycle(modules []Module, graph map[string][]string, emitted map[string]bool) []string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range graph[name] {
+			if emitted[dep] {
+				continue
+			}
+			switch state[dep] {
+			case visiting:
+				for i, n := range path {
+					if n == dep {
+						return append(append([]string{}, path[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = done
+		return nil
+	}
+
+	for _, m := range modules {
+		name := m.LocalName()
+		if emitted[name] || state[name] != unvisited {
+			continue
+		}
+		if cycle := visit(name); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// referencedModules returns the local names of modules whose outputs m references,
+// either as Output values or as module.<name>.<attr> strings in exported fields.
+// PURE: Calculation.
+func referencedModules(m Module) []string {
+	var refs []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			refs = append(refs, name)
+		}
+	}
+	collectReferences(reflect.ValueOf(m), add, make(map[uintptr]bool))
+	return refs
+}
+
+// collectReferences walks v and reports every module it references.
+// PURE: Calculation.
+func collectReferences(v reflect.Value, add func(string), visited map[uintptr]bool) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() || visited[v.Pointer()] {
+			return
+		}
+		visited[v.Pointer()] = true
+		collectReferences(v.Elem(), add, visited)
+
+	case reflect.Interface:
+		if !v.IsNil() {
+			collectReferences(v.Elem(), add, visited)
+		}
+
+	case reflect.String:
+		addModuleReferences(v.String(), add)
+
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(Output{}) {
+			if v.CanInterface() {
+				if o, ok := v.Interface().(Output); ok && o.module != nil {
+					add(o.module.LocalName())
+				}
+			}
+			return
+		}
+		if v.Type() == reflect.TypeOf(hclgen.Expr{}) {
+			addModuleReferences(v.Interface().(hclgen.Expr).String(), add)
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				collectReferences(v.Field(i), add, visited)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectReferences(v.Index(i), add, visited)
+		}
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			collectReferences(iter.Value(), add, visited)
+		}
+	}
+}
+
+// addModuleReferences reports every module referenced in the string template s,
+// such as orders_queue in "${module.orders_queue.queue_arn}".
+// PURE: Calculation.
+func addModuleReferences(s string, add func(string)) {
+	for _, traversal := range hclgen.Variables(s) {
+		if traversal.RootName() != "module" || len(traversal) < 2 {
+			continue
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+			add(attr.Name)
+		}
+	}
+}
+
+// withDependsOn adds depends_on = [module.<dep>, ...] to the module block named name.
+// PURE: Calculation.
+func withDependsOn(config, name string, deps []string) (string, error) {
+	if len(deps) == 0 {
+		return config, nil
+	}
+
+	file, diags := hclwrite.ParseConfig([]byte(config), name+".tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", fmt.Errorf("failed to parse config for %s: %w", name, diags)
+	}
+
+	block := file.Body().FirstMatchingBlock("module", []string{name})
+	if block == nil {
+		return "", fmt.Errorf("config for %s has no module %q block to add depends_on to", name, name)
+	}
+
+	refs := make([]hclwrite.Tokens, 0, len(deps))
+	for _, dep := range deps {
+		refs = append(refs, hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "module"},
+			hcl.TraverseAttr{Name: dep},
+		}))
+	}
+	block.Body().SetAttributeRaw("depends_on", hclwrite.TokensForTuple(refs))
+
+	return string(file.Bytes()), nil
+}
+
+// explicitDependencies returns name's AddDependency targets without duplicates.
+// PURE: Calculation.
+func (s *Stack) explicitDependencies(name string) []string {
+	var deps []string
+	seen := make(map[string]bool)
+	for _, dep := range s.Dependencies[name] {
+		if !seen[dep] {
+			seen[dep] = true
+			deps = append(deps, dep)
+		}
+	}
+	return deps
+}
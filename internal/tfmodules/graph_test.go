@@ -0,0 +1,286 @@
+package tfmodules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RefModule is a test module whose exported fields may reference other modules.
+type RefModule struct {
+	name    string
+	Inputs  map[string]interface{}
+	Targets []Output
+	Source  string
+}
+
+func (m *RefModule) LocalName() string {
+	return m.name
+}
+
+func (m *RefModule) Configuration() (string, error) {
+	return "module \"" + m.name + "\" {\n  source = \"./modules/" + m.name + "\"\n}\n", nil
+}
+
+// moduleNames returns the local names of modules in order.
+func moduleNames(modules []Module) []string {
+	names := make([]string, 0, len(modules))
+	for _, m := range modules {
+		names = append(names, m.LocalName())
+	}
+	return names
+}
+
+func TestStack_SortedModules(t *testing.T) {
+	t.Run("keeps insertion order without dependencies", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "a"})
+		stack.AddModule(&RefModule{name: "b"})
+		stack.AddModule(&RefModule{name: "c"})
+
+		sorted, err := stack.SortedModules()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, moduleNames(sorted))
+	})
+
+	t.Run("orders explicit dependencies first", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "api"})
+		stack.AddModule(&RefModule{name: "queue"})
+		stack.AddModule(&RefModule{name: "table"})
+		stack.AddDependency("api", "queue")
+		stack.AddDependency("queue", "table")
+
+		sorted, err := stack.SortedModules()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"table", "queue", "api"}, moduleNames(sorted))
+	})
+
+	t.Run("infers dependencies from Output values", func(t *testing.T) {
+		table := &RefModule{name: "table"}
+		queue := &RefModule{name: "queue"}
+		api := &RefModule{
+			name:    "api",
+			Inputs:  map[string]interface{}{"TABLE_ARN": NewOutput(table, "dynamodb_table_arn")},
+			Targets: []Output{NewOutput(queue, "queue_arn")},
+		}
+
+		stack := NewStack("test")
+		stack.AddModule(api)
+		stack.AddModule(queue)
+		stack.AddModule(table)
+
+		sorted, err := stack.SortedModules()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"queue", "table", "api"}, moduleNames(sorted))
+	})
+
+	t.Run("infers dependencies from reference strings", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "api", Source: "${module.bucket.s3_bucket_id}"})
+		stack.AddModule(&RefModule{name: "bucket"})
+
+		sorted, err := stack.SortedModules()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bucket", "api"}, moduleNames(sorted))
+	})
+
+	t.Run("ignores references to modules outside the stack", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "api", Source: "${module.external.arn}"})
+
+		sorted, err := stack.SortedModules()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api"}, moduleNames(sorted))
+	})
+
+	t.Run("reports the cycle path", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "standalone"})
+		stack.AddModule(&RefModule{name: "a"})
+		stack.AddModule(&RefModule{name: "b"})
+		stack.AddModule(&RefModule{name: "c", Source: "${module.a.arn}"})
+		stack.AddDependency("a", "b")
+		stack.AddDependency("b", "c")
+
+		_, err := stack.SortedModules()
+
+		require.Error(t, err)
+		assert.Equal(t, "dependency cycle: a -> b -> c -> a", err.Error())
+	})
+
+	t.Run("rejects self dependencies", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "a"})
+		stack.AddDependency("a", "a")
+
+		_, err := stack.SortedModules()
+
+		require.Error(t, err)
+		assert.Equal(t, "dependency cycle: a -> a", err.Error())
+	})
+
+	t.Run("rejects dependencies on unknown modules", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "a"})
+		stack.AddDependency("a", "missing")
+
+		_, err := stack.SortedModules()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `module "a" depends on unknown module "missing"`)
+	})
+
+	t.Run("rejects duplicate module names", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "a"})
+		stack.AddModule(&RefModule{name: "a"})
+
+		_, err := stack.SortedModules()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `duplicate module name "a"`)
+	})
+}
+
+func TestStack_ToHCL_DependsOn(t *testing.T) {
+	t.Run("renders depends_on for explicit dependencies only", func(t *testing.T) {
+		table := &RefModule{name: "table"}
+		queue := &RefModule{name: "queue"}
+		api := &RefModule{name: "api", Targets: []Output{NewOutput(table, "arn")}}
+
+		stack := NewStack("test")
+		stack.AddModule(api)
+		stack.AddModule(queue)
+		stack.AddModule(table)
+		stack.AddDependency("api", "queue")
+		stack.AddDependency("api", "queue")
+
+		hcl, err := stack.ToHCL()
+
+		require.NoError(t, err)
+		assert.Contains(t, hcl, "depends_on = [module.queue]")
+		assert.Equal(t, 1, strings.Count(hcl, "depends_on"))
+		assert.Less(t, strings.Index(hcl, `module "queue"`), strings.Index(hcl, `module "api"`))
+		assert.Less(t, strings.Index(hcl, `module "table"`), strings.Index(hcl, `module "api"`))
+	})
+
+	t.Run("fails when the config has no matching module block", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&MockModule{name: "a", config: "# not a module"})
+		stack.AddModule(&MockModule{name: "b", config: "# not a module"})
+		stack.AddDependency("a", "b")
+
+		hcl, err := stack.ToHCL()
+
+		require.Error(t, err)
+		assert.Empty(t, hcl)
+		assert.Contains(t, err.Error(), `no module "a" block`)
+	})
+
+	t.Run("fails on cycles", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "a"})
+		stack.AddModule(&RefModule{name: "b"})
+		stack.AddDependency("a", "b")
+		stack.AddDependency("b", "a")
+
+		_, err := stack.ToHCL()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dependency cycle: a -> b -> a")
+	})
+}
+
+func TestStack_ToHCLFiles(t *testing.T) {
+	t.Run("renders one file per module", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "api"})
+		stack.AddModule(&RefModule{name: "queue"})
+		stack.AddDependency("api", "queue")
+
+		files, err := stack.ToHCLFiles()
+
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		assert.Contains(t, files["api.tf"], `module "api"`)
+		assert.Contains(t, files["api.tf"], "depends_on = [module.queue]")
+		assert.Contains(t, files["queue.tf"], `module "queue"`)
+		assert.NotContains(t, files["queue.tf"], "depends_on")
+	})
+
+	t.Run("matches the single-file rendering", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "api"})
+		stack.AddModule(&RefModule{name: "queue"})
+		stack.AddDependency("api", "queue")
+
+		files, err := stack.ToHCLFiles()
+		require.NoError(t, err)
+		hcl, err := stack.ToHCL()
+		require.NoError(t, err)
+
+		assert.Equal(t, files["queue.tf"]+"\n"+files["api.tf"], hcl)
+	})
+
+	t.Run("returns nothing on error", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&MockModule{name: "bad", err: assert.AnError})
+
+		files, err := stack.ToHCLFiles()
+
+		require.Error(t, err)
+		assert.Nil(t, files)
+	})
+}
+
+func TestStack_ToJSON(t *testing.T) {
+	t.Run("renders modules and depends_on as JSON", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "api"})
+		stack.AddModule(&RefModule{name: "queue"})
+		stack.AddDependency("api", "queue")
+
+		got, err := stack.ToJSON()
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"module": {
+			"api": {"source": "./modules/api", "depends_on": ["module.queue"]},
+			"queue": {"source": "./modules/queue"}
+		}}`, got)
+	})
+
+	t.Run("renders one file per module", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&RefModule{name: "api"})
+		stack.AddModule(&RefModule{name: "queue"})
+		stack.AddDependency("api", "queue")
+
+		files, err := stack.ToJSONFiles()
+
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		assert.JSONEq(t, `{"module": {"api": {"source": "./modules/api", "depends_on": ["module.queue"]}}}`, files["api.tf.json"])
+		assert.JSONEq(t, `{"module": {"queue": {"source": "./modules/queue"}}}`, files["queue.tf.json"])
+	})
+
+	t.Run("returns nothing on error", func(t *testing.T) {
+		stack := NewStack("test")
+		stack.AddModule(&MockModule{name: "bad", err: assert.AnError})
+
+		got, err := stack.ToJSON()
+		require.Error(t, err)
+		assert.Empty(t, got)
+
+		files, err := stack.ToJSONFiles()
+		require.Error(t, err)
+		assert.Nil(t, files)
+	})
+}
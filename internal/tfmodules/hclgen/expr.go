@@ -0,0 +1,255 @@
+package hclgen
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Strings in module fields are Terraform string templates, exactly as in
+// Terraform's JSON syntax: "orders" is a literal, "${var.namespace}orders"
+// interpolates, "${module.q.queue_arn}" is the bare reference and a literal
+// "${" is written "$${". Expr builds these strings from typed parts so callers
+// don't have to assemble template syntax by hand.
+
+// Expr is a Terraform expression: a reference, function call or template.
+// Use it in interface{} fields directly, or its String form in string fields.
+type Expr struct {
+	// template is the expression as a string template, e.g. "${var.x}-suffix".
+	template string
+	// err is a rendering error from building the expression, reported when
+	// the expression is rendered.
+	err error
+}
+
+// Expression is implemented by values that render as a Terraform expression,
+// such as Expr and tfmodules.Output.
+type Expression interface {
+	Expr() Expr
+}
+
+// Ref returns a reference to a named value, e.g. Ref("module.orders.queue_arn")
+// or Ref("var.namespace").
+// PURE: Calculation.
+func Ref(address string) Expr {
+	traversal, diags := hclsyntax.ParseTraversalAbs([]byte(address), "reference", hcl.InitialPos)
+	if diags.HasErrors() || len(traversal) < 2 {
+		return Expr{err: fmt.Errorf("invalid reference %q", address)}
+	}
+	return Raw(address)
+}
+
+// Raw returns an expression from its HCL source, e.g. Raw("length(var.subnets)").
+// PURE: Calculation.
+func Raw(src string) Expr {
+	if _, diags := hclsyntax.ParseExpression([]byte(src), "expression", hcl.InitialPos); diags.HasErrors() {
+		return Expr{err: fmt.Errorf("invalid expression %q: %w", src, diags)}
+	}
+	return Expr{template: "${" + src + "}"}
+}
+
+// Func returns a function call. Arguments are rendered like module fields:
+// Expressions as written, strings as templates and other Go values as literals.
+// PURE: Calculation.
+func Func(name string, args ...interface{}) Expr {
+	parts := make([]string, 0, len(args))
+	for i, arg := range args {
+		tokens, err := valueTokens(reflect.ValueOf(&arg).Elem())
+		if err != nil {
+			return Expr{err: fmt.Errorf("%s argument %d: %w", name, i, err)}
+		}
+		parts = append(parts, strings.TrimSpace(string(tokens.Bytes())))
+	}
+	return Raw(name + "(" + strings.Join(parts, ", ") + ")")
+}
+
+// JSONEncode returns jsonencode(v), with v rendered as an HCL value so it may
+// hold references, e.g. an IAM policy whose Resource is a module output.
+// PURE: Calculation.
+func JSONEncode(v interface{}) Expr {
+	return Func("jsonencode", v)
+}
+
+// Template returns a string template concatenating its parts: strings are
+// literal text and Expressions are interpolated, e.g.
+// Template(Ref("var.namespace"), "orders").
+// PURE: Calculation.
+func Template(parts ...interface{}) Expr {
+	var b strings.Builder
+	for i, part := range parts {
+		switch p := part.(type) {
+		case string:
+			b.WriteString(escapeTemplate(p))
+		case Expression:
+			e := p.Expr()
+			if e.err != nil {
+				return e
+			}
+			b.WriteString(e.template)
+		default:
+			return Expr{err: fmt.Errorf("template part %d: expected string or expression, got %T", i, part)}
+		}
+	}
+	return Expr{template: b.String()}
+}
+
+// Expr returns e, so that Expr implements Expression.
+func (e Expr) Expr() Expr {
+	return e
+}
+
+// String returns e as a string template for string fields, e.g.
+// "${module.orders.queue_arn}".
+func (e Expr) String() string {
+	return e.template
+}
+
+// Err returns the error from building e, if any.
+func (e Expr) Err() error {
+	return e.err
+}
+
+// IsExpression reports whether s is a string template with interpolations or
+// directives, whose value is only known at plan time, rather than a literal.
+// PURE: Calculation.
+func IsExpression(s string) bool {
+	_, literal := templateLiteral(s)
+	return !literal
+}
+
+// Variables returns the references in the string template s, e.g.
+// module.orders.queue_arn in "${module.orders.queue_arn}/*".
+// PURE: Calculation.
+func Variables(s string) []hcl.Traversal {
+	if !strings.Contains(s, "${") && !strings.Contains(s, "%{") {
+		return nil
+	}
+	expr, diags := hclsyntax.ParseTemplate([]byte(s), "template", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+	return expr.Variables()
+}
+
+// templateLiteral evaluates s when it is a literal template.
+// PURE: Calculation.
+func templateLiteral(s string) (string, bool) {
+	if !strings.Contains(s, "${") && !strings.Contains(s, "%{") {
+		return s, true
+	}
+	expr, diags := hclsyntax.ParseTemplate([]byte(s), "template", hcl.InitialPos)
+	if diags.HasErrors() || len(expr.Variables()) > 0 {
+		return "", false
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || val.Type() != cty.String || val.IsNull() {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+// stringTokens renders a string template: a literal as a quoted string, a
+// single interpolation as its bare expression, multi-line text ending in a
+// newline as a heredoc and anything else as a quoted template.
+// PURE: Calculation.
+func stringTokens(s string) (hclwrite.Tokens, error) {
+	literal, isLiteral := templateLiteral(s)
+	if isHeredoc(s) {
+		if _, diags := hclsyntax.ParseTemplate([]byte(s), "template", hcl.InitialPos); diags.HasErrors() {
+			return nil, fmt.Errorf("invalid template %q: %w", s, diags)
+		}
+		// A heredoc's body is the template itself, "$${" escapes included
+		marker := heredocMarker(s)
+		return expressionTokens("<<" + marker + "\n" + s + marker)
+	}
+	if isLiteral {
+		return hclwrite.TokensForValue(cty.StringVal(literal)), nil
+	}
+
+	expr, diags := hclsyntax.ParseTemplate([]byte(s), "template", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("invalid template %q: %w", s, diags)
+	}
+	if wrap, ok := expr.(*hclsyntax.TemplateWrapExpr); ok {
+		return expressionTokens(string(wrap.Wrapped.Range().SliceBytes([]byte(s))))
+	}
+
+	template, ok := expr.(*hclsyntax.TemplateExpr)
+	if !ok {
+		return nil, fmt.Errorf("unsupported template %q", s)
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, part := range template.Parts {
+		source := string(part.Range().SliceBytes([]byte(s)))
+		switch {
+		case isLiteralPart(part):
+			b.WriteString(quoteLiteral(part.(*hclsyntax.LiteralValueExpr).Val.AsString()))
+		case strings.HasPrefix(source, "%{"):
+			b.WriteString(source)
+		default:
+			b.WriteString("${" + source + "}")
+		}
+	}
+	b.WriteByte('"')
+	return expressionTokens(b.String())
+}
+
+// isHeredoc reports whether s reads better as a heredoc: several lines, each
+// ending in a newline.
+// PURE: Calculation.
+func isHeredoc(s string) bool {
+	return strings.Count(s, "\n") > 1 && strings.HasSuffix(s, "\n") && !strings.Contains(s, "\r")
+}
+
+// heredocMarker returns a heredoc delimiter that no line of s equals.
+// PURE: Calculation.
+func heredocMarker(s string) string {
+	lines := strings.Split(s, "\n")
+	for i := 0; ; i++ {
+		marker := "EOT"
+		if i > 0 {
+			marker = fmt.Sprintf("EOT%d", i)
+		}
+		taken := false
+		for _, line := range lines {
+			if strings.TrimSpace(line) == marker {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			return marker
+		}
+	}
+}
+
+// isLiteralPart reports whether a template part is literal text.
+// PURE: Calculation.
+func isLiteralPart(part hclsyntax.Expression) bool {
+	lit, ok := part.(*hclsyntax.LiteralValueExpr)
+	return ok && lit.Val.Type() == cty.String
+}
+
+// quoteLiteral escapes literal template text for a quoted HCL string.
+// PURE: Calculation.
+func quoteLiteral(s string) string {
+	return escapeTemplate(strings.NewReplacer(
+		`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`,
+	).Replace(s))
+}
+
+// exprTokens renders an Expression, reporting an error from building it.
+// PURE: Calculation.
+func exprTokens(e Expression) (hclwrite.Tokens, error) {
+	expr := e.Expr()
+	if expr.err != nil {
+		return nil, expr.err
+	}
+	return stringTokens(expr.template)
+}
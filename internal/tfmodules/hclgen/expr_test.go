@@ -0,0 +1,157 @@
+package hclgen_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+)
+
+// TestExpr tests the string templates built by the expression constructors.
+func TestExpr(t *testing.T) {
+	tests := []struct {
+		name string
+		expr hclgen.Expr
+		want string
+	}{
+		{"reference", hclgen.Ref("module.orders.queue_arn"), "${module.orders.queue_arn}"},
+		{"indexed reference", hclgen.Ref("module.vpc.private_subnets[0]"), "${module.vpc.private_subnets[0]}"},
+		{"raw", hclgen.Raw("length(var.subnets)"), "${length(var.subnets)}"},
+		{"function", hclgen.Func("format", "%s-%s", hclgen.Ref("var.namespace"), 3), `${format("%s-%s", var.namespace, 3)}`},
+		{"function with template argument", hclgen.Func("upper", "${var.name}-x"), `${upper("${var.name}-x")}`},
+		{"template", hclgen.Template(hclgen.Ref("var.namespace"), "-orders"), "${var.namespace}-orders"},
+		{"template escapes literals", hclgen.Template("costs ${literal} ", hclgen.Ref("var.x")), "costs $${literal} ${var.x}"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.expr.Err())
+			assert.Equal(t, tc.want, tc.expr.String())
+		})
+	}
+}
+
+// TestExpr_Errors tests that invalid expressions report an error when rendered.
+func TestExpr_Errors(t *testing.T) {
+	type module struct {
+		Value interface{} `hcl:"value,attr"`
+	}
+
+	for name, expr := range map[string]hclgen.Expr{
+		"reference without attribute": hclgen.Ref("module"),
+		"reference with a call":       hclgen.Ref("upper(var.x)"),
+		"raw syntax error":            hclgen.Raw("var.x +"),
+		"function argument":           hclgen.Func("max", make(chan int)),
+		"template part":               hclgen.Template(42),
+		"template expression":         hclgen.Template("a", hclgen.Ref("nope")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, expr.Err())
+
+			_, err := hclgen.ToHCLWrite("test", "source", "", &module{Value: expr})
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestToHCLWrite_Expressions tests how string templates and Exprs render.
+func TestToHCLWrite_Expressions(t *testing.T) {
+	type module struct {
+		Literal  *string                `hcl:"literal,attr"`
+		Ref      *string                `hcl:"ref,attr"`
+		Template *string                `hcl:"template,attr"`
+		Escaped  *string                `hcl:"escaped,attr"`
+		Policy   interface{}            `hcl:"policy,attr"`
+		Names    []interface{}          `hcl:"names,attr"`
+		Env      map[string]interface{} `hcl:"env,attr"`
+	}
+
+	literal := "module.docs"
+	ref := hclgen.Ref("module.orders.queue_arn").String()
+	template := "${var.x}-suffix"
+	escaped := "$${not_interpolated}"
+
+	result, err := hclgen.ToHCLWrite("test", "source", "", &module{
+		Literal:  &literal,
+		Ref:      &ref,
+		Template: &template,
+		Escaped:  &escaped,
+		Policy: hclgen.JSONEncode(map[string]interface{}{
+			"Resource": hclgen.Ref("module.orders.queue_arn"),
+		}),
+		Names: []interface{}{hclgen.Func("upper", hclgen.Ref("var.name")), "plain"},
+		Env:   map[string]interface{}{"STAGE": hclgen.Template(hclgen.Ref("var.namespace"), "prod")},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `literal = "module.docs"`)
+	assert.Contains(t, result, "ref      = module.orders.queue_arn")
+	assert.Contains(t, result, `template = "${var.x}-suffix"`)
+	assert.Contains(t, result, `escaped = "$${not_interpolated}"`)
+	assert.Contains(t, result, "policy = jsonencode({\n    Resource = module.orders.queue_arn\n  })")
+	assert.Contains(t, result, `names   = [upper(var.name), "plain"]`)
+	assert.Contains(t, result, `STAGE = "${var.namespace}prod"`)
+
+	t.Run("renders the same configuration after parsing", func(t *testing.T) {
+		var parsed module
+		block, err := hclgen.FromHCL([]byte(result), "main.tf", "test", &parsed)
+		require.NoError(t, err)
+		again, err := block.ToHCLWrite(&parsed)
+		require.NoError(t, err)
+		assert.Equal(t, result, again)
+	})
+}
+
+// TestIsExpression tests telling string templates from literals.
+func TestIsExpression(t *testing.T) {
+	for s, want := range map[string]bool{
+		"orders":                   false,
+		"module.docs":              false,
+		"costs $${literal}":        false,
+		"${var.x}":                 true,
+		"${var.x}-suffix":          true,
+		"${upper(\"a\")}":          true,
+		"%{if var.x}a%{endif}":     true,
+		"prefix-${module.q.arn}/*": true,
+	} {
+		assert.Equal(t, want, hclgen.IsExpression(s), s)
+	}
+}
+
+// TestVariables tests listing the references in a string template.
+func TestVariables(t *testing.T) {
+	vars := hclgen.Variables("${module.orders.queue_arn}/${var.path}")
+	require.Len(t, vars, 2)
+	assert.Equal(t, "module", vars[0].RootName())
+	assert.Equal(t, "var", vars[1].RootName())
+
+	assert.Empty(t, hclgen.Variables("module.orders.queue_arn"))
+	assert.Empty(t, hclgen.Variables("$${var.x}"))
+}
+
+// TestToHCLWrite_Heredocs tests that multi-line strings render as heredocs.
+func TestToHCLWrite_Heredocs(t *testing.T) {
+	type module struct {
+		Script *string `hcl:"script,attr"`
+	}
+
+	for value, want := range map[string]string{
+		"line one\nline two\n":         "script = <<EOT\nline one\nline two\nEOT\n",
+		"cost $${literal}\n${var.x}\n": "script = <<EOT\ncost $${literal}\n${var.x}\nEOT\n",
+		"a\nEOT\n":                     "script = <<EOT1\na\nEOT\nEOT1\n",
+		"no trailing\nnewline":         `script = "no trailing\nnewline"`,
+		"single line\n":                `script = "single line\n"`,
+	} {
+		result, err := hclgen.ToHCLWrite("test", "source", "", &module{Script: &value})
+		require.NoError(t, err, value)
+		assert.Contains(t, result, want, value)
+
+		var parsed module
+		block, err := hclgen.FromHCL([]byte(result), "main.tf", "test", &parsed)
+		require.NoError(t, err, value)
+		require.NotNil(t, parsed.Script, value)
+		assert.Equal(t, value, *parsed.Script, value)
+		assert.Empty(t, block.Extra, value)
+	}
+}
@@ -0,0 +1,569 @@
+package hclgen
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// ModuleBlock is a module call read from Terraform source.
+type ModuleBlock struct {
+	LocalName string
+	Source    string
+	Version   string
+
+	// Extra holds the arguments that have no typed field to live in, as HCL
+	// expression source: unknown module inputs, meta-arguments such as
+	// depends_on or count, and values the field's type can't hold (a
+	// reference where a number or list is expected).
+	Extra map[string]string
+}
+
+// ParseModuleBlocks lists the module blocks in src, in file order, with their
+// label, source and version. Arguments are not decoded; see FromHCL.
+// PURE: Calculation.
+func ParseModuleBlocks(src []byte, filename string) ([]ModuleBlock, error) {
+	body, err := parseBody(src, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []ModuleBlock
+	for _, block := range body.Blocks {
+		if block.Type != "module" {
+			continue
+		}
+		header, err := moduleHeader(block)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, header)
+	}
+	return blocks, nil
+}
+
+// FromHCL decodes the module block labeled localName in src into v, a pointer
+// to a module struct, reversing ToHCLWrite. Arguments are matched to fields by
+// their hcl tag; string fields hold string templates, so module.x.y is kept as
+// "${module.x.y}", which ToHCLWrite renders unquoted again. Arguments that don't fit a field are kept
+// in the returned block's Extra. A nil v keeps every argument in Extra.
+// PURE: Calculation.
+func FromHCL(src []byte, filename, localName string, v interface{}) (ModuleBlock, error) {
+	body, err := parseBody(src, filename)
+	if err != nil {
+		return ModuleBlock{}, err
+	}
+
+	var block *hclsyntax.Block
+	for _, b := range body.Blocks {
+		if b.Type == "module" && len(b.Labels) == 1 && b.Labels[0] == localName {
+			block = b
+			break
+		}
+	}
+	if block == nil {
+		return ModuleBlock{}, fmt.Errorf("%s: no module %q block", filename, localName)
+	}
+
+	result, err := moduleHeader(block)
+	if err != nil {
+		return ModuleBlock{}, err
+	}
+	result.Extra = make(map[string]string)
+
+	var target reflect.Value
+	if v != nil {
+		target = reflect.ValueOf(v)
+		if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+			return ModuleBlock{}, fmt.Errorf("expected pointer to struct, got %T", v)
+		}
+		target = target.Elem()
+		setStringField(target, "Source", result.Source)
+		setStringField(target, "Version", result.Version)
+	}
+
+	for name, attr := range block.Body.Attributes {
+		if name == "source" || name == "version" {
+			continue
+		}
+		if target.IsValid() {
+			if field, ok := bodyField(target, name, false); ok {
+				if decoded, err := decodeExpr(attr.Expr, src, field.Type()); err == nil {
+					field.Set(decoded)
+					continue
+				}
+			}
+		}
+		result.Extra[name] = exprSource(attr.Expr, src)
+	}
+
+	for _, nested := range block.Body.Blocks {
+		field, ok := reflect.Value{}, false
+		if target.IsValid() {
+			field, ok = bodyField(target, nested.Type, true)
+		}
+		if !ok {
+			return ModuleBlock{}, fmt.Errorf("module %s: unsupported block %q", localName, nested.Type)
+		}
+		if err := decodeBlock(nested, src, field); err != nil {
+			return ModuleBlock{}, fmt.Errorf("module %s: block %s: %w", localName, nested.Type, err)
+		}
+	}
+
+	return result, nil
+}
+
+// ToHCLWrite renders v like ToHCLWrite with the block's label, source and
+// version, followed by its Extra arguments sorted by name. A nil v renders
+// only the Extra arguments.
+// PURE: Same input always produces same output.
+func (b ModuleBlock) ToHCLWrite(v interface{}) (string, error) {
+	if v == nil {
+		v = struct{}{}
+	}
+
+	f := hclwrite.NewEmptyFile()
+	body := f.Body().AppendNewBlock("module", []string{b.LocalName}).Body()
+	body.SetAttributeValue("source", cty.StringVal(b.Source))
+	if b.Version != "" {
+		body.SetAttributeValue("version", cty.StringVal(b.Version))
+	}
+
+	if err := structToHCLWrite(body, v); err != nil {
+		return "", err
+	}
+
+	names := make([]string, 0, len(b.Extra))
+	for name := range b.Extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tokens, err := expressionTokens(b.Extra[name])
+		if err != nil {
+			return "", fmt.Errorf("attribute %s: %w", name, err)
+		}
+		body.SetAttributeRaw(name, tokens)
+	}
+
+	return string(f.Bytes()), nil
+}
+
+// parseBody parses src as native HCL syntax.
+// PURE: Calculation.
+func parseBody(src []byte, filename string) (*hclsyntax.Body, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, fmt.Errorf("%s: unexpected body type %T", filename, file.Body)
+	}
+	return body, nil
+}
+
+// moduleHeader reads a module block's label and its literal source and version.
+// PURE: Calculation.
+func moduleHeader(block *hclsyntax.Block) (ModuleBlock, error) {
+	if len(block.Labels) != 1 {
+		return ModuleBlock{}, fmt.Errorf("%s: module block needs exactly one label", block.DefRange())
+	}
+	header := ModuleBlock{LocalName: block.Labels[0]}
+
+	for name, dst := range map[string]*string{"source": &header.Source, "version": &header.Version} {
+		attr, ok := block.Body.Attributes[name]
+		if !ok {
+			continue
+		}
+		val, diags := attr.Expr.Value(nil)
+		if diags.HasErrors() || val.Type() != cty.String || val.IsNull() {
+			return ModuleBlock{}, fmt.Errorf("module %s: %s must be a literal string", header.LocalName, name)
+		}
+		*dst = val.AsString()
+	}
+	if header.Source == "" {
+		return ModuleBlock{}, fmt.Errorf("module %s: missing source", header.LocalName)
+	}
+	return header, nil
+}
+
+// taggedField is a struct field matched by its hcl tag.
+type taggedField struct {
+	value reflect.Value
+	block bool // Tagged ",block"
+	// special is set for the fields ToHCLWrite renders itself or leaves out
+	// of module and block bodies: Source, Version and Region.
+	special bool
+}
+
+// fieldByTag returns the settable field of struct v whose hcl tag names it.
+// PURE: Calculation.
+func fieldByTag(v reflect.Value, name string) (taggedField, bool) {
+	typ := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		parts := strings.Split(field.Tag.Get("hcl"), ",")
+		if parts[0] == "" || parts[0] == "-" || parts[0] != name {
+			continue
+		}
+		return taggedField{
+			value:   v.Field(i),
+			block:   len(parts) > 1 && parts[1] == "block",
+			special: field.Name == "Source" || field.Name == "Version" || field.Name == "Region",
+		}, true
+	}
+	return taggedField{}, false
+}
+
+// bodyField returns the field of struct v that a module or block body
+// argument (block false) or nested block (block true) decodes into.
+// PURE: Calculation.
+func bodyField(v reflect.Value, name string, block bool) (reflect.Value, bool) {
+	field, ok := fieldByTag(v, name)
+	if !ok || field.special || field.block != block {
+		return reflect.Value{}, false
+	}
+	return field.value, true
+}
+
+// setStringField sets v's string field name, if it has one.
+func setStringField(v reflect.Value, name, value string) {
+	if field := v.FieldByName(name); field.IsValid() && field.Kind() == reflect.String && field.CanSet() {
+		field.SetString(value)
+	}
+}
+
+// decodeBlock decodes a nested block into a struct, slice-of-struct or
+// map-of-struct field, the shapes writeBlock renders.
+// PURE: Calculation.
+func decodeBlock(block *hclsyntax.Block, src []byte, field reflect.Value) error {
+	switch field.Kind() {
+	case reflect.Ptr:
+		if len(block.Labels) != 0 {
+			return fmt.Errorf("unexpected labels %v", block.Labels)
+		}
+		elem := reflect.New(field.Type().Elem())
+		if err := decodeStructBody(block.Body, src, elem.Elem()); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+
+	case reflect.Struct:
+		if len(block.Labels) != 0 {
+			return fmt.Errorf("unexpected labels %v", block.Labels)
+		}
+		return decodeStructBody(block.Body, src, field)
+
+	case reflect.Slice:
+		if len(block.Labels) != 0 {
+			return fmt.Errorf("unexpected labels %v", block.Labels)
+		}
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := decodeBlock(block, src, elem); err != nil {
+			return err
+		}
+		field.Set(reflect.Append(field, elem))
+		return nil
+
+	case reflect.Map:
+		if len(block.Labels) != 1 {
+			return fmt.Errorf("expected one label, got %d", len(block.Labels))
+		}
+		if field.IsNil() {
+			field.Set(reflect.MakeMap(field.Type()))
+		}
+		elem := reflect.New(field.Type().Elem()).Elem()
+		if err := decodeBlock(&hclsyntax.Block{Type: block.Type, Body: block.Body}, src, elem); err != nil {
+			return err
+		}
+		field.SetMapIndex(reflect.ValueOf(block.Labels[0]), elem)
+		return nil
+
+	default:
+		return fmt.Errorf("cannot decode a block into %v", field.Type())
+	}
+}
+
+// decodeStructBody decodes a nested block body into struct v. Unlike the module
+// body there is nowhere to keep unknown arguments, so they are an error.
+// PURE: Calculation.
+func decodeStructBody(body *hclsyntax.Body, src []byte, v reflect.Value) error {
+	for name, attr := range body.Attributes {
+		field, ok := bodyField(v, name, false)
+		if !ok {
+			return fmt.Errorf("unknown attribute %q", name)
+		}
+		decoded, err := decodeExpr(attr.Expr, src, field.Type())
+		if err != nil {
+			return fmt.Errorf("attribute %s: %w", name, err)
+		}
+		field.Set(decoded)
+	}
+	for _, nested := range body.Blocks {
+		field, ok := bodyField(v, nested.Type, true)
+		if !ok {
+			return fmt.Errorf("unknown block %q", nested.Type)
+		}
+		if err := decodeBlock(nested, src, field); err != nil {
+			return fmt.Errorf("block %s: %w", nested.Type, err)
+		}
+	}
+	return nil
+}
+
+// decodeExpr decodes expr into a new value of type typ. Tuples and objects are
+// decoded element by element so they may hold references; any other expression
+// must either be constant or, for string and interface targets, a template.
+// PURE: Calculation.
+func decodeExpr(expr hclsyntax.Expression, src []byte, typ reflect.Type) (reflect.Value, error) {
+	out := reflect.New(typ).Elem()
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		elem, err := decodeExpr(expr, src, typ.Elem())
+		if err != nil {
+			return out, err
+		}
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+
+	case reflect.Slice:
+		tuple, ok := expr.(*hclsyntax.TupleConsExpr)
+		if !ok {
+			return out, fmt.Errorf("expected a list, got %s", exprSource(expr, src))
+		}
+		out = reflect.MakeSlice(typ, 0, len(tuple.Exprs))
+		for i, e := range tuple.Exprs {
+			elem, err := decodeExpr(e, src, typ.Elem())
+			if err != nil {
+				return out, fmt.Errorf("element %d: %w", i, err)
+			}
+			out = reflect.Append(out, elem)
+		}
+		return out, nil
+
+	case reflect.Map:
+		items, err := objectItems(expr, src)
+		if err != nil {
+			return out, err
+		}
+		out = reflect.MakeMapWithSize(typ, len(items))
+		for _, item := range items {
+			val, err := decodeExpr(item.value, src, typ.Elem())
+			if err != nil {
+				return out, fmt.Errorf("key %s: %w", item.key, err)
+			}
+			out.SetMapIndex(reflect.ValueOf(item.key).Convert(typ.Key()), val)
+		}
+		return out, nil
+
+	case reflect.Struct:
+		items, err := objectItems(expr, src)
+		if err != nil {
+			return out, err
+		}
+		for _, item := range items {
+			// structTokens writes every tagged field, blocks included, as an object key
+			field, ok := fieldByTag(out, item.key)
+			if !ok {
+				return out, fmt.Errorf("unknown key %q", item.key)
+			}
+			val, err := decodeExpr(item.value, src, field.value.Type())
+			if err != nil {
+				return out, fmt.Errorf("key %s: %w", item.key, err)
+			}
+			field.value.Set(val)
+		}
+		return out, nil
+
+	case reflect.Interface:
+		val, err := decodeDynamic(expr, src)
+		if err != nil {
+			return out, err
+		}
+		if val != nil {
+			out.Set(reflect.ValueOf(val))
+		}
+		return out, nil
+
+	case reflect.String:
+		if template, ok := templateSource(expr, src); ok {
+			out.SetString(template)
+			return out, nil
+		}
+	}
+
+	val, err := constantValue(expr, src)
+	if err != nil {
+		return out, err
+	}
+	if typ.Kind() == reflect.String && val.Type() == cty.String {
+		// Strings are templates: a literal "${" is written "$${"
+		out.SetString(escapeTemplate(val.AsString()))
+		return out, nil
+	}
+	if err := gocty.FromCtyValue(val, out.Addr().Interface()); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// decodeDynamic decodes expr for an interface{} target: strings, bools, ints
+// and floats, []interface{} and map[string]interface{}, and references as
+// string templates.
+// PURE: Calculation.
+func decodeDynamic(expr hclsyntax.Expression, src []byte) (interface{}, error) {
+	switch e := expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		elems := make([]interface{}, 0, len(e.Exprs))
+		for i, elemExpr := range e.Exprs {
+			elem, err := decodeDynamic(elemExpr, src)
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
+			}
+			elems = append(elems, elem)
+		}
+		return elems, nil
+
+	case *hclsyntax.ObjectConsExpr:
+		items, err := objectItems(e, src)
+		if err != nil {
+			return nil, err
+		}
+		obj := make(map[string]interface{}, len(items))
+		for _, item := range items {
+			val, err := decodeDynamic(item.value, src)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", item.key, err)
+			}
+			obj[item.key] = val
+		}
+		return obj, nil
+	}
+
+	if template, ok := templateSource(expr, src); ok {
+		return template, nil
+	}
+
+	val, err := constantValue(expr, src)
+	if err != nil {
+		return nil, err
+	}
+	switch val.Type() {
+	case cty.String:
+		return escapeTemplate(val.AsString()), nil
+	case cty.Bool:
+		return val.True(), nil
+	case cty.Number:
+		bf := val.AsBigFloat()
+		if bf.IsInt() {
+			if i, acc := bf.Int64(); acc == big.Exact {
+				return int(i), nil
+			}
+		}
+		f, _ := bf.Float64()
+		return f, nil
+	default:
+		return nil, fmt.Errorf("unsupported value %s", exprSource(expr, src))
+	}
+}
+
+// objectItem is one key/value pair of an object constructor.
+type objectItem struct {
+	key   string
+	value hclsyntax.Expression
+}
+
+// objectItems returns the items of an object constructor with constant string keys.
+// PURE: Calculation.
+func objectItems(expr hclsyntax.Expression, src []byte) ([]objectItem, error) {
+	obj, ok := expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %s", exprSource(expr, src))
+	}
+	items := make([]objectItem, 0, len(obj.Items))
+	for _, item := range obj.Items {
+		key, diags := item.KeyExpr.Value(nil)
+		if diags.HasErrors() || key.Type() != cty.String || key.IsNull() {
+			return nil, fmt.Errorf("object key must be a constant string: %s", exprSource(item.KeyExpr, src))
+		}
+		items = append(items, objectItem{key: key.AsString(), value: item.ValueExpr})
+	}
+	return items, nil
+}
+
+// templateSource returns a computed expression as the string template that
+// ToHCLWrite renders back to it: "${module.x.y}" for a reference or function
+// call, and the template itself for "${var.x}-suffix". Constants, tuples and
+// objects are not templates.
+// PURE: Calculation.
+func templateSource(expr hclsyntax.Expression, src []byte) (string, bool) {
+	switch e := expr.(type) {
+	case *hclsyntax.TupleConsExpr, *hclsyntax.ObjectConsExpr:
+		return "", false
+	case *hclsyntax.TemplateExpr:
+		if e.IsStringLiteral() {
+			return "", false
+		}
+		return templateJSON(e, src), true
+	case *hclsyntax.TemplateWrapExpr:
+		return interpolation(e.Wrapped, src), true
+	}
+	if len(expr.Variables()) == 0 {
+		if _, diags := expr.Value(nil); !diags.HasErrors() {
+			return "", false
+		}
+	}
+	return interpolation(expr, src), true
+}
+
+// constantValue evaluates an expression without variables or functions.
+// PURE: Calculation.
+func constantValue(expr hclsyntax.Expression, src []byte) (cty.Value, error) {
+	if len(expr.Variables()) > 0 {
+		return cty.NilVal, fmt.Errorf("not a constant: %s", exprSource(expr, src))
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, fmt.Errorf("not a constant: %s", exprSource(expr, src))
+	}
+	if val.IsNull() || !val.IsKnown() {
+		return cty.NilVal, fmt.Errorf("unsupported value %s", exprSource(expr, src))
+	}
+	return val, nil
+}
+
+// exprSource returns the source text of expr.
+// PURE: Calculation.
+func exprSource(expr hclsyntax.Expression, src []byte) string {
+	rng := expr.Range()
+	return string(src[rng.Start.Byte:rng.End.Byte])
+}
+
+// expressionTokens returns the tokens of an HCL expression given as source.
+// PURE: Calculation.
+func expressionTokens(expr string) (hclwrite.Tokens, error) {
+	file, diags := hclwrite.ParseConfig([]byte("value = "+expr+"\n"), "expression", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	attr := file.Body().GetAttribute("value")
+	if attr == nil {
+		return nil, fmt.Errorf("invalid expression %q", expr)
+	}
+	return attr.Expr().BuildTokens(nil), nil
+}
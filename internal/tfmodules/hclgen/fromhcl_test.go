@@ -0,0 +1,237 @@
+package hclgen_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+)
+
+// queueModule is a module struct shaped like the generated tfmodules types.
+type queueModule struct {
+	Source  string  `hcl:"source,attr"`
+	Version string  `hcl:"version,attr"`
+	Region  *string `hcl:"region,attr"`
+
+	Name       *string                `hcl:"name,attr"`
+	Delay      *int                   `hcl:"delay_seconds,attr"`
+	FIFO       *bool                  `hcl:"fifo_queue,attr"`
+	Ratio      *float64               `hcl:"ratio,attr"`
+	Tags       map[string]string      `hcl:"tags,attr"`
+	Subnets    []string               `hcl:"subnet_ids,attr"`
+	Policy     interface{}            `hcl:"policy,attr"`
+	Statements map[string]statement   `hcl:"statements,attr"`
+	Settings   map[string]interface{} `hcl:"settings,attr"`
+}
+
+type statement struct {
+	Effect     *string  `json:"effect,omitempty" hcl:"effect,attr"`
+	Actions    []string `json:"actions,omitempty" hcl:"actions,attr"`
+	Principals []struct {
+		Type        string   `json:"type" hcl:"type,attr"`
+		Identifiers []string `json:"identifiers" hcl:"identifiers,attr"`
+	} `json:"principals,omitempty" hcl:"principals,block"`
+}
+
+const queueHCL = `
+module "other" {
+  source = "./other"
+}
+
+module "orders" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "~> 4.0"
+
+  name          = "orders"
+  delay_seconds = 5
+  fifo_queue    = true
+  ratio         = 0.5
+  tags          = { Team = "payments", "cost-center" = "42" }
+  subnet_ids    = [module.vpc.private_subnets[0], "subnet-123"]
+  policy        = { Version = "2012-10-17", Statement = [{ Effect = "Allow", Count = 3 }] }
+  settings      = { queue_arn = "${module.dlq.queue_arn}", retries = 3 }
+
+  statements = {
+    publish = {
+      effect  = "Allow"
+      actions = ["sqs:SendMessage"]
+      principals = [{
+        type        = "Service"
+        identifiers = ["sns.amazonaws.com"]
+      }]
+    }
+  }
+
+  region             = "us-east-1"
+  visibility_timeout = var.visibility_timeout
+  kms_master_key_id  = "alias/${var.namespace}-orders"
+  depends_on         = [module.dlq]
+}
+`
+
+// TestFromHCL_DecodesTypedFields tests decoding arguments into matching fields.
+func TestFromHCL_DecodesTypedFields(t *testing.T) {
+	var module queueModule
+	block, err := hclgen.FromHCL([]byte(queueHCL), "main.tf", "orders", &module)
+	require.NoError(t, err)
+
+	assert.Equal(t, "orders", block.LocalName)
+	assert.Equal(t, "terraform-aws-modules/sqs/aws", block.Source)
+	assert.Equal(t, "~> 4.0", block.Version)
+	assert.Equal(t, "terraform-aws-modules/sqs/aws", module.Source)
+	assert.Equal(t, "~> 4.0", module.Version)
+
+	require.NotNil(t, module.Name)
+	assert.Equal(t, "orders", *module.Name)
+	require.NotNil(t, module.Delay)
+	assert.Equal(t, 5, *module.Delay)
+	require.NotNil(t, module.FIFO)
+	assert.True(t, *module.FIFO)
+	require.NotNil(t, module.Ratio)
+	assert.InDelta(t, 0.5, *module.Ratio, 0)
+	assert.Equal(t, map[string]string{"Team": "payments", "cost-center": "42"}, module.Tags)
+
+	require.Contains(t, module.Statements, "publish")
+	publish := module.Statements["publish"]
+	assert.Equal(t, []string{"sqs:SendMessage"}, publish.Actions)
+	require.Len(t, publish.Principals, 1)
+	assert.Equal(t, "Service", publish.Principals[0].Type)
+
+	assert.Equal(t, map[string]interface{}{
+		"Version":   "2012-10-17",
+		"Statement": []interface{}{map[string]interface{}{"Effect": "Allow", "Count": 3}},
+	}, module.Policy)
+}
+
+// TestFromHCL_KeepsReferences tests that references decode to string templates.
+func TestFromHCL_KeepsReferences(t *testing.T) {
+	var module queueModule
+	_, err := hclgen.FromHCL([]byte(queueHCL), "main.tf", "orders", &module)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"${module.vpc.private_subnets[0]}", "subnet-123"}, module.Subnets)
+	assert.Equal(t, "${module.dlq.queue_arn}", module.Settings["queue_arn"])
+	assert.Equal(t, 3, module.Settings["retries"])
+}
+
+// TestFromHCL_KeepsUnknownAttributes tests that arguments without a field are kept as source.
+func TestFromHCL_KeepsUnknownAttributes(t *testing.T) {
+	var module queueModule
+	block, err := hclgen.FromHCL([]byte(queueHCL), "main.tf", "orders", &module)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"region":             `"us-east-1"`,
+		"visibility_timeout": "var.visibility_timeout",
+		"kms_master_key_id":  `"alias/${var.namespace}-orders"`,
+		"depends_on":         "[module.dlq]",
+	}, block.Extra)
+	assert.Nil(t, module.Region, "region is rendered by the caller, not ToHCLWrite")
+
+	t.Run("values the field type can't hold are kept too", func(t *testing.T) {
+		src := `module "q" {
+  source        = "s"
+  delay_seconds = var.delay
+  subnet_ids    = var.subnets
+}`
+		var module queueModule
+		block, err := hclgen.FromHCL([]byte(src), "main.tf", "q", &module)
+		require.NoError(t, err)
+
+		assert.Nil(t, module.Delay)
+		assert.Nil(t, module.Subnets)
+		assert.Equal(t, map[string]string{
+			"delay_seconds": "var.delay",
+			"subnet_ids":    "var.subnets",
+		}, block.Extra)
+	})
+
+	t.Run("a nil target keeps every argument", func(t *testing.T) {
+		block, err := hclgen.FromHCL([]byte(queueHCL), "main.tf", "other", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "./other", block.Source)
+		assert.Empty(t, block.Extra)
+	})
+}
+
+// TestFromHCL_StringTemplates tests that string fields hold string templates:
+// literals stay literal however much they look like references.
+func TestFromHCL_StringTemplates(t *testing.T) {
+	for src, want := range map[string]string{
+		`"module.not_a_reference"`:  "module.not_a_reference",
+		`"${var.namespace}-orders"`: "${var.namespace}-orders",
+		`"costs $${literal}"`:       "costs $${literal}",
+		`upper(var.name)`:           "${upper(var.name)}",
+	} {
+		var module queueModule
+		block, err := hclgen.FromHCL([]byte("module \"q\" {\n  source = \"s\"\n  name   = "+src+"\n}\n"), "main.tf", "q", &module)
+		require.NoError(t, err, src)
+		require.NotNil(t, module.Name, src)
+		assert.Equal(t, want, *module.Name, src)
+		assert.Empty(t, block.Extra, src)
+	}
+}
+
+// TestFromHCL_RoundTrip tests that decoding and rendering preserves the configuration.
+func TestFromHCL_RoundTrip(t *testing.T) {
+	var module queueModule
+	block, err := hclgen.FromHCL([]byte(queueHCL), "main.tf", "orders", &module)
+	require.NoError(t, err)
+
+	rendered, err := block.ToHCLWrite(&module)
+	require.NoError(t, err)
+
+	assert.Contains(t, rendered, `module "orders"`)
+	assert.Contains(t, rendered, "module.vpc.private_subnets[0]")
+	assert.Contains(t, rendered, "queue_arn = module.dlq.queue_arn")
+	assert.Contains(t, rendered, `"alias/${var.namespace}-orders"`)
+	assert.Contains(t, rendered, "depends_on")
+	assert.NotContains(t, rendered, "$${")
+
+	var again queueModule
+	reparsed, err := hclgen.FromHCL([]byte(rendered), "main.tf", "orders", &again)
+	require.NoError(t, err)
+	assert.Equal(t, module, again)
+	assert.Equal(t, block, reparsed)
+
+	second, err := reparsed.ToHCLWrite(&again)
+	require.NoError(t, err)
+	assert.Equal(t, rendered, second)
+}
+
+// TestFromHCL_Errors tests inputs FromHCL rejects.
+func TestFromHCL_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		target  interface{}
+		wantErr string
+	}{
+		{"invalid syntax", `module "q" {`, &queueModule{}, "main.tf"},
+		{"missing block", `module "other" { source = "s" }`, &queueModule{}, `no module "q" block`},
+		{"missing source", `module "q" {}`, &queueModule{}, "missing source"},
+		{"computed source", `module "q" { source = var.src }`, &queueModule{}, "source must be a literal string"},
+		{"non-pointer target", `module "q" { source = "s" }`, queueModule{}, "expected pointer to struct"},
+		{"unknown block", "module \"q\" {\n  source = \"s\"\n  lifecycle {}\n}", &queueModule{}, `unsupported block "lifecycle"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := hclgen.FromHCL([]byte(tt.src), "main.tf", "q", tt.target)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// TestParseModuleBlocks tests listing module blocks in file order.
+func TestParseModuleBlocks(t *testing.T) {
+	blocks, err := hclgen.ParseModuleBlocks([]byte(queueHCL), "main.tf")
+	require.NoError(t, err)
+
+	require.Len(t, blocks, 2)
+	assert.Equal(t, hclgen.ModuleBlock{LocalName: "other", Source: "./other"}, blocks[0])
+	assert.Equal(t, hclgen.ModuleBlock{LocalName: "orders", Source: "terraform-aws-modules/sqs/aws", Version: "~> 4.0"}, blocks[1])
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -36,6 +37,44 @@ func ToHCLWrite(localName, source, version string, v interface{}) (string, error
 	return string(f.Bytes()), nil
 }
 
+// ToHCLWriteArgs renders a module block from an argument map, for modules
+// without a typed struct. Arguments are sorted by name and rendered like
+// module fields: strings are templates, Expressions are written as
+// expressions, slices become tuples and maps and hcl-tagged structs become
+// objects. Nil arguments are left out.
+// PURE: Same input always produces same output.
+func ToHCLWriteArgs(localName, source, version string, args map[string]interface{}) (string, error) {
+	f := hclwrite.NewEmptyFile()
+	moduleBody := f.Body().AppendNewBlock("module", []string{localName}).Body()
+
+	moduleBody.SetAttributeValue("source", cty.StringVal(source))
+	if version != "" {
+		moduleBody.SetAttributeValue("version", cty.StringVal(version))
+	}
+
+	names := make([]string, 0, len(args))
+	for name := range args {
+		if name == "source" || name == "version" {
+			return "", fmt.Errorf("argument %s: set the module's %s instead", name, name)
+		}
+		if !hclsyntax.ValidIdentifier(name) {
+			return "", fmt.Errorf("argument %q: not a valid identifier", name)
+		}
+		if args[name] != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := setAttribute(moduleBody, name, reflect.ValueOf(args[name])); err != nil {
+			return "", fmt.Errorf("argument %s: %w", name, err)
+		}
+	}
+
+	return string(hclwrite.Format(f.Bytes())), nil
+}
+
 // structToHCLWrite converts a struct to HCL attributes and blocks using hclwrite.
 // PURE: Deterministic conversion based on struct tags.
 func structToHCLWrite(body *hclwrite.Body, v interface{}) error {
@@ -133,7 +172,8 @@ func structToHCLWrite(body *hclwrite.Body, v interface{}) error {
 	return nil
 }
 
-// setAttribute sets an attribute in the HCL body, handling Terraform references.
+// setAttribute sets an attribute in the HCL body. Strings are string templates
+// and Expressions are written as expressions; see Expr.
 // PURE: Deterministic attribute setting.
 func setAttribute(body *hclwrite.Body, name string, v reflect.Value) error {
 	// Dereference pointers
@@ -153,18 +193,6 @@ func setAttribute(body *hclwrite.Body, name string, v reflect.Value) error {
 	}
 
 	switch v.Kind() {
-	case reflect.String:
-		s := v.String()
-		// Detect Terraform references (${...} or unquoted references)
-		if isTerraformReference(s) {
-			// Use SetAttributeRaw for Terraform expressions
-			tokens := tokenizeReference(s)
-			body.SetAttributeRaw(name, tokens)
-		} else {
-			body.SetAttributeValue(name, cty.StringVal(s))
-		}
-		return nil
-
 	case reflect.Bool:
 		body.SetAttributeValue(name, cty.BoolVal(v.Bool()))
 		return nil
@@ -181,19 +209,13 @@ func setAttribute(body *hclwrite.Body, name string, v reflect.Value) error {
 		body.SetAttributeValue(name, cty.NumberFloatVal(v.Float()))
 		return nil
 
-	case reflect.Slice, reflect.Array:
-		return setSliceAttribute(body, name, v)
-
-	case reflect.Map:
-		return setMapAttribute(body, name, v)
-
-	case reflect.Struct:
-		// Structs as attributes should be converted to objects
-		ctyVal, err := goValueToCty(v)
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		// Strings and collections may hold expressions at any depth, so render them as raw tokens
+		tokens, err := valueTokens(v)
 		if err != nil {
 			return err
 		}
-		body.SetAttributeValue(name, ctyVal)
+		body.SetAttributeRaw(name, tokens)
 		return nil
 
 	default:
@@ -201,175 +223,153 @@ func setAttribute(body *hclwrite.Body, name string, v reflect.Value) error {
 	}
 }
 
-// setSliceAttribute sets a slice/array attribute.
-// PURE: Deterministic slice conversion.
-func setSliceAttribute(body *hclwrite.Body, name string, v reflect.Value) error {
-	if v.Len() == 0 {
-		return nil
-	}
-
-	// Check if any element is a Terraform reference
-	hasReferences := false
-	for i := 0; i < v.Len(); i++ {
-		elem := v.Index(i)
-		if elem.Kind() == reflect.String && isTerraformReference(elem.String()) {
-			hasReferences = true
-			break
+// valueTokens renders a Go value as an HCL expression. Strings are string
+// templates and Expressions are written as expressions at any depth, lists
+// become tuples, maps and structs become objects with sorted keys, and unset
+// struct fields are omitted.
+// PURE: Deterministic conversion.
+func valueTokens(v reflect.Value) (hclwrite.Tokens, error) {
+	if v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType)), nil
 		}
+		return valueTokens(v.Elem())
 	}
 
-	if hasReferences {
-		// Build raw token list for array with references
-		tokens := hclwrite.Tokens{{Type: 91, Bytes: []byte("[")}} // [
-		for i := 0; i < v.Len(); i++ {
-			if i > 0 {
-				tokens = append(tokens, &hclwrite.Token{Type: 44, Bytes: []byte(",")})  // ,
-				tokens = append(tokens, &hclwrite.Token{Type: 10, Bytes: []byte(" ")}) // space
-			}
-			elem := v.Index(i)
-			if elem.Kind() == reflect.String {
-				s := elem.String()
-				if isTerraformReference(s) {
-					// Add reference without quotes
-					tokens = append(tokens, tokenizeReference(s)...)
-				} else {
-					// Add quoted string
-					tokens = append(tokens, &hclwrite.Token{Type: 34, Bytes: []byte(`"` + s + `"`)})
-				}
-			}
+	// Expr and values such as tfmodules.Output render as an expression
+	if v.Kind() == reflect.Struct && v.CanInterface() {
+		if e, ok := v.Interface().(Expression); ok {
+			return exprTokens(e)
 		}
-		tokens = append(tokens, &hclwrite.Token{Type: 93, Bytes: []byte("]")}) // ]
-		body.SetAttributeRaw(name, tokens)
-		return nil
-	}
-
-	// No references - use standard cty conversion
-	ctyVal, err := goValueToCty(v)
-	if err != nil {
-		return err
 	}
-	body.SetAttributeValue(name, ctyVal)
-	return nil
-}
 
-// setMapAttribute sets a map attribute.
-// PURE: Deterministic map conversion.
-func setMapAttribute(body *hclwrite.Body, name string, v reflect.Value) error {
-	if v.Len() == 0 {
-		return nil
-	}
+	switch v.Kind() {
+	case reflect.String:
+		return stringTokens(v.String())
 
-	// Check if any value is a Terraform reference
-	hasReferences := false
-	iter := v.MapRange()
-	for iter.Next() {
-		val := iter.Value()
-		if val.Kind() == reflect.String && isTerraformReference(val.String()) {
-			hasReferences = true
-			break
-		} else if val.Kind() == reflect.Interface {
-			elem := val.Elem()
-			if elem.Kind() == reflect.String && isTerraformReference(elem.String()) {
-				hasReferences = true
-				break
+	case reflect.Slice, reflect.Array:
+		elems := make([]hclwrite.Tokens, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			elem, err := valueTokens(v.Index(i))
+			if err != nil {
+				return nil, fmt.Errorf("element %d: %w", i, err)
 			}
+			// A heredoc's closing marker must end its line, even before a comma
+			if last := elem[len(elem)-1]; last.Type == hclsyntax.TokenCHeredoc {
+				elem = append(elem, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+			}
+			elems = append(elems, elem)
 		}
-	}
+		return hclwrite.TokensForTuple(elems), nil
 
-	if hasReferences {
-		// Build raw token map with sorted keys
+	case reflect.Map:
 		keys := make([]string, 0, v.Len())
-		iter = v.MapRange()
+		iter := v.MapRange()
 		for iter.Next() {
-			keys = append(keys, iter.Key().String())
+			key, ok := iter.Key().Interface().(string)
+			if !ok {
+				return nil, fmt.Errorf("map key must be string, got %v", iter.Key().Kind())
+			}
+			keys = append(keys, key)
 		}
 		sort.Strings(keys)
 
-		tokens := hclwrite.Tokens{{Type: 123, Bytes: []byte("{")}} // {
-		tokens = append(tokens, &hclwrite.Token{Type: 10, Bytes: []byte("\n")})
-
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
 		for _, key := range keys {
-			mapVal := v.MapIndex(reflect.ValueOf(key))
-
-			// Add indentation
-			tokens = append(tokens, &hclwrite.Token{Type: 10, Bytes: []byte("    ")})
-			// Add key
-			tokens = append(tokens, &hclwrite.Token{Type: 1, Bytes: []byte(key)})
-			// Add =
-			tokens = append(tokens, &hclwrite.Token{Type: 10, Bytes: []byte(" ")})
-			tokens = append(tokens, &hclwrite.Token{Type: 61, Bytes: []byte("=")})
-			tokens = append(tokens, &hclwrite.Token{Type: 10, Bytes: []byte(" ")})
-
-			// Add value
-			if mapVal.Kind() == reflect.Interface {
-				mapVal = mapVal.Elem()
+			val, err := valueTokens(v.MapIndex(reflect.ValueOf(key)))
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", key, err)
 			}
-
-			if mapVal.Kind() == reflect.String {
-				s := mapVal.String()
-				if isTerraformReference(s) {
-					tokens = append(tokens, tokenizeReference(s)...)
-				} else {
-					tokens = append(tokens, &hclwrite.Token{Type: 34, Bytes: []byte(`"` + s + `"`)})
-				}
-			} else {
-				// Use cty for non-string values
-				ctyVal, err := goValueToCty(mapVal)
-				if err != nil {
-					return err
-				}
-				valTokens := hclwrite.TokensForValue(ctyVal)
-				tokens = append(tokens, valTokens...)
+			name, err := objectKeyTokens(key)
+			if err != nil {
+				return nil, fmt.Errorf("key %s: %w", key, err)
 			}
-
-			tokens = append(tokens, &hclwrite.Token{Type: 10, Bytes: []byte("\n")})
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{Name: name, Value: val})
 		}
+		return hclwrite.TokensForObject(attrs), nil
 
-		tokens = append(tokens, &hclwrite.Token{Type: 10, Bytes: []byte("  ")})
-		tokens = append(tokens, &hclwrite.Token{Type: 125, Bytes: []byte("}")}) // }
-		body.SetAttributeRaw(name, tokens)
-		return nil
-	}
+	case reflect.Struct:
+		return structTokens(v)
 
-	// No references - use standard cty conversion
-	ctyVal, err := goValueToCty(v)
-	if err != nil {
-		return err
+	default:
+		ctyVal, err := goValueToCty(v)
+		if err != nil {
+			return nil, err
+		}
+		return hclwrite.TokensForValue(ctyVal), nil
 	}
-	body.SetAttributeValue(name, ctyVal)
-	return nil
 }
 
-// isTerraformReference checks if a string is a Terraform reference.
-// PURE: Deterministic check.
-func isTerraformReference(s string) bool {
-	// Check for interpolation syntax: ${...}
-	if strings.HasPrefix(s, "${") && strings.HasSuffix(s, "}") {
-		return true
+// structTokens renders a struct as an object of its hcl-tagged fields, sorted by name.
+// Nested ",block" fields are rendered as attributes: inside a module argument they
+// are object keys, not blocks.
+// PURE: Deterministic conversion.
+func structTokens(v reflect.Value) (hclwrite.Tokens, error) {
+	typ := v.Type()
+
+	type objectAttr struct {
+		name  string
+		value reflect.Value
+	}
+	var fields []objectAttr
+	for i := 0; i < v.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		hclTag := field.Tag.Get("hcl")
+		if hclTag == "" || hclTag == "-" {
+			continue
+		}
+		if isUnset(v.Field(i), field) {
+			continue
+		}
+		fields = append(fields, objectAttr{name: strings.Split(hclTag, ",")[0], value: v.Field(i)})
 	}
-	// Check for direct references: var.name, module.name.output, etc.
-	if strings.HasPrefix(s, "var.") ||
-		strings.HasPrefix(s, "module.") ||
-		strings.HasPrefix(s, "data.") ||
-		strings.HasPrefix(s, "resource.") ||
-		strings.HasPrefix(s, "local.") {
-		return true
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+
+	attrs := make([]hclwrite.ObjectAttrTokens, 0, len(fields))
+	for _, field := range fields {
+		val, err := valueTokens(field.value)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.name, err)
+		}
+		attrs = append(attrs, hclwrite.ObjectAttrTokens{Name: hclwrite.TokensForIdentifier(field.name), Value: val})
 	}
-	return false
+	return hclwrite.TokensForObject(attrs), nil
 }
 
-// tokenizeReference converts a Terraform reference string to hclwrite tokens.
-// PURE: Deterministic tokenization.
-func tokenizeReference(s string) hclwrite.Tokens {
-	// For ${...} syntax, strip the delimiters
-	if strings.HasPrefix(s, "${") && strings.HasSuffix(s, "}") {
-		s = s[2 : len(s)-1]
+// isUnset reports whether a nested struct field should be left out: nil pointers,
+// empty collections, and zero values of fields tagged json omitempty.
+// PURE: Calculation.
+func isUnset(v reflect.Value, field reflect.StructField) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	default:
+		return strings.Contains(field.Tag.Get("json"), ",omitempty") && v.IsZero()
 	}
+}
 
-	// Return as identifier token
-	return hclwrite.Tokens{
-		{Type: 1, Bytes: []byte(s)}, // IDENT token type
+// objectKeyTokens renders a map key: an identifier as is, anything else as a
+// string template like other strings, so "${var.namespace}orders" is
+// interpolated. A bare interpolation is parenthesized, since HCL reads a
+// naked reference key as a literal.
+// PURE: Calculation.
+func objectKeyTokens(key string) (hclwrite.Tokens, error) {
+	if hclsyntax.ValidIdentifier(key) {
+		return hclwrite.TokensForIdentifier(key), nil
+	}
+	tokens, err := stringTokens(key)
+	if err != nil {
+		return nil, err
+	}
+	if tokens[0].Type != hclsyntax.TokenOQuote {
+		return expressionTokens("(" + string(tokens.Bytes()) + ")")
 	}
+	return tokens, nil
 }
 
 // goValueToCty converts a Go reflect.Value to a cty.Value.
@@ -196,11 +196,11 @@ func TestToHCLWrite_TerraformReferences(t *testing.T) {
 	}
 
 	tableName := "${module.dynamodb.table_name}"
-	bucketName := "module.s3.bucket_id"
-	varRef := "var.namespace"
-	dataRef := "data.aws_caller_identity.current.account_id"
-	localRef := "local.region"
-	resourceRef := "resource.aws_iam_role.lambda.arn"
+	bucketName := "${module.s3.bucket_id}"
+	varRef := "${var.namespace}"
+	dataRef := "${data.aws_caller_identity.current.account_id}"
+	localRef := "${local.region}"
+	resourceRef := "module.docs"
 
 	module := &ModuleWithRefs{
 		TableName:   &tableName,
@@ -231,8 +231,9 @@ func TestToHCLWrite_TerraformReferences(t *testing.T) {
 	assert.Contains(t, result, "local_ref")
 	assert.Contains(t, result, "local.region")
 
+	// Strings without ${...} are literals, however much they look like references
 	assert.Contains(t, result, "resource_ref")
-	assert.Contains(t, result, "resource.aws_iam_role.lambda.arn")
+	assert.Contains(t, result, `"module.docs"`)
 }
 
 // TestToHCLWrite_SliceWithReferences tests slices containing Terraform references.
@@ -245,7 +246,7 @@ func TestToHCLWrite_SliceWithReferences(t *testing.T) {
 		Layers: []string{
 			"arn:aws:lambda:us-east-1:123:layer:test",
 			"${module.layer.arn}",
-			"module.other_layer.arn",
+			"${module.other_layer.arn}",
 		},
 	}
 
@@ -271,7 +272,7 @@ func TestToHCLWrite_MapWithReferences(t *testing.T) {
 	module := &ModuleWithRefMap{
 		Environment: map[string]string{
 			"TABLE_NAME": "${module.dynamodb.table_name}",
-			"BUCKET":     "module.s3.bucket_id",
+			"BUCKET":     "${module.s3.bucket_id}",
 			"REGION":     "us-east-1",
 		},
 	}
@@ -291,6 +292,28 @@ func TestToHCLWrite_MapWithReferences(t *testing.T) {
 	assert.NotContains(t, result, `"module.s3.bucket_id"`)
 }
 
+// TestToHCLWrite_MapKeyTemplates tests map keys holding string templates.
+func TestToHCLWrite_MapKeyTemplates(t *testing.T) {
+	type ModuleWithKeys struct {
+		Rules map[string]int `hcl:"rules,attr"`
+	}
+
+	module := &ModuleWithKeys{
+		Rules: map[string]int{
+			"${var.namespace}orders": 1,
+			"${var.key}":             2,
+			"my-rule":                3,
+		},
+	}
+
+	result, err := hclgen.ToHCLWrite("test", "source", "", module)
+	require.NoError(t, err)
+
+	assert.Contains(t, result, `"${var.namespace}orders" = 1`)
+	assert.Contains(t, result, `(var.key)`)
+	assert.Contains(t, result, `my-rule `)
+}
+
 // TestToHCLWrite_NestedStruct tests nested struct as attribute.
 func TestToHCLWrite_NestedStruct(t *testing.T) {
 	type CORS struct {
@@ -557,7 +580,7 @@ func TestToHCLWrite_ComplexRealWorld(t *testing.T) {
 		MemorySize:   &memory,
 		EnvironmentVariables: map[string]string{
 			"TABLE_NAME":  "${module.dynamodb.table_name}",
-			"BUCKET_NAME": "module.s3.bucket_id",
+			"BUCKET_NAME": "${module.s3.bucket_id}",
 			"REGION":      "us-east-1",
 		},
 		Layers: []string{
@@ -1021,7 +1044,7 @@ func TestToHCLWrite_MixedMapValues(t *testing.T) {
 			"count":   5,
 			"rate":    1.5,
 			"name":    "test",
-			"ref":     "var.namespace",
+			"ref":     "${var.namespace}",
 		},
 	}
 
@@ -1166,4 +1189,31 @@ func TestToHCLWrite_RefInSliceNonString(t *testing.T) {
 	assert.Contains(t, result, "1")
 	assert.Contains(t, result, "2")
 	assert.Contains(t, result, "3")
-}
\ No newline at end of file
+}
+// testRef mimics tfmodules.Output: a value that renders as a reference expression.
+type testRef struct{ module, attribute string }
+
+func (r testRef) Expr() hclgen.Expr { return hclgen.Ref("module." + r.module + "." + r.attribute) }
+
+// TestToHCLWrite_RefValues tests that Expressions render unquoted.
+func TestToHCLWrite_RefValues(t *testing.T) {
+	type ModuleWithRefs struct {
+		QueueArn  interface{}            `hcl:"queue_arn,attr"`
+		TableArns []interface{}          `hcl:"table_arns,attr"`
+		Env       map[string]interface{} `hcl:"env,attr"`
+	}
+
+	module := &ModuleWithRefs{
+		QueueArn:  testRef{"orders_queue", "queue_arn"},
+		TableArns: []interface{}{testRef{"orders_table", "dynamodb_table_arn"}},
+		Env:       map[string]interface{}{"QUEUE_URL": testRef{"orders_queue", "queue_url"}},
+	}
+
+	result, err := hclgen.ToHCLWrite("test", "source", "", module)
+	require.NoError(t, err)
+
+	assert.Contains(t, result, "module.orders_queue.queue_arn")
+	assert.Contains(t, result, "[module.orders_table.dynamodb_table_arn]")
+	assert.Contains(t, result, "module.orders_queue.queue_url")
+	assert.NotContains(t, result, `"module.`)
+}
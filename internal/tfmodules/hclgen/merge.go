@@ -0,0 +1,400 @@
+package hclgen
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// BlockAction says what merging generated code into a file did to one of its
+// top-level blocks.
+type BlockAction string
+
+const (
+	BlockCreated BlockAction = "created" // Block was added to the file
+	BlockUpdated BlockAction = "updated" // Block was replaced or gained arguments
+	BlockSkipped BlockAction = "skipped" // Block was already up to date
+	BlockRemoved BlockAction = "removed" // Block was removed from the file
+)
+
+// BlockChange records what merging did to one top-level block.
+type BlockChange struct {
+	Address string // e.g. module.orders, aws_sqs_queue.orders or output.orders_url
+	Action  BlockAction
+}
+
+// ErrBlockEdited reports a block that differs from what forge last generated
+// for it, so replacing it would lose someone's changes.
+var ErrBlockEdited = errors.New("was edited since it was generated")
+
+// checksumComment starts the comment MergeHCL puts on the first line of every
+// labeled block it writes, recording the checksum of the block as generated.
+const checksumComment = "# forge:checksum "
+
+// MergeHCL merges the top-level blocks of generated Terraform into an existing
+// file, matching them by type and labels:
+//   - missing blocks are appended, with the comments before them;
+//   - blocks already up to date are left alone;
+//   - blocks forge generated and nobody edited since are replaced;
+//   - unlabeled blocks such as locals gain the arguments and nested blocks
+//     they lack.
+//
+// A labeled block that differs from the generated one and was edited, or
+// wasn't generated by forge at all, fails with ErrBlockEdited unless force is
+// set. Text after the last generated block is appended unless the file
+// already contains it. Existing content is kept byte for byte.
+// PURE: Calculation.
+func MergeHCL(dst, src []byte, filename string, force bool) ([]byte, []BlockChange, error) {
+	generated, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, fmt.Errorf("failed to parse generated %s: %w", filename, diags)
+	}
+	file, diags := hclwrite.ParseConfig(dst, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", filename, diags)
+	}
+
+	var (
+		changes  []BlockChange
+		appended [][]byte
+		start    int
+	)
+	for _, block := range generated.Body.(*hclsyntax.Body).Blocks {
+		end := block.Range().End.Byte
+		chunk, diags := hclwrite.ParseConfig(bytes.TrimLeft(src[start:end], "\n"), filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, nil, fmt.Errorf("failed to parse generated %s: %w", filename, diags)
+		}
+		start = end
+
+		gen := chunk.Body().Blocks()[0]
+		address := blockAddress(gen.Type(), gen.Labels())
+		existing := file.Body().FirstMatchingBlock(gen.Type(), gen.Labels())
+
+		switch {
+		case existing == nil:
+			if len(gen.Labels()) > 0 {
+				setBlockBody(gen, gen.Body().BuildTokens(nil))
+			}
+			appended = append(appended, chunk.Bytes())
+			changes = append(changes, BlockChange{Address: address, Action: BlockCreated})
+
+		case len(gen.Labels()) == 0:
+			action := BlockSkipped
+			if mergeBody(existing.Body(), gen.Body()) {
+				action = BlockUpdated
+			}
+			changes = append(changes, BlockChange{Address: address, Action: action})
+
+		case normalizedBody(existing) == normalizedBody(gen):
+			changes = append(changes, BlockChange{Address: address, Action: BlockSkipped})
+
+		case force || blockChecksum(existing) == recordedChecksum(existing):
+			setBlockBody(existing, gen.Body().BuildTokens(nil))
+			changes = append(changes, BlockChange{Address: address, Action: BlockUpdated})
+
+		default:
+			return nil, nil, fmt.Errorf("%s %w", address, ErrBlockEdited)
+		}
+	}
+
+	out := file.Bytes()
+	for _, section := range appended {
+		out = appendSection(out, section)
+	}
+	if trailing := bytes.TrimSpace(src[start:]); len(trailing) > 0 && !bytes.Contains(dst, trailing) {
+		out = appendSection(out, trailing)
+	}
+	return out, changes, nil
+}
+
+// UnmergeHCL is the inverse of MergeHCL: it removes from an existing file the
+// labeled blocks generated Terraform declares, with their lead comments, and
+// records a BlockRemoved change for each. When it removed any, the generated
+// text outside blocks, such as a "# Generated by" header, is removed too where
+// it appears on lines of its own. Unlabeled blocks such as locals may be
+// shared and are left alone.
+//
+// A block edited since MergeHCL recorded its checksum, or that has none
+// because forge didn't write it, fails with ErrBlockEdited unless force is
+// set, since the block is no longer only what forge generated.
+// PURE: Calculation.
+func UnmergeHCL(dst, src []byte, filename string, force bool) ([]byte, []BlockChange, error) {
+	generated, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, fmt.Errorf("failed to parse generated %s: %w", filename, diags)
+	}
+	file, diags := hclwrite.ParseConfig(dst, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", filename, diags)
+	}
+
+	var (
+		removed  []BlockChange
+		freeText [][]byte
+		start    int
+	)
+	for _, block := range generated.Body.(*hclsyntax.Body).Blocks {
+		freeText = append(freeText, withoutLeadComments(src[start:block.Range().Start.Byte]))
+		start = block.Range().End.Byte
+		if len(block.Labels) == 0 {
+			continue
+		}
+		existing := file.Body().FirstMatchingBlock(block.Type, block.Labels)
+		if existing == nil {
+			continue
+		}
+		address := blockAddress(block.Type, block.Labels)
+		if !force && blockChecksum(existing) != recordedChecksum(existing) {
+			return nil, nil, fmt.Errorf("%s %w", address, ErrBlockEdited)
+		}
+		file.Body().RemoveBlock(existing)
+		removed = append(removed, BlockChange{Address: address, Action: BlockRemoved})
+	}
+	if len(removed) == 0 {
+		return dst, nil, nil
+	}
+
+	out := file.Bytes()
+	for _, text := range append(freeText, src[start:]) {
+		for _, line := range bytes.Split(text, []byte("\n")) {
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				out = removeLine(out, line)
+			}
+		}
+	}
+	return tidyBlankLines(out), removed, nil
+}
+
+// withoutLeadComments returns the text before a block without the comment
+// lines directly above it, which belong to the block and go with it. Another
+// block may have the same comment, e.g. "# Permission for EventBridge to
+// invoke processor", which must not be taken for generated text.
+// PURE: Calculation.
+func withoutLeadComments(text []byte) []byte {
+	lines := bytes.SplitAfter(text, []byte("\n"))
+	end := len(lines)
+	if end > 0 && len(bytes.TrimSpace(lines[end-1])) == 0 {
+		end--
+	}
+	for end > 0 {
+		line := bytes.TrimSpace(lines[end-1])
+		if !bytes.HasPrefix(line, []byte("#")) && !bytes.HasPrefix(line, []byte("//")) {
+			break
+		}
+		end--
+	}
+	return bytes.Join(lines[:end], nil)
+}
+
+// AppendRemovedBlocks appends a removed block to an existing file for each
+// resource or module address that no removed block in the file is "from" yet,
+// telling Terraform to forget the object without destroying it. It returns
+// the addresses it appended blocks for.
+// PURE: Calculation.
+func AppendRemovedBlocks(dst []byte, addresses []string, filename string) ([]byte, []string, error) {
+	file, diags := hclwrite.ParseConfig(dst, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", filename, diags)
+	}
+
+	existing := make(map[string]bool)
+	for _, block := range file.Body().Blocks() {
+		if from := block.Body().GetAttribute("from"); block.Type() == "removed" && from != nil {
+			existing[strings.ReplaceAll(normalizedTokens(from.Expr().BuildTokens(nil)), " ", "")] = true
+		}
+	}
+
+	var (
+		appended []string
+		sections [][]byte
+	)
+	for _, address := range addresses {
+		if existing[address] {
+			continue
+		}
+		existing[address] = true
+
+		section := hclwrite.NewEmptyFile()
+		removed := section.Body().AppendNewBlock("removed", nil).Body()
+		removed.SetAttributeTraversal("from", addressTraversal(address))
+		removed.AppendNewline()
+		removed.AppendNewBlock("lifecycle", nil).Body().SetAttributeValue("destroy", cty.False)
+		sections = append(sections, section.Bytes())
+		appended = append(appended, address)
+	}
+
+	out := dst
+	for _, section := range sections {
+		out = appendSection(out, section)
+	}
+	return out, appended, nil
+}
+
+// addressTraversal returns the traversal a Terraform address is written as,
+// e.g. module.orders.
+// PURE: Calculation.
+func addressTraversal(address string) hcl.Traversal {
+	parts := strings.Split(address, ".")
+	traversal := hcl.Traversal{hcl.TraverseRoot{Name: parts[0]}}
+	for _, part := range parts[1:] {
+		traversal = append(traversal, hcl.TraverseAttr{Name: part})
+	}
+	return traversal
+}
+
+// blankLines matches the runs of blank lines removing blocks leaves behind.
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// tidyBlankLines collapses runs of blank lines into one and ends the file
+// with a single newline.
+// PURE: Calculation.
+func tidyBlankLines(file []byte) []byte {
+	file = bytes.TrimLeft(blankLines.ReplaceAll(file, []byte("\n\n")), "\n")
+	if len(bytes.TrimSpace(file)) == 0 {
+		return nil
+	}
+	return append(bytes.TrimRight(file, "\n"), '\n')
+}
+
+// removeLine removes the first line of file that is exactly line, along with
+// a blank line following it.
+// PURE: Calculation.
+func removeLine(file, line []byte) []byte {
+	lines := bytes.SplitAfter(file, []byte("\n"))
+	for i, l := range lines {
+		if !bytes.Equal(bytes.TrimRight(l, "\r\n"), line) {
+			continue
+		}
+		end := i + 1
+		if end < len(lines) && len(bytes.TrimSpace(lines[end])) == 0 {
+			end++
+		}
+		return bytes.Join(append(lines[:i:i], lines[end:]...), nil)
+	}
+	return file
+}
+
+// blockAddress returns how Terraform refers to a block: resources by type and
+// name, everything else prefixed with the block type.
+// PURE: Calculation.
+func blockAddress(blockType string, labels []string) string {
+	if blockType == "resource" {
+		return strings.Join(labels, ".")
+	}
+	return strings.Join(append([]string{blockType}, labels...), ".")
+}
+
+// setBlockBody replaces a block's body with tokens, recording their checksum
+// on the block's first line.
+// PURE: Calculation (mutates only the block passed in).
+func setBlockBody(block *hclwrite.Block, tokens hclwrite.Tokens) {
+	marked := hclwrite.Tokens{
+		{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+		{Type: hclsyntax.TokenComment, Bytes: []byte(checksumComment + checksum(normalizedTokens(tokens)) + "\n"), SpacesBefore: 2},
+	}
+	if len(tokens) > 0 && tokens[0].Type == hclsyntax.TokenNewline {
+		tokens = tokens[1:]
+	}
+	body := block.Body()
+	body.Clear()
+	body.AppendUnstructuredTokens(append(marked, tokens...))
+}
+
+// mergeBody adds the arguments and nested blocks of src that dst lacks,
+// reporting whether it added any.
+// PURE: Calculation (mutates only dst).
+func mergeBody(dst, src *hclwrite.Body) bool {
+	changed := false
+	attrs := src.Attributes()
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if dst.GetAttribute(name) == nil {
+			dst.SetAttributeRaw(name, attrs[name].Expr().BuildTokens(nil))
+			changed = true
+		}
+	}
+	for _, block := range src.Blocks() {
+		if dst.FirstMatchingBlock(block.Type(), block.Labels()) == nil {
+			dst.AppendBlock(block)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// normalizedBody returns a block's body without its checksum comment or
+// whitespace, so formatting doesn't count as an edit.
+// PURE: Calculation.
+func normalizedBody(block *hclwrite.Block) string {
+	return normalizedTokens(block.Body().BuildTokens(nil))
+}
+
+// normalizedTokens is normalizedBody for body tokens.
+// PURE: Calculation.
+func normalizedTokens(tokens hclwrite.Tokens) string {
+	parts := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		text := strings.TrimSpace(string(token.Bytes))
+		if token.Type == hclsyntax.TokenNewline || text == "" || strings.HasPrefix(text, checksumComment) {
+			continue
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// checksum returns the checksum of a normalized block body.
+// PURE: Calculation.
+func checksum(normalized string) string {
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:8])
+}
+
+// blockChecksum returns the checksum of a block as it is now.
+// PURE: Calculation.
+func blockChecksum(block *hclwrite.Block) string {
+	return checksum(normalizedBody(block))
+}
+
+// recordedChecksum returns the checksum forge recorded when it generated a
+// block, or "" for blocks forge didn't generate.
+// PURE: Calculation.
+func recordedChecksum(block *hclwrite.Block) string {
+	for _, token := range block.Body().BuildTokens(nil) {
+		if token.Type != hclsyntax.TokenComment {
+			continue
+		}
+		if comment := strings.TrimSpace(string(token.Bytes)); strings.HasPrefix(comment, checksumComment) {
+			return strings.TrimPrefix(comment, checksumComment)
+		}
+	}
+	return ""
+}
+
+// appendSection appends a section to a file, separated from existing content
+// by a blank line.
+// PURE: Calculation.
+func appendSection(file, section []byte) []byte {
+	section = append(bytes.TrimSpace(section), '\n')
+	if len(bytes.TrimSpace(file)) == 0 {
+		return section
+	}
+	out := append([]byte{}, bytes.TrimRight(file, "\n")...)
+	out = append(out, '\n', '\n')
+	return append(out, section...)
+}
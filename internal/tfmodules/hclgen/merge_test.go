@@ -0,0 +1,347 @@
+package hclgen_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+)
+
+// checksumLine matches the checksum comment MergeHCL writes into blocks.
+var checksumLine = regexp.MustCompile(`(?m)^  # forge:checksum [0-9a-f]{16}\n`)
+
+const generatedQueue = `# Generated by forge add sqs orders
+
+module "orders" {
+  source = "terraform-aws-modules/sqs/aws"
+
+  name = "${var.namespace}orders"
+}
+`
+
+const generatedOutputs = `# Outputs for orders
+output "orders_url" {
+  value = module.orders.queue_url
+}
+
+output "orders_arn" {
+  value = module.orders.queue_arn
+}
+`
+
+// TestMergeHCL tests merging generated blocks into existing files.
+func TestMergeHCL(t *testing.T) {
+	created, changes, err := hclgen.MergeHCL(nil, []byte(generatedQueue), "sqs.tf", false)
+	require.NoError(t, err)
+	assert.Equal(t, []hclgen.BlockChange{{Address: "module.orders", Action: hclgen.BlockCreated}}, changes)
+	assert.Equal(t, `# Generated by forge add sqs orders
+
+module "orders" {
+  # forge:checksum 0000000000000000
+  source = "terraform-aws-modules/sqs/aws"
+
+  name = "${var.namespace}orders"
+}
+`, checksumLine.ReplaceAllString(string(created), "  # forge:checksum 0000000000000000\n"))
+
+	t.Run("is idempotent", func(t *testing.T) {
+		again, changes, err := hclgen.MergeHCL(created, []byte(generatedQueue), "sqs.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, string(created), string(again))
+		assert.Equal(t, []hclgen.BlockChange{{Address: "module.orders", Action: hclgen.BlockSkipped}}, changes)
+	})
+
+	t.Run("appends new blocks after existing content", func(t *testing.T) {
+		existing := "# Hand-written\nresource \"aws_s3_bucket\" \"assets\" {}\n\noutput \"orders_url\" {\n  value = module.orders.queue_url\n}"
+
+		merged, changes, err := hclgen.MergeHCL([]byte(existing), []byte(generatedOutputs), "outputs.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, []hclgen.BlockChange{
+			{Address: "output.orders_url", Action: hclgen.BlockSkipped},
+			{Address: "output.orders_arn", Action: hclgen.BlockCreated},
+		}, changes)
+		assert.Equal(t, existing+"\n\noutput \"orders_arn\" {\n  # forge:checksum 0000000000000000\n  value = module.orders.queue_arn\n}\n",
+			checksumLine.ReplaceAllString(string(merged), "  # forge:checksum 0000000000000000\n"))
+	})
+
+	t.Run("replaces generated blocks nobody edited", func(t *testing.T) {
+		regenerated := []byte(`module "orders" {
+  source = "terraform-aws-modules/sqs/aws"
+
+  name       = "${var.namespace}orders"
+  create_dlq = true
+}
+`)
+		merged, changes, err := hclgen.MergeHCL(created, regenerated, "sqs.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, []hclgen.BlockChange{{Address: "module.orders", Action: hclgen.BlockUpdated}}, changes)
+		assert.Contains(t, string(merged), "# Generated by forge add sqs orders\n", "existing comments are kept")
+		assert.Contains(t, string(merged), "create_dlq = true")
+		assert.Len(t, checksumLine.FindAllString(string(merged), -1), 1)
+
+		again, changes, err := hclgen.MergeHCL(merged, regenerated, "sqs.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, string(merged), string(again))
+		assert.Equal(t, hclgen.BlockSkipped, changes[0].Action)
+	})
+
+	t.Run("ignores formatting changes", func(t *testing.T) {
+		reformatted := regexp.MustCompile(`\n\n  name`).ReplaceAll(created, []byte("\n  name  "))
+
+		_, changes, err := hclgen.MergeHCL(reformatted, []byte(generatedQueue), "sqs.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, hclgen.BlockSkipped, changes[0].Action)
+	})
+
+	t.Run("refuses to replace edited blocks", func(t *testing.T) {
+		edited := regexp.MustCompile(`orders"\n}`).ReplaceAll(created, []byte("orders\"\n  delay_seconds = 5\n}"))
+		regenerated := []byte(`module "orders" {
+  source = "terraform-aws-modules/sqs/aws"
+  name   = "orders"
+}
+`)
+
+		_, _, err := hclgen.MergeHCL(edited, regenerated, "sqs.tf", false)
+		require.ErrorIs(t, err, hclgen.ErrBlockEdited)
+		assert.EqualError(t, err, "module.orders was edited since it was generated")
+
+		merged, changes, err := hclgen.MergeHCL(edited, regenerated, "sqs.tf", true)
+		require.NoError(t, err)
+		assert.Equal(t, hclgen.BlockUpdated, changes[0].Action)
+		assert.NotContains(t, string(merged), "delay_seconds")
+	})
+
+	t.Run("refuses to replace blocks forge didn't generate", func(t *testing.T) {
+		handWritten := []byte("module \"orders\" {\n  source = \"./queue\"\n}\n")
+
+		_, _, err := hclgen.MergeHCL(handWritten, []byte(generatedQueue), "sqs.tf", false)
+		assert.ErrorIs(t, err, hclgen.ErrBlockEdited)
+	})
+
+	t.Run("merges unlabeled blocks", func(t *testing.T) {
+		existing := []byte("locals {\n  a = 1\n}\n")
+
+		merged, changes, err := hclgen.MergeHCL(existing, []byte("locals {\n  a = 2\n  b = 3\n}\n"), "locals.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, []hclgen.BlockChange{{Address: "locals", Action: hclgen.BlockUpdated}}, changes)
+		assert.Equal(t, "locals {\n  a = 1\n  b = 3\n}\n", string(merged))
+	})
+
+	t.Run("appends text outside blocks once", func(t *testing.T) {
+		merged, changes, err := hclgen.MergeHCL([]byte("# Existing"), []byte("# New"), "notes.tf", false)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Equal(t, "# Existing\n\n# New\n", string(merged))
+
+		again, _, err := hclgen.MergeHCL(merged, []byte("# New"), "notes.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, string(merged), string(again))
+	})
+
+	t.Run("fails on invalid syntax", func(t *testing.T) {
+		_, _, err := hclgen.MergeHCL([]byte(`module "x" {`), []byte(generatedQueue), "sqs.tf", false)
+		assert.ErrorContains(t, err, "failed to parse sqs.tf")
+
+		_, _, err = hclgen.MergeHCL(nil, []byte(`module "x" {`), "sqs.tf", false)
+		assert.ErrorContains(t, err, "failed to parse generated sqs.tf")
+	})
+}
+
+// TestMergeTFJSONBlocks tests merging generated blocks into Terraform JSON.
+func TestMergeTFJSONBlocks(t *testing.T) {
+	existing := []byte(`{"output": {"a": {"value": 1}}, "locals": {"x": 1}}`)
+	src := []byte(`{
+  "output": {"a": {"value": 1}, "b": {"value": 2}},
+  "resource": {"aws_sqs_queue": {"q": {"name": "q"}}},
+  "locals": {"y": 2}
+}`)
+
+	merged, changes, err := hclgen.MergeTFJSONBlocks(existing, src, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "output": {"a": {"value": 1}, "b": {"value": 2}},
+  "resource": {"aws_sqs_queue": {"q": {"name": "q"}}},
+  "locals": {"x": 1, "y": 2}
+}`, string(merged))
+	assert.Equal(t, []hclgen.BlockChange{
+		{Address: "locals", Action: hclgen.BlockUpdated},
+		{Address: "output.a", Action: hclgen.BlockSkipped},
+		{Address: "output.b", Action: hclgen.BlockCreated},
+		{Address: "aws_sqs_queue.q", Action: hclgen.BlockCreated},
+	}, changes)
+
+	t.Run("refuses to replace blocks that differ", func(t *testing.T) {
+		changed := []byte(`{"resource": {"aws_sqs_queue": {"q": {"name": "other"}}}}`)
+
+		_, _, err := hclgen.MergeTFJSONBlocks(merged, changed, false)
+		require.ErrorIs(t, err, hclgen.ErrBlockEdited)
+		assert.EqualError(t, err, "aws_sqs_queue.q was edited since it was generated")
+
+		replaced, changes, err := hclgen.MergeTFJSONBlocks(merged, changed, true)
+		require.NoError(t, err)
+		assert.Contains(t, string(replaced), `"name": "other"`)
+		assert.Equal(t, []hclgen.BlockChange{{Address: "aws_sqs_queue.q", Action: hclgen.BlockUpdated}}, changes)
+	})
+}
+
+// TestUnmergeHCL tests removing generated blocks from existing files.
+func TestUnmergeHCL(t *testing.T) {
+	queue, _, err := hclgen.MergeHCL(nil, []byte(generatedQueue), "sqs.tf", false)
+	require.NoError(t, err)
+	existing := `# Generated by forge add sqs payments
+
+module "payments" {
+  source = "terraform-aws-modules/sqs/aws"
+}
+
+` + string(queue) + `
+locals {
+  a = 1
+}
+`
+
+	out, removed, err := hclgen.UnmergeHCL([]byte(existing), []byte(generatedQueue+"\nlocals {\n  a = 1\n}\n"), "sqs.tf", false)
+	require.NoError(t, err)
+	assert.Equal(t, []hclgen.BlockChange{{Address: "module.orders", Action: hclgen.BlockRemoved}}, removed)
+	assert.Equal(t, `# Generated by forge add sqs payments
+
+module "payments" {
+  source = "terraform-aws-modules/sqs/aws"
+}
+
+locals {
+  a = 1
+}
+`, string(out))
+
+	t.Run("removes blocks with their lead comments", func(t *testing.T) {
+		outputs, _, err := hclgen.MergeHCL([]byte("output \"keep\" {\n  value = 1\n}\n"), []byte(generatedOutputs), "outputs.tf", false)
+		require.NoError(t, err)
+
+		out, removed, err := hclgen.UnmergeHCL(outputs, []byte(generatedOutputs), "outputs.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, []hclgen.BlockChange{
+			{Address: "output.orders_url", Action: hclgen.BlockRemoved},
+			{Address: "output.orders_arn", Action: hclgen.BlockRemoved},
+		}, removed)
+		assert.Equal(t, "output \"keep\" {\n  value = 1\n}\n", string(out))
+	})
+
+	t.Run("keeps the same lead comment on other blocks", func(t *testing.T) {
+		permission := func(name string) string {
+			return "# Permission for EventBridge to invoke processor\nresource \"aws_lambda_permission\" \"" + name + "\" {\n  statement_id = \"" + name + "\"\n}\n"
+		}
+		existing, _, err := hclgen.MergeHCL(nil, []byte(permission("audit")), "lambda_processor.tf", false)
+		require.NoError(t, err)
+		existing, _, err = hclgen.MergeHCL(existing, []byte(permission("orders")), "lambda_processor.tf", false)
+		require.NoError(t, err)
+		kept, _, err := hclgen.MergeHCL(nil, []byte(permission("audit")), "lambda_processor.tf", false)
+		require.NoError(t, err)
+
+		out, removed, err := hclgen.UnmergeHCL(existing, []byte(permission("orders")), "lambda_processor.tf", false)
+		require.NoError(t, err)
+		assert.Equal(t, []hclgen.BlockChange{{Address: "aws_lambda_permission.orders", Action: hclgen.BlockRemoved}}, removed)
+		assert.Equal(t, string(kept), string(out))
+	})
+
+	t.Run("leaves files without the blocks untouched", func(t *testing.T) {
+		other := "# Generated by forge add sqs orders\n\nresource \"aws_s3_bucket\" \"b\" {}\n"
+
+		out, removed, err := hclgen.UnmergeHCL([]byte(other), []byte(generatedQueue), "main.tf", false)
+		require.NoError(t, err)
+		assert.Empty(t, removed)
+		assert.Equal(t, other, string(out))
+	})
+
+	t.Run("refuses blocks edited since they were generated", func(t *testing.T) {
+		edited := strings.Replace(string(queue), `name = "${var.namespace}orders"`, `name = "orders"`, 1)
+
+		_, _, err := hclgen.UnmergeHCL([]byte(edited), []byte(generatedQueue), "sqs.tf", false)
+		require.ErrorIs(t, err, hclgen.ErrBlockEdited)
+		assert.Contains(t, err.Error(), "module.orders")
+
+		out, removed, err := hclgen.UnmergeHCL([]byte(edited), []byte(generatedQueue), "sqs.tf", true)
+		require.NoError(t, err)
+		assert.Equal(t, []hclgen.BlockChange{{Address: "module.orders", Action: hclgen.BlockRemoved}}, removed)
+		assert.Empty(t, strings.TrimSpace(string(out)))
+	})
+
+	t.Run("refuses blocks forge did not write", func(t *testing.T) {
+		_, _, err := hclgen.UnmergeHCL([]byte(generatedQueue), []byte(generatedQueue), "sqs.tf", false)
+		require.ErrorIs(t, err, hclgen.ErrBlockEdited)
+
+		_, removed, err := hclgen.UnmergeHCL([]byte(generatedQueue), []byte(generatedQueue), "sqs.tf", true)
+		require.NoError(t, err)
+		assert.Len(t, removed, 1)
+	})
+}
+
+// TestAppendRemovedBlocks tests appending removed blocks for addresses.
+func TestAppendRemovedBlocks(t *testing.T) {
+	existing := "removed {\n  from = module.users\n}\n"
+
+	out, appended, err := hclgen.AppendRemovedBlocks([]byte(existing), []string{"module.orders", "module.users", "aws_sqs_queue.jobs"}, "removed.tf")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"module.orders", "aws_sqs_queue.jobs"}, appended)
+	assert.Equal(t, existing+`
+removed {
+  from = module.orders
+
+  lifecycle {
+    destroy = false
+  }
+}
+
+removed {
+  from = aws_sqs_queue.jobs
+
+  lifecycle {
+    destroy = false
+  }
+}
+`, string(out))
+
+	again, appended, err := hclgen.AppendRemovedBlocks(out, []string{"module.orders"}, "removed.tf")
+	require.NoError(t, err)
+	assert.Empty(t, appended)
+	assert.Equal(t, string(out), string(again))
+}
+
+// TestUnmergeTFJSON tests removing generated blocks from Terraform JSON.
+func TestUnmergeTFJSON(t *testing.T) {
+	existing := []byte(`{
+  "module": {"orders": {"source": "x"}},
+  "output": {"orders_url": {"value": 1}, "keep": {"value": 2}},
+  "locals": {"a": 1}
+}`)
+	src := []byte(`{
+  "module": {"orders": {"source": "x"}},
+  "output": {"orders_url": {"value": 1}, "orders_arn": {"value": 3}},
+  "locals": {"a": 1}
+}`)
+
+	out, removed, err := hclgen.UnmergeTFJSON(existing, src, false)
+	require.NoError(t, err)
+	assert.Equal(t, []hclgen.BlockChange{
+		{Address: "module.orders", Action: hclgen.BlockRemoved},
+		{Address: "output.orders_url", Action: hclgen.BlockRemoved},
+	}, removed)
+	assert.JSONEq(t, `{"output": {"keep": {"value": 2}}, "locals": {"a": 1}}`, string(out))
+
+	t.Run("refuses blocks that differ from the generated ones", func(t *testing.T) {
+		edited := []byte(`{"module": {"orders": {"source": "y"}}}`)
+
+		_, _, err := hclgen.UnmergeTFJSON(edited, src, false)
+		require.ErrorIs(t, err, hclgen.ErrBlockEdited)
+		assert.Contains(t, err.Error(), "module.orders")
+
+		out, removed, err := hclgen.UnmergeTFJSON(edited, src, true)
+		require.NoError(t, err)
+		assert.Len(t, removed, 1)
+		assert.JSONEq(t, `{}`, string(out))
+	})
+}
@@ -0,0 +1,552 @@
+package hclgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// rawReferenceArguments are the meta-arguments whose JSON form is a plain
+// reference string, e.g. "module.queue", rather than a "${...}" template.
+var rawReferenceArguments = map[string]bool{
+	"depends_on":           true,
+	"providers":            true,
+	"ignore_changes":       true,
+	"replace_triggered_by": true,
+}
+
+// ToTFJSON converts a module struct to Terraform JSON syntax. It renders the
+// same module block as ToHCLWrite, with references encoded as "${...}" strings.
+// PURE: Same input always produces same output.
+func ToTFJSON(localName, source, version string, v interface{}) (string, error) {
+	config, err := ToHCLWrite(localName, source, version, v)
+	if err != nil {
+		return "", err
+	}
+	out, err := HCLToJSON([]byte(config), localName+".tf")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// HCLToJSON converts Terraform native syntax to the equivalent Terraform JSON
+// syntax (https://developer.hashicorp.com/terraform/language/syntax/json).
+// Constant values become JSON values; references, function calls and other
+// expressions become "${...}" template strings. Literal "${" and "%{" are
+// escaped so Terraform doesn't read them as templates. Comments are dropped.
+// PURE: Calculation.
+func HCLToJSON(src []byte, filename string) ([]byte, error) {
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, diags)
+	}
+
+	body, err := bodyJSON(file.Body.(*hclsyntax.Body), src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", filename, err)
+	}
+	return marshalTFJSON(body)
+}
+
+// MergeTFJSON merges two Terraform JSON documents, as if their blocks were in
+// one file. Objects are merged recursively; any other value present in both
+// documents must be equal, since Terraform rejects duplicate blocks.
+// PURE: Calculation.
+func MergeTFJSON(dst, src []byte) ([]byte, error) {
+	var base, addition map[string]interface{}
+	if len(bytes.TrimSpace(dst)) > 0 {
+		if err := unmarshalTFJSON(dst, &base); err != nil {
+			return nil, fmt.Errorf("failed to parse existing JSON: %w", err)
+		}
+	}
+	if err := unmarshalTFJSON(src, &addition); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	merged, err := mergeObjects(base, addition, nil)
+	if err != nil {
+		return nil, err
+	}
+	return marshalTFJSON(merged)
+}
+
+// tfjsonLabels is the number of labels of the block types Terraform JSON nests
+// under their labels. Other block types, such as locals, are merged key by key.
+var tfjsonLabels = map[string]int{
+	"resource": 2,
+	"data":     2,
+	"module":   1,
+	"output":   1,
+	"variable": 1,
+}
+
+// MergeTFJSONBlocks is MergeHCL for Terraform JSON: the blocks of src are added
+// to dst, blocks already equal are skipped, and blocks that differ fail with
+// ErrBlockEdited unless force is set, which replaces them. JSON has no
+// comments to record what forge generated, so any difference counts as an
+// edit. Blocks without labels are merged as MergeTFJSON does.
+// PURE: Calculation.
+func MergeTFJSONBlocks(dst, src []byte, force bool) ([]byte, []BlockChange, error) {
+	base := map[string]interface{}{}
+	if len(bytes.TrimSpace(dst)) > 0 {
+		if err := unmarshalTFJSON(dst, &base); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse existing JSON: %w", err)
+		}
+	}
+	var addition map[string]interface{}
+	if err := unmarshalTFJSON(src, &addition); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var changes []BlockChange
+	for _, blockType := range sortedKeys(addition) {
+		labels, ok := tfjsonLabels[blockType]
+		if !ok {
+			existing, had := base[blockType]
+			merged, err := mergeObjects(base, map[string]interface{}{blockType: addition[blockType]}, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+			action := BlockCreated
+			if had {
+				action = BlockUpdated
+				if jsonEqual(existing, merged[blockType]) {
+					action = BlockSkipped
+				}
+			}
+			base = merged
+			changes = append(changes, BlockChange{Address: blockType, Action: action})
+			continue
+		}
+
+		if err := mergeJSONBlocks(base, addition, []string{blockType}, labels, force, &changes); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	out, err := marshalTFJSON(base)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, changes, nil
+}
+
+// UnmergeTFJSON is UnmergeHCL for Terraform JSON: it removes the labeled
+// blocks src declares from dst, dropping objects left empty, and records a
+// BlockRemoved change for each block it removed. As in MergeTFJSONBlocks, a
+// block that differs from src counts as edited and fails with ErrBlockEdited
+// unless force is set.
+// PURE: Calculation.
+func UnmergeTFJSON(dst, src []byte, force bool) ([]byte, []BlockChange, error) {
+	var base, generated map[string]interface{}
+	if err := unmarshalTFJSON(dst, &base); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse existing JSON: %w", err)
+	}
+	if err := unmarshalTFJSON(src, &generated); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	var removed []BlockChange
+	for _, blockType := range sortedKeys(generated) {
+		if labels, ok := tfjsonLabels[blockType]; ok {
+			if err := removeJSONBlocks(base, generated, []string{blockType}, labels, force, &removed); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if len(removed) == 0 {
+		return dst, nil, nil
+	}
+
+	out, err := marshalTFJSON(base)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, removed, nil
+}
+
+// removeJSONBlocks removes the blocks nested labels deep under path in src
+// from dst, recording a BlockChange for each.
+// PURE: Calculation (mutates only dst).
+func removeJSONBlocks(dst, src map[string]interface{}, path []string, labels int, force bool, removed *[]BlockChange) error {
+	key := path[len(path)-1]
+	existing, ok := dst[key]
+	if !ok {
+		return nil
+	}
+	if labels == 0 {
+		address := blockAddress(path[0], path[1:])
+		if !force && !jsonEqual(existing, src[key]) {
+			return fmt.Errorf("%s %w", address, ErrBlockEdited)
+		}
+		delete(dst, key)
+		*removed = append(*removed, BlockChange{Address: address, Action: BlockRemoved})
+		return nil
+	}
+
+	srcObj, ok1 := src[key].(map[string]interface{})
+	dstObj, ok2 := existing.(map[string]interface{})
+	if !ok1 || !ok2 {
+		return nil
+	}
+	for _, label := range sortedKeys(srcObj) {
+		if err := removeJSONBlocks(dstObj, srcObj, append(path, label), labels-1, force, removed); err != nil {
+			return err
+		}
+	}
+	if len(dstObj) == 0 {
+		delete(dst, key)
+	}
+	return nil
+}
+
+// mergeJSONBlocks merges the blocks nested labels deep under path in src into
+// dst, recording a BlockChange for each.
+// PURE: Calculation (mutates only dst).
+func mergeJSONBlocks(dst, src map[string]interface{}, path []string, labels int, force bool, changes *[]BlockChange) error {
+	key := path[len(path)-1]
+	value := src[key]
+	if labels > 0 {
+		srcObj, ok1 := value.(map[string]interface{})
+		dstObj, ok2 := dst[key].(map[string]interface{})
+		if ok1 && (ok2 || dst[key] == nil) {
+			if dstObj == nil {
+				dstObj = make(map[string]interface{}, len(srcObj))
+				dst[key] = dstObj
+			}
+			for _, label := range sortedKeys(srcObj) {
+				if err := mergeJSONBlocks(dstObj, srcObj, append(path, label), labels-1, force, changes); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	address := blockAddress(path[0], path[1:])
+	existing, ok := dst[key]
+	switch {
+	case !ok:
+		dst[key] = value
+		*changes = append(*changes, BlockChange{Address: address, Action: BlockCreated})
+	case jsonEqual(existing, value):
+		*changes = append(*changes, BlockChange{Address: address, Action: BlockSkipped})
+	case force:
+		dst[key] = value
+		*changes = append(*changes, BlockChange{Address: address, Action: BlockUpdated})
+	default:
+		return fmt.Errorf("%s %w", address, ErrBlockEdited)
+	}
+	return nil
+}
+
+// jsonEqual reports whether two decoded JSON values are the same.
+// PURE: Calculation.
+func jsonEqual(a, b interface{}) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return bytes.Equal(x, y)
+}
+
+// sortedKeys returns the keys of a JSON object in order.
+// PURE: Calculation.
+func sortedKeys(obj map[string]interface{}) []string {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unmarshalTFJSON decodes a JSON document, keeping numbers as written.
+// PURE: Calculation.
+func unmarshalTFJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// mergeObjects returns dst with every key of src added, reporting the path of
+// the first conflicting value.
+// PURE: Calculation.
+func mergeObjects(dst, src map[string]interface{}, path []string) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(dst)+len(src))
+	for k, v := range dst {
+		merged[k] = v
+	}
+	for k, v := range src {
+		existing, ok := merged[k]
+		if !ok {
+			merged[k] = v
+			continue
+		}
+		keyPath := append(append([]string{}, path...), k)
+		existingObj, ok1 := existing.(map[string]interface{})
+		vObj, ok2 := v.(map[string]interface{})
+		if ok1 && ok2 {
+			sub, err := mergeObjects(existingObj, vObj, keyPath)
+			if err != nil {
+				return nil, err
+			}
+			merged[k] = sub
+			continue
+		}
+		a, _ := json.Marshal(existing)
+		b, _ := json.Marshal(v)
+		if !bytes.Equal(a, b) {
+			return nil, fmt.Errorf("%s is already defined", strings.Join(keyPath, "."))
+		}
+	}
+	return merged, nil
+}
+
+// marshalTFJSON renders a JSON document with sorted keys, two-space indent and
+// a trailing newline, without escaping <, > and & in strings.
+// PURE: Calculation.
+func marshalTFJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bodyJSON converts a body to a JSON object. Blocks are nested under their
+// type and labels; repeated blocks at the same path become an array.
+// PURE: Calculation.
+func bodyJSON(body *hclsyntax.Body, src []byte) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(body.Attributes)+len(body.Blocks))
+
+	for name, attr := range body.Attributes {
+		value, err := attributeJSON(name, attr.Expr, src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", attr.NameRange, err)
+		}
+		out[name] = value
+	}
+
+	for _, block := range body.Blocks {
+		if _, ok := body.Attributes[block.Type]; ok {
+			return nil, fmt.Errorf("%s: %q is both an attribute and a block", block.DefRange(), block.Type)
+		}
+		content, err := bodyJSON(block.Body, src)
+		if err != nil {
+			return nil, err
+		}
+
+		parent := out
+		key := block.Type
+		for _, label := range block.Labels {
+			next, ok := parent[key].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				parent[key] = next
+			}
+			parent, key = next, label
+		}
+		switch existing := parent[key].(type) {
+		case nil:
+			parent[key] = content
+		case []interface{}:
+			parent[key] = append(existing, content)
+		default:
+			parent[key] = []interface{}{existing, content}
+		}
+	}
+
+	return out, nil
+}
+
+// attributeJSON converts an attribute value, writing the references of
+// meta-arguments such as depends_on as plain strings.
+// PURE: Calculation.
+func attributeJSON(name string, expr hclsyntax.Expression, src []byte) (interface{}, error) {
+	if !rawReferenceArguments[name] {
+		return expressionJSON(expr, src)
+	}
+	switch e := expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		items := make([]interface{}, 0, len(e.Exprs))
+		for _, item := range e.Exprs {
+			value, err := attributeJSON(name, item, src)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case *hclsyntax.ObjectConsExpr:
+		return objectJSON(e, src, func(item hclsyntax.Expression) (interface{}, error) {
+			return attributeJSON(name, item, src)
+		})
+	case *hclsyntax.ScopeTraversalExpr, *hclsyntax.RelativeTraversalExpr:
+		return string(e.Range().SliceBytes(src)), nil
+	}
+	return expressionJSON(expr, src)
+}
+
+// expressionJSON converts an expression to a JSON value.
+// PURE: Calculation.
+func expressionJSON(expr hclsyntax.Expression, src []byte) (interface{}, error) {
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		return objectJSON(e, src, func(item hclsyntax.Expression) (interface{}, error) {
+			return expressionJSON(item, src)
+		})
+	case *hclsyntax.TupleConsExpr:
+		items := make([]interface{}, 0, len(e.Exprs))
+		for _, item := range e.Exprs {
+			value, err := expressionJSON(item, src)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, value)
+		}
+		return items, nil
+	case *hclsyntax.TemplateExpr:
+		if !e.IsStringLiteral() {
+			return templateJSON(e, src), nil
+		}
+	case *hclsyntax.TemplateWrapExpr:
+		return interpolation(e.Wrapped, src), nil
+	}
+
+	if len(expr.Variables()) == 0 {
+		if value, diags := expr.Value(nil); !diags.HasErrors() {
+			return ctyJSON(value)
+		}
+	}
+	return interpolation(expr, src), nil
+}
+
+// objectJSON converts an object constructor. Terraform JSON reads property
+// names as string templates, so keys computed from references, such as
+// "${var.namespace}orders", are written as templates.
+// PURE: Calculation.
+func objectJSON(e *hclsyntax.ObjectConsExpr, src []byte, convert func(hclsyntax.Expression) (interface{}, error)) (interface{}, error) {
+	out := make(map[string]interface{}, len(e.Items))
+	for _, item := range e.Items {
+		key, err := objectKeyJSON(item.KeyExpr, src)
+		if err != nil {
+			return nil, err
+		}
+		value, err := convert(item.ValueExpr)
+		if err != nil {
+			return nil, err
+		}
+		out[key] = value
+	}
+	return out, nil
+}
+
+// objectKeyJSON converts an object key to a JSON property name.
+// PURE: Calculation.
+func objectKeyJSON(expr hclsyntax.Expression, src []byte) (string, error) {
+	if key := hcl.ExprAsKeyword(expr); key != "" {
+		return key, nil
+	}
+	if value, diags := expr.Value(nil); !diags.HasErrors() && value.Type() == cty.String && !value.IsNull() {
+		return escapeTemplate(value.AsString()), nil
+	}
+	if wrapped, ok := expr.(*hclsyntax.ObjectConsKeyExpr); ok {
+		expr = wrapped.Wrapped
+	}
+	if parens, ok := expr.(*hclsyntax.ParenthesesExpr); ok {
+		expr = parens.Expression
+	}
+	value, err := expressionJSON(expr, src)
+	key, ok := value.(string)
+	if err != nil || !ok {
+		return "", fmt.Errorf("%s: object keys must be strings", expr.Range())
+	}
+	return key, nil
+}
+
+// templateJSON converts a string template: literal parts are escaped,
+// interpolated expressions are wrapped in "${...}" and template directives
+// are copied as written.
+// PURE: Calculation.
+func templateJSON(e *hclsyntax.TemplateExpr, src []byte) string {
+	var b strings.Builder
+	for _, part := range e.Parts {
+		if lit, ok := part.(*hclsyntax.LiteralValueExpr); ok && lit.Val.Type() == cty.String {
+			b.WriteString(escapeTemplate(lit.Val.AsString()))
+			continue
+		}
+		source := string(part.Range().SliceBytes(src))
+		if strings.HasPrefix(source, "%{") {
+			b.WriteString(source)
+			continue
+		}
+		b.WriteString(interpolation(part, src))
+	}
+	return b.String()
+}
+
+// interpolation wraps an expression's source in "${...}".
+// PURE: Calculation.
+func interpolation(expr hclsyntax.Expression, src []byte) string {
+	return "${" + string(expr.Range().SliceBytes(src)) + "}"
+}
+
+// escapeTemplate escapes the template sequences of a literal string.
+// PURE: Calculation.
+func escapeTemplate(s string) string {
+	s = strings.ReplaceAll(s, "${", "$${")
+	return strings.ReplaceAll(s, "%{", "%%{")
+}
+
+// ctyJSON converts a constant value to a JSON value, escaping strings.
+// PURE: Calculation.
+func ctyJSON(v cty.Value) (interface{}, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+	if !v.IsWhollyKnown() {
+		return nil, fmt.Errorf("value is not known")
+	}
+
+	ty := v.Type()
+	switch {
+	case ty == cty.String:
+		return escapeTemplate(v.AsString()), nil
+	case ty == cty.Number:
+		return json.Number(v.AsBigFloat().Text('f', -1)), nil
+	case ty == cty.Bool:
+		return v.True(), nil
+	case ty.IsListType() || ty.IsSetType() || ty.IsTupleType():
+		items := make([]interface{}, 0, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			item, err := ctyJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case ty.IsMapType() || ty.IsObjectType():
+		out := make(map[string]interface{}, v.LengthInt())
+		for it := v.ElementIterator(); it.Next(); {
+			k, elem := it.Element()
+			item, err := ctyJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			out[escapeTemplate(k.AsString())] = item
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", ty.FriendlyName())
+}
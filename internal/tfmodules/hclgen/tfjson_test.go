@@ -0,0 +1,246 @@
+package hclgen_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+)
+
+// TestToTFJSON tests that module structs render as Terraform JSON.
+func TestToTFJSON(t *testing.T) {
+	type QueueModule struct {
+		Name      *string           `hcl:"name,attr"`
+		Delay     *int              `hcl:"delay_seconds,attr"`
+		Policy    *string           `hcl:"policy,attr"`
+		Tags      map[string]string `hcl:"tags,attr"`
+		Consumers []string          `hcl:"consumers,attr"`
+	}
+
+	name := "${var.namespace}orders"
+	delay := 5
+	policy := "${module.policy.json}"
+	module := &QueueModule{
+		Name:      &name,
+		Delay:     &delay,
+		Policy:    &policy,
+		Tags:      map[string]string{"Team": "platform", "Note": "costs $${literal}"},
+		Consumers: []string{"${module.worker.arn}", "arn:aws:iam::123:root"},
+	}
+
+	result, err := hclgen.ToTFJSON("orders", "terraform-aws-modules/sqs/aws", "~> 4.0", module)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "module": {
+    "orders": {
+      "consumers": [
+        "${module.worker.arn}",
+        "arn:aws:iam::123:root"
+      ],
+      "delay_seconds": 5,
+      "name": "${var.namespace}orders",
+      "policy": "${module.policy.json}",
+      "source": "terraform-aws-modules/sqs/aws",
+      "tags": {
+        "Note": "costs $${literal}",
+        "Team": "platform"
+      },
+      "version": "~> 4.0"
+    }
+  }
+}
+`, result)
+}
+
+// TestHCLToJSON tests conversion of native syntax to JSON syntax.
+func TestHCLToJSON(t *testing.T) {
+	src := `
+# Comments are dropped
+module "worker" {
+  source     = "terraform-aws-modules/lambda/aws"
+  timeout    = 30
+  ratio      = 0.5
+  enabled    = true
+  nothing    = null
+  depends_on = [module.queue]
+  providers = {
+    aws = aws.west
+  }
+  policy = jsonencode({
+    Resource = module.queue.queue_arn
+  })
+  greeting = "hello %{if var.formal}sir%{endif}"
+  env = {
+    QUEUE_URL = module.queue.queue_url
+    "LOG.LEVEL" = "info"
+  }
+}
+
+resource "aws_lambda_event_source_mapping" "worker_queue" {
+  event_source_arn = module.queue.queue_arn
+
+  scaling_config {
+    maximum_concurrency = 2
+  }
+}
+
+resource "aws_lambda_event_source_mapping" "other" {
+  tags = {}
+}
+
+locals {
+  a = 1
+}
+
+locals {
+  b = -1
+}
+`
+
+	got, err := hclgen.HCLToJSON([]byte(src), "main.tf")
+	require.NoError(t, err)
+
+	assert.Equal(t, `{
+  "locals": [
+    {
+      "a": 1
+    },
+    {
+      "b": -1
+    }
+  ],
+  "module": {
+    "worker": {
+      "depends_on": [
+        "module.queue"
+      ],
+      "enabled": true,
+      "env": {
+        "LOG.LEVEL": "info",
+        "QUEUE_URL": "${module.queue.queue_url}"
+      },
+      "greeting": "hello %{if var.formal}sir%{endif}",
+      "nothing": null,
+      "policy": "${jsonencode({\n    Resource = module.queue.queue_arn\n  })}",
+      "providers": {
+        "aws": "aws.west"
+      },
+      "ratio": 0.5,
+      "source": "terraform-aws-modules/lambda/aws",
+      "timeout": 30
+    }
+  },
+  "resource": {
+    "aws_lambda_event_source_mapping": {
+      "other": {
+        "tags": {}
+      },
+      "worker_queue": {
+        "event_source_arn": "${module.queue.queue_arn}",
+        "scaling_config": {
+          "maximum_concurrency": 2
+        }
+      }
+    }
+  }
+}
+`, string(got))
+
+	t.Run("Terraform reads the same references back", func(t *testing.T) {
+		file, diags := json.Parse(got, "main.tf.json")
+		require.False(t, diags.HasErrors(), diags.Error())
+
+		content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "module", LabelNames: []string{"name"}}},
+		})
+		require.False(t, diags.HasErrors(), diags.Error())
+		require.Len(t, content.Blocks, 1)
+
+		attrs, diags := content.Blocks[0].Body.JustAttributes()
+		require.False(t, diags.HasErrors(), diags.Error())
+
+		for name, want := range map[string]string{
+			"policy": "module",
+			"env":    "module",
+		} {
+			vars := attrs[name].Expr.Variables()
+			require.NotEmpty(t, vars, name)
+			assert.Equal(t, want, vars[0].RootName(), name)
+		}
+
+		value, diags := attrs["timeout"].Expr.Value(nil)
+		require.False(t, diags.HasErrors(), diags.Error())
+		assert.Equal(t, "30", value.AsBigFloat().String())
+	})
+
+	t.Run("fails on invalid syntax", func(t *testing.T) {
+		_, err := hclgen.HCLToJSON([]byte(`module "x" {`), "broken.tf")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "broken.tf")
+	})
+
+	t.Run("writes computed object keys as templates", func(t *testing.T) {
+		got, err := hclgen.HCLToJSON([]byte("tags = {\n  (var.key) = 1\n  \"${var.namespace}orders\" = 2\n  \"$${literal}\" = 3\n}\n"), "keys.tf")
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"tags": {"${var.key}": 1, "${var.namespace}orders": 2, "$${literal}": 3}}`, string(got))
+	})
+
+	t.Run("fails on object keys that are not strings", func(t *testing.T) {
+		_, err := hclgen.HCLToJSON([]byte("tags = { ([var.key]) = 1 }\n"), "keys.tf")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "object keys must be strings")
+	})
+}
+
+// TestMergeTFJSON tests merging JSON documents as appended blocks.
+func TestMergeTFJSON(t *testing.T) {
+	existing := []byte(`{"module": {"a": {"source": "./a"}}, "output": {"a": {"value": "${module.a.x}"}}}`)
+
+	t.Run("merges blocks", func(t *testing.T) {
+		got, err := hclgen.MergeTFJSON(existing, []byte(`{"module": {"b": {"source": "./b"}}}`))
+		require.NoError(t, err)
+		assert.Equal(t, `{
+  "module": {
+    "a": {
+      "source": "./a"
+    },
+    "b": {
+      "source": "./b"
+    }
+  },
+  "output": {
+    "a": {
+      "value": "${module.a.x}"
+    }
+  }
+}
+`, string(got))
+	})
+
+	t.Run("accepts an empty document", func(t *testing.T) {
+		got, err := hclgen.MergeTFJSON(nil, []byte(`{"locals": {"a": 1}}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"locals": {"a": 1}}`, string(got))
+	})
+
+	t.Run("accepts identical blocks", func(t *testing.T) {
+		_, err := hclgen.MergeTFJSON(existing, []byte(`{"module": {"a": {"source": "./a"}}}`))
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejects conflicting values", func(t *testing.T) {
+		_, err := hclgen.MergeTFJSON(existing, []byte(`{"module": {"a": {"source": "./other"}}}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "module.a.source is already defined")
+	})
+
+	t.Run("rejects invalid JSON", func(t *testing.T) {
+		_, err := hclgen.MergeTFJSON([]byte("{"), []byte(`{}`))
+		assert.Error(t, err)
+	})
+}
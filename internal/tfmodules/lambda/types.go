@@ -1,7 +1,18 @@
 // Package lambda provides type-safe Terraform module definitions for terraform-aws-modules/lambda/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-lambda v7.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/lambda (see variables_gen.go).
 package lambda
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module lambda -source terraform-aws-modules/lambda/aws
+
+import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
+
 type (
 	// Module represents the terraform-aws-modules/lambda/aws module.
 	// All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
@@ -51,7 +62,7 @@ type (
 
 	// Runtime is the Lambda Function runtime
 	// Valid values: nodejs20.x, python3.13, java21, go1.x, dotnet8, ruby3.3, provided.al2023, etc.
-	Runtime *string `json:"runtime,omitempty" hcl:"runtime,attr"`
+	Runtime *string `json:"runtime,omitempty" validate:"oneof=nodejs18.x nodejs20.x nodejs22.x python3.9 python3.10 python3.11 python3.12 python3.13 java8.al2 java11 java17 java21 dotnet8 ruby3.2 ruby3.3 ruby3.4 provided provided.al2 provided.al2023 go1.x" hcl:"runtime,attr"`
 
 	// LambdaRole is the IAM role ARN attached to the Lambda Function
 	LambdaRole *string `json:"lambda_role,omitempty" hcl:"lambda_role,attr"`
@@ -77,7 +88,7 @@ type (
 
 	// PackageType is the deployment package type
 	// Valid values: "Zip" | "Image"
-	PackageType *string `json:"package_type,omitempty" hcl:"package_type,attr"`
+	PackageType *string `json:"package_type,omitempty" validate:"oneof=Zip Image" hcl:"package_type,attr"`
 
 	// ImageURI is the ECR image URI (for Image package type)
 	ImageURI *string `json:"image_uri,omitempty" hcl:"image_uri,attr"`
@@ -94,14 +105,15 @@ type (
 	// LocalExistingPackage is the path to an existing package file
 	LocalExistingPackage *string `json:"local_existing_package,omitempty" hcl:"local_existing_package,attr"`
 
-	// S3Bucket is the S3 bucket for Lambda deployment package
-	S3Bucket *string `json:"s3_bucket,omitempty" hcl:"s3_bucket,attr"`
+	// IgnoreSourceCodeHash disables redeploying when the package changes. Unless set,
+	// the module sets source_code_hash to filebase64sha256(local_existing_package).
+	IgnoreSourceCodeHash *bool `json:"ignore_source_code_hash,omitempty" hcl:"ignore_source_code_hash,attr"`
 
-	// S3Key is the S3 key for Lambda deployment package
-	S3Key *string `json:"s3_key,omitempty" hcl:"s3_key,attr"`
+	// S3Bucket is the S3 bucket the module stores built packages in
+	S3Bucket *string `json:"s3_bucket,omitempty" hcl:"s3_bucket,attr"`
 
-	// S3ObjectVersion is the S3 object version
-	S3ObjectVersion *string `json:"s3_object_version,omitempty" hcl:"s3_object_version,attr"`
+	// S3ExistingPackage points at an existing package in S3 (keys: bucket, key, version)
+	S3ExistingPackage map[string]string `json:"s3_existing_package,omitempty" hcl:"s3_existing_package,attr"`
 
 	// ================================
 	// Architecture & Layers
@@ -155,7 +167,7 @@ type (
 
 	// TracingMode is the X-Ray tracing mode
 	// Valid values: "PassThrough" | "Active"
-	TracingMode *string `json:"tracing_mode,omitempty" hcl:"tracing_mode,attr"`
+	TracingMode *string `json:"tracing_mode,omitempty" validate:"oneof=PassThrough Active" hcl:"tracing_mode,attr"`
 
 	// CloudwatchLogsRetentionInDays is the log retention period
 	CloudwatchLogsRetentionInDays *int `json:"cloudwatch_logs_retention_in_days,omitempty" hcl:"cloudwatch_logs_retention_in_days,attr"`
@@ -193,7 +205,7 @@ type (
 
 	// InvokeMode is the invoke mode for Function URL
 	// Valid values: "BUFFERED" | "RESPONSE_STREAM"
-	InvokeMode *string `json:"invoke_mode,omitempty" hcl:"invoke_mode,attr"`
+	InvokeMode *string `json:"invoke_mode,omitempty" validate:"oneof=BUFFERED RESPONSE_STREAM" hcl:"invoke_mode,attr"`
 
 	// ================================
 	// Async Event Config
@@ -332,10 +344,10 @@ type (
 	EventSourceARN string `json:"event_source_arn" hcl:"event_source_arn,attr"`
 
 	// BatchSize is the maximum batch size (1-10000)
-	BatchSize *int `json:"batch_size,omitempty" hcl:"batch_size,attr"`
+	BatchSize *int `json:"batch_size,omitempty" validate:"min=1,max=10000" hcl:"batch_size,attr"`
 
 	// MaximumBatchingWindowInSeconds is the max batching window (0-300)
-	MaximumBatchingWindowInSeconds *int `json:"maximum_batching_window_in_seconds,omitempty" hcl:"maximum_batching_window_in_seconds,attr"`
+	MaximumBatchingWindowInSeconds *int `json:"maximum_batching_window_in_seconds,omitempty" validate:"min=0,max=300" hcl:"maximum_batching_window_in_seconds,attr"`
 
 	// StartingPosition is the position in stream
 	// Valid values: "TRIM_HORIZON" | "LATEST"
@@ -364,7 +376,7 @@ type (
 // NewModule creates a new Lambda module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/lambda/aws"
-	version := "~> 7.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	createFunction := true
 	createPackage := true
@@ -489,8 +501,17 @@ func (m *Module) LocalName() string {
 	return "lambda_function"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 func (m *Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid lambda module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -15,7 +17,7 @@ func TestNewModule(t *testing.T) {
 		// Verify basic properties
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/lambda/aws", module.Source)
-		assert.Equal(t, "~> 7.0", module.Version)
+		assert.Equal(t, "~> 8.0", module.Version)
 		assert.NotNil(t, module.FunctionName)
 		assert.Equal(t, name, *module.FunctionName)
 
@@ -415,13 +417,86 @@ func TestModule_LocalName(t *testing.T) {
 }
 
 func TestModule_Configuration(t *testing.T) {
-	t.Run("returns empty string and nil error as placeholder", func(t *testing.T) {
+	t.Run("generates valid HCL for basic function", func(t *testing.T) {
 		module := NewModule("test_function")
 
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
+		golden.Assert(t, "basic_function", config)
+	})
+
+	t.Run("generates HCL with nested configuration and references", func(t *testing.T) {
+		batchSize := 10
+		module := NewModule("orders").
+			WithRuntime("python3.12", "app.handler").
+			WithMemoryAndTimeout(512, 30).
+			WithEnvironment(map[string]string{
+				"LOG_LEVEL":  "info",
+				"TABLE_NAME": "${module.orders_table.dynamodb_table_id}",
+			}).
+			WithVPC([]string{"subnet-a", "subnet-b"}, []string{"sg-1"}).
+			WithLayers("arn:aws:lambda:us-east-1:123456789012:layer:shared:3").
+			WithFunctionURL("NONE", &CORSConfig{
+				AllowOrigins: []string{"*"},
+				AllowMethods: []string{"GET", "POST"},
+			}).
+			WithEventSourceMapping("sqs", EventSourceMapping{
+				EventSourceARN: "${module.orders_queue.queue_arn}",
+				BatchSize:      &batchSize,
+			}).
+			WithTags(map[string]string{"Team": "platform"})
+
+		config, err := module.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "full_function", config)
+	})
+}
+
+func TestModule_Validate(t *testing.T) {
+	t.Run("accepts the defaults", func(t *testing.T) {
+		assert.NoError(t, NewModule("test_function").Validate())
+	})
+
+	t.Run("reports every out-of-range or unknown value", func(t *testing.T) {
+		module := NewModule("test_function").
+			WithRuntime("cobol85", "main").
+			WithMemoryAndTimeout(64, 901)
+		packageType := "Jar"
+		module.PackageType = &packageType
+
+		err := module.Validate()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "4 invalid fields:")
+		assert.Contains(t, err.Error(), `runtime: must be one of`)
+		assert.Contains(t, err.Error(), "memory_size: must be at least 128, got 64")
+		assert.Contains(t, err.Error(), "timeout: must be at most 900, got 901")
+		assert.Contains(t, err.Error(), `package_type: must be one of Zip, Image, got "Jar"`)
+	})
+
+	t.Run("checks nested event source mappings", func(t *testing.T) {
+		batchSize := 0
+		module := NewModule("test_function").WithEventSourceMapping("sqs", EventSourceMapping{
+			EventSourceARN: "${module.orders_queue.queue_arn}",
+			BatchSize:      &batchSize,
+		})
+
+		err := module.Validate()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `event_source_mapping["sqs"].batch_size: must be at least 1, got 0`)
+	})
+
+	t.Run("blocks Configuration", func(t *testing.T) {
+		module := NewModule("test_function").WithMemoryAndTimeout(64, 30)
+
+		config, err := module.Configuration()
+
+		require.Error(t, err)
 		assert.Empty(t, config)
+		assert.Contains(t, err.Error(), "invalid lambda module test_function: memory_size")
 	})
 }
 
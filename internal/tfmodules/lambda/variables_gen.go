@@ -0,0 +1,759 @@
+// Code generated by tfmodules-gen from .forge/modules/lambda. DO NOT EDIT.
+
+package lambda
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/lambda/aws these types were generated from.
+const ModuleVersion = "8.1.2"
+
+// Variables holds every input declared by terraform-aws-modules/lambda/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Controls whether resources should be created
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// Controls whether Lambda package should be created
+	//
+	// Default: true
+	CreatePackage *bool `json:"create_package,omitempty" hcl:"create_package,attr"`
+
+	// Controls whether Lambda Function resource should be created
+	//
+	// Default: true
+	CreateFunction *bool `json:"create_function,omitempty" hcl:"create_function,attr"`
+
+	// Controls whether Lambda Layer resource should be created
+	//
+	// Default: false
+	CreateLayer *bool `json:"create_layer,omitempty" hcl:"create_layer,attr"`
+
+	// Controls whether IAM role for Lambda Function should be created
+	//
+	// Default: true
+	CreateRole *bool `json:"create_role,omitempty" hcl:"create_role,attr"`
+
+	// Controls whether the Lambda Function URL resource should be created
+	//
+	// Default: false
+	CreateLambdaFunctionURL *bool `json:"create_lambda_function_url,omitempty" hcl:"create_lambda_function_url,attr"`
+
+	// Controls whether the SAM metadata null resource should be created
+	//
+	// Default: false
+	CreateSamMetadata *bool `json:"create_sam_metadata,omitempty" hcl:"create_sam_metadata,attr"`
+
+	// Do you agree that Putin doesn't respect Ukrainian sovereignty and territorial integrity? More info: https://en.wikipedia.org/wiki/Putin_khuylo!
+	//
+	// Default: true
+	PutinKhuylo *bool `json:"putin_khuylo,omitempty" hcl:"putin_khuylo,attr"`
+
+	// Region where the resource(s) will be managed. Defaults to the region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+
+	// Set this to true if using Lambda@Edge, to enable publishing, limit the timeout, and allow edgelambda.amazonaws.com to invoke the function
+	//
+	// Default: false
+	LambdaAtEdge *bool `json:"lambda_at_edge,omitempty" hcl:"lambda_at_edge,attr"`
+
+	// Whether to specify a wildcard in IAM policy used by Lambda@Edge to allow logging in all regions
+	//
+	// Default: true
+	LambdaAtEdgeLogsAllRegions *bool `json:"lambda_at_edge_logs_all_regions,omitempty" hcl:"lambda_at_edge_logs_all_regions,attr"`
+
+	// A unique name for your Lambda Function
+	//
+	// Default: ""
+	FunctionName *string `json:"function_name,omitempty" hcl:"function_name,attr"`
+
+	// Lambda Function entrypoint in your code
+	//
+	// Default: ""
+	Handler *string `json:"handler,omitempty" hcl:"handler,attr"`
+
+	// Lambda Function runtime
+	//
+	// Default: ""
+	Runtime *string `json:"runtime,omitempty" hcl:"runtime,attr"`
+
+	// IAM role ARN attached to the Lambda Function. This governs both who / what can invoke your Lambda Function, as well as what resources our Lambda Function has access to. See Lambda Permission Model for more details.
+	//
+	// Default: ""
+	LambdaRole *string `json:"lambda_role,omitempty" hcl:"lambda_role,attr"`
+
+	// Description of your Lambda Function (or Layer)
+	//
+	// Default: ""
+	Description *string `json:"description,omitempty" hcl:"description,attr"`
+
+	// Amazon Resource Name (ARN) for a Code Signing Configuration
+	CodeSigningConfigARN *string `json:"code_signing_config_arn,omitempty" hcl:"code_signing_config_arn,attr"`
+
+	// List of Lambda Layer Version ARNs (maximum of 5) to attach to your Lambda Function.
+	Layers []string `json:"layers,omitempty" hcl:"layers,attr"`
+
+	// Instruction set architecture for your Lambda function. Valid values are ["x86_64"] and ["arm64"].
+	Architectures []string `json:"architectures,omitempty" hcl:"architectures,attr"`
+
+	// The ARN of KMS key to use by your Lambda Function
+	KMSKeyARN *string `json:"kms_key_arn,omitempty" hcl:"kms_key_arn,attr"`
+
+	// Amount of memory in MB your Lambda Function can use at runtime. Valid value between 128 MB to 10,240 MB (10 GB), in 64 MB increments.
+	//
+	// Default: 128
+	MemorySize *int `json:"memory_size,omitempty" hcl:"memory_size,attr"`
+
+	// Amount of ephemeral storage (/tmp) in MB your Lambda Function can use at runtime. Valid value between 512 MB to 10,240 MB (10 GB).
+	//
+	// Default: 512
+	EphemeralStorageSize *int `json:"ephemeral_storage_size,omitempty" hcl:"ephemeral_storage_size,attr"`
+
+	// Whether to publish creation/change as new Lambda Function Version.
+	//
+	// Default: false
+	Publish *bool `json:"publish,omitempty" hcl:"publish,attr"`
+
+	// The amount of reserved concurrent executions for this Lambda Function. A value of 0 disables Lambda Function from being triggered and -1 removes any concurrency limitations. Defaults to Unreserved Concurrency Limits -1.
+	//
+	// Default: -1
+	ReservedConcurrentExecutions *int `json:"reserved_concurrent_executions,omitempty" hcl:"reserved_concurrent_executions,attr"`
+
+	// The amount of time your Lambda Function has to run in seconds.
+	//
+	// Default: 3
+	Timeout *int `json:"timeout,omitempty" hcl:"timeout,attr"`
+
+	// The ARN of an SNS topic or SQS queue to notify when an invocation fails.
+	DeadLetterTargetARN *string `json:"dead_letter_target_arn,omitempty" hcl:"dead_letter_target_arn,attr"`
+
+	// A map that defines environment variables for the Lambda Function.
+	//
+	// Default: {}
+	EnvironmentVariables map[string]string `json:"environment_variables,omitempty" hcl:"environment_variables,attr"`
+
+	// Tracing mode of the Lambda Function. Valid value can be either PassThrough or Active.
+	TracingMode *string `json:"tracing_mode,omitempty" hcl:"tracing_mode,attr"`
+
+	// List of subnet ids when Lambda Function should run in the VPC. Usually private or intra subnets.
+	VPCSubnetIDs []string `json:"vpc_subnet_ids,omitempty" hcl:"vpc_subnet_ids,attr"`
+
+	// List of security group ids when Lambda Function should run in the VPC.
+	VPCSecurityGroupIDs []string `json:"vpc_security_group_ids,omitempty" hcl:"vpc_security_group_ids,attr"`
+
+	// Allows outbound IPv6 traffic on VPC functions that are connected to dual-stack subnets
+	Ipv6AllowedForDualStack *bool `json:"ipv6_allowed_for_dual_stack,omitempty" hcl:"ipv6_allowed_for_dual_stack,attr"`
+
+	// A map of tags to assign to resources.
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// Set to false to not include the default tag in the tags map.
+	//
+	// Default: true
+	IncludeDefaultTag *bool `json:"include_default_tag,omitempty" hcl:"include_default_tag,attr"`
+
+	// A map of tags to assign only to the lambda function
+	//
+	// Default: {}
+	FunctionTags map[string]string `json:"function_tags,omitempty" hcl:"function_tags,attr"`
+
+	// A map of tags to assign to S3 bucket object.
+	//
+	// Default: {}
+	S3ObjectTags map[string]string `json:"s3_object_tags,omitempty" hcl:"s3_object_tags,attr"`
+
+	// Set to true to not merge tags with s3_object_tags. Useful to avoid breaching S3 Object 10 tag limit.
+	//
+	// Default: false
+	S3ObjectTagsOnly *bool `json:"s3_object_tags_only,omitempty" hcl:"s3_object_tags_only,attr"`
+
+	// The Lambda deployment package type. Valid options: Zip or Image
+	//
+	// Default: "Zip"
+	PackageType *string `json:"package_type,omitempty" hcl:"package_type,attr"`
+
+	// The ECR image URI containing the function's deployment package.
+	ImageURI *string `json:"image_uri,omitempty" hcl:"image_uri,attr"`
+
+	// The ENTRYPOINT for the docker image
+	//
+	// Default: []
+	ImageConfigEntryPoint []string `json:"image_config_entry_point,omitempty" hcl:"image_config_entry_point,attr"`
+
+	// The CMD for the docker image
+	//
+	// Default: []
+	ImageConfigCommand []string `json:"image_config_command,omitempty" hcl:"image_config_command,attr"`
+
+	// The working directory for the docker image
+	ImageConfigWorkingDirectory *string `json:"image_config_working_directory,omitempty" hcl:"image_config_working_directory,attr"`
+
+	// (Optional) Snap start settings for low-latency startups
+	//
+	// Default: false
+	SnapStart *bool `json:"snap_start,omitempty" hcl:"snap_start,attr"`
+
+	// (Optional) When true, all security groups defined in vpc_security_group_ids will be replaced with the default security group after the function is destroyed. Set the replacement_security_group_ids variable to use a custom list of security groups for replacement instead.
+	ReplaceSecurityGroupsOnDestroy *bool `json:"replace_security_groups_on_destroy,omitempty" hcl:"replace_security_groups_on_destroy,attr"`
+
+	// (Optional) List of security group IDs to assign to orphaned Lambda function network interfaces upon destruction. replace_security_groups_on_destroy must be set to true to use this attribute.
+	ReplacementSecurityGroupIDs []string `json:"replacement_security_group_ids,omitempty" hcl:"replacement_security_group_ids,attr"`
+
+	// Define maximum timeout for creating, updating, and deleting Lambda Function resources
+	//
+	// Default: {}
+	Timeouts map[string]string `json:"timeouts,omitempty" hcl:"timeouts,attr"`
+
+	// Set to true if you do not wish the function to be deleted at destroy time, and instead just remove the function from the Terraform state. Useful for Lambda@Edge functions attached to CloudFront distributions.
+	SkipDestroy *bool `json:"skip_destroy,omitempty" hcl:"skip_destroy,attr"`
+
+	// Whether to use unqualified alias pointing to $LATEST version in Lambda Function URL
+	//
+	// Default: true
+	CreateUnqualifiedAliasLambdaFunctionURL *bool `json:"create_unqualified_alias_lambda_function_url,omitempty" hcl:"create_unqualified_alias_lambda_function_url,attr"`
+
+	// The type of authentication that the Lambda Function URL uses. Set to 'AWS_IAM' to restrict access to authenticated IAM users only. Set to 'NONE' to bypass IAM authentication and create a public endpoint.
+	//
+	// Default: "NONE"
+	AuthorizationType *string `json:"authorization_type,omitempty" hcl:"authorization_type,attr"`
+
+	// CORS settings to be used by the Lambda Function URL
+	//
+	// Default: {}
+	CORS interface{} `json:"cors,omitempty" hcl:"cors,attr"`
+
+	// Invoke mode of the Lambda Function URL. Valid values are BUFFERED (default) and RESPONSE_STREAM.
+	InvokeMode *string `json:"invoke_mode,omitempty" hcl:"invoke_mode,attr"`
+
+	// Whether to override the default_tags from provider? NB: S3 objects support a maximum of 10 tags.
+	//
+	// Default: false
+	S3ObjectOverrideDefaultTags *bool `json:"s3_object_override_default_tags,omitempty" hcl:"s3_object_override_default_tags,attr"`
+
+	// Name of Lambda Layer to create
+	//
+	// Default: ""
+	LayerName *string `json:"layer_name,omitempty" hcl:"layer_name,attr"`
+
+	// Whether to retain the old version of a previously deployed Lambda Layer.
+	//
+	// Default: false
+	LayerSkipDestroy *bool `json:"layer_skip_destroy,omitempty" hcl:"layer_skip_destroy,attr"`
+
+	// License info for your Lambda Layer. Eg, MIT or full url of a license.
+	//
+	// Default: ""
+	LicenseInfo *string `json:"license_info,omitempty" hcl:"license_info,attr"`
+
+	// A list of Runtimes this layer is compatible with. Up to 5 runtimes can be specified.
+	//
+	// Default: []
+	CompatibleRuntimes []string `json:"compatible_runtimes,omitempty" hcl:"compatible_runtimes,attr"`
+
+	// A list of Architectures Lambda layer is compatible with. Currently x86_64 and arm64 can be specified.
+	CompatibleArchitectures []string `json:"compatible_architectures,omitempty" hcl:"compatible_architectures,attr"`
+
+	// Controls whether async event configuration for Lambda Function/Alias should be created
+	//
+	// Default: false
+	CreateAsyncEventConfig *bool `json:"create_async_event_config,omitempty" hcl:"create_async_event_config,attr"`
+
+	// Whether to allow async event configuration on current version of Lambda Function (this will revoke permissions from previous version because Terraform manages only current resources)
+	//
+	// Default: true
+	CreateCurrentVersionAsyncEventConfig *bool `json:"create_current_version_async_event_config,omitempty" hcl:"create_current_version_async_event_config,attr"`
+
+	// Whether to allow async event configuration on unqualified alias pointing to $LATEST version
+	//
+	// Default: true
+	CreateUnqualifiedAliasAsyncEventConfig *bool `json:"create_unqualified_alias_async_event_config,omitempty" hcl:"create_unqualified_alias_async_event_config,attr"`
+
+	// Maximum age of a request that Lambda sends to a function for processing in seconds. Valid values between 60 and 21600.
+	MaximumEventAgeInSeconds *int `json:"maximum_event_age_in_seconds,omitempty" hcl:"maximum_event_age_in_seconds,attr"`
+
+	// Maximum number of times to retry when the function returns an error. Valid values between 0 and 2. Defaults to 2.
+	MaximumRetryAttempts *int `json:"maximum_retry_attempts,omitempty" hcl:"maximum_retry_attempts,attr"`
+
+	// Amazon Resource Name (ARN) of the destination resource for failed asynchronous invocations
+	DestinationOnFailure *string `json:"destination_on_failure,omitempty" hcl:"destination_on_failure,attr"`
+
+	// Amazon Resource Name (ARN) of the destination resource for successful asynchronous invocations
+	DestinationOnSuccess *string `json:"destination_on_success,omitempty" hcl:"destination_on_success,attr"`
+
+	// Amount of capacity to allocate. Set to 1 or greater to enable, or set to 0 to disable provisioned concurrency.
+	//
+	// Default: -1
+	ProvisionedConcurrentExecutions *int `json:"provisioned_concurrent_executions,omitempty" hcl:"provisioned_concurrent_executions,attr"`
+
+	// Whether to allow triggers on current version of Lambda Function (this will revoke permissions from previous version because Terraform manages only current resources)
+	//
+	// Default: true
+	CreateCurrentVersionAllowedTriggers *bool `json:"create_current_version_allowed_triggers,omitempty" hcl:"create_current_version_allowed_triggers,attr"`
+
+	// Whether to allow triggers on unqualified alias pointing to $LATEST version
+	//
+	// Default: true
+	CreateUnqualifiedAliasAllowedTriggers *bool `json:"create_unqualified_alias_allowed_triggers,omitempty" hcl:"create_unqualified_alias_allowed_triggers,attr"`
+
+	// Map of allowed triggers to create Lambda permissions
+	//
+	// Default: {}
+	AllowedTriggers map[string]interface{} `json:"allowed_triggers,omitempty" hcl:"allowed_triggers,attr"`
+
+	// Map of event source mapping
+	//
+	// Default: {}
+	EventSourceMapping interface{} `json:"event_source_mapping,omitempty" hcl:"event_source_mapping,attr"`
+
+	// Whether to use an existing CloudWatch log group or create new
+	//
+	// Default: false
+	UseExistingCloudwatchLogGroup *bool `json:"use_existing_cloudwatch_log_group,omitempty" hcl:"use_existing_cloudwatch_log_group,attr"`
+
+	// Specifies the number of days you want to retain log events in the specified log group. Possible values are: 1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, and 3653.
+	CloudwatchLogsRetentionInDays *int `json:"cloudwatch_logs_retention_in_days,omitempty" hcl:"cloudwatch_logs_retention_in_days,attr"`
+
+	// The ARN of the KMS Key to use when encrypting log data.
+	CloudwatchLogsKMSKeyID *string `json:"cloudwatch_logs_kms_key_id,omitempty" hcl:"cloudwatch_logs_kms_key_id,attr"`
+
+	// Whether to keep the log group (and any logs it may contain) at destroy time.
+	//
+	// Default: false
+	CloudwatchLogsSkipDestroy *bool `json:"cloudwatch_logs_skip_destroy,omitempty" hcl:"cloudwatch_logs_skip_destroy,attr"`
+
+	// Specified the log class of the log group. Possible values are: `STANDARD` or `INFREQUENT_ACCESS`
+	CloudwatchLogsLogGroupClass *string `json:"cloudwatch_logs_log_group_class,omitempty" hcl:"cloudwatch_logs_log_group_class,attr"`
+
+	// A map of tags to assign to the resource.
+	//
+	// Default: {}
+	CloudwatchLogsTags map[string]string `json:"cloudwatch_logs_tags,omitempty" hcl:"cloudwatch_logs_tags,attr"`
+
+	// Name of IAM role to use for Lambda Function
+	RoleName *string `json:"role_name,omitempty" hcl:"role_name,attr"`
+
+	// Description of IAM role to use for Lambda Function
+	RoleDescription *string `json:"role_description,omitempty" hcl:"role_description,attr"`
+
+	// Path of IAM role to use for Lambda Function
+	RolePath *string `json:"role_path,omitempty" hcl:"role_path,attr"`
+
+	// Specifies to force detaching any policies the IAM role has before destroying it.
+	//
+	// Default: true
+	RoleForceDetachPolicies *bool `json:"role_force_detach_policies,omitempty" hcl:"role_force_detach_policies,attr"`
+
+	// The ARN of the policy that is used to set the permissions boundary for the IAM role used by Lambda Function
+	RolePermissionsBoundary *string `json:"role_permissions_boundary,omitempty" hcl:"role_permissions_boundary,attr"`
+
+	// A map of tags to assign to IAM role
+	//
+	// Default: {}
+	RoleTags map[string]string `json:"role_tags,omitempty" hcl:"role_tags,attr"`
+
+	// Maximum session duration, in seconds, for the IAM role
+	//
+	// Default: 3600
+	RoleMaximumSessionDuration *int `json:"role_maximum_session_duration,omitempty" hcl:"role_maximum_session_duration,attr"`
+
+	// IAM policy name. It override the default value, which is the same as role_name
+	PolicyName *string `json:"policy_name,omitempty" hcl:"policy_name,attr"`
+
+	// Controls whether CloudWatch Logs policy should be added to IAM role for Lambda Function
+	//
+	// Default: true
+	AttachCloudwatchLogsPolicy *bool `json:"attach_cloudwatch_logs_policy,omitempty" hcl:"attach_cloudwatch_logs_policy,attr"`
+
+	// Controls whether to add the create log group permission to the CloudWatch logs policy
+	//
+	// Default: true
+	AttachCreateLogGroupPermission *bool `json:"attach_create_log_group_permission,omitempty" hcl:"attach_create_log_group_permission,attr"`
+
+	// Controls whether SNS/SQS dead letter notification policy should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachDeadLetterPolicy *bool `json:"attach_dead_letter_policy,omitempty" hcl:"attach_dead_letter_policy,attr"`
+
+	// Controls whether VPC/network policy should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachNetworkPolicy *bool `json:"attach_network_policy,omitempty" hcl:"attach_network_policy,attr"`
+
+	// Controls whether X-Ray tracing policy should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachTracingPolicy *bool `json:"attach_tracing_policy,omitempty" hcl:"attach_tracing_policy,attr"`
+
+	// Controls whether async event policy should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachAsyncEventPolicy *bool `json:"attach_async_event_policy,omitempty" hcl:"attach_async_event_policy,attr"`
+
+	// Controls whether policy_json should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachPolicyJSON *bool `json:"attach_policy_json,omitempty" hcl:"attach_policy_json,attr"`
+
+	// Controls whether policy_jsons should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachPolicyJsons *bool `json:"attach_policy_jsons,omitempty" hcl:"attach_policy_jsons,attr"`
+
+	// Controls whether policy should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachPolicy *bool `json:"attach_policy,omitempty" hcl:"attach_policy,attr"`
+
+	// Controls whether list of policies should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachPolicies *bool `json:"attach_policies,omitempty" hcl:"attach_policies,attr"`
+
+	// Number of policies JSON to attach to IAM role for Lambda Function
+	//
+	// Default: 0
+	NumberOfPolicyJsons *int `json:"number_of_policy_jsons,omitempty" hcl:"number_of_policy_jsons,attr"`
+
+	// Number of policies to attach to IAM role for Lambda Function
+	//
+	// Default: 0
+	NumberOfPolicies *int `json:"number_of_policies,omitempty" hcl:"number_of_policies,attr"`
+
+	// Controls whether policy_statements should be added to IAM role for Lambda Function
+	//
+	// Default: false
+	AttachPolicyStatements *bool `json:"attach_policy_statements,omitempty" hcl:"attach_policy_statements,attr"`
+
+	// List of additional trusted entities for assuming Lambda Function role (trust relationship)
+	//
+	// Default: []
+	TrustedEntities interface{} `json:"trusted_entities,omitempty" hcl:"trusted_entities,attr"`
+
+	// Map of dynamic policy statements for assuming Lambda Function role (trust relationship)
+	//
+	// Default: {}
+	AssumeRolePolicyStatements interface{} `json:"assume_role_policy_statements,omitempty" hcl:"assume_role_policy_statements,attr"`
+
+	// An additional policy document as JSON to attach to the Lambda Function role
+	PolicyJSON *string `json:"policy_json,omitempty" hcl:"policy_json,attr"`
+
+	// List of additional policy documents as JSON to attach to Lambda Function role
+	//
+	// Default: []
+	PolicyJsons []string `json:"policy_jsons,omitempty" hcl:"policy_jsons,attr"`
+
+	// An additional policy document ARN to attach to the Lambda Function role
+	Policy *string `json:"policy,omitempty" hcl:"policy,attr"`
+
+	// List of policy statements ARN to attach to Lambda Function role
+	//
+	// Default: []
+	Policies []string `json:"policies,omitempty" hcl:"policies,attr"`
+
+	// Map of dynamic policy statements to attach to Lambda Function role
+	//
+	// Default: {}
+	PolicyStatements interface{} `json:"policy_statements,omitempty" hcl:"policy_statements,attr"`
+
+	// The Amazon Resource Name (ARN) of the Amazon EFS Access Point that provides access to the file system.
+	FileSystemARN *string `json:"file_system_arn,omitempty" hcl:"file_system_arn,attr"`
+
+	// The path where the function can access the file system, starting with /mnt/.
+	FileSystemLocalMountPath *string `json:"file_system_local_mount_path,omitempty" hcl:"file_system_local_mount_path,attr"`
+
+	// Directory name where artifacts should be stored
+	//
+	// Default: "builds"
+	ArtifactsDir *string `json:"artifacts_dir,omitempty" hcl:"artifacts_dir,attr"`
+
+	// Directory name where artifacts should be stored in the S3 bucket. If unset, the path from `artifacts_dir` is used
+	S3Prefix *string `json:"s3_prefix,omitempty" hcl:"s3_prefix,attr"`
+
+	// Whether to ignore changes to the function's source code hash. Set to true if you manage infrastructure and code deployments separately.
+	//
+	// Default: false
+	IgnoreSourceCodeHash *bool `json:"ignore_source_code_hash,omitempty" hcl:"ignore_source_code_hash,attr"`
+
+	// The absolute path to an existing zip-file to use
+	LocalExistingPackage *string `json:"local_existing_package,omitempty" hcl:"local_existing_package,attr"`
+
+	// The S3 bucket object with keys bucket, key, version pointing to an existing zip-file to use
+	S3ExistingPackage map[string]string `json:"s3_existing_package,omitempty" hcl:"s3_existing_package,attr"`
+
+	// Whether to store produced artifacts on S3 or locally.
+	//
+	// Default: false
+	StoreOnS3 *bool `json:"store_on_s3,omitempty" hcl:"store_on_s3,attr"`
+
+	// Specifies the desired Storage Class for the artifact uploaded to S3. Can be either STANDARD, REDUCED_REDUNDANCY, ONEZONE_IA, INTELLIGENT_TIERING, or STANDARD_IA.
+	//
+	// Default: "ONEZONE_IA"
+	S3ObjectStorageClass *string `json:"s3_object_storage_class,omitempty" hcl:"s3_object_storage_class,attr"`
+
+	// S3 bucket to store artifacts
+	S3Bucket *string `json:"s3_bucket,omitempty" hcl:"s3_bucket,attr"`
+
+	// The canned ACL to apply. Valid values are private, public-read, public-read-write, aws-exec-read, authenticated-read, bucket-owner-read, and bucket-owner-full-control. Defaults to private.
+	//
+	// Default: "private"
+	S3ACL *string `json:"s3_acl,omitempty" hcl:"s3_acl,attr"`
+
+	// Specifies server-side encryption of the object in S3. Valid values are "AES256" and "aws:kms".
+	S3ServerSideEncryption *string `json:"s3_server_side_encryption,omitempty" hcl:"s3_server_side_encryption,attr"`
+
+	// Specifies a custom KMS key to use for S3 object encryption.
+	S3KMSKeyID *string `json:"s3_kms_key_id,omitempty" hcl:"s3_kms_key_id,attr"`
+
+	// The absolute path to a local file or directory containing your Lambda source code
+	SourcePath interface{} `json:"source_path,omitempty" hcl:"source_path,attr"`
+
+	// The string to add into hashing function. Useful when building same source path for different functions.
+	//
+	// Default: ""
+	HashExtra *string `json:"hash_extra,omitempty" hcl:"hash_extra,attr"`
+
+	// Whether to build dependencies in Docker
+	//
+	// Default: false
+	BuildInDocker *bool `json:"build_in_docker,omitempty" hcl:"build_in_docker,attr"`
+
+	// Path to a Dockerfile when building in Docker
+	//
+	// Default: ""
+	DockerFile *string `json:"docker_file,omitempty" hcl:"docker_file,attr"`
+
+	// Root dir where to build in Docker
+	//
+	// Default: ""
+	DockerBuildRoot *string `json:"docker_build_root,omitempty" hcl:"docker_build_root,attr"`
+
+	// Docker image to use for the build
+	//
+	// Default: ""
+	DockerImage *string `json:"docker_image,omitempty" hcl:"docker_image,attr"`
+
+	// Whether to pass SSH_AUTH_SOCK into docker environment or not
+	//
+	// Default: false
+	DockerWithSshAgent *bool `json:"docker_with_ssh_agent,omitempty" hcl:"docker_with_ssh_agent,attr"`
+
+	// Whether to mount a shared pip cache folder into docker environment or not
+	DockerPipCache interface{} `json:"docker_pip_cache,omitempty" hcl:"docker_pip_cache,attr"`
+
+	// Additional options to pass to the docker run command (e.g. to set environment variables, volumes, etc.)
+	//
+	// Default: []
+	DockerAdditionalOptions []string `json:"docker_additional_options,omitempty" hcl:"docker_additional_options,attr"`
+
+	// Path to the Docker entrypoint to use
+	DockerEntrypoint *string `json:"docker_entrypoint,omitempty" hcl:"docker_entrypoint,attr"`
+
+	// Whether to recreate missing Lambda package if it is missing locally or not
+	//
+	// Default: true
+	RecreateMissingPackage *bool `json:"recreate_missing_package,omitempty" hcl:"recreate_missing_package,attr"`
+
+	// Whether to recreate the Lambda package if the timestamp changes
+	//
+	// Default: true
+	TriggerOnPackageTimestamp *bool `json:"trigger_on_package_timestamp,omitempty" hcl:"trigger_on_package_timestamp,attr"`
+
+	// Whether to disable archive local execution output
+	//
+	// Default: true
+	QuietArchiveLocalExec *bool `json:"quiet_archive_local_exec,omitempty" hcl:"quiet_archive_local_exec,attr"`
+
+	// The log format of the Lambda Function. Valid values are "JSON" or "Text".
+	//
+	// Default: "Text"
+	LoggingLogFormat *string `json:"logging_log_format,omitempty" hcl:"logging_log_format,attr"`
+
+	// The application log level of the Lambda Function. Valid values are "TRACE", "DEBUG", "INFO", "WARN", "ERROR", or "FATAL".
+	//
+	// Default: "INFO"
+	LoggingApplicationLogLevel *string `json:"logging_application_log_level,omitempty" hcl:"logging_application_log_level,attr"`
+
+	// The system log level of the Lambda Function. Valid values are "DEBUG", "INFO", or "WARN".
+	//
+	// Default: "INFO"
+	LoggingSystemLogLevel *string `json:"logging_system_log_level,omitempty" hcl:"logging_system_log_level,attr"`
+
+	// The CloudWatch log group to send logs to.
+	LoggingLogGroup *string `json:"logging_log_group,omitempty" hcl:"logging_log_group,attr"`
+
+	// Lambda function recursion configuration. Valid values are Allow or Terminate.
+	RecursiveLoop *string `json:"recursive_loop,omitempty" hcl:"recursive_loop,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/lambda/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// LambdaFunctionARN references lambda_function_arn: The ARN of the Lambda Function
+func (o Outputs) LambdaFunctionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_arn")
+}
+
+// LambdaFunctionARNStatic references lambda_function_arn_static: The static ARN of the Lambda Function. Use this to avoid cycle errors between resources (e.g., Step Functions)
+func (o Outputs) LambdaFunctionARNStatic() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_arn_static")
+}
+
+// LambdaFunctionInvokeARN references lambda_function_invoke_arn: The Invoke ARN of the Lambda Function
+func (o Outputs) LambdaFunctionInvokeARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_invoke_arn")
+}
+
+// LambdaFunctionName references lambda_function_name: The name of the Lambda Function
+func (o Outputs) LambdaFunctionName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_name")
+}
+
+// LambdaFunctionQualifiedARN references lambda_function_qualified_arn: The ARN identifying your Lambda Function Version
+func (o Outputs) LambdaFunctionQualifiedARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_qualified_arn")
+}
+
+// LambdaFunctionQualifiedInvokeARN references lambda_function_qualified_invoke_arn: The Invoke ARN identifying your Lambda Function Version
+func (o Outputs) LambdaFunctionQualifiedInvokeARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_qualified_invoke_arn")
+}
+
+// LambdaFunctionVersion references lambda_function_version: Latest published version of Lambda Function
+func (o Outputs) LambdaFunctionVersion() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_version")
+}
+
+// LambdaFunctionLastModified references lambda_function_last_modified: The date Lambda Function resource was last modified
+func (o Outputs) LambdaFunctionLastModified() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_last_modified")
+}
+
+// LambdaFunctionKMSKeyARN references lambda_function_kms_key_arn: The ARN for the KMS encryption key of Lambda Function
+func (o Outputs) LambdaFunctionKMSKeyARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_kms_key_arn")
+}
+
+// LambdaFunctionSourceCodeHash references lambda_function_source_code_hash: Base64-encoded representation of raw SHA-256 sum of the zip file
+func (o Outputs) LambdaFunctionSourceCodeHash() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_source_code_hash")
+}
+
+// LambdaFunctionSourceCodeSize references lambda_function_source_code_size: The size in bytes of the function .zip file
+func (o Outputs) LambdaFunctionSourceCodeSize() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_source_code_size")
+}
+
+// LambdaFunctionSigningJobARN references lambda_function_signing_job_arn: ARN of the signing job
+func (o Outputs) LambdaFunctionSigningJobARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_signing_job_arn")
+}
+
+// LambdaFunctionSigningProfileVersionARN references lambda_function_signing_profile_version_arn: ARN of the signing profile version
+func (o Outputs) LambdaFunctionSigningProfileVersionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_signing_profile_version_arn")
+}
+
+// LambdaFunctionURL references lambda_function_url: The URL of the Lambda Function URL
+func (o Outputs) LambdaFunctionURL() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_url")
+}
+
+// LambdaFunctionURLID references lambda_function_url_id: The Lambda Function URL generated id
+func (o Outputs) LambdaFunctionURLID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_function_url_id")
+}
+
+// LambdaLayerARN references lambda_layer_arn: The ARN of the Lambda Layer with version
+func (o Outputs) LambdaLayerARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_layer_arn")
+}
+
+// LambdaLayerLayerARN references lambda_layer_layer_arn: The ARN of the Lambda Layer without version
+func (o Outputs) LambdaLayerLayerARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_layer_layer_arn")
+}
+
+// LambdaLayerCreatedDate references lambda_layer_created_date: The date Lambda Layer resource was created
+func (o Outputs) LambdaLayerCreatedDate() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_layer_created_date")
+}
+
+// LambdaLayerSourceCodeSize references lambda_layer_source_code_size: The size in bytes of the Lambda Layer .zip file
+func (o Outputs) LambdaLayerSourceCodeSize() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_layer_source_code_size")
+}
+
+// LambdaLayerVersion references lambda_layer_version: The Lambda Layer version
+func (o Outputs) LambdaLayerVersion() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_layer_version")
+}
+
+// LambdaEventSourceMappingARN references lambda_event_source_mapping_arn: The event source mapping ARN
+func (o Outputs) LambdaEventSourceMappingARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_event_source_mapping_arn")
+}
+
+// LambdaEventSourceMappingFunctionARN references lambda_event_source_mapping_function_arn: The the ARN of the Lambda function the event source mapping is sending events to
+func (o Outputs) LambdaEventSourceMappingFunctionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_event_source_mapping_function_arn")
+}
+
+// LambdaEventSourceMappingState references lambda_event_source_mapping_state: The state of the event source mapping
+func (o Outputs) LambdaEventSourceMappingState() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_event_source_mapping_state")
+}
+
+// LambdaEventSourceMappingStateTransitionReason references lambda_event_source_mapping_state_transition_reason: The reason the event source mapping is in its current state
+func (o Outputs) LambdaEventSourceMappingStateTransitionReason() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_event_source_mapping_state_transition_reason")
+}
+
+// LambdaEventSourceMappingUuid references lambda_event_source_mapping_uuid: The UUID of the created event source mapping
+func (o Outputs) LambdaEventSourceMappingUuid() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_event_source_mapping_uuid")
+}
+
+// LambdaRoleARN references lambda_role_arn: The ARN of the IAM role created for the Lambda Function
+func (o Outputs) LambdaRoleARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_role_arn")
+}
+
+// LambdaRoleName references lambda_role_name: The name of the IAM role created for the Lambda Function
+func (o Outputs) LambdaRoleName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_role_name")
+}
+
+// LambdaRoleUniqueID references lambda_role_unique_id: The unique id of the IAM role created for the Lambda Function
+func (o Outputs) LambdaRoleUniqueID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_role_unique_id")
+}
+
+// LambdaCloudwatchLogGroupARN references lambda_cloudwatch_log_group_arn: The ARN of the Cloudwatch Log Group
+func (o Outputs) LambdaCloudwatchLogGroupARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_cloudwatch_log_group_arn")
+}
+
+// LambdaCloudwatchLogGroupName references lambda_cloudwatch_log_group_name: The name of the Cloudwatch Log Group
+func (o Outputs) LambdaCloudwatchLogGroupName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "lambda_cloudwatch_log_group_name")
+}
+
+// LocalFilename references local_filename: The filename of zip archive deployed (if deployment was from local)
+func (o Outputs) LocalFilename() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "local_filename")
+}
+
+// S3Object references s3_object: The map with S3 object data of zip archive deployed (if deployment was from S3)
+func (o Outputs) S3Object() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_object")
+}
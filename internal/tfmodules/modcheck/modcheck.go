@@ -0,0 +1,339 @@
+// Package modcheck checks Terraform module calls against the variables of
+// the modules vendored in .forge/modules, without running terraform or
+// downloading providers.
+package modcheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/agext/levenshtein"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/lewis/forge/internal/tfmodules/codegen"
+)
+
+// Result is the outcome of checking a directory of Terraform files.
+type Result struct {
+	// Files are the parsed files, for printing diagnostics with source snippets.
+	Files map[string]*hcl.File
+	// Calls counts module blocks; Checked counts those checked against a
+	// vendored schema of a matching version.
+	Calls   int
+	Checked int
+	// Diagnostics lists every problem found, in file order.
+	Diagnostics hcl.Diagnostics
+}
+
+// metaArguments are the module block arguments Terraform handles itself.
+var metaArguments = map[string]bool{
+	"source": true, "version": true, "count": true, "for_each": true,
+	"providers": true, "depends_on": true,
+}
+
+// moduleSchema selects module blocks from a Terraform file.
+var moduleSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "module", LabelNames: []string{"name"}}},
+}
+
+// vendoredNames maps registry module names to their .forge/modules directory
+// where the two differ.
+var vendoredNames = map[string]string{
+	"dynamodb-table": "dynamodb",
+	"s3-bucket":      "s3",
+}
+
+// VendoredDir returns the .forge/modules directory name for a
+// terraform-aws-modules registry source, e.g. sqs for terraform-aws-modules/sqs/aws.
+// PURE: Calculation.
+func VendoredDir(source string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(source, "registry.terraform.io/"), "/")
+	if len(parts) != 3 || parts[0] != "terraform-aws-modules" || parts[2] != "aws" {
+		return "", false
+	}
+	if dir, ok := vendoredNames[parts[1]]; ok {
+		return dir, true
+	}
+	return parts[1], true
+}
+
+// RegistrySource returns the terraform-aws-modules registry source of a
+// .forge/modules directory, e.g. terraform-aws-modules/sqs/aws for sqs. It is
+// the inverse of VendoredDir.
+// PURE: Calculation.
+func RegistrySource(dir string) string {
+	for name, vendored := range vendoredNames {
+		if vendored == dir {
+			return "terraform-aws-modules/" + name + "/aws"
+		}
+	}
+	return "terraform-aws-modules/" + dir + "/aws"
+}
+
+// CheckDir checks every module call in the .tf and .tf.json files of
+// infraDir against the schemas vendored in modulesDir. Syntax errors and
+// schema violations are returned as diagnostics; the error is for I/O failures.
+// ACTION: Performs I/O (reads Terraform files and vendored modules).
+func CheckDir(infraDir, modulesDir string) (Result, error) {
+	entries, err := os.ReadDir(infraDir)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read %s: %w", infraDir, err)
+	}
+
+	var (
+		parser  = hclparse.NewParser()
+		result  Result
+		schemas = make(map[string]*codegen.Schema)
+	)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !(strings.HasSuffix(name, ".tf") || strings.HasSuffix(name, ".tf.json")) {
+			continue
+		}
+
+		path := filepath.Join(infraDir, name)
+		var (
+			file  *hcl.File
+			diags hcl.Diagnostics
+		)
+		if strings.HasSuffix(name, ".json") {
+			file, diags = parser.ParseJSONFile(path)
+		} else {
+			file, diags = parser.ParseHCLFile(path)
+		}
+		result.Diagnostics = append(result.Diagnostics, diags...)
+		if file == nil || diags.HasErrors() {
+			continue
+		}
+
+		content, _, diags := file.Body.PartialContent(moduleSchema)
+		result.Diagnostics = append(result.Diagnostics, diags...)
+		for _, block := range content.Blocks {
+			result.Calls++
+			schema, diags, err := lookupSchema(block, infraDir, modulesDir, schemas)
+			if err != nil {
+				return Result{}, err
+			}
+			result.Diagnostics = append(result.Diagnostics, diags...)
+			if schema == nil {
+				continue
+			}
+			if diags := checkVersion(block, *schema, modulesDir); diags != nil {
+				result.Diagnostics = append(result.Diagnostics, diags...)
+				continue
+			}
+			result.Checked++
+			result.Diagnostics = append(result.Diagnostics, CheckCall(block, *schema)...)
+		}
+	}
+
+	result.Files = parser.Files()
+	return result, nil
+}
+
+// lookupSchema loads the vendored schema for a module call's source, caching
+// it by directory. Local sources pointing into modulesDir, as written for
+// module_source = "vendored", use that module's schema. Calls to other local
+// or unknown sources have no schema; registry sources that are not vendored
+// get a warning.
+// ACTION: Performs I/O (reads the vendored module).
+func lookupSchema(block *hcl.Block, infraDir, modulesDir string, cache map[string]*codegen.Schema) (*codegen.Schema, hcl.Diagnostics, error) {
+	attrs, _ := block.Body.JustAttributes()
+	source := moduleSource(attrs)
+	dir, ok := VendoredDir(source)
+	if !ok {
+		dir, ok = localVendoredDir(source, infraDir, modulesDir)
+	}
+	if !ok {
+		return nil, nil, nil
+	}
+	if schema, ok := cache[dir]; ok {
+		return schema, nil, nil
+	}
+
+	path := filepath.Join(modulesDir, dir)
+	if _, err := os.Stat(filepath.Join(path, "variables.tf")); os.IsNotExist(err) {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagWarning,
+			Summary:  "Module not vendored",
+			Detail:   fmt.Sprintf("No schema for %q in %s, so the arguments of module %q were not checked.", source, path, block.Labels[0]),
+			Subject:  attrs["source"].Expr.Range().Ptr(),
+		}}, nil
+	}
+
+	schema, err := codegen.ParseModule(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	cache[dir] = &schema
+	return &schema, nil, nil
+}
+
+// localVendoredDir returns the modulesDir directory that a local source, read
+// from infraDir, points at, e.g. sqs for "../.forge/modules/sqs".
+// PURE: Calculation.
+func localVendoredDir(source, infraDir, modulesDir string) (string, bool) {
+	if !strings.HasPrefix(source, "./") && !strings.HasPrefix(source, "../") {
+		return "", false
+	}
+	rel, err := filepath.Rel(modulesDir, filepath.Join(infraDir, filepath.FromSlash(source)))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") || strings.ContainsRune(rel, filepath.Separator) {
+		return "", false
+	}
+	return rel, true
+}
+
+// checkVersion warns when a module call's version constraint excludes the
+// vendored release, whose variables may differ from the version Terraform
+// will install.
+// PURE: Calculation.
+func checkVersion(block *hcl.Block, schema codegen.Schema, modulesDir string) hcl.Diagnostics {
+	attrs, _ := block.Body.JustAttributes()
+	versionAttr, ok := attrs["version"]
+	if !ok || schema.Version == "" {
+		return nil
+	}
+	value, diags := versionAttr.Expr.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+		return nil
+	}
+
+	constraints, err := version.NewConstraint(value.AsString())
+	if err != nil {
+		return nil
+	}
+	vendored, err := version.NewVersion(schema.Version)
+	if err != nil || constraints.Check(vendored) {
+		return nil
+	}
+
+	dir, _ := VendoredDir(moduleSource(attrs))
+	return hcl.Diagnostics{{
+		Severity: hcl.DiagWarning,
+		Summary:  "Vendored module version differs",
+		Detail: fmt.Sprintf("%s is version %s, which does not match %q, so the arguments of module %q were not checked.",
+			filepath.Join(modulesDir, dir), schema.Version, value.AsString(), block.Labels[0]),
+		Subject: versionAttr.Expr.Range().Ptr(),
+	}}
+}
+
+// moduleSource returns the literal source of a module call, or "" when it is
+// missing or computed.
+// PURE: Calculation.
+func moduleSource(attrs hcl.Attributes) string {
+	attr, ok := attrs["source"]
+	if !ok {
+		return ""
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+		return ""
+	}
+	return value.AsString()
+}
+
+// CheckCall checks the arguments of a module block against a module schema:
+// every argument must be a variable, every required variable must be set,
+// and constant values must convert to the variable's type.
+// PURE: Calculation.
+func CheckCall(block *hcl.Block, schema codegen.Schema) hcl.Diagnostics {
+	attrs, diags := block.Body.JustAttributes()
+
+	variables := make(map[string]codegen.Variable, len(schema.Variables))
+	for _, v := range schema.Variables {
+		variables[v.Name] = v
+	}
+
+	for _, attr := range sortedAttributes(attrs) {
+		if metaArguments[attr.Name] {
+			continue
+		}
+		v, ok := variables[attr.Name]
+		if !ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Unsupported argument",
+				Detail:   unsupportedDetail(attr.Name, schema),
+				Subject:  attr.NameRange.Ptr(),
+			})
+			continue
+		}
+		if diag := checkType(attr, v); diag != nil {
+			diags = append(diags, diag)
+		}
+	}
+
+	for _, v := range schema.Variables {
+		if _, ok := attrs[v.Name]; !ok && v.Required() {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Missing required argument",
+				Detail:   fmt.Sprintf("The argument %q is required, but no definition was found.", v.Name),
+				Subject:  block.DefRange.Ptr(),
+			})
+		}
+	}
+
+	return diags
+}
+
+// checkType reports a constant argument value that can't convert to the
+// variable's type. Values that reference anything are only known at plan time
+// and are not checked.
+// PURE: Calculation.
+func checkType(attr *hcl.Attribute, v codegen.Variable) *hcl.Diagnostic {
+	if v.Type == cty.DynamicPseudoType || len(attr.Expr.Variables()) > 0 {
+		return nil
+	}
+	value, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() || !value.IsWhollyKnown() || value.IsNull() {
+		return nil
+	}
+	if _, err := convert.Convert(value, v.Type); err != nil {
+		return &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Incorrect argument type",
+			Detail:   fmt.Sprintf("The argument %q must be %s: %s.", attr.Name, typeexpr.TypeString(v.Type), err),
+			Subject:  attr.Expr.Range().Ptr(),
+		}
+	}
+	return nil
+}
+
+// unsupportedDetail explains an unknown argument, suggesting the closest
+// variable name when it looks like a typo.
+// PURE: Calculation.
+func unsupportedDetail(name string, schema codegen.Schema) string {
+	detail := fmt.Sprintf("An argument named %q is not expected here.", name)
+	best, bestDistance := "", 3
+	for _, v := range schema.Variables {
+		if d := levenshtein.Distance(name, v.Name, nil); d < bestDistance {
+			best, bestDistance = v.Name, d
+		}
+	}
+	if best != "" {
+		detail += fmt.Sprintf(" Did you mean %q?", best)
+	}
+	return detail
+}
+
+// sortedAttributes returns attributes in source order.
+// PURE: Calculation.
+func sortedAttributes(attrs hcl.Attributes) []*hcl.Attribute {
+	sorted := make([]*hcl.Attribute, 0, len(attrs))
+	for _, attr := range attrs {
+		sorted = append(sorted, attr)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Range.Start.Byte < sorted[j].Range.Start.Byte
+	})
+	return sorted
+}
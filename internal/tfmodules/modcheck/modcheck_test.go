@@ -0,0 +1,143 @@
+package modcheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// problem is the comparable part of a diagnostic.
+type problem struct {
+	Severity hcl.DiagnosticSeverity
+	Summary  string
+	Detail   string
+	Location string
+}
+
+func problems(diags hcl.Diagnostics) []problem {
+	out := make([]problem, 0, len(diags))
+	for _, d := range diags {
+		p := problem{Severity: d.Severity, Summary: d.Summary, Detail: d.Detail}
+		if d.Subject != nil {
+			p.Location = fmt.Sprintf("%s:%d", filepath.Base(d.Subject.Filename), d.Subject.Start.Line)
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+func TestCheckDir(t *testing.T) {
+	result, err := CheckDir(filepath.Join("testdata", "infra"), filepath.Join("testdata", "modules"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 6, result.Calls)
+	assert.Equal(t, 3, result.Checked, "local, unvendored and other versions are skipped")
+	assert.Contains(t, result.Files, filepath.Join("testdata", "infra", "main.tf"))
+
+	assert.Equal(t, []problem{
+		{hcl.DiagError, "Unsupported argument", `An argument named "visibilty_timeout_seconds" is not expected here. Did you mean "visibility_timeout_seconds"?`, "main.tf:6"},
+		{hcl.DiagError, "Incorrect argument type", `The argument "create_dlq" must be bool: a bool is required.`, "main.tf:7"},
+		{hcl.DiagError, "Missing required argument", `The argument "name" is required, but no definition was found.`, "main.tf:11"},
+		{hcl.DiagWarning, "Module not vendored", `No schema for "terraform-aws-modules/sns/aws" in ` + filepath.Join("testdata", "modules", "sns") + `, so the arguments of module "topic" were not checked.`, "main.tf:26"},
+		{hcl.DiagWarning, "Vendored module version differs", filepath.Join("testdata", "modules", "sqs") + ` is version 5.1.0, which does not match "~> 4.0", so the arguments of module "legacy" were not checked.`, "main.tf:32"},
+		{hcl.DiagError, "Incorrect argument type", `The argument "tags" must be map(string): map of string required.`, "queue.tf.json:6"},
+	}, problems(result.Diagnostics))
+}
+
+func TestCheckDir_VendoredSources(t *testing.T) {
+	result, err := CheckDir(filepath.Join("testdata", "vendored"), filepath.Join("testdata", "modules"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Calls)
+	assert.Equal(t, 1, result.Checked, "local sources outside the modules directory are skipped")
+	assert.Equal(t, []problem{
+		{hcl.DiagError, "Unsupported argument", `An argument named "visibilty_timeout_seconds" is not expected here. Did you mean "visibility_timeout_seconds"?`, "main.tf:5"},
+	}, problems(result.Diagnostics))
+}
+
+func TestCheckDir_Errors(t *testing.T) {
+	t.Run("fails when the directory is missing", func(t *testing.T) {
+		_, err := CheckDir(filepath.Join("testdata", "missing"), filepath.Join("testdata", "modules"))
+		assert.Error(t, err)
+	})
+
+	t.Run("reports syntax errors as diagnostics", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`module "q" {`), 0o644))
+
+		result, err := CheckDir(dir, filepath.Join("testdata", "modules"))
+		require.NoError(t, err)
+		assert.True(t, result.Diagnostics.HasErrors())
+		assert.Zero(t, result.Calls)
+	})
+
+	t.Run("reports blocks inside module calls", func(t *testing.T) {
+		dir := t.TempDir()
+		src := "module \"q\" {\n  source = \"terraform-aws-modules/sqs/aws\"\n  name   = \"q\"\n  tags {}\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(src), 0o644))
+
+		result, err := CheckDir(dir, filepath.Join("testdata", "modules"))
+		require.NoError(t, err)
+		require.Len(t, result.Diagnostics, 1)
+		assert.Contains(t, result.Diagnostics[0].Summary, "Unexpected \"tags\" block")
+	})
+}
+
+// TestCheckDir_GoldenFiles checks the rendered output of every tfmodules
+// package against the modules vendored in .forge/modules. Every package pins
+// the release it was generated from, so every call is checked.
+func TestCheckDir_GoldenFiles(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "*", "testdata", "*.golden"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	dir := t.TempDir()
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		require.NoError(t, err)
+		name := filepath.Base(filepath.Dir(filepath.Dir(path))) + "_" + filepath.Base(path) + ".tf"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), src, 0o644))
+	}
+
+	result, err := CheckDir(dir, filepath.Join("..", "..", "..", ".forge", "modules"))
+	require.NoError(t, err)
+	assert.Positive(t, result.Checked)
+	assert.Equal(t, result.Calls, result.Checked, "calls pinning another version are skipped: %s", result.Diagnostics)
+	assert.Empty(t, result.Diagnostics, "rendered modules must match their vendored variables")
+}
+
+func TestVendoredDir(t *testing.T) {
+	tests := map[string]string{
+		"terraform-aws-modules/sqs/aws":                       "sqs",
+		"registry.terraform.io/terraform-aws-modules/sns/aws": "sns",
+		"terraform-aws-modules/dynamodb-table/aws":            "dynamodb",
+		"terraform-aws-modules/s3-bucket/aws":                 "s3",
+	}
+	for source, want := range tests {
+		got, ok := VendoredDir(source)
+		assert.True(t, ok, source)
+		assert.Equal(t, want, got, source)
+	}
+
+	for _, source := range []string{"./modules/queue", "hashicorp/consul/aws", "terraform-aws-modules/sqs/google"} {
+		_, ok := VendoredDir(source)
+		assert.False(t, ok, source)
+	}
+}
+
+func TestRegistrySource(t *testing.T) {
+	for _, source := range []string{
+		"terraform-aws-modules/sqs/aws",
+		"terraform-aws-modules/dynamodb-table/aws",
+		"terraform-aws-modules/s3-bucket/aws",
+	} {
+		dir, ok := VendoredDir(source)
+		require.True(t, ok, source)
+		assert.Equal(t, source, RegistrySource(dir))
+	}
+}
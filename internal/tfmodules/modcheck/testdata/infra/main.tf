@@ -0,0 +1,35 @@
+module "orders" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "~> 5.0"
+
+  name                      = "orders"
+  visibilty_timeout_seconds = 30
+  create_dlq                = "sometimes"
+  tags                      = { Team = "payments" }
+}
+
+module "events" {
+  source = "terraform-aws-modules/sqs/aws"
+
+  create_dlq                 = var.create_dlq
+  visibility_timeout_seconds = "60"
+  redrive_policy             = { maxReceiveCount = 5 }
+  depends_on                 = [module.orders]
+}
+
+module "local" {
+  source = "./modules/local"
+  any    = "thing"
+}
+
+module "topic" {
+  source = "terraform-aws-modules/sns/aws"
+  name   = "alerts"
+}
+
+module "legacy" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "~> 4.0"
+
+  name_typo = "legacy"
+}
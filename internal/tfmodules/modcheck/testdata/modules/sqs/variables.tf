@@ -0,0 +1,24 @@
+variable "name" {
+  description = "Name of the queue"
+  type        = string
+}
+
+variable "create_dlq" {
+  type    = bool
+  default = false
+}
+
+variable "visibility_timeout_seconds" {
+  type    = number
+  default = null
+}
+
+variable "tags" {
+  type    = map(string)
+  default = {}
+}
+
+variable "redrive_policy" {
+  type    = any
+  default = {}
+}
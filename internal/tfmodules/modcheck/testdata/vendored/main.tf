@@ -0,0 +1,11 @@
+module "orders" {
+  source = "../modules/sqs"
+
+  name                      = "orders"
+  visibilty_timeout_seconds = 30
+}
+
+module "local" {
+  source = "../infra"
+  any    = "thing"
+}
@@ -3,7 +3,11 @@
 package tfmodules
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
 )
 
 // Module is the base interface that all Terraform modules must implement.
@@ -52,6 +56,15 @@ func (b BaseModule) Version() string {
 	return b.version
 }
 
+// VersionConstraint returns the version constraint typed modules pin their
+// calls with: the major release of moduleVersion, the release their generated
+// Variables were generated from, e.g. "~> 5.0" for "5.1.0".
+// PURE: Calculation.
+func VersionConstraint(moduleVersion string) string {
+	major, _, _ := strings.Cut(moduleVersion, ".")
+	return "~> " + major + ".0"
+}
+
 // Output represents a Terraform module output reference.
 // This allows type-safe references to module outputs in other resources.
 type Output struct {
@@ -70,39 +83,39 @@ func NewOutput(module Module, attribute string) Output {
 	}
 }
 
-// Example: "module.my_queue.queue_arn".
+// Ref returns the output's address, e.g. "module.my_queue.queue_arn".
 func (o Output) Ref() string {
 	return fmt.Sprintf("module.%s.%s", o.module.LocalName(), o.attribute)
 }
 
-// String returns the string representation.
+// Expr returns the output as a reference expression, so that an Output renders
+// unquoted in interface{} fields and hclgen.Template.
+func (o Output) Expr() hclgen.Expr {
+	return hclgen.Ref(o.Ref())
+}
+
+// String returns the output as a string template for string fields, e.g.
+// "${module.my_queue.queue_arn}".
 func (o Output) String() string {
-	return o.Ref()
+	return o.Expr().String()
 }
 
-// ModuleCall is a helper to generate module block HCL.
+// ModuleCall is a helper to generate module block HCL for modules without a
+// typed package.
 type ModuleCall struct {
 	Name    string
 	Source  string
 	Version string
-	Args    map[string]interface{}
+	// Args are the module's arguments: strings are string templates, so
+	// "${module.x.y}" is a reference; hclgen.Expr and Output values render as
+	// expressions; slices, maps and hcl-tagged structs nest at any depth.
+	Args map[string]interface{}
 }
 
-// ToHCL converts the module call to HCL string.
-func (m ModuleCall) ToHCL() string {
-	hcl := fmt.Sprintf("module \"%s\" {\n", m.Name)
-	hcl += fmt.Sprintf("  source  = \"%s\"\n", m.Source)
-	if m.Version != "" {
-		hcl += fmt.Sprintf("  version = \"%s\"\n", m.Version)
-	}
-	hcl += "\n"
-
-	// TODO: Convert Args to proper HCL
-	// For now, this is a simplified version
-	// Full implementation would use hclwrite or lingon's HCL marshaling
-
-	hcl += "}\n"
-	return hcl
+// ToHCL converts the module call to HCL, with arguments sorted by name.
+// PURE: Same call always renders the same HCL.
+func (m ModuleCall) ToHCL() (string, error) {
+	return hclgen.ToHCLWriteArgs(m.Name, m.Source, m.Version, m.Args)
 }
 
 // Validator provides validation for module configurations.
@@ -154,27 +167,112 @@ func (s *Stack) AddDependency(dependent, dependsOn string) {
 	s.Dependencies[dependent] = append(s.Dependencies[dependent], dependsOn)
 }
 
-// ToHCL generates HCL for all modules in the stack.
+// ToHCL generates HCL for all modules in the stack, in dependency order.
+// Explicit dependencies are rendered as depends_on; references to another
+// module's outputs already order the modules and need no depends_on.
 func (s *Stack) ToHCL() (string, error) {
-	var hcl string
+	rendered, err := s.render()
+	if err != nil {
+		return "", err
+	}
 
-	for _, m := range s.Modules {
-		config, err := m.Configuration()
+	configs := make([]string, 0, len(rendered))
+	for _, r := range rendered {
+		configs = append(configs, strings.TrimRight(r.config, "\n")+"\n")
+	}
+	return strings.Join(configs, "\n"), nil
+}
+
+// ToHCLFiles generates HCL for the stack split into one file per module,
+// keyed by "<local name>.tf". Terraform loads every file in the directory,
+// so the files together are equivalent to ToHCL.
+func (s *Stack) ToHCLFiles() (map[string]string, error) {
+	rendered, err := s.render()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(rendered))
+	for _, r := range rendered {
+		files[r.name+".tf"] = strings.TrimRight(r.config, "\n") + "\n"
+	}
+	return files, nil
+}
+
+// ToJSON generates the stack as one Terraform JSON document, the .tf.json
+// equivalent of ToHCL. References are encoded as "${...}" strings and
+// explicit dependencies as depends_on.
+func (s *Stack) ToJSON() (string, error) {
+	config, err := s.ToHCL()
+	if err != nil {
+		return "", err
+	}
+	out, err := hclgen.HCLToJSON([]byte(config), s.Name+".tf")
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// ToJSONFiles generates the stack as one Terraform JSON file per module,
+// keyed by "<local name>.tf.json".
+func (s *Stack) ToJSONFiles() (map[string]string, error) {
+	rendered, err := s.render()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string, len(rendered))
+	for _, r := range rendered {
+		out, err := hclgen.HCLToJSON([]byte(r.config), r.name+".tf")
 		if err != nil {
-			return "", fmt.Errorf("failed to generate config for %s: %w", m.LocalName(), err)
+			return nil, err
 		}
-		hcl += config + "\n\n"
+		files[r.name+".tf.json"] = string(out)
 	}
+	return files, nil
+}
 
-	return hcl, nil
+// renderedModule is one module's configuration within a stack.
+type renderedModule struct {
+	name   string
+	config string
 }
 
-// Validate validates all modules in the stack.
+// render generates each module's configuration in dependency order.
+func (s *Stack) render() ([]renderedModule, error) {
+	sorted, err := s.SortedModules()
+	if err != nil {
+		return nil, err
+	}
+
+	rendered := make([]renderedModule, 0, len(sorted))
+	for _, m := range sorted {
+		config, err := m.Configuration()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate config for %s: %w", m.LocalName(), err)
+		}
+		config, err = withDependsOn(config, m.LocalName(), s.explicitDependencies(m.LocalName()))
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, renderedModule{name: m.LocalName(), config: config})
+	}
+	return rendered, nil
+}
+
+// Validate validates all modules in the stack and checks that the
+// dependency graph is acyclic and only refers to modules in the stack.
+// Every failure is reported, joined into one error.
 func (s *Stack) Validate() error {
+	var errs []error
 	for _, m := range s.Modules {
 		if err := WithValidation(m); err != nil {
-			return fmt.Errorf("validation failed for %s: %w", m.LocalName(), err)
+			errs = append(errs, fmt.Errorf("validation failed for %s: %w", m.LocalName(), err))
 		}
 	}
-	return nil
+	if _, err := s.SortedModules(); err != nil {
+		errs = append(errs, fmt.Errorf("validation failed for stack %s: %w", s.Name, err))
+	}
+	return errors.Join(errs...)
 }
@@ -1,10 +1,14 @@
 package tfmodules
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
 )
 
 // MockModule is a test implementation of the Module interface.
@@ -174,11 +178,13 @@ func TestOutput_Ref(t *testing.T) {
 }
 
 func TestOutput_String(t *testing.T) {
-	t.Run("returns same as Ref", func(t *testing.T) {
+	t.Run("returns Ref as a string template", func(t *testing.T) {
 		module := &MockModule{name: "my_queue"}
 		output := NewOutput(module, "queue_url")
 
-		assert.Equal(t, output.Ref(), output.String())
+		assert.Equal(t, "${module.my_queue.queue_url}", output.String())
+		assert.Equal(t, "${"+output.Ref()+"}", output.Expr().String())
+		assert.NoError(t, output.Expr().Err())
 	})
 }
 
@@ -190,7 +196,8 @@ func TestModuleCall_ToHCL(t *testing.T) {
 			Version: "~> 4.0",
 		}
 
-		hcl := mc.ToHCL()
+		hcl, err := mc.ToHCL()
+		require.NoError(t, err)
 
 		assert.Contains(t, hcl, `module "my_module"`)
 		assert.Contains(t, hcl, `source  = "terraform-aws-modules/sqs/aws"`)
@@ -203,29 +210,107 @@ func TestModuleCall_ToHCL(t *testing.T) {
 			Source: "terraform-aws-modules/vpc/aws",
 		}
 
-		hcl := mc.ToHCL()
+		hcl, err := mc.ToHCL()
+		require.NoError(t, err)
 
 		assert.Contains(t, hcl, `module "my_module"`)
-		assert.Contains(t, hcl, `source  = "terraform-aws-modules/vpc/aws"`)
+		assert.Contains(t, hcl, `source = "terraform-aws-modules/vpc/aws"`)
 		assert.NotContains(t, hcl, "version")
 	})
 
 	t.Run("generates HCL with arguments map", func(t *testing.T) {
+		type rule struct {
+			Port     int      `hcl:"port,attr"`
+			Cidrs    []string `hcl:"cidrs,attr"`
+			Optional *string  `hcl:"optional,attr"`
+		}
+		vpc := &MockModule{name: "vpc"}
+
 		mc := ModuleCall{
 			Name:    "test",
 			Source:  "source",
 			Version: "1.0",
 			Args: map[string]interface{}{
-				"name": "test",
-				"size": 42,
+				"name":       "${var.namespace}test",
+				"size":       42,
+				"enabled":    true,
+				"ratio":      0.5,
+				"subnet_ids": NewOutput(vpc, "private_subnets"),
+				"zones":      []string{"a", "b"},
+				"rules":      []rule{{Port: 443, Cidrs: []string{"10.0.0.0/8"}}},
+				"tags": map[string]interface{}{
+					"Team":        "platform",
+					"cost-center": 42,
+					"with space":  "quoted key",
+					"nested":      map[string]string{"vpc": "${module.vpc.vpc_id}"},
+				},
+				"policy":  hclgen.JSONEncode(map[string]interface{}{"Resource": NewOutput(vpc, "arn")}),
+				"script":  "#!/bin/sh\necho ${var.stage}\n",
+				"literal": "module.docs",
+				"unset":   nil,
 			},
 		}
 
-		hcl := mc.ToHCL()
+		hcl, err := mc.ToHCL()
+		require.NoError(t, err)
+
+		assert.Equal(t, `module "test" {
+  source  = "source"
+  version = "1.0"
+  enabled = true
+  literal = "module.docs"
+  name    = "${var.namespace}test"
+  policy = jsonencode({
+    Resource = module.vpc.arn
+  })
+  ratio = 0.5
+  rules = [{
+    cidrs = ["10.0.0.0/8"]
+    port  = 443
+  }]
+  script     = <<EOT
+#!/bin/sh
+echo ${var.stage}
+EOT
+  size       = 42
+  subnet_ids = module.vpc.private_subnets
+  tags = {
+    Team        = "platform"
+    cost-center = 42
+    nested = {
+      vpc = module.vpc.vpc_id
+    }
+    "with space" = "quoted key"
+  }
+  zones = ["a", "b"]
+}
+`, hcl)
+	})
+
+	t.Run("renders the same HCL every time", func(t *testing.T) {
+		mc := ModuleCall{Name: "test", Source: "source", Args: map[string]interface{}{
+			"a": 1, "b": map[string]int{"z": 1, "y": 2, "x": 3}, "c": []string{"q"},
+		}}
+		first, err := mc.ToHCL()
+		require.NoError(t, err)
+		for i := 0; i < 10; i++ {
+			again, err := mc.ToHCL()
+			require.NoError(t, err)
+			assert.Equal(t, first, again)
+		}
+	})
 
-		// Args are not yet rendered (TODO in implementation)
-		assert.Contains(t, hcl, `module "test"`)
-		assert.Contains(t, hcl, "}")
+	t.Run("fails on invalid arguments", func(t *testing.T) {
+		for name, args := range map[string]map[string]interface{}{
+			"reserved name":   {"source": "other"},
+			"invalid name":    {"not valid": 1},
+			"invalid value":   {"ch": make(chan int)},
+			"invalid expr":    {"ref": hclgen.Ref("nope")},
+			"non-string keys": {"m": map[int]string{1: "a"}},
+		} {
+			_, err := ModuleCall{Name: "test", Source: "source", Args: args}.ToHCL()
+			assert.Error(t, err, name)
+		}
 	})
 }
 
@@ -486,7 +571,7 @@ func TestStack_Validate(t *testing.T) {
 		assert.Contains(t, err.Error(), "validation failed for invalid")
 	})
 
-	t.Run("stops at first validation error", func(t *testing.T) {
+	t.Run("reports the invalid module", func(t *testing.T) {
 		stack := NewStack("test")
 		validator1 := &MockValidator{
 			MockModule:  MockModule{name: "module1"},
@@ -504,6 +589,23 @@ func TestStack_Validate(t *testing.T) {
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "module1")
+		assert.NotContains(t, err.Error(), "module2")
+	})
+
+	t.Run("aggregates errors from every module and the graph", func(t *testing.T) {
+		tooSmall := errors.New("memory_size: too small")
+		stack := NewStack("test")
+		stack.AddModule(&MockValidator{MockModule: MockModule{name: "module1"}, validateErr: tooSmall})
+		stack.AddModule(&MockValidator{MockModule: MockModule{name: "module2"}, validateErr: errors.New("timeout: too large")})
+		stack.AddDependency("module1", "missing")
+
+		err := stack.Validate()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed for module1: memory_size: too small")
+		assert.Contains(t, err.Error(), "validation failed for module2: timeout: too large")
+		assert.Contains(t, err.Error(), `module "module1" depends on unknown module "missing"`)
+		assert.ErrorIs(t, err, tooSmall)
 	})
 
 	t.Run("validates mixed modules", func(t *testing.T) {
@@ -528,9 +630,9 @@ func TestStack_CompleteWorkflow(t *testing.T) {
 		stack := NewStack("serverless-app")
 
 		// Add modules
-		queue := &MockModule{name: "orders_queue", config: "# SQS config"}
-		table := &MockModule{name: "orders_table", config: "# DynamoDB config"}
-		topic := &MockModule{name: "notifications", config: "# SNS config"}
+		queue := &MockModule{name: "orders_queue", config: "# SQS config\nmodule \"orders_queue\" {}\n"}
+		table := &MockModule{name: "orders_table", config: "# DynamoDB config\nmodule \"orders_table\" {}\n"}
+		topic := &MockModule{name: "notifications", config: "# SNS config\nmodule \"notifications\" {}\n"}
 
 		stack.AddModule(queue)
 		stack.AddModule(table)
@@ -551,6 +653,12 @@ func TestStack_CompleteWorkflow(t *testing.T) {
 		assert.Contains(t, hcl, "# DynamoDB config")
 		assert.Contains(t, hcl, "# SNS config")
 
+		// Check dependency order and depends_on
+		assert.Less(t, strings.Index(hcl, "# DynamoDB config"), strings.Index(hcl, "# SQS config"))
+		assert.Less(t, strings.Index(hcl, "# SQS config"), strings.Index(hcl, "# SNS config"))
+		assert.Contains(t, hcl, "depends_on = [module.orders_table]")
+		assert.Contains(t, hcl, "depends_on = [module.orders_queue]")
+
 		// Check structure
 		assert.Len(t, stack.Modules, 3)
 		assert.Len(t, stack.Dependencies, 2)
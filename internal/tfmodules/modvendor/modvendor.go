@@ -0,0 +1,412 @@
+// Package modvendor vendors the Terraform modules bundled with forge into a
+// project's .forge/modules directory, so terraform init needs no registry
+// access. Vendored modules are recorded with their version and checksum in
+// .forge/modules.lock.hcl, and module calls are pointed at the copies.
+package modvendor
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/lewis/forge/internal/tfmodules/codegen"
+	"github.com/lewis/forge/internal/tfmodules/modcheck"
+)
+
+const (
+	// Dir is where a project's vendored modules live, relative to its root.
+	Dir = ".forge/modules"
+
+	// LockFile records the vendored modules, relative to the project root.
+	LockFile = ".forge/modules.lock.hcl"
+
+	lockHeader = `# Generated by "forge modules vendor". DO NOT EDIT.
+#
+# Records the version and checksum of every module in .forge/modules, checked
+# by "forge modules verify".
+`
+)
+
+// Module is a vendored module, as recorded in the lock file.
+type Module struct {
+	// Name is the module's directory in .forge/modules, e.g. sqs.
+	Name string `hcl:"name,label"`
+	// Source is the registry source the module replaces, e.g. terraform-aws-modules/sqs/aws.
+	Source string `hcl:"source"`
+	// Version is the module's release, from its CHANGELOG.md, or "".
+	Version string `hcl:"version"`
+	// Checksum is the Checksum of the module's directory.
+	Checksum string `hcl:"checksum"`
+}
+
+// Lock is the content of the lock file.
+type Lock struct {
+	Modules []Module `hcl:"module,block"`
+}
+
+// Module returns the locked module named name.
+// PURE: Calculation.
+func (l Lock) Module(name string) (Module, bool) {
+	for _, m := range l.Modules {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return Module{}, false
+}
+
+// Status is the result of verifying one vendored module.
+type Status struct {
+	Module
+	// Problem says why the vendored copy doesn't match the lock file, e.g.
+	// "missing", or is "" when it matches.
+	Problem string
+}
+
+// Bundled describes every module in bundle, one directory per module, sorted
+// by name.
+// ACTION: Performs I/O (reads the bundle).
+func Bundled(bundle fs.FS) ([]Module, error) {
+	entries, err := fs.ReadDir(bundle, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundled modules: %w", err)
+	}
+
+	modules := make([]Module, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		module, err := describe(bundle, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// describe reads the version and checksum of the module in dir.
+// ACTION: Performs I/O (reads the module's files).
+func describe(fsys fs.FS, dir string) (Module, error) {
+	moduleFS, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return Module{}, err
+	}
+	checksum, err := Checksum(moduleFS)
+	if err != nil {
+		return Module{}, fmt.Errorf("failed to checksum module %s: %w", dir, err)
+	}
+	changelog, err := fs.ReadFile(moduleFS, "CHANGELOG.md")
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return Module{}, fmt.Errorf("failed to read module %s: %w", dir, err)
+	}
+	return Module{
+		Name:     dir,
+		Source:   modcheck.RegistrySource(dir),
+		Version:  codegen.ChangelogVersion(changelog),
+		Checksum: checksum,
+	}, nil
+}
+
+// Checksum hashes every file in fsys with its path, so renaming, adding or
+// removing a file changes it too, e.g. "sha256:9f86d0...".
+// ACTION: Performs I/O (reads files).
+func Checksum(fsys fs.FS) (string, error) {
+	summary := sha256.New()
+	err := fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		fmt.Fprintf(summary, "%x  %s\n", sum, path)
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(summary.Sum(nil)), nil
+}
+
+// Vendor copies the named modules, or every module when names is empty, from
+// bundle into projectRoot's .forge/modules and records them in the lock file.
+// A module's previous copy is replaced; other vendored modules are kept.
+// ACTION: Performs I/O (writes .forge/modules and the lock file).
+func Vendor(bundle fs.FS, projectRoot string, names []string) ([]Module, error) {
+	bundled, err := Bundled(bundle)
+	if err != nil {
+		return nil, err
+	}
+	available := make(map[string]Module, len(bundled))
+	for _, m := range bundled {
+		available[m.Name] = m
+	}
+
+	selected := bundled
+	if len(names) > 0 {
+		selected = make([]Module, 0, len(names))
+		for _, name := range names {
+			m, ok := available[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown module %q: forge bundles %s", name, strings.Join(moduleNames(bundled), ", "))
+			}
+			selected = append(selected, m)
+		}
+	}
+
+	lock, err := ReadLock(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range selected {
+		if err := copyModule(bundle, m.Name, filepath.Join(projectRoot, filepath.FromSlash(Dir), m.Name)); err != nil {
+			return nil, err
+		}
+		lock = lock.with(m)
+	}
+
+	if err := WriteLock(projectRoot, lock); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// with returns l recording m, replacing any module of the same name.
+// PURE: Calculation.
+func (l Lock) with(m Module) Lock {
+	modules := make([]Module, 0, len(l.Modules)+1)
+	for _, existing := range l.Modules {
+		if existing.Name != m.Name {
+			modules = append(modules, existing)
+		}
+	}
+	modules = append(modules, m)
+	sort.Slice(modules, func(i, j int) bool { return modules[i].Name < modules[j].Name })
+	return Lock{Modules: modules}
+}
+
+// copyModule replaces dst with a copy of the module dir in bundle.
+// ACTION: Performs I/O (removes and writes files).
+func copyModule(bundle fs.FS, dir, dst string) error {
+	if err := os.RemoveAll(dst); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", dst, err)
+	}
+	moduleFS, err := fs.Sub(bundle, dir)
+	if err != nil {
+		return err
+	}
+	return fs.WalkDir(moduleFS, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, filepath.FromSlash(path))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		data, err := fs.ReadFile(moduleFS, path)
+		if err != nil {
+			return err
+		}
+		//nolint:gosec // Vendored modules are project files
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		return nil
+	})
+}
+
+// ReadLock reads projectRoot's lock file. A missing lock file is an empty lock.
+// ACTION: Performs I/O (reads the lock file).
+func ReadLock(projectRoot string) (Lock, error) {
+	path := filepath.Join(projectRoot, filepath.FromSlash(LockFile))
+	src, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Lock{}, nil
+	}
+	if err != nil {
+		return Lock{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var lock Lock
+	if err := hclsimple.Decode(path, src, nil, &lock); err != nil {
+		return Lock{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return lock, nil
+}
+
+// WriteLock writes lock to projectRoot's lock file.
+// ACTION: Performs I/O (writes the lock file).
+func WriteLock(projectRoot string, lock Lock) error {
+	f := hclwrite.NewEmptyFile()
+	for i, m := range lock.Modules {
+		if i > 0 {
+			f.Body().AppendNewline()
+		}
+		f.Body().AppendBlock(gohcl.EncodeAsBlock(m, "module"))
+	}
+
+	path := filepath.Join(projectRoot, filepath.FromSlash(LockFile))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	content := append([]byte(lockHeader+"\n"), hclwrite.Format(f.Bytes())...)
+	//nolint:gosec // The lock file is a project file
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Verify compares projectRoot's .forge/modules with its lock file. Every
+// locked module and every vendored directory gets a Status, sorted by name.
+// ACTION: Performs I/O (reads .forge/modules and the lock file).
+func Verify(projectRoot string) ([]Status, error) {
+	lock, err := ReadLock(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(projectRoot, filepath.FromSlash(Dir))
+	vendored := os.DirFS(dir)
+
+	var statuses []Status
+	for _, m := range lock.Modules {
+		status := Status{Module: m}
+		actual, err := describe(vendored, m.Name)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			status.Problem = "missing"
+		case err != nil:
+			return nil, err
+		case actual.Checksum != m.Checksum:
+			status.Problem = "modified since it was vendored"
+		}
+		statuses = append(statuses, status)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	for _, entry := range entries {
+		if _, ok := lock.Module(entry.Name()); ok || !entry.IsDir() {
+			continue
+		}
+		actual, err := describe(vendored, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, Status{Module: actual, Problem: "not in " + LockFile})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses, nil
+}
+
+// RewriteSources points the module calls in src whose registry source is
+// vendored in lock at their copy under modulesPath, the path from src's
+// directory to .forge/modules, e.g. "../.forge/modules" for infra/*.tf. Their
+// version is removed, since Terraform rejects one on local sources, once the
+// vendored release is checked against it. Calls to a terraform-aws-modules
+// source that is not vendored, or whose version excludes the vendored release,
+// are an error: the project asked not to reach the registry.
+// PURE: Calculation.
+func RewriteSources(src []byte, filename string, lock Lock, modulesPath string) ([]byte, error) {
+	f, diags := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, diags)
+	}
+
+	for _, block := range f.Body().Blocks() {
+		if block.Type() != "module" || len(block.Labels()) != 1 {
+			continue
+		}
+		source, ok := literalString(block.Body().GetAttribute("source"))
+		if !ok {
+			continue
+		}
+		dir, ok := modcheck.VendoredDir(source)
+		if !ok {
+			continue
+		}
+		vendored, ok := lock.Module(dir)
+		if !ok {
+			return nil, fmt.Errorf("%s: module %q uses %s, which is not vendored: run \"forge modules vendor %s\"",
+				filename, block.Labels()[0], source, dir)
+		}
+		if err := checkVersion(block, vendored); err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		block.Body().SetAttributeValue("source", cty.StringVal(strings.TrimSuffix(modulesPath, "/")+"/"+dir))
+		block.Body().RemoveAttribute("version")
+	}
+
+	return hclwrite.Format(f.Bytes()), nil
+}
+
+// checkVersion fails when a module call's version constraint excludes the
+// vendored release, which would otherwise silently replace the one it pins.
+// PURE: Calculation.
+func checkVersion(block *hclwrite.Block, vendored Module) error {
+	pinned, ok := literalString(block.Body().GetAttribute("version"))
+	if !ok || vendored.Version == "" {
+		return nil
+	}
+	constraints, err := version.NewConstraint(pinned)
+	if err != nil {
+		return fmt.Errorf("module %q has an invalid version %q: %w", block.Labels()[0], pinned, err)
+	}
+	release, err := version.NewVersion(vendored.Version)
+	if err != nil {
+		return fmt.Errorf("%s/%s has an invalid version %q: %w", Dir, vendored.Name, vendored.Version, err)
+	}
+	if !constraints.Check(release) {
+		return fmt.Errorf("module %q pins version %q, but %s/%s is %s: update the version or vendor a matching release",
+			block.Labels()[0], pinned, Dir, vendored.Name, vendored.Version)
+	}
+	return nil
+}
+
+// literalString returns the value of an attribute holding a literal string.
+// PURE: Calculation.
+func literalString(attr *hclwrite.Attribute) (string, bool) {
+	if attr == nil {
+		return "", false
+	}
+	expr, diags := hclsyntax.ParseExpression(attr.Expr().BuildTokens(nil).Bytes(), "source", hcl.InitialPos)
+	if diags.HasErrors() {
+		return "", false
+	}
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+		return "", false
+	}
+	return value.AsString(), true
+}
+
+// moduleNames returns the names of modules.
+// PURE: Calculation.
+func moduleNames(modules []Module) []string {
+	names := make([]string, 0, len(modules))
+	for _, m := range modules {
+		names = append(names, m.Name)
+	}
+	return names
+}
@@ -0,0 +1,260 @@
+package modvendor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge"
+)
+
+// bundle is a small stand-in for the modules bundled with forge.
+var bundle = fstest.MapFS{
+	"sqs/main.tf":               {Data: []byte("resource \"aws_sqs_queue\" \"this\" {}\n")},
+	"sqs/CHANGELOG.md":          {Data: []byte("# Changelog\n\n## [5.1.0](https://example.com) (2025-01-01)\n")},
+	"dynamodb/main.tf":          {Data: []byte("resource \"aws_dynamodb_table\" \"this\" {}\n")},
+	"dynamodb/CHANGELOG.md":     {Data: []byte("## [4.2.0](https://example.com)\n")},
+	"dynamodb/scripts/extra.py": {Data: []byte("print('hi')\n")},
+}
+
+func TestBundled(t *testing.T) {
+	modules, err := Bundled(bundle)
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	assert.Equal(t, "dynamodb", modules[0].Name)
+	assert.Equal(t, "terraform-aws-modules/dynamodb-table/aws", modules[0].Source)
+	assert.Equal(t, "4.2.0", modules[0].Version)
+	assert.Equal(t, "sqs", modules[1].Name)
+	assert.Equal(t, "5.1.0", modules[1].Version)
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, modules[1].Checksum)
+
+	t.Run("describes the modules bundled with forge", func(t *testing.T) {
+		modules, err := Bundled(forge.Modules())
+		require.NoError(t, err)
+		require.NotEmpty(t, modules)
+		for _, m := range modules {
+			assert.NotEmpty(t, m.Version, m.Name)
+		}
+		assert.Contains(t, moduleNames(modules), "sqs")
+		assert.Contains(t, moduleNames(modules), "lambda")
+	})
+}
+
+func TestChecksum(t *testing.T) {
+	base, err := Checksum(fstest.MapFS{"a.tf": {Data: []byte("a")}})
+	require.NoError(t, err)
+
+	same, err := Checksum(fstest.MapFS{"a.tf": {Data: []byte("a")}})
+	require.NoError(t, err)
+	assert.Equal(t, base, same)
+
+	for name, fsys := range map[string]fstest.MapFS{
+		"changed content": {"a.tf": {Data: []byte("b")}},
+		"renamed file":    {"b.tf": {Data: []byte("a")}},
+		"added file":      {"a.tf": {Data: []byte("a")}, "b.tf": {Data: []byte("")}},
+	} {
+		sum, err := Checksum(fsys)
+		require.NoError(t, err, name)
+		assert.NotEqual(t, base, sum, name)
+	}
+}
+
+func TestVendor(t *testing.T) {
+	root := t.TempDir()
+
+	vendored, err := Vendor(bundle, root, []string{"sqs"})
+	require.NoError(t, err)
+	require.Len(t, vendored, 1)
+
+	data, err := os.ReadFile(filepath.Join(root, ".forge", "modules", "sqs", "main.tf"))
+	require.NoError(t, err)
+	assert.Equal(t, "resource \"aws_sqs_queue\" \"this\" {}\n", string(data))
+	assert.NoDirExists(t, filepath.Join(root, ".forge", "modules", "dynamodb"))
+
+	lock, err := os.ReadFile(filepath.Join(root, ".forge", "modules.lock.hcl"))
+	require.NoError(t, err)
+	assert.Equal(t, `# Generated by "forge modules vendor". DO NOT EDIT.
+#
+# Records the version and checksum of every module in .forge/modules, checked
+# by "forge modules verify".
+
+module "sqs" {
+  source   = "terraform-aws-modules/sqs/aws"
+  version  = "5.1.0"
+  checksum = "`+vendored[0].Checksum+`"
+}
+`, string(lock))
+
+	t.Run("adds modules to the lock file", func(t *testing.T) {
+		_, err := Vendor(bundle, root, nil)
+		require.NoError(t, err)
+
+		lock, err := ReadLock(root)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"dynamodb", "sqs"}, moduleNames(lock.Modules))
+		assert.FileExists(t, filepath.Join(root, ".forge", "modules", "dynamodb", "scripts", "extra.py"))
+	})
+
+	t.Run("replaces the previous copy", func(t *testing.T) {
+		stray := filepath.Join(root, ".forge", "modules", "sqs", "stray.tf")
+		require.NoError(t, os.WriteFile(stray, []byte("x"), 0o644))
+
+		_, err := Vendor(bundle, root, []string{"sqs"})
+		require.NoError(t, err)
+		assert.NoFileExists(t, stray)
+	})
+
+	t.Run("rejects unknown modules", func(t *testing.T) {
+		_, err := Vendor(bundle, root, []string{"vpc"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown module "vpc": forge bundles dynamodb, sqs`)
+	})
+}
+
+func TestReadLock(t *testing.T) {
+	t.Run("a missing lock file is empty", func(t *testing.T) {
+		lock, err := ReadLock(t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, lock.Modules)
+	})
+
+	t.Run("fails on an invalid lock file", func(t *testing.T) {
+		root := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(root, ".forge"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, ".forge", "modules.lock.hcl"), []byte(`module "sqs" {`), 0o644))
+
+		_, err := ReadLock(root)
+		assert.Error(t, err)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	root := t.TempDir()
+	_, err := Vendor(bundle, root, nil)
+	require.NoError(t, err)
+
+	statuses, err := Verify(root)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	for _, s := range statuses {
+		assert.Empty(t, s.Problem, s.Name)
+	}
+
+	modules := filepath.Join(root, ".forge", "modules")
+	require.NoError(t, os.WriteFile(filepath.Join(modules, "sqs", "main.tf"), []byte("# edited\n"), 0o644))
+	require.NoError(t, os.RemoveAll(filepath.Join(modules, "dynamodb")))
+	require.NoError(t, os.MkdirAll(filepath.Join(modules, "vpc"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(modules, "vpc", "main.tf"), []byte("\n"), 0o644))
+
+	statuses, err = Verify(root)
+	require.NoError(t, err)
+	problems := make(map[string]string, len(statuses))
+	for _, s := range statuses {
+		problems[s.Name] = s.Problem
+	}
+	assert.Equal(t, map[string]string{
+		"dynamodb": "missing",
+		"sqs":      "modified since it was vendored",
+		"vpc":      "not in .forge/modules.lock.hcl",
+	}, problems)
+}
+
+func TestRewriteSources(t *testing.T) {
+	lock := Lock{Modules: []Module{{Name: "sqs", Version: "5.1.0"}, {Name: "dynamodb", Version: "5.2.0"}}}
+	src := `# Orders queue
+module "orders" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "~> 5.0"
+
+  name = "${var.namespace}orders"
+}
+
+module "table" {
+  source  = "registry.terraform.io/terraform-aws-modules/dynamodb-table/aws"
+  version = ">= 5.0, < 6.0"
+  name    = "table"
+}
+
+module "local" {
+  source = "./modules/local"
+}
+
+module "other" {
+  source  = "hashicorp/consul/aws"
+  version = "0.1.0"
+}
+`
+
+	got, err := RewriteSources([]byte(src), "main.tf", lock, "../.forge/modules")
+	require.NoError(t, err)
+	assert.Equal(t, `# Orders queue
+module "orders" {
+  source = "../.forge/modules/sqs"
+
+  name = "${var.namespace}orders"
+}
+
+module "table" {
+  source = "../.forge/modules/dynamodb"
+  name   = "table"
+}
+
+module "local" {
+  source = "./modules/local"
+}
+
+module "other" {
+  source  = "hashicorp/consul/aws"
+  version = "0.1.0"
+}
+`, string(got))
+
+	t.Run("fails on modules that are not vendored", func(t *testing.T) {
+		_, err := RewriteSources([]byte(src), "main.tf", Lock{Modules: []Module{{Name: "sqs"}}}, "../.forge/modules")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `main.tf: module "table" uses registry.terraform.io/terraform-aws-modules/dynamodb-table/aws, which is not vendored: run "forge modules vendor dynamodb"`)
+	})
+
+	t.Run("fails on versions the vendored release does not match", func(t *testing.T) {
+		src := `module "orders" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "~> 4.0"
+}
+`
+		_, err := RewriteSources([]byte(src), "main.tf", lock, "../.forge/modules")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `main.tf: module "orders" pins version "~> 4.0", but .forge/modules/sqs is 5.1.0`)
+	})
+
+	t.Run("fails on invalid versions", func(t *testing.T) {
+		src := `module "orders" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "latest"
+}
+`
+		_, err := RewriteSources([]byte(src), "main.tf", lock, "../.forge/modules")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `module "orders" has an invalid version "latest"`)
+	})
+
+	t.Run("accepts any version when the release is unknown", func(t *testing.T) {
+		src := `module "orders" {
+  source  = "terraform-aws-modules/sqs/aws"
+  version = "~> 4.0"
+}
+`
+		got, err := RewriteSources([]byte(src), "main.tf", Lock{Modules: []Module{{Name: "sqs"}}}, "../.forge/modules")
+		require.NoError(t, err)
+		assert.NotContains(t, string(got), "version")
+	})
+
+	t.Run("fails on invalid syntax", func(t *testing.T) {
+		_, err := RewriteSources([]byte(`module "x" {`), "broken.tf", lock, "../.forge/modules")
+		assert.Error(t, err)
+	})
+}
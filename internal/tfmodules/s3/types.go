@@ -1,9 +1,16 @@
 // Package s3 provides type-safe Terraform module definitions for terraform-aws-modules/s3-bucket/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-s3-bucket v4.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/s3 (see variables_gen.go).
 package s3
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module s3 -source terraform-aws-modules/s3-bucket/aws
+
 import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
 	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
 )
 
 // Module represents the terraform-aws-modules/s3-bucket/aws module.
@@ -290,6 +297,9 @@ type Module struct {
 	// MetadataJournalTableRecordExpiration is the journal record expiration state
 	// Valid values: "ENABLED" | "DISABLED"
 	MetadataJournalTableRecordExpiration *string `json:"metadata_journal_table_record_expiration,omitempty" hcl:"metadata_journal_table_record_expiration,attr"`
+
+	// localName overrides the local identifier derived from Bucket.
+	localName string
 }
 
 // Grant represents an ACL policy grant.
@@ -310,7 +320,7 @@ type Grant struct {
 // NewModule creates a new S3 module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/s3-bucket/aws"
-	version := "~> 4.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	blockPublicACLs := true
 	blockPublicPolicy := true
@@ -416,6 +426,13 @@ func (m *Module) WithLifecycleRule(rule map[string]interface{}) *Module {
 	return m
 }
 
+// WithLocalName sets the local identifier of the module call, for buckets
+// whose bucket is not a valid Terraform identifier.
+func (m *Module) WithLocalName(name string) *Module {
+	m.localName = name
+	return m
+}
+
 // WithTags adds tags to the bucket.
 func (m *Module) WithTags(tags map[string]string) *Module {
 	if m.Tags == nil {
@@ -429,14 +446,27 @@ func (m *Module) WithTags(tags map[string]string) *Module {
 
 // LocalName returns the local identifier for this module instance.
 func (m *Module) LocalName() string {
+	if m.localName != "" {
+		return m.localName
+	}
 	if m.Bucket != nil {
 		return *m.Bucket
 	}
 	return "s3_bucket"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 // PURE: Same module configuration always produces the same HCL output.
 func (m *Module) Configuration() (string, error) {
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid s3 module %s: %w", m.LocalName(), err)
+	}
 	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
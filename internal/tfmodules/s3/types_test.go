@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -14,7 +16,7 @@ func TestNewModule(t *testing.T) {
 
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/s3-bucket/aws", module.Source)
-		assert.Equal(t, "~> 4.0", module.Version)
+		assert.Equal(t, "~> 5.0", module.Version)
 		assert.NotNil(t, module.Bucket)
 		assert.Equal(t, name, *module.Bucket)
 
@@ -285,6 +287,13 @@ func TestModule_LocalName(t *testing.T) {
 
 		assert.Equal(t, "s3_bucket", module.LocalName())
 	})
+
+	t.Run("returns local name when set", func(t *testing.T) {
+		module := NewModule("${var.namespace}uploads").WithLocalName("uploads")
+
+		assert.Equal(t, "uploads", module.LocalName())
+		assert.Equal(t, "module.uploads.s3_bucket_arn", module.Outputs().S3BucketARN().Ref())
+	})
 }
 
 func TestModule_Configuration(t *testing.T) {
@@ -294,15 +303,7 @@ func TestModule_Configuration(t *testing.T) {
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.NotEmpty(t, config)
-
-		// Verify basic structure
-		assert.Contains(t, config, `module "test-bucket" {`)
-		assert.Contains(t, config, `source  = "terraform-aws-modules/s3-bucket/aws"`)
-		assert.Contains(t, config, `version = "~> 4.0"`)
-		assert.Contains(t, config, `bucket = "test-bucket"`)
-		assert.Contains(t, config, `block_public_acls = true`)
-		assert.Contains(t, config, `object_ownership = "BucketOwnerEnforced"`)
+		golden.Assert(t, "basic_bucket", config)
 	})
 
 	t.Run("generates HCL with all options", func(t *testing.T) {
@@ -316,11 +317,7 @@ func TestModule_Configuration(t *testing.T) {
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.Contains(t, config, `bucket = "assets"`)
-		assert.Contains(t, config, `enabled = "true"`)
-		assert.Contains(t, config, `index_document = "index.html"`)
-		assert.Contains(t, config, `error_document = "error.html"`)
-		assert.Contains(t, config, `Environment = "production"`)
+		golden.Assert(t, "all_options", config)
 	})
 }
 
@@ -0,0 +1,405 @@
+// Code generated by tfmodules-gen from .forge/modules/s3. DO NOT EDIT.
+
+package s3
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/s3-bucket/aws these types were generated from.
+const ModuleVersion = "5.8.2"
+
+// Variables holds every input declared by terraform-aws-modules/s3-bucket/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Controls if S3 bucket should be created
+	//
+	// Default: true
+	CreateBucket *bool `json:"create_bucket,omitempty" hcl:"create_bucket,attr"`
+
+	// Controls if S3 bucket should have ELB log delivery policy attached
+	//
+	// Default: false
+	AttachElbLogDeliveryPolicy *bool `json:"attach_elb_log_delivery_policy,omitempty" hcl:"attach_elb_log_delivery_policy,attr"`
+
+	// Controls if S3 bucket should have ALB/NLB log delivery policy attached
+	//
+	// Default: false
+	AttachLbLogDeliveryPolicy *bool `json:"attach_lb_log_delivery_policy,omitempty" hcl:"attach_lb_log_delivery_policy,attr"`
+
+	// Controls if S3 bucket should have S3 access log delivery policy attached
+	//
+	// Default: false
+	AttachAccessLogDeliveryPolicy *bool `json:"attach_access_log_delivery_policy,omitempty" hcl:"attach_access_log_delivery_policy,attr"`
+
+	// Controls if S3 bucket should have CloudTrail log delivery policy attached
+	//
+	// Default: false
+	AttachCloudtrailLogDeliveryPolicy *bool `json:"attach_cloudtrail_log_delivery_policy,omitempty" hcl:"attach_cloudtrail_log_delivery_policy,attr"`
+
+	// Controls if S3 bucket should have deny non-SSL transport policy attached
+	//
+	// Default: false
+	AttachDenyInsecureTransportPolicy *bool `json:"attach_deny_insecure_transport_policy,omitempty" hcl:"attach_deny_insecure_transport_policy,attr"`
+
+	// Controls if S3 bucket should require the latest version of TLS
+	//
+	// Default: false
+	AttachRequireLatestTLSPolicy *bool `json:"attach_require_latest_tls_policy,omitempty" hcl:"attach_require_latest_tls_policy,attr"`
+
+	// Controls if S3 bucket should have bucket policy attached (set to `true` to use value of `policy` as bucket policy)
+	//
+	// Default: false
+	AttachPolicy *bool `json:"attach_policy,omitempty" hcl:"attach_policy,attr"`
+
+	// Controls if a user defined public bucket policy will be attached (set to `false` to allow upstream to apply defaults to the bucket)
+	//
+	// Default: true
+	AttachPublicPolicy *bool `json:"attach_public_policy,omitempty" hcl:"attach_public_policy,attr"`
+
+	// Controls if S3 bucket should have bucket inventory destination policy attached.
+	//
+	// Default: false
+	AttachInventoryDestinationPolicy *bool `json:"attach_inventory_destination_policy,omitempty" hcl:"attach_inventory_destination_policy,attr"`
+
+	// Controls if S3 bucket should have bucket analytics destination policy attached.
+	//
+	// Default: false
+	AttachAnalyticsDestinationPolicy *bool `json:"attach_analytics_destination_policy,omitempty" hcl:"attach_analytics_destination_policy,attr"`
+
+	// Controls if S3 bucket should deny incorrect encryption headers policy attached.
+	//
+	// Default: false
+	AttachDenyIncorrectEncryptionHeaders *bool `json:"attach_deny_incorrect_encryption_headers,omitempty" hcl:"attach_deny_incorrect_encryption_headers,attr"`
+
+	// Controls if S3 bucket policy should deny usage of incorrect KMS key SSE.
+	//
+	// Default: false
+	AttachDenyIncorrectKMSKeySSE *bool `json:"attach_deny_incorrect_kms_key_sse,omitempty" hcl:"attach_deny_incorrect_kms_key_sse,attr"`
+
+	// The ARN of KMS key which should be allowed in PutObject
+	AllowedKMSKeyARN *string `json:"allowed_kms_key_arn,omitempty" hcl:"allowed_kms_key_arn,attr"`
+
+	// Controls if S3 bucket should deny unencrypted object uploads policy attached.
+	//
+	// Default: false
+	AttachDenyUnencryptedObjectUploads *bool `json:"attach_deny_unencrypted_object_uploads,omitempty" hcl:"attach_deny_unencrypted_object_uploads,attr"`
+
+	// Controls if S3 bucket should deny SSEC encrypted object uploads.
+	//
+	// Default: false
+	AttachDenySsecEncryptedObjectUploads *bool `json:"attach_deny_ssec_encrypted_object_uploads,omitempty" hcl:"attach_deny_ssec_encrypted_object_uploads,attr"`
+
+	// Controls if S3 bucket should have WAF log delivery policy attached
+	//
+	// Default: false
+	AttachWAFLogDeliveryPolicy *bool `json:"attach_waf_log_delivery_policy,omitempty" hcl:"attach_waf_log_delivery_policy,attr"`
+
+	// Region where the resource(s) will be managed. Defaults to the region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+
+	// (Optional, Forces new resource) The name of the bucket. If omitted, Terraform will assign a random, unique name.
+	Bucket *string `json:"bucket,omitempty" hcl:"bucket,attr"`
+
+	// (Optional, Forces new resource) Creates a unique bucket name beginning with the specified prefix. Conflicts with bucket.
+	BucketPrefix *string `json:"bucket_prefix,omitempty" hcl:"bucket_prefix,attr"`
+
+	// (Optional) The canned ACL to apply. Conflicts with `grant`
+	ACL *string `json:"acl,omitempty" hcl:"acl,attr"`
+
+	// (Optional) A valid bucket policy JSON document. Note that if the policy document is not specific enough (but still valid), Terraform may view the policy as constantly changing in a terraform plan. In this case, please make sure you use the verbose/specific version of the policy. For more information about building AWS IAM policy documents with Terraform, see the AWS IAM Policy Document Guide.
+	Policy *string `json:"policy,omitempty" hcl:"policy,attr"`
+
+	// (Optional) A mapping of tags to assign to the bucket.
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// (Optional, Default:false ) A boolean that indicates all objects should be deleted from the bucket so that the bucket can be destroyed without error. These objects are not recoverable.
+	//
+	// Default: false
+	ForceDestroy *bool `json:"force_destroy,omitempty" hcl:"force_destroy,attr"`
+
+	// (Optional) Sets the accelerate configuration of an existing bucket. Can be Enabled or Suspended.
+	AccelerationStatus *string `json:"acceleration_status,omitempty" hcl:"acceleration_status,attr"`
+
+	// (Optional) Specifies who should bear the cost of Amazon S3 data transfer. Can be either BucketOwner or Requester. By default, the owner of the S3 bucket would incur the costs of any data transfer. See Requester Pays Buckets developer guide for more information.
+	RequestPayer *string `json:"request_payer,omitempty" hcl:"request_payer,attr"`
+
+	// Map containing static web-site hosting or redirect configuration.
+	//
+	// Default: {}
+	Website interface{} `json:"website,omitempty" hcl:"website,attr"`
+
+	// List of maps containing rules for Cross-Origin Resource Sharing.
+	//
+	// Default: []
+	CORSRule interface{} `json:"cors_rule,omitempty" hcl:"cors_rule,attr"`
+
+	// Map containing versioning configuration.
+	//
+	// Default: {}
+	Versioning map[string]string `json:"versioning,omitempty" hcl:"versioning,attr"`
+
+	// Map containing access bucket logging configuration.
+	//
+	// Default: {}
+	Logging interface{} `json:"logging,omitempty" hcl:"logging,attr"`
+
+	// (Optional) List of S3 bucket ARNs which should be allowed to deliver access logs to this bucket.
+	//
+	// Default: []
+	AccessLogDeliveryPolicySourceBuckets []string `json:"access_log_delivery_policy_source_buckets,omitempty" hcl:"access_log_delivery_policy_source_buckets,attr"`
+
+	// (Optional) List of AWS Account IDs should be allowed to deliver access logs to this bucket.
+	//
+	// Default: []
+	AccessLogDeliveryPolicySourceAccounts []string `json:"access_log_delivery_policy_source_accounts,omitempty" hcl:"access_log_delivery_policy_source_accounts,attr"`
+
+	// (Optional) List of AWS Organization IDs should be allowed to deliver access logs to this bucket.
+	//
+	// Default: []
+	AccessLogDeliveryPolicySourceOrganizations []string `json:"access_log_delivery_policy_source_organizations,omitempty" hcl:"access_log_delivery_policy_source_organizations,attr"`
+
+	// (Optional) List of AWS Organization IDs should be allowed to deliver ALB/NLB logs to this bucket.
+	//
+	// Default: []
+	LbLogDeliveryPolicySourceOrganizations []string `json:"lb_log_delivery_policy_source_organizations,omitempty" hcl:"lb_log_delivery_policy_source_organizations,attr"`
+
+	// An ACL policy grant. Conflicts with `acl`
+	//
+	// Default: []
+	Grant interface{} `json:"grant,omitempty" hcl:"grant,attr"`
+
+	// Bucket owner's display name and ID. Conflicts with `acl`
+	//
+	// Default: {}
+	Owner map[string]string `json:"owner,omitempty" hcl:"owner,attr"`
+
+	// The account ID of the expected bucket owner
+	ExpectedBucketOwner *string `json:"expected_bucket_owner,omitempty" hcl:"expected_bucket_owner,attr"`
+
+	// The default minimum object size behavior applied to the lifecycle configuration. Valid values: all_storage_classes_128K (default), varies_by_storage_class
+	TransitionDefaultMinimumObjectSize *string `json:"transition_default_minimum_object_size,omitempty" hcl:"transition_default_minimum_object_size,attr"`
+
+	// List of maps containing configuration of object lifecycle management.
+	//
+	// Default: []
+	LifecycleRule interface{} `json:"lifecycle_rule,omitempty" hcl:"lifecycle_rule,attr"`
+
+	// Map containing cross-region replication configuration.
+	//
+	// Default: {}
+	ReplicationConfiguration interface{} `json:"replication_configuration,omitempty" hcl:"replication_configuration,attr"`
+
+	// Map containing server-side encryption configuration.
+	//
+	// Default: {}
+	ServerSideEncryptionConfiguration interface{} `json:"server_side_encryption_configuration,omitempty" hcl:"server_side_encryption_configuration,attr"`
+
+	// Map containing intelligent tiering configuration.
+	//
+	// Default: {}
+	IntelligentTiering interface{} `json:"intelligent_tiering,omitempty" hcl:"intelligent_tiering,attr"`
+
+	// Map containing S3 object locking configuration.
+	//
+	// Default: {}
+	ObjectLockConfiguration interface{} `json:"object_lock_configuration,omitempty" hcl:"object_lock_configuration,attr"`
+
+	// Map containing bucket metric configuration.
+	//
+	// Default: []
+	MetricConfiguration interface{} `json:"metric_configuration,omitempty" hcl:"metric_configuration,attr"`
+
+	// Map containing S3 inventory configuration.
+	//
+	// Default: {}
+	InventoryConfiguration interface{} `json:"inventory_configuration,omitempty" hcl:"inventory_configuration,attr"`
+
+	// The inventory source account id.
+	InventorySourceAccountID *string `json:"inventory_source_account_id,omitempty" hcl:"inventory_source_account_id,attr"`
+
+	// The inventory source bucket ARN.
+	InventorySourceBucketARN *string `json:"inventory_source_bucket_arn,omitempty" hcl:"inventory_source_bucket_arn,attr"`
+
+	// Whether or not the inventory source bucket is also the destination bucket.
+	//
+	// Default: false
+	InventorySelfSourceDestination *bool `json:"inventory_self_source_destination,omitempty" hcl:"inventory_self_source_destination,attr"`
+
+	// Map containing bucket analytics configuration.
+	//
+	// Default: {}
+	AnalyticsConfiguration interface{} `json:"analytics_configuration,omitempty" hcl:"analytics_configuration,attr"`
+
+	// The analytics source account id.
+	AnalyticsSourceAccountID *string `json:"analytics_source_account_id,omitempty" hcl:"analytics_source_account_id,attr"`
+
+	// The analytics source bucket ARN.
+	AnalyticsSourceBucketARN *string `json:"analytics_source_bucket_arn,omitempty" hcl:"analytics_source_bucket_arn,attr"`
+
+	// Whether or not the analytics source bucket is also the destination bucket.
+	//
+	// Default: false
+	AnalyticsSelfSourceDestination *bool `json:"analytics_self_source_destination,omitempty" hcl:"analytics_self_source_destination,attr"`
+
+	// Whether S3 bucket should have an Object Lock configuration enabled.
+	//
+	// Default: false
+	ObjectLockEnabled *bool `json:"object_lock_enabled,omitempty" hcl:"object_lock_enabled,attr"`
+
+	// Whether Amazon S3 should block public ACLs for this bucket.
+	//
+	// Default: true
+	BlockPublicAcls *bool `json:"block_public_acls,omitempty" hcl:"block_public_acls,attr"`
+
+	// Whether Amazon S3 should block public bucket policies for this bucket.
+	//
+	// Default: true
+	BlockPublicPolicy *bool `json:"block_public_policy,omitempty" hcl:"block_public_policy,attr"`
+
+	// Whether to skip destroying the S3 Bucket Public Access Block configuration when destroying the bucket. Only used if `public_access_block` is set to true.
+	//
+	// Default: true
+	SkipDestroyPublicAccessBlock *bool `json:"skip_destroy_public_access_block,omitempty" hcl:"skip_destroy_public_access_block,attr"`
+
+	// Whether Amazon S3 should ignore public ACLs for this bucket.
+	//
+	// Default: true
+	IgnorePublicAcls *bool `json:"ignore_public_acls,omitempty" hcl:"ignore_public_acls,attr"`
+
+	// Whether Amazon S3 should restrict public bucket policies for this bucket.
+	//
+	// Default: true
+	RestrictPublicBuckets *bool `json:"restrict_public_buckets,omitempty" hcl:"restrict_public_buckets,attr"`
+
+	// Whether to manage S3 Bucket Ownership Controls on this bucket.
+	//
+	// Default: false
+	ControlObjectOwnership *bool `json:"control_object_ownership,omitempty" hcl:"control_object_ownership,attr"`
+
+	// Object ownership. Valid values: BucketOwnerEnforced, BucketOwnerPreferred or ObjectWriter. 'BucketOwnerEnforced': ACLs are disabled, and the bucket owner automatically owns and has full control over every object in the bucket. 'BucketOwnerPreferred': Objects uploaded to the bucket change ownership to the bucket owner if the objects are uploaded with the bucket-owner-full-control canned ACL. 'ObjectWriter': The uploading account will own the object if the object is uploaded with the bucket-owner-full-control canned ACL.
+	//
+	// Default: "BucketOwnerEnforced"
+	ObjectOwnership *string `json:"object_ownership,omitempty" hcl:"object_ownership,attr"`
+
+	// If the s3 bucket created is a directory bucket
+	//
+	// Default: false
+	IsDirectoryBucket *bool `json:"is_directory_bucket,omitempty" hcl:"is_directory_bucket,attr"`
+
+	// Data redundancy. Valid values: `SingleAvailabilityZone`
+	DataRedundancy *string `json:"data_redundancy,omitempty" hcl:"data_redundancy,attr"`
+
+	// Bucket type. Valid values: `Directory`
+	//
+	// Default: "Directory"
+	Type *string `json:"type,omitempty" hcl:"type,attr"`
+
+	// Availability Zone ID or Local Zone ID
+	AvailabilityZoneID *string `json:"availability_zone_id,omitempty" hcl:"availability_zone_id,attr"`
+
+	// Location type. Valid values: `AvailabilityZone` or `LocalZone`
+	LocationType *string `json:"location_type,omitempty" hcl:"location_type,attr"`
+
+	// Whether to create metadata configuration resource
+	//
+	// Default: false
+	CreateMetadataConfiguration *bool `json:"create_metadata_configuration,omitempty" hcl:"create_metadata_configuration,attr"`
+
+	// Configuration state of the inventory table, indicating whether the inventory table is enabled or disabled. Valid values: ENABLED, DISABLED
+	MetadataInventoryTableConfigurationState *string `json:"metadata_inventory_table_configuration_state,omitempty" hcl:"metadata_inventory_table_configuration_state,attr"`
+
+	// Encryption configuration block
+	MetadataEncryptionConfiguration interface{} `json:"metadata_encryption_configuration,omitempty" hcl:"metadata_encryption_configuration,attr"`
+
+	// Number of days to retain journal table records
+	MetadataJournalTableRecordExpirationDays *int `json:"metadata_journal_table_record_expiration_days,omitempty" hcl:"metadata_journal_table_record_expiration_days,attr"`
+
+	// Whether journal table record expiration is enabled or disabled. Valid values: ENABLED, DISABLED
+	MetadataJournalTableRecordExpiration *string `json:"metadata_journal_table_record_expiration,omitempty" hcl:"metadata_journal_table_record_expiration,attr"`
+
+	// Do you agree that Putin doesn't respect Ukrainian sovereignty and territorial integrity? More info: https://en.wikipedia.org/wiki/Putin_khuylo!
+	//
+	// Default: true
+	PutinKhuylo *bool `json:"putin_khuylo,omitempty" hcl:"putin_khuylo,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/s3-bucket/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// S3BucketID references s3_bucket_id: The name of the bucket.
+func (o Outputs) S3BucketID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_id")
+}
+
+// S3BucketARN references s3_bucket_arn: The ARN of the bucket. Will be of format arn:aws:s3:::bucketname.
+func (o Outputs) S3BucketARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_arn")
+}
+
+// S3BucketBucketDomainName references s3_bucket_bucket_domain_name: The bucket domain name. Will be of format bucketname.s3.amazonaws.com.
+func (o Outputs) S3BucketBucketDomainName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_bucket_domain_name")
+}
+
+// S3BucketBucketRegionalDomainName references s3_bucket_bucket_regional_domain_name: The bucket region-specific domain name. The bucket domain name including the region name, please refer here for format. Note: The AWS CloudFront allows specifying S3 region-specific endpoint when creating S3 origin, it will prevent redirect issues from CloudFront to S3 Origin URL.
+func (o Outputs) S3BucketBucketRegionalDomainName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_bucket_regional_domain_name")
+}
+
+// S3BucketHostedZoneID references s3_bucket_hosted_zone_id: The Route 53 Hosted Zone ID for this bucket's region.
+func (o Outputs) S3BucketHostedZoneID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_hosted_zone_id")
+}
+
+// S3BucketLifecycleConfigurationRules references s3_bucket_lifecycle_configuration_rules: The lifecycle rules of the bucket, if the bucket is configured with lifecycle rules. If not, this will be an empty string.
+func (o Outputs) S3BucketLifecycleConfigurationRules() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_lifecycle_configuration_rules")
+}
+
+// S3BucketPolicy references s3_bucket_policy: The policy of the bucket, if the bucket is configured with a policy. If not, this will be an empty string.
+func (o Outputs) S3BucketPolicy() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_policy")
+}
+
+// S3BucketRegion references s3_bucket_region: The AWS region this bucket resides in.
+func (o Outputs) S3BucketRegion() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_region")
+}
+
+// S3BucketWebsiteEndpoint references s3_bucket_website_endpoint: The website endpoint, if the bucket is configured with a website. If not, this will be an empty string.
+func (o Outputs) S3BucketWebsiteEndpoint() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_website_endpoint")
+}
+
+// S3BucketWebsiteDomain references s3_bucket_website_domain: The domain of the website endpoint, if the bucket is configured with a website. If not, this will be an empty string. This is used to create Route 53 alias records.
+func (o Outputs) S3BucketWebsiteDomain() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_website_domain")
+}
+
+// S3DirectoryBucketName references s3_directory_bucket_name: Name of the directory bucket.
+func (o Outputs) S3DirectoryBucketName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_directory_bucket_name")
+}
+
+// S3DirectoryBucketARN references s3_directory_bucket_arn: ARN of the directory bucket.
+func (o Outputs) S3DirectoryBucketARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_directory_bucket_arn")
+}
+
+// AwsS3BucketVersioningStatus references aws_s3_bucket_versioning_status: The versioning status of the bucket. Will be 'Enabled', 'Suspended', or 'Disabled'.
+func (o Outputs) AwsS3BucketVersioningStatus() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "aws_s3_bucket_versioning_status")
+}
+
+// S3BucketTags references s3_bucket_tags: Tags of the bucket.
+func (o Outputs) S3BucketTags() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "s3_bucket_tags")
+}
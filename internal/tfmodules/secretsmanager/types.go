@@ -1,7 +1,18 @@
 // Package secretsmanager provides type-safe Terraform module definitions for terraform-aws-modules/secrets-manager/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-secrets-manager v1.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/secrets-manager (see variables_gen.go).
 package secretsmanager
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module secrets-manager -source terraform-aws-modules/secrets-manager/aws
+
+import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
+
 // Module represents the terraform-aws-modules/secrets-manager/aws module.
 // All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
 type Module struct {
@@ -176,7 +187,7 @@ type RotationRules struct {
 // NewModule creates a new Secrets Manager module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/secrets-manager/aws"
-	version := "~> 1.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	recoveryWindow := 30
 	blockPublic := true
@@ -268,8 +279,17 @@ func (m *Module) LocalName() string {
 	return "secret"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 func (m *Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid secretsmanager module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
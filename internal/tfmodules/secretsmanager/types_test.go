@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -15,7 +17,7 @@ func TestNewModule(t *testing.T) {
 		// Verify basic properties
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/secrets-manager/aws", module.Source)
-		assert.Equal(t, "~> 1.0", module.Version)
+		assert.Equal(t, "~> 2.0", module.Version)
 		assert.NotNil(t, module.Name)
 		assert.Equal(t, name, *module.Name)
 
@@ -335,14 +337,35 @@ func TestModule_LocalName(t *testing.T) {
 }
 
 func TestModule_Configuration(t *testing.T) {
-	t.Run("returns empty string and nil error as placeholder", func(t *testing.T) {
+	t.Run("generates valid HCL for basic secret", func(t *testing.T) {
 		module := NewModule("test_secret")
 
 		config, err := module.Configuration()
 
-		// Current implementation is a placeholder
 		require.NoError(t, err)
-		assert.Empty(t, config)
+		golden.Assert(t, "basic_secret", config)
+	})
+
+	t.Run("generates HCL with rotation and policy statements", func(t *testing.T) {
+		effect := "Allow"
+		module := NewModule("db_credentials").
+			WithKMSKey("alias/aws/secretsmanager").
+			WithRecoveryWindow(7).
+			WithRotation("${module.rotator.lambda_function_arn}", 30).
+			WithPolicy("read", PolicyStatement{
+				Effect:  &effect,
+				Actions: []string{"secretsmanager:GetSecretValue"},
+				Principals: []Principal{{
+					Type:        "AWS",
+					Identifiers: []string{"${module.api.lambda_role_arn}"},
+				}},
+			}).
+			WithTags(map[string]string{"Team": "platform"})
+
+		config, err := module.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "rotation_and_policy", config)
 	})
 }
 
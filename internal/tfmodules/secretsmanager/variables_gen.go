@@ -0,0 +1,163 @@
+// Code generated by tfmodules-gen from .forge/modules/secrets-manager. DO NOT EDIT.
+
+package secretsmanager
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/secrets-manager/aws these types were generated from.
+const ModuleVersion = "2.0.1"
+
+// Variables holds every input declared by terraform-aws-modules/secrets-manager/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Determines whether resources will be created (affects all resources)
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// Region where the resource(s) will be managed. Defaults to the Region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+
+	// A map of tags to add to all resources
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// A description of the secret
+	Description *string `json:"description,omitempty" hcl:"description,attr"`
+
+	// Accepts boolean value to specify whether to overwrite a secret with the same name in the destination Region
+	ForceOverwriteReplicaSecret *bool `json:"force_overwrite_replica_secret,omitempty" hcl:"force_overwrite_replica_secret,attr"`
+
+	// ARN or Id of the AWS KMS key to be used to encrypt the secret values in the versions stored in this secret. If you need to reference a CMK in a different account, you can use only the key ARN. If you don't specify this value, then Secrets Manager defaults to using the AWS account's default KMS key (the one named `aws/secretsmanager`
+	KMSKeyID *string `json:"kms_key_id,omitempty" hcl:"kms_key_id,attr"`
+
+	// Friendly name of the new secret. The secret name can consist of uppercase letters, lowercase letters, digits, and any of the following characters: `/_+=.@-`
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// Creates a unique name beginning with the specified prefix
+	NamePrefix *string `json:"name_prefix,omitempty" hcl:"name_prefix,attr"`
+
+	// Number of days that AWS Secrets Manager waits before it can delete the secret. This value can be `0` to force deletion without recovery or range from `7` to `30` days. The default value is `30`
+	RecoveryWindowInDays *int `json:"recovery_window_in_days,omitempty" hcl:"recovery_window_in_days,attr"`
+
+	// Configuration block to support secret replication
+	Replica map[string]map[string]interface{} `json:"replica,omitempty" hcl:"replica,attr"`
+
+	// Determines whether a policy will be created
+	//
+	// Default: false
+	CreatePolicy *bool `json:"create_policy,omitempty" hcl:"create_policy,attr"`
+
+	// List of IAM policy documents that are merged together into the exported document. Statements must have unique `sid`s
+	//
+	// Default: []
+	SourcePolicyDocuments []string `json:"source_policy_documents,omitempty" hcl:"source_policy_documents,attr"`
+
+	// List of IAM policy documents that are merged together into the exported document. In merging, statements with non-blank `sid`s will override statements with the same `sid`
+	//
+	// Default: []
+	OverridePolicyDocuments []string `json:"override_policy_documents,omitempty" hcl:"override_policy_documents,attr"`
+
+	// A map of IAM policy [statements](https://registry.terraform.io/providers/hashicorp/aws/latest/docs/data-sources/iam_policy_document#statement) for custom permission usage
+	PolicyStatements map[string]map[string]interface{} `json:"policy_statements,omitempty" hcl:"policy_statements,attr"`
+
+	// Makes an optional API call to Zelkova to validate the Resource Policy to prevent broad access to your secret
+	BlockPublicPolicy *bool `json:"block_public_policy,omitempty" hcl:"block_public_policy,attr"`
+
+	// Determines whether or not Terraform will ignore changes made externally to `secret_string` or `secret_binary`. Changing this value after creation is a destructive operation
+	//
+	// Default: false
+	IgnoreSecretChanges *bool `json:"ignore_secret_changes,omitempty" hcl:"ignore_secret_changes,attr"`
+
+	// Specifies binary data that you want to encrypt and store in this version of the secret. This is required if `secret_string` or `secret_string_wo` is not set. Needs to be encoded to base64
+	SecretBinary *string `json:"secret_binary,omitempty" hcl:"secret_binary,attr"`
+
+	// Specifies text data that you want to encrypt and store in this version of the secret. This is required if `secret_binary` or `secret_string_wo` is not set
+	SecretString *string `json:"secret_string,omitempty" hcl:"secret_string,attr"`
+
+	// Specifies text data that you want to encrypt and store in this version of the secret. This is required if `secret_binary` or `secret_string` is not set
+	SecretStringWo *string `json:"secret_string_wo,omitempty" hcl:"secret_string_wo,attr"`
+
+	// Used together with `secret_string_wo` to trigger an update. Increment this value when an update to `secret_string_wo` is required
+	SecretStringWoVersion *string `json:"secret_string_wo_version,omitempty" hcl:"secret_string_wo_version,attr"`
+
+	// Specifies a list of staging labels that are attached to this version of the secret. A staging label must be unique to a single version of the secret
+	VersionStages []string `json:"version_stages,omitempty" hcl:"version_stages,attr"`
+
+	// Determines whether an ephemeral random password will be generated for `secret_string_wo`
+	//
+	// Default: false
+	CreateRandomPassword *bool `json:"create_random_password,omitempty" hcl:"create_random_password,attr"`
+
+	// The length of the generated random password
+	//
+	// Default: 32
+	RandomPasswordLength *int `json:"random_password_length,omitempty" hcl:"random_password_length,attr"`
+
+	// Supply your own list of special characters to use for string generation. This overrides the default character list in the special argument
+	//
+	// Default: "!@#$%&*()-_=+[]{}<>:?"
+	RandomPasswordOverrideSpecial *string `json:"random_password_override_special,omitempty" hcl:"random_password_override_special,attr"`
+
+	// Determines whether secret rotation is enabled
+	//
+	// Default: false
+	EnableRotation *bool `json:"enable_rotation,omitempty" hcl:"enable_rotation,attr"`
+
+	// Specifies whether to rotate the secret immediately or wait until the next scheduled rotation window. The rotation schedule is defined in `rotation_rules`
+	RotateImmediately *bool `json:"rotate_immediately,omitempty" hcl:"rotate_immediately,attr"`
+
+	// Specifies the ARN of the Lambda function that can rotate the secret
+	//
+	// Default: ""
+	RotationLambdaARN *string `json:"rotation_lambda_arn,omitempty" hcl:"rotation_lambda_arn,attr"`
+
+	// A structure that defines the rotation configuration for this secret
+	RotationRules map[string]interface{} `json:"rotation_rules,omitempty" hcl:"rotation_rules,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/secrets-manager/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// SecretARN references secret_arn: The ARN of the secret
+func (o Outputs) SecretARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secret_arn")
+}
+
+// SecretID references secret_id: The ID of the secret
+func (o Outputs) SecretID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secret_id")
+}
+
+// SecretName references secret_name: The name of the secret
+func (o Outputs) SecretName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secret_name")
+}
+
+// SecretReplica references secret_replica: Attributes of the replica created
+func (o Outputs) SecretReplica() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secret_replica")
+}
+
+// SecretString references secret_string: The secret string
+func (o Outputs) SecretString() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secret_string")
+}
+
+// SecretBinary references secret_binary: The secret binary
+func (o Outputs) SecretBinary() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secret_binary")
+}
+
+// SecretVersionID references secret_version_id: The unique identifier of the version of the secret
+func (o Outputs) SecretVersionID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secret_version_id")
+}
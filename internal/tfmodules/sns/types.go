@@ -1,9 +1,16 @@
 // Package sns provides type-safe Terraform module definitions for terraform-aws-modules/sns/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-sns v6.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/sns (see variables_gen.go).
 package sns
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module sns -source terraform-aws-modules/sns/aws
+
 import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
 	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
 )
 
 // Module represents the terraform-aws-modules/sns/aws module.
@@ -129,6 +136,9 @@ type Module struct {
 
 	// DataProtectionPolicy is the data protection policy JSON
 	DataProtectionPolicy *string `json:"data_protection_policy,omitempty" hcl:"data_protection_policy,attr"`
+
+	// localName overrides the local identifier derived from Name.
+	localName string
 }
 
 // FeedbackConfig represents delivery feedback configuration.
@@ -234,7 +244,7 @@ type Condition struct {
 // NewModule creates a new SNS module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/sns/aws"
-	version := "~> 6.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	createPolicy := true
 	enableDefaultPolicy := true
@@ -293,6 +303,13 @@ func (m *Module) WithSQSSubscription(name, queueARN string, rawMessageDelivery b
 	return m.WithSubscription(name, sub)
 }
 
+// WithLocalName sets the local identifier of the module call, for topics
+// whose name is not a valid Terraform identifier.
+func (m *Module) WithLocalName(name string) *Module {
+	m.localName = name
+	return m
+}
+
 // WithTags adds tags to the topic.
 func (m *Module) WithTags(tags map[string]string) *Module {
 	if m.Tags == nil {
@@ -306,14 +323,27 @@ func (m *Module) WithTags(tags map[string]string) *Module {
 
 // LocalName returns the local identifier for this module instance.
 func (m *Module) LocalName() string {
+	if m.localName != "" {
+		return m.localName
+	}
 	if m.Name != nil {
 		return *m.Name
 	}
 	return "sns_topic"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 // PURE: Same module configuration always produces the same HCL output.
 func (m *Module) Configuration() (string, error) {
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid sns module %s: %w", m.LocalName(), err)
+	}
 	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -14,7 +16,7 @@ func TestNewModule(t *testing.T) {
 
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/sns/aws", module.Source)
-		assert.Equal(t, "~> 6.0", module.Version)
+		assert.Equal(t, "~> 7.0", module.Version)
 		assert.NotNil(t, module.Name)
 		assert.Equal(t, name, *module.Name)
 
@@ -249,6 +251,13 @@ func TestModule_LocalName(t *testing.T) {
 
 		assert.Equal(t, "sns_topic", module.LocalName())
 	})
+
+	t.Run("returns local name when set", func(t *testing.T) {
+		module := NewModule("${var.namespace}alerts").WithLocalName("alerts")
+
+		assert.Equal(t, "alerts", module.LocalName())
+		assert.Equal(t, "module.alerts.topic_arn", module.Outputs().TopicARN().Ref())
+	})
 }
 
 func TestModule_Configuration(t *testing.T) {
@@ -258,15 +267,7 @@ func TestModule_Configuration(t *testing.T) {
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.NotEmpty(t, config)
-
-		// Verify basic structure
-		assert.Contains(t, config, `module "test_topic" {`)
-		assert.Contains(t, config, `source  = "terraform-aws-modules/sns/aws"`)
-		assert.Contains(t, config, `version = "~> 6.0"`)
-		assert.Contains(t, config, `name = "test_topic"`)
-		assert.Contains(t, config, `create = true`)
-		assert.Contains(t, config, `create_topic_policy = true`)
+		golden.Assert(t, "basic_topic", config)
 	})
 
 	t.Run("generates HCL with all options", func(t *testing.T) {
@@ -280,11 +281,7 @@ func TestModule_Configuration(t *testing.T) {
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.Contains(t, config, `name = "notifications"`)
-		assert.Contains(t, config, `fifo_topic = true`)
-		assert.Contains(t, config, `content_based_deduplication = true`)
-		assert.Contains(t, config, `kms_master_key_id = "alias/aws/sns"`)
-		assert.Contains(t, config, `Environment = "production"`)
+		golden.Assert(t, "all_options", config)
 	})
 }
 
@@ -0,0 +1,168 @@
+// Code generated by tfmodules-gen from .forge/modules/sns. DO NOT EDIT.
+
+package sns
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/sns/aws these types were generated from.
+const ModuleVersion = "7.0.0"
+
+// Variables holds every input declared by terraform-aws-modules/sns/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Determines whether resources will be created (affects all resources)
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// Region where the resource(s) will be managed. Defaults to the Region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+
+	// A map of tags to add to all resources
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// The name of the SNS topic to create
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// Determines whether `name` is used as a prefix
+	//
+	// Default: false
+	UseNamePrefix *bool `json:"use_name_prefix,omitempty" hcl:"use_name_prefix,attr"`
+
+	// Map of IAM role ARNs and sample rate for success and failure feedback
+	//
+	// Default: {}
+	ApplicationFeedback map[string]interface{} `json:"application_feedback,omitempty" hcl:"application_feedback,attr"`
+
+	// Boolean indicating whether or not to enable content-based deduplication for FIFO topics
+	//
+	// Default: false
+	ContentBasedDeduplication *bool `json:"content_based_deduplication,omitempty" hcl:"content_based_deduplication,attr"`
+
+	// The SNS delivery policy
+	DeliveryPolicy *string `json:"delivery_policy,omitempty" hcl:"delivery_policy,attr"`
+
+	// The display name for the SNS topic
+	DisplayName *string `json:"display_name,omitempty" hcl:"display_name,attr"`
+
+	// Enables higher throughput for FIFO topics by adjusting the scope of deduplication. This attribute has two possible values, Topic and MessageGroup
+	FifoThroughputScope *string `json:"fifo_throughput_scope,omitempty" hcl:"fifo_throughput_scope,attr"`
+
+	// Boolean indicating whether or not to create a FIFO (first-in-first-out) topic
+	//
+	// Default: false
+	FifoTopic *bool `json:"fifo_topic,omitempty" hcl:"fifo_topic,attr"`
+
+	// Map of IAM role ARNs and sample rate for success and failure feedback
+	//
+	// Default: {}
+	FirehoseFeedback map[string]interface{} `json:"firehose_feedback,omitempty" hcl:"firehose_feedback,attr"`
+
+	// Map of IAM role ARNs and sample rate for success and failure feedback
+	//
+	// Default: {}
+	HTTPFeedback map[string]interface{} `json:"http_feedback,omitempty" hcl:"http_feedback,attr"`
+
+	// The ID of an AWS-managed customer master key (CMK) for Amazon SNS or a custom CMK
+	KMSMasterKeyID *string `json:"kms_master_key_id,omitempty" hcl:"kms_master_key_id,attr"`
+
+	// Map of IAM role ARNs and sample rate for success and failure feedback
+	//
+	// Default: {}
+	LambdaFeedback map[string]interface{} `json:"lambda_feedback,omitempty" hcl:"lambda_feedback,attr"`
+
+	// An externally created fully-formed AWS policy as JSON
+	TopicPolicy *string `json:"topic_policy,omitempty" hcl:"topic_policy,attr"`
+
+	// Map of IAM role ARNs and sample rate for success and failure feedback
+	//
+	// Default: {}
+	SQSFeedback map[string]interface{} `json:"sqs_feedback,omitempty" hcl:"sqs_feedback,attr"`
+
+	// If SignatureVersion should be `1` (`SHA1`) or `2` (`SHA256`). The signature version corresponds to the hashing algorithm used while creating the signature of the notifications, subscription confirmations, or unsubscribe confirmation messages sent by Amazon SNS
+	SignatureVersion *int `json:"signature_version,omitempty" hcl:"signature_version,attr"`
+
+	// Tracing mode of an Amazon SNS topic. Valid values: `PassThrough`, `Active`
+	TracingConfig *string `json:"tracing_config,omitempty" hcl:"tracing_config,attr"`
+
+	// The message archive policy for FIFO topics
+	ArchivePolicy *string `json:"archive_policy,omitempty" hcl:"archive_policy,attr"`
+
+	// Determines whether an SNS topic policy is created
+	//
+	// Default: true
+	CreateTopicPolicy *bool `json:"create_topic_policy,omitempty" hcl:"create_topic_policy,attr"`
+
+	// List of IAM policy documents that are merged together into the exported document. Statements must have unique `sid`s
+	//
+	// Default: []
+	SourceTopicPolicyDocuments []string `json:"source_topic_policy_documents,omitempty" hcl:"source_topic_policy_documents,attr"`
+
+	// List of IAM policy documents that are merged together into the exported document. In merging, statements with non-blank `sid`s will override statements with the same `sid`
+	//
+	// Default: []
+	OverrideTopicPolicyDocuments []string `json:"override_topic_policy_documents,omitempty" hcl:"override_topic_policy_documents,attr"`
+
+	// Specifies whether to enable the default topic policy. Defaults to `true`
+	//
+	// Default: true
+	EnableDefaultTopicPolicy *bool `json:"enable_default_topic_policy,omitempty" hcl:"enable_default_topic_policy,attr"`
+
+	// A map of IAM policy [statements](https://registry.terraform.io/providers/hashicorp/aws/latest/docs/data-sources/iam_policy_document#statement) for custom permission usage
+	TopicPolicyStatements map[string]map[string]interface{} `json:"topic_policy_statements,omitempty" hcl:"topic_policy_statements,attr"`
+
+	// Determines whether an SNS subscription is created
+	//
+	// Default: true
+	CreateSubscription *bool `json:"create_subscription,omitempty" hcl:"create_subscription,attr"`
+
+	// A map of subscription definitions to create
+	//
+	// Default: {}
+	Subscriptions map[string]map[string]interface{} `json:"subscriptions,omitempty" hcl:"subscriptions,attr"`
+
+	// A map of data protection policy statements
+	DataProtectionPolicy *string `json:"data_protection_policy,omitempty" hcl:"data_protection_policy,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/sns/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// TopicARN references topic_arn: The ARN of the SNS topic, as a more obvious property (clone of id)
+func (o Outputs) TopicARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "topic_arn")
+}
+
+// TopicID references topic_id: The ARN of the SNS topic
+func (o Outputs) TopicID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "topic_id")
+}
+
+// TopicName references topic_name: The name of the topic
+func (o Outputs) TopicName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "topic_name")
+}
+
+// TopicOwner references topic_owner: The AWS Account ID of the SNS topic owner
+func (o Outputs) TopicOwner() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "topic_owner")
+}
+
+// TopicBeginningArchiveTime references topic_beginning_archive_time: The oldest timestamp at which a FIFO topic subscriber can start a replay
+func (o Outputs) TopicBeginningArchiveTime() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "topic_beginning_archive_time")
+}
+
+// Subscriptions references subscriptions: Map of subscriptions created and their attributes
+func (o Outputs) Subscriptions() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "subscriptions")
+}
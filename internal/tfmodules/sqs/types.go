@@ -1,9 +1,16 @@
 // Package sqs provides type-safe Terraform module definitions for terraform-aws-modules/sqs/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-sqs v4.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/sqs (see variables_gen.go).
 package sqs
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module sqs -source terraform-aws-modules/sqs/aws
+
 import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
 	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
 )
 
 // Module represents the terraform-aws-modules/sqs/aws module.
@@ -33,7 +40,7 @@ type Module struct {
 
 	// DeduplicationScope specifies whether message deduplication occurs at the message group or queue level
 	// Valid values: "messageGroup" | "queue"
-	DeduplicationScope *string `json:"deduplication_scope,omitempty" hcl:"deduplication_scope,attr"`
+	DeduplicationScope *string `json:"deduplication_scope,omitempty" validate:"oneof=messageGroup queue" hcl:"deduplication_scope,attr"`
 
 	// DelaySeconds is the time in seconds that the delivery of all messages will be delayed
 	// Valid range: 0-900 (15 minutes)
@@ -44,7 +51,7 @@ type Module struct {
 
 	// FifoThroughputLimit specifies whether the FIFO queue throughput quota applies to entire queue or per message group
 	// Valid values: "perQueue" | "perMessageGroupId"
-	FifoThroughputLimit *string `json:"fifo_throughput_limit,omitempty" hcl:"fifo_throughput_limit,attr"`
+	FifoThroughputLimit *string `json:"fifo_throughput_limit,omitempty" validate:"oneof=perQueue perMessageGroupId" hcl:"fifo_throughput_limit,attr"`
 
 	// KmsDataKeyReusePeriodSeconds is the length of time for which Amazon SQS can reuse a data key
 	// Valid range: 60-86400 (1 minute to 24 hours)
@@ -164,6 +171,9 @@ type Module struct {
 
 	// DLQQueuePolicyStatements is a map of IAM policy statements for DLQ
 	DLQQueuePolicyStatements map[string]PolicyStatement `json:"dlq_queue_policy_statements,omitempty" hcl:"dlq_queue_policy_statements,attr"`
+
+	// localName overrides the local identifier derived from Name.
+	localName string
 }
 
 // PolicyStatement represents an IAM policy statement.
@@ -220,7 +230,7 @@ type Condition struct {
 // NewModule creates a new SQS module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/sqs/aws"
-	version := "~> 4.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	createDLQ := true
 	sqsManagedSSE := true
 	dlqSSE := true
@@ -268,6 +278,13 @@ func (m *Module) WithoutDLQ() *Module {
 	return m
 }
 
+// WithLocalName sets the local identifier of the module call, for queues
+// whose name is not a valid Terraform identifier.
+func (m *Module) WithLocalName(name string) *Module {
+	m.localName = name
+	return m
+}
+
 // WithTags adds tags to the queue.
 func (m *Module) WithTags(tags map[string]string) *Module {
 	if m.Tags == nil {
@@ -281,14 +298,27 @@ func (m *Module) WithTags(tags map[string]string) *Module {
 
 // This is extracted from the Name field if set, or defaults to "sqs_queue".
 func (m *Module) LocalName() string {
+	if m.localName != "" {
+		return m.localName
+	}
 	if m.Name != nil {
 		return *m.Name
 	}
 	return "sqs_queue"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 // PURE: Same module configuration always produces the same HCL output.
 func (m *Module) Configuration() (string, error) {
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid sqs module %s: %w", m.LocalName(), err)
+	}
 	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
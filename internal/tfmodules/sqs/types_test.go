@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -15,7 +17,7 @@ func TestNewModule(t *testing.T) {
 		// Verify basic properties
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/sqs/aws", module.Source)
-		assert.Equal(t, "~> 4.0", module.Version)
+		assert.Equal(t, "~> 5.0", module.Version)
 		assert.NotNil(t, module.Name)
 		assert.Equal(t, name, *module.Name)
 
@@ -263,6 +265,34 @@ func TestModule_LocalName(t *testing.T) {
 
 		assert.Equal(t, emptyName, module.LocalName())
 	})
+
+	t.Run("returns local name when set", func(t *testing.T) {
+		module := NewModule("${var.namespace}orders").WithLocalName("orders")
+
+		assert.Equal(t, "orders", module.LocalName())
+		assert.Equal(t, "module.orders.queue_arn", module.Outputs().QueueARN().Ref())
+	})
+}
+
+func TestModule_Outputs(t *testing.T) {
+	t.Run("references outputs by the module's local name", func(t *testing.T) {
+		module := NewModule("orders")
+
+		assert.Equal(t, "module.orders.queue_arn", module.Outputs().QueueARN().Ref())
+		assert.Equal(t, "module.orders.dead_letter_queue_url", module.Outputs().DeadLetterQueueURL().Ref())
+	})
+
+	t.Run("wires one module into another", func(t *testing.T) {
+		dlq := NewModule("orders_dlq")
+		queue := NewModule("orders").WithoutDLQ()
+		queue.RedrivePolicy = map[string]interface{}{
+			"deadLetterTargetArn": dlq.Outputs().QueueARN(),
+		}
+
+		config, err := queue.Configuration()
+		require.NoError(t, err)
+		assert.Contains(t, config, "deadLetterTargetArn = module.orders_dlq.queue_arn")
+	})
 }
 
 func TestModule_Configuration(t *testing.T) {
@@ -272,13 +302,7 @@ func TestModule_Configuration(t *testing.T) {
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.NotEmpty(t, config)
-
-		// Verify basic structure
-		assert.Contains(t, config, `module "test_queue" {`)
-		assert.Contains(t, config, `source  = "terraform-aws-modules/sqs/aws"`)
-		assert.Contains(t, config, `version = "~> 4.0"`)
-		assert.Contains(t, config, `name = "test_queue"`)
+		golden.Assert(t, "basic_queue", config)
 	})
 
 	t.Run("generates HCL with all options", func(t *testing.T) {
@@ -292,12 +316,7 @@ func TestModule_Configuration(t *testing.T) {
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.Contains(t, config, `name = "orders_queue"`)
-		assert.Contains(t, config, `create_dlq = true`)
-		assert.Contains(t, config, `fifo_queue = true`)
-		assert.Contains(t, config, `content_based_deduplication = true`)
-		assert.Contains(t, config, `kms_master_key_id = "arn:aws:kms:us-east-1:123456789012:key/12345"`)
-		assert.Contains(t, config, `Environment = "production"`)
+		golden.Assert(t, "all_options", config)
 	})
 }
 
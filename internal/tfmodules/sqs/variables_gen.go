@@ -0,0 +1,234 @@
+// Code generated by tfmodules-gen from .forge/modules/sqs. DO NOT EDIT.
+
+package sqs
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/sqs/aws these types were generated from.
+const ModuleVersion = "5.1.0"
+
+// Variables holds every input declared by terraform-aws-modules/sqs/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Whether to create SQS queue
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// Region where the resource(s) will be managed. Defaults to the Region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+
+	// A mapping of tags to assign to all resources
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// Enables content-based deduplication for FIFO queues
+	ContentBasedDeduplication *bool `json:"content_based_deduplication,omitempty" hcl:"content_based_deduplication,attr"`
+
+	// Specifies whether message deduplication occurs at the message group or queue level
+	DeduplicationScope *string `json:"deduplication_scope,omitempty" hcl:"deduplication_scope,attr"`
+
+	// The time in seconds that the delivery of all messages in the queue will be delayed. An integer from 0 to 900 (15 minutes)
+	DelaySeconds *int `json:"delay_seconds,omitempty" hcl:"delay_seconds,attr"`
+
+	// Boolean designating a FIFO queue
+	//
+	// Default: false
+	FifoQueue *bool `json:"fifo_queue,omitempty" hcl:"fifo_queue,attr"`
+
+	// Specifies whether the FIFO queue throughput quota applies to the entire queue or per message group
+	FifoThroughputLimit *string `json:"fifo_throughput_limit,omitempty" hcl:"fifo_throughput_limit,attr"`
+
+	// The length of time, in seconds, for which Amazon SQS can reuse a data key to encrypt or decrypt messages before calling AWS KMS again. An integer representing seconds, between 60 seconds (1 minute) and 86,400 seconds (24 hours)
+	KMSDataKeyReusePeriodSeconds *int `json:"kms_data_key_reuse_period_seconds,omitempty" hcl:"kms_data_key_reuse_period_seconds,attr"`
+
+	// The ID of an AWS-managed customer master key (CMK) for Amazon SQS or a custom CMK
+	KMSMasterKeyID *string `json:"kms_master_key_id,omitempty" hcl:"kms_master_key_id,attr"`
+
+	// The limit of how many bytes a message can contain before Amazon SQS rejects it. An integer from 1024 bytes (1 KiB) up to 1048576 bytes (1024 KiB). The default for this attribute is 262144 (256 KiB)
+	MaxMessageSize *int `json:"max_message_size,omitempty" hcl:"max_message_size,attr"`
+
+	// The number of seconds Amazon SQS retains a message. Integer representing seconds, from 60 (1 minute) to 1209600 (14 days)
+	MessageRetentionSeconds *int `json:"message_retention_seconds,omitempty" hcl:"message_retention_seconds,attr"`
+
+	// This is the human-readable name of the queue. If omitted, Terraform will assign a random name
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// Determines whether `name` is used as a prefix
+	//
+	// Default: false
+	UseNamePrefix *bool `json:"use_name_prefix,omitempty" hcl:"use_name_prefix,attr"`
+
+	// The time for which a ReceiveMessage call will wait for a message to arrive (long polling) before returning. An integer from 0 to 20 (seconds)
+	ReceiveWaitTimeSeconds *int `json:"receive_wait_time_seconds,omitempty" hcl:"receive_wait_time_seconds,attr"`
+
+	// The JSON policy to set up the Dead Letter Queue redrive permission, see AWS docs
+	//
+	// Default: {}
+	RedriveAllowPolicy interface{} `json:"redrive_allow_policy,omitempty" hcl:"redrive_allow_policy,attr"`
+
+	// The JSON policy to set up the Dead Letter Queue, see AWS docs. Note: when specifying maxReceiveCount, you must specify it as an integer (5), and not a string ("5")
+	//
+	// Default: {}
+	RedrivePolicy interface{} `json:"redrive_policy,omitempty" hcl:"redrive_policy,attr"`
+
+	// Boolean to enable server-side encryption (SSE) of message content with SQS-owned encryption keys
+	//
+	// Default: true
+	SQSManagedSSEEnabled *bool `json:"sqs_managed_sse_enabled,omitempty" hcl:"sqs_managed_sse_enabled,attr"`
+
+	// The visibility timeout for the queue. An integer from 0 to 43200 (12 hours)
+	VisibilityTimeoutSeconds *int `json:"visibility_timeout_seconds,omitempty" hcl:"visibility_timeout_seconds,attr"`
+
+	// Whether to create SQS queue policy
+	//
+	// Default: false
+	CreateQueuePolicy *bool `json:"create_queue_policy,omitempty" hcl:"create_queue_policy,attr"`
+
+	// List of IAM policy documents that are merged together into the exported document. Statements must have unique `sid`s
+	//
+	// Default: []
+	SourceQueuePolicyDocuments []string `json:"source_queue_policy_documents,omitempty" hcl:"source_queue_policy_documents,attr"`
+
+	// List of IAM policy documents that are merged together into the exported document. In merging, statements with non-blank `sid`s will override statements with the same `sid`
+	//
+	// Default: []
+	OverrideQueuePolicyDocuments []string `json:"override_queue_policy_documents,omitempty" hcl:"override_queue_policy_documents,attr"`
+
+	// A map of IAM policy [statements](https://registry.terraform.io/providers/hashicorp/aws/latest/docs/data-sources/iam_policy_document#statement) for custom permission usage
+	QueuePolicyStatements map[string]map[string]interface{} `json:"queue_policy_statements,omitempty" hcl:"queue_policy_statements,attr"`
+
+	// Determines whether to create SQS dead letter queue
+	//
+	// Default: false
+	CreateDLQ *bool `json:"create_dlq,omitempty" hcl:"create_dlq,attr"`
+
+	// Enables content-based deduplication for FIFO queues
+	DLQContentBasedDeduplication *bool `json:"dlq_content_based_deduplication,omitempty" hcl:"dlq_content_based_deduplication,attr"`
+
+	// Specifies whether message deduplication occurs at the message group or queue level
+	DLQDeduplicationScope *string `json:"dlq_deduplication_scope,omitempty" hcl:"dlq_deduplication_scope,attr"`
+
+	// The time in seconds that the delivery of all messages in the queue will be delayed. An integer from 0 to 900 (15 minutes)
+	DLQDelaySeconds *int `json:"dlq_delay_seconds,omitempty" hcl:"dlq_delay_seconds,attr"`
+
+	// The length of time, in seconds, for which Amazon SQS can reuse a data key to encrypt or decrypt messages before calling AWS KMS again. An integer representing seconds, between 60 seconds (1 minute) and 86,400 seconds (24 hours)
+	DLQKMSDataKeyReusePeriodSeconds *int `json:"dlq_kms_data_key_reuse_period_seconds,omitempty" hcl:"dlq_kms_data_key_reuse_period_seconds,attr"`
+
+	// The ID of an AWS-managed customer master key (CMK) for Amazon SQS or a custom CMK
+	DLQKMSMasterKeyID *string `json:"dlq_kms_master_key_id,omitempty" hcl:"dlq_kms_master_key_id,attr"`
+
+	// The number of seconds Amazon SQS retains a message. Integer representing seconds, from 60 (1 minute) to 1209600 (14 days)
+	DLQMessageRetentionSeconds *int `json:"dlq_message_retention_seconds,omitempty" hcl:"dlq_message_retention_seconds,attr"`
+
+	// This is the human-readable name of the queue. If omitted, Terraform will assign a random name
+	DLQName *string `json:"dlq_name,omitempty" hcl:"dlq_name,attr"`
+
+	// The time for which a ReceiveMessage call will wait for a message to arrive (long polling) before returning. An integer from 0 to 20 (seconds)
+	DLQReceiveWaitTimeSeconds *int `json:"dlq_receive_wait_time_seconds,omitempty" hcl:"dlq_receive_wait_time_seconds,attr"`
+
+	// Determines whether to create a redrive allow policy for the dead letter queue
+	//
+	// Default: true
+	CreateDLQRedriveAllowPolicy *bool `json:"create_dlq_redrive_allow_policy,omitempty" hcl:"create_dlq_redrive_allow_policy,attr"`
+
+	// The JSON policy to set up the Dead Letter Queue redrive permission, see AWS docs
+	//
+	// Default: {}
+	DLQRedriveAllowPolicy interface{} `json:"dlq_redrive_allow_policy,omitempty" hcl:"dlq_redrive_allow_policy,attr"`
+
+	// Boolean to enable server-side encryption (SSE) of message content with SQS-owned encryption keys
+	//
+	// Default: true
+	DLQSQSManagedSSEEnabled *bool `json:"dlq_sqs_managed_sse_enabled,omitempty" hcl:"dlq_sqs_managed_sse_enabled,attr"`
+
+	// Specifies whether the Dead Letter Queue FIFO queue throughput quota applies to the entire queue or per message group
+	DLQFifoThroughputLimit *string `json:"dlq_fifo_throughput_limit,omitempty" hcl:"dlq_fifo_throughput_limit,attr"`
+
+	// The visibility timeout for the queue. An integer from 0 to 43200 (12 hours)
+	DLQVisibilityTimeoutSeconds *int `json:"dlq_visibility_timeout_seconds,omitempty" hcl:"dlq_visibility_timeout_seconds,attr"`
+
+	// A mapping of additional tags to assign to the dead letter queue
+	//
+	// Default: {}
+	DLQTags map[string]string `json:"dlq_tags,omitempty" hcl:"dlq_tags,attr"`
+
+	// Whether to create SQS queue policy
+	//
+	// Default: false
+	CreateDLQQueuePolicy *bool `json:"create_dlq_queue_policy,omitempty" hcl:"create_dlq_queue_policy,attr"`
+
+	// List of IAM policy documents that are merged together into the exported document. Statements must have unique `sid`s
+	//
+	// Default: []
+	SourceDLQQueuePolicyDocuments []string `json:"source_dlq_queue_policy_documents,omitempty" hcl:"source_dlq_queue_policy_documents,attr"`
+
+	// List of IAM policy documents that are merged together into the exported document. In merging, statements with non-blank `sid`s will override statements with the same `sid`
+	//
+	// Default: []
+	OverrideDLQQueuePolicyDocuments []string `json:"override_dlq_queue_policy_documents,omitempty" hcl:"override_dlq_queue_policy_documents,attr"`
+
+	// A map of IAM policy [statements](https://registry.terraform.io/providers/hashicorp/aws/latest/docs/data-sources/iam_policy_document#statement) for custom permission usage
+	DLQQueuePolicyStatements map[string]map[string]interface{} `json:"dlq_queue_policy_statements,omitempty" hcl:"dlq_queue_policy_statements,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/sqs/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// QueueID references queue_id: The URL for the created Amazon SQS queue
+func (o Outputs) QueueID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "queue_id")
+}
+
+// QueueARN references queue_arn: The ARN of the SQS queue
+func (o Outputs) QueueARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "queue_arn")
+}
+
+// QueueARNStatic references queue_arn_static: The ARN of the SQS queue. Use this to avoid cycle errors between resources (e.g., Step Functions)
+func (o Outputs) QueueARNStatic() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "queue_arn_static")
+}
+
+// QueueURL references queue_url: Same as `queue_id`: The URL for the created Amazon SQS queue
+func (o Outputs) QueueURL() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "queue_url")
+}
+
+// QueueName references queue_name: The name of the SQS queue
+func (o Outputs) QueueName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "queue_name")
+}
+
+// DeadLetterQueueID references dead_letter_queue_id: The URL for the created Amazon SQS queue
+func (o Outputs) DeadLetterQueueID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dead_letter_queue_id")
+}
+
+// DeadLetterQueueARN references dead_letter_queue_arn: The ARN of the SQS queue
+func (o Outputs) DeadLetterQueueARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dead_letter_queue_arn")
+}
+
+// DeadLetterQueueARNStatic references dead_letter_queue_arn_static: The ARN of the SQS queue. Use this to avoid cycle errors between resources (e.g., Step Functions)
+func (o Outputs) DeadLetterQueueARNStatic() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dead_letter_queue_arn_static")
+}
+
+// DeadLetterQueueURL references dead_letter_queue_url: Same as `dead_letter_queue_id`: The URL for the created Amazon SQS queue
+func (o Outputs) DeadLetterQueueURL() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dead_letter_queue_url")
+}
+
+// DeadLetterQueueName references dead_letter_queue_name: The name of the SQS queue
+func (o Outputs) DeadLetterQueueName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "dead_letter_queue_name")
+}
@@ -1,7 +1,18 @@
 // Package ssm provides type-safe Terraform module definitions for terraform-aws-modules/ssm-parameter/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-ssm-parameter v1.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/ssm-parameter (see variables_gen.go).
 package ssm
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module ssm-parameter -source terraform-aws-modules/ssm-parameter/aws
+
+import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
+
 // Module represents the terraform-aws-modules/ssm-parameter/aws module.
 // All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
 type Module struct {
@@ -35,12 +46,12 @@ type Module struct {
 
 	// Type of the parameter
 	// Valid values: "String" | "StringList" | "SecureString"
-	Type *string `json:"type,omitempty" hcl:"type,attr"`
+	Type *string `json:"type,omitempty" validate:"oneof=String StringList SecureString" hcl:"type,attr"`
 
 	// Tier to assign to the parameter
 	// Valid values: "Standard" | "Advanced" | "Intelligent-Tiering"
 	// Note: Downgrading Advanced to Standard recreates the resource
-	Tier *string `json:"tier,omitempty" hcl:"tier,attr"`
+	Tier *string `json:"tier,omitempty" validate:"oneof=Standard Advanced Intelligent-Tiering" hcl:"tier,attr"`
 
 	// Value of the parameter (for String and SecureString types)
 	Value *string `json:"value,omitempty" hcl:"value,attr"`
@@ -66,7 +77,7 @@ type Module struct {
 // NewModule creates a new SSM Parameter module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/ssm-parameter/aws"
-	version := "~> 1.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	paramType := "String"
 	tier := "Standard"
@@ -161,8 +172,17 @@ func (m *Module) LocalName() string {
 	return "parameter"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 func (m *Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid ssm module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -360,14 +362,25 @@ func TestModule_LocalName(t *testing.T) {
 }
 
 func TestModule_Configuration(t *testing.T) {
-	t.Run("returns empty string and nil error as placeholder", func(t *testing.T) {
-		module := NewModule("/test")
+	t.Run("generates valid HCL for basic parameter", func(t *testing.T) {
+		module := NewModule("app_config").WithValue("enabled")
+
+		config, err := module.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "basic_parameter", config)
+	})
+
+	t.Run("generates HCL for a secure string", func(t *testing.T) {
+		module := NewModule("db_password").
+			WithSecureString("${var.db_password}", "alias/aws/ssm").
+			WithAdvancedTier().
+			WithTags(map[string]string{"Team": "platform"})
 
 		config, err := module.Configuration()
 
-		// Current implementation is a placeholder
 		require.NoError(t, err)
-		assert.Empty(t, config)
+		golden.Assert(t, "secure_string", config)
 	})
 }
 
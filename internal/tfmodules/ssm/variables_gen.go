@@ -0,0 +1,126 @@
+// Code generated by tfmodules-gen from .forge/modules/ssm-parameter. DO NOT EDIT.
+
+package ssm
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/ssm-parameter/aws these types were generated from.
+const ModuleVersion = "1.2.0"
+
+// Variables holds every input declared by terraform-aws-modules/ssm-parameter/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Whether to create SSM Parameter
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// Whether to create SSM Parameter and ignore changes in value
+	//
+	// Default: false
+	IgnoreValueChanges *bool `json:"ignore_value_changes,omitempty" hcl:"ignore_value_changes,attr"`
+
+	// Whether the type of the value should be considered as secure or not?
+	//
+	// Default: false
+	SecureType *bool `json:"secure_type,omitempty" hcl:"secure_type,attr"`
+
+	// Name of SSM parameter
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// Value of the parameter
+	Value *string `json:"value,omitempty" hcl:"value,attr"`
+
+	// List of values of the parameter (will be jsonencoded to store as string natively in SSM)
+	//
+	// Default: []
+	Values []string `json:"values,omitempty" hcl:"values,attr"`
+
+	// Description of the parameter
+	Description *string `json:"description,omitempty" hcl:"description,attr"`
+
+	// Type of the parameter. Valid types are String, StringList and SecureString.
+	Type *string `json:"type,omitempty" hcl:"type,attr"`
+
+	// Parameter tier to assign to the parameter. If not specified, will use the default parameter tier for the region. Valid tiers are Standard, Advanced, and Intelligent-Tiering. Downgrading an Advanced tier parameter to Standard will recreate the resource.
+	Tier *string `json:"tier,omitempty" hcl:"tier,attr"`
+
+	// KMS key ID or ARN for encrypting a parameter (when type is SecureString)
+	KeyID *string `json:"key_id,omitempty" hcl:"key_id,attr"`
+
+	// Regular expression used to validate the parameter value.
+	AllowedPattern *string `json:"allowed_pattern,omitempty" hcl:"allowed_pattern,attr"`
+
+	// Data type of the parameter. Valid values: text, aws:ssm:integration and aws:ec2:image for AMI format.
+	DataType *string `json:"data_type,omitempty" hcl:"data_type,attr"`
+
+	// A mapping of tags to assign to resources
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// Overwrite an existing parameter. If not specified, defaults to false during create operations to avoid overwriting existing resources and then true for all subsequent operations once the resource is managed by Terraform. Only relevant if ignore_value_changes is false.
+	//
+	// Default: false
+	Overwrite *bool `json:"overwrite,omitempty" hcl:"overwrite,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/ssm-parameter/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// RawValue references raw_value: Raw value of the parameter (as it is stored in SSM). Use 'value' output to get jsondecode'd value
+func (o Outputs) RawValue() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "raw_value")
+}
+
+// Value references value: Parameter value after jsondecode(). Probably this is what you are looking for
+func (o Outputs) Value() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "value")
+}
+
+// InsecureValue references insecure_value: Insecure value of the parameter
+func (o Outputs) InsecureValue() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "insecure_value")
+}
+
+// SecureValue references secure_value: Secure value of the parameter
+func (o Outputs) SecureValue() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secure_value")
+}
+
+// SecureType references secure_type: Whether SSM parameter is a SecureString or not?
+func (o Outputs) SecureType() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "secure_type")
+}
+
+// SsmParameterARN references ssm_parameter_arn: The ARN of the parameter
+func (o Outputs) SsmParameterARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "ssm_parameter_arn")
+}
+
+// SsmParameterVersion references ssm_parameter_version: Version of the parameter
+func (o Outputs) SsmParameterVersion() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "ssm_parameter_version")
+}
+
+// SsmParameterName references ssm_parameter_name: Name of the parameter
+func (o Outputs) SsmParameterName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "ssm_parameter_name")
+}
+
+// SsmParameterType references ssm_parameter_type: Type of the parameter
+func (o Outputs) SsmParameterType() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "ssm_parameter_type")
+}
+
+// SsmParameterTagsAll references ssm_parameter_tags_all: All tags used for the parameter
+func (o Outputs) SsmParameterTagsAll() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "ssm_parameter_tags_all")
+}
@@ -1,7 +1,18 @@
 // Package stepfunctions provides type-safe Terraform module definitions for terraform-aws-modules/step-functions/aws.
-// Generated from https://github.com/terraform-aws-modules/terraform-aws-step-functions v4.0
+// Module and its builders are hand-written; Variables and Outputs are generated
+// from the module vendored in .forge/modules/step-functions (see variables_gen.go).
 package stepfunctions
 
+//go:generate go run github.com/lewis/forge/cmd/tfmodules-gen -module step-functions -source terraform-aws-modules/step-functions/aws
+
+import (
+	"fmt"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+	"github.com/lewis/forge/internal/tfmodules/validate"
+)
+
 // Module represents the terraform-aws-modules/step-functions/aws module.
 // All fields use pointers to distinguish between "not set" (nil) and "set to zero value".
 type Module struct {
@@ -41,7 +52,7 @@ type Module struct {
 
 	// Type determines whether a Standard or Express state machine is created
 	// Valid values: "STANDARD" | "EXPRESS"
-	Type *string `json:"type,omitempty" hcl:"type,attr"`
+	Type *string `json:"type,omitempty" validate:"oneof=STANDARD EXPRESS" hcl:"type,attr"`
 
 	// RoleARN is the IAM role ARN to use
 	RoleARN *string `json:"role_arn,omitempty" hcl:"role_arn,attr"`
@@ -73,17 +84,12 @@ type Module struct {
 	CloudwatchLogGroupKMSKeyID *string `json:"cloudwatch_log_group_kms_key_id,omitempty" hcl:"cloudwatch_log_group_kms_key_id,attr"`
 
 	// ================================
-	// Tracing
+	// Service Integrations & Policies
 	// ================================
 
-	// TracingConfiguration enables X-Ray tracing
-	TracingConfiguration *TracingConfiguration `json:"tracing_configuration,omitempty" hcl:"tracing_configuration,attr"`
-
-	// AttachPolicyForLambda attaches policies for Lambda integration
-	AttachPolicyForLambda *bool `json:"attach_policy_for_lambda,omitempty" hcl:"attach_policy_for_lambda,attr"`
-
-	// LambdaFunctionARNs are Lambda function ARNs to allow invocation
-	LambdaFunctionARNs []string `json:"lambda_function_arns,omitempty" hcl:"lambda_function_arns,attr"`
+	// ServiceIntegrations are the AWS services the role may call; the xray
+	// integration also enables X-Ray tracing on the state machine
+	ServiceIntegrations *ServiceIntegrations `json:"service_integrations,omitempty" hcl:"service_integrations,attr"`
 
 	// AttachPolicyJSONs attaches custom JSON policies
 	AttachPolicyJSONs *bool `json:"attach_policy_jsons,omitempty" hcl:"attach_policy_jsons,attr"`
@@ -106,9 +112,6 @@ type Module struct {
 	// AttachCloudwatchLogsPolicy attaches CloudWatch Logs policy
 	AttachCloudwatchLogsPolicy *bool `json:"attach_cloudwatch_logs_policy,omitempty" hcl:"attach_cloudwatch_logs_policy,attr"`
 
-	// AttachXRayPolicy attaches X-Ray tracing policy
-	AttachXRayPolicy *bool `json:"attach_xray_policy,omitempty" hcl:"attach_xray_policy,attr"`
-
 	// Timeouts for Terraform resource management
 	Timeouts map[string]string `json:"sfn_state_machine_timeouts,omitempty" hcl:"sfn_state_machine_timeouts,attr"`
 }
@@ -117,13 +120,13 @@ type Module struct {
 type EncryptionConfiguration struct {
 	// Type is the encryption type
 	// Valid values: "AWS_OWNED_KEY" | "CUSTOMER_MANAGED_KMS_KEY"
-	Type *string `json:"type,omitempty" hcl:"type,attr"`
+	Type *string `json:"type,omitempty" validate:"oneof=AWS_OWNED_KEY CUSTOMER_MANAGED_KMS_KEY" hcl:"type,attr"`
 
 	// KMSKeyID is the KMS key ID for customer-managed keys
 	KMSKeyID *string `json:"kms_key_id,omitempty" hcl:"kms_key_id,attr"`
 
 	// KMSDataKeyReusePeriodSeconds is the data key reuse period (60-900)
-	KMSDataKeyReusePeriodSeconds *int `json:"kms_data_key_reuse_period_seconds,omitempty" hcl:"kms_data_key_reuse_period_seconds,attr"`
+	KMSDataKeyReusePeriodSeconds *int `json:"kms_data_key_reuse_period_seconds,omitempty" validate:"min=60,max=900" hcl:"kms_data_key_reuse_period_seconds,attr"`
 }
 
 // LoggingConfiguration represents logging settings.
@@ -139,10 +142,25 @@ type LoggingConfiguration struct {
 	LogDestination *string `json:"log_destination,omitempty" hcl:"log_destination,attr"`
 }
 
-// TracingConfiguration represents X-Ray tracing settings.
-type TracingConfiguration struct {
-	// Enabled indicates if X-Ray tracing is enabled
-	Enabled *bool `json:"enabled,omitempty" hcl:"enabled,attr"`
+// ServiceIntegrations represents the service_integrations map of the module.
+type ServiceIntegrations struct {
+	// Lambda allows invoking Lambda functions
+	Lambda *LambdaIntegration `json:"lambda,omitempty" hcl:"lambda,attr"`
+
+	// XRay allows sending traces and enables X-Ray tracing
+	XRay *XRayIntegration `json:"xray,omitempty" hcl:"xray,attr"`
+}
+
+// LambdaIntegration lists the Lambda functions the state machine may invoke.
+type LambdaIntegration struct {
+	// Lambda are the function ARNs
+	Lambda []string `json:"lambda,omitempty" hcl:"lambda,attr"`
+}
+
+// XRayIntegration enables X-Ray tracing.
+type XRayIntegration struct {
+	// XRay enables the integration
+	XRay *bool `json:"xray,omitempty" hcl:"xray,attr"`
 }
 
 // PolicyStatement represents an IAM policy statement.
@@ -160,7 +178,7 @@ type PolicyStatement struct {
 // NewModule creates a new Step Functions module with sensible defaults.
 func NewModule(name string) *Module {
 	source := "terraform-aws-modules/step-functions/aws"
-	version := "~> 4.0"
+	version := tfmodules.VersionConstraint(ModuleVersion)
 	create := true
 	createRole := true
 	sfnType := "STANDARD"
@@ -209,11 +227,7 @@ func (m *Module) WithLogging(level string, includeExecutionData bool) *Module {
 // WithTracing enables X-Ray tracing.
 func (m *Module) WithTracing() *Module {
 	enabled := true
-	m.TracingConfiguration = &TracingConfiguration{
-		Enabled: &enabled,
-	}
-	attachXRay := true
-	m.AttachXRayPolicy = &attachXRay
+	m.integrations().XRay = &XRayIntegration{XRay: &enabled}
 	return m
 }
 
@@ -230,12 +244,22 @@ func (m *Module) WithEncryption(kmsKeyID string, reusePeriod int) *Module {
 
 // WithLambdaIntegration configures Lambda function permissions.
 func (m *Module) WithLambdaIntegration(lambdaARNs ...string) *Module {
-	attachLambda := true
-	m.AttachPolicyForLambda = &attachLambda
-	m.LambdaFunctionARNs = append(m.LambdaFunctionARNs, lambdaARNs...)
+	integrations := m.integrations()
+	if integrations.Lambda == nil {
+		integrations.Lambda = &LambdaIntegration{}
+	}
+	integrations.Lambda.Lambda = append(integrations.Lambda.Lambda, lambdaARNs...)
 	return m
 }
 
+// integrations returns m.ServiceIntegrations, creating it if unset.
+func (m *Module) integrations() *ServiceIntegrations {
+	if m.ServiceIntegrations == nil {
+		m.ServiceIntegrations = &ServiceIntegrations{}
+	}
+	return m.ServiceIntegrations
+}
+
 // WithTags adds tags to the state machine.
 func (m *Module) WithTags(tags map[string]string) *Module {
 	if m.Tags == nil {
@@ -255,8 +279,17 @@ func (m *Module) LocalName() string {
 	return "state_machine"
 }
 
-// Configuration generates the HCL configuration for this module.
+// Validate checks the module's validate tags (value ranges and allowed values),
+// reporting every violation at once.
+// PURE: Calculation.
+func (m *Module) Validate() error {
+	return validate.Struct(m)
+}
+
+// Configuration validates the module and generates its HCL configuration.
 func (m *Module) Configuration() (string, error) {
-	// TODO: Implement full HCL generation using hclwrite or lingon's marshaling
-	return "", nil
+	if err := m.Validate(); err != nil {
+		return "", fmt.Errorf("invalid stepfunctions module %s: %w", m.LocalName(), err)
+	}
+	return hclgen.ToHCLWrite(m.LocalName(), m.Source, m.Version, m)
 }
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules/golden"
 )
 
 func TestNewModule(t *testing.T) {
@@ -14,7 +16,7 @@ func TestNewModule(t *testing.T) {
 
 		require.NotNil(t, module)
 		assert.Equal(t, "terraform-aws-modules/step-functions/aws", module.Source)
-		assert.Equal(t, "~> 4.0", module.Version)
+		assert.Equal(t, "~> 5.0", module.Version)
 		assert.NotNil(t, module.Name)
 		assert.Equal(t, name, *module.Name)
 
@@ -120,7 +122,7 @@ func TestModule_WithExpressType(t *testing.T) {
 			WithTracing()
 
 		assert.Equal(t, "EXPRESS", *module.Type)
-		assert.NotNil(t, module.TracingConfiguration)
+		assert.NotNil(t, module.ServiceIntegrations.XRay)
 	})
 }
 
@@ -168,11 +170,9 @@ func TestModule_WithTracing(t *testing.T) {
 		result := module.WithTracing()
 
 		assert.Equal(t, module, result)
-		assert.NotNil(t, module.TracingConfiguration)
-		assert.NotNil(t, module.TracingConfiguration.Enabled)
-		assert.True(t, *module.TracingConfiguration.Enabled)
-		assert.NotNil(t, module.AttachXRayPolicy)
-		assert.True(t, *module.AttachXRayPolicy)
+		require.NotNil(t, module.ServiceIntegrations)
+		require.NotNil(t, module.ServiceIntegrations.XRay)
+		assert.True(t, *module.ServiceIntegrations.XRay.XRay)
 	})
 
 	t.Run("supports method chaining", func(t *testing.T) {
@@ -180,7 +180,7 @@ func TestModule_WithTracing(t *testing.T) {
 			WithTracing().
 			WithLogging("ALL", true)
 
-		assert.True(t, *module.TracingConfiguration.Enabled)
+		assert.True(t, *module.ServiceIntegrations.XRay.XRay)
 		assert.NotNil(t, module.LoggingConfiguration)
 	})
 }
@@ -229,12 +229,9 @@ func TestModule_WithLambdaIntegration(t *testing.T) {
 		result := module.WithLambdaIntegration(lambdaARNs...)
 
 		assert.Equal(t, module, result)
-		assert.NotNil(t, module.AttachPolicyForLambda)
-		assert.True(t, *module.AttachPolicyForLambda)
-		assert.NotNil(t, module.LambdaFunctionARNs)
-		assert.Len(t, module.LambdaFunctionARNs, 2)
-		assert.Contains(t, module.LambdaFunctionARNs, lambdaARNs[0])
-		assert.Contains(t, module.LambdaFunctionARNs, lambdaARNs[1])
+		require.NotNil(t, module.ServiceIntegrations)
+		require.NotNil(t, module.ServiceIntegrations.Lambda)
+		assert.Equal(t, lambdaARNs, module.ServiceIntegrations.Lambda.Lambda)
 	})
 
 	t.Run("appends Lambda ARNs when called multiple times", func(t *testing.T) {
@@ -243,15 +240,14 @@ func TestModule_WithLambdaIntegration(t *testing.T) {
 		module.WithLambdaIntegration("arn1")
 		module.WithLambdaIntegration("arn2", "arn3")
 
-		assert.Len(t, module.LambdaFunctionARNs, 3)
+		assert.Len(t, module.ServiceIntegrations.Lambda.Lambda, 3)
 	})
 
 	t.Run("supports single Lambda function", func(t *testing.T) {
 		module := NewModule("test")
 		module.WithLambdaIntegration("lambda-arn")
 
-		assert.Len(t, module.LambdaFunctionARNs, 1)
-		assert.Equal(t, "lambda-arn", module.LambdaFunctionARNs[0])
+		assert.Equal(t, []string{"lambda-arn"}, module.ServiceIntegrations.Lambda.Lambda)
 	})
 }
 
@@ -307,13 +303,28 @@ func TestModule_LocalName(t *testing.T) {
 }
 
 func TestModule_Configuration(t *testing.T) {
-	t.Run("returns empty string and nil error as placeholder", func(t *testing.T) {
+	t.Run("generates valid HCL for basic state machine", func(t *testing.T) {
 		module := NewModule("test_state_machine")
 
 		config, err := module.Configuration()
 
 		require.NoError(t, err)
-		assert.Empty(t, config)
+		golden.Assert(t, "basic_state_machine", config)
+	})
+
+	t.Run("generates HCL with logging, tracing and integrations", func(t *testing.T) {
+		module := NewModule("order_flow").
+			WithDefinition(`{"StartAt":"Process","States":{"Process":{"Type":"Pass","End":true}}}`).
+			WithExpressType().
+			WithLogging("ALL", true).
+			WithTracing().
+			WithLambdaIntegration("${module.process_order.lambda_function_arn}").
+			WithTags(map[string]string{"Team": "platform"})
+
+		config, err := module.Configuration()
+
+		require.NoError(t, err)
+		golden.Assert(t, "full_state_machine", config)
 	})
 }
 
@@ -334,9 +345,9 @@ func TestModule_FluentAPI(t *testing.T) {
 		assert.Equal(t, "STANDARD", *module.Type)
 		assert.NotNil(t, module.Definition)
 		assert.NotNil(t, module.LoggingConfiguration)
-		assert.NotNil(t, module.TracingConfiguration)
+		assert.NotNil(t, module.ServiceIntegrations.XRay)
 		assert.NotNil(t, module.EncryptionConfiguration)
-		assert.Len(t, module.LambdaFunctionARNs, 2)
+		assert.Len(t, module.ServiceIntegrations.Lambda.Lambda, 2)
 		assert.Equal(t, "platform", module.Tags["Team"])
 	})
 
@@ -410,26 +421,6 @@ func TestLoggingConfiguration(t *testing.T) {
 	})
 }
 
-func TestTracingConfiguration(t *testing.T) {
-	t.Run("creates enabled tracing configuration", func(t *testing.T) {
-		enabled := true
-		config := TracingConfiguration{
-			Enabled: &enabled,
-		}
-
-		assert.True(t, *config.Enabled)
-	})
-
-	t.Run("creates disabled tracing configuration", func(t *testing.T) {
-		enabled := false
-		config := TracingConfiguration{
-			Enabled: &enabled,
-		}
-
-		assert.False(t, *config.Enabled)
-	})
-}
-
 func TestPolicyStatement(t *testing.T) {
 	t.Run("creates policy statement for Lambda invocation", func(t *testing.T) {
 		effect := "Allow"
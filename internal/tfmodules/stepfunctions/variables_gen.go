@@ -0,0 +1,260 @@
+// Code generated by tfmodules-gen from .forge/modules/step-functions. DO NOT EDIT.
+
+package stepfunctions
+
+import "github.com/lewis/forge/internal/tfmodules"
+
+// ModuleVersion is the version of terraform-aws-modules/step-functions/aws these types were generated from.
+const ModuleVersion = "5.0.2"
+
+// Variables holds every input declared by terraform-aws-modules/step-functions/aws.
+// Unset (nil or empty) fields keep the module default.
+type Variables struct {
+	// Whether to create Step Function resource
+	//
+	// Default: true
+	Create *bool `json:"create,omitempty" hcl:"create,attr"`
+
+	// Whether to create IAM role for the Step Function
+	//
+	// Default: true
+	CreateRole *bool `json:"create_role,omitempty" hcl:"create_role,attr"`
+
+	// Whether to use an existing IAM role for this Step Function
+	//
+	// Default: false
+	UseExistingRole *bool `json:"use_existing_role,omitempty" hcl:"use_existing_role,attr"`
+
+	// Whether to use an existing CloudWatch log group or create new
+	//
+	// Default: false
+	UseExistingCloudwatchLogGroup *bool `json:"use_existing_cloudwatch_log_group,omitempty" hcl:"use_existing_cloudwatch_log_group,attr"`
+
+	// Region where the resource(s) will be managed. Defaults to the region set in the provider configuration
+	Region *string `json:"region,omitempty" hcl:"region,attr"`
+
+	// The name of the Step Function
+	//
+	// Default: ""
+	Name *string `json:"name,omitempty" hcl:"name,attr"`
+
+	// The Amazon States Language definition of the Step Function
+	//
+	// Default: ""
+	Definition *string `json:"definition,omitempty" hcl:"definition,attr"`
+
+	// The Amazon Resource Name (ARN) of the IAM role to use for this Step Function
+	//
+	// Default: ""
+	RoleARN *string `json:"role_arn,omitempty" hcl:"role_arn,attr"`
+
+	// Maps of tags to assign to the Step Function
+	//
+	// Default: {}
+	Tags map[string]string `json:"tags,omitempty" hcl:"tags,attr"`
+
+	// Determines whether a Standard or Express state machine is created. The default is STANDARD. Valid Values: STANDARD | EXPRESS
+	//
+	// Default: "STANDARD"
+	Type *string `json:"type,omitempty" hcl:"type,attr"`
+
+	// Create, update, and delete timeout configurations for the step function.
+	//
+	// Default: {}
+	SfnStateMachineTimeouts map[string]string `json:"sfn_state_machine_timeouts,omitempty" hcl:"sfn_state_machine_timeouts,attr"`
+
+	// Determines whether to set a version of the state machine when it is created.
+	//
+	// Default: false
+	Publish *bool `json:"publish,omitempty" hcl:"publish,attr"`
+
+	// Defines what encryption configuration is used to encrypt data in the State Machine.
+	//
+	// Default: {}
+	EncryptionConfiguration interface{} `json:"encryption_configuration,omitempty" hcl:"encryption_configuration,attr"`
+
+	// Defines what execution history events are logged and where they are logged
+	//
+	// Default: {}
+	LoggingConfiguration map[string]string `json:"logging_configuration,omitempty" hcl:"logging_configuration,attr"`
+
+	// Name of Cloudwatch Logs group name to use.
+	CloudwatchLogGroupName *string `json:"cloudwatch_log_group_name,omitempty" hcl:"cloudwatch_log_group_name,attr"`
+
+	// Specifies the number of days you want to retain log events in the specified log group. Possible values are: 1, 3, 5, 7, 14, 30, 60, 90, 120, 150, 180, 365, 400, 545, 731, 1827, and 3653.
+	CloudwatchLogGroupRetentionInDays *int `json:"cloudwatch_log_group_retention_in_days,omitempty" hcl:"cloudwatch_log_group_retention_in_days,attr"`
+
+	// The ARN of the KMS Key to use when encrypting log data.
+	CloudwatchLogGroupKMSKeyID *string `json:"cloudwatch_log_group_kms_key_id,omitempty" hcl:"cloudwatch_log_group_kms_key_id,attr"`
+
+	// A map of tags to assign to the resource.
+	//
+	// Default: {}
+	CloudwatchLogGroupTags map[string]string `json:"cloudwatch_log_group_tags,omitempty" hcl:"cloudwatch_log_group_tags,attr"`
+
+	// Controls whether CloudWatch Logs policy should be added to IAM role for Lambda Function
+	//
+	// Default: true
+	AttachCloudwatchLogsPolicy *bool `json:"attach_cloudwatch_logs_policy,omitempty" hcl:"attach_cloudwatch_logs_policy,attr"`
+
+	// Name of AWS regions where IAM role can be assumed by the Step Function
+	//
+	// Default: ""
+	AwsRegionAssumeRole *string `json:"aws_region_assume_role,omitempty" hcl:"aws_region_assume_role,attr"`
+
+	// Name of IAM role to use for Step Function
+	RoleName *string `json:"role_name,omitempty" hcl:"role_name,attr"`
+
+	// Description of IAM role to use for Step Function
+	RoleDescription *string `json:"role_description,omitempty" hcl:"role_description,attr"`
+
+	// Path of IAM role to use for Step Function
+	RolePath *string `json:"role_path,omitempty" hcl:"role_path,attr"`
+
+	// Specifies to force detaching any policies the IAM role has before destroying it.
+	//
+	// Default: true
+	RoleForceDetachPolicies *bool `json:"role_force_detach_policies,omitempty" hcl:"role_force_detach_policies,attr"`
+
+	// The ARN of the policy that is used to set the permissions boundary for the IAM role used by Step Function
+	RolePermissionsBoundary *string `json:"role_permissions_boundary,omitempty" hcl:"role_permissions_boundary,attr"`
+
+	// A map of tags to assign to IAM role
+	//
+	// Default: {}
+	RoleTags map[string]string `json:"role_tags,omitempty" hcl:"role_tags,attr"`
+
+	// Whether to attach AWS Service policies to IAM role
+	//
+	// Default: true
+	AttachPoliciesForIntegrations *bool `json:"attach_policies_for_integrations,omitempty" hcl:"attach_policies_for_integrations,attr"`
+
+	// Map of AWS service integrations to allow in IAM role policy
+	//
+	// Default: {}
+	ServiceIntegrations interface{} `json:"service_integrations,omitempty" hcl:"service_integrations,attr"`
+
+	// Controls whether policy_json should be added to IAM role
+	//
+	// Default: false
+	AttachPolicyJSON *bool `json:"attach_policy_json,omitempty" hcl:"attach_policy_json,attr"`
+
+	// Controls whether policy_jsons should be added to IAM role
+	//
+	// Default: false
+	AttachPolicyJsons *bool `json:"attach_policy_jsons,omitempty" hcl:"attach_policy_jsons,attr"`
+
+	// Controls whether policy should be added to IAM role
+	//
+	// Default: false
+	AttachPolicy *bool `json:"attach_policy,omitempty" hcl:"attach_policy,attr"`
+
+	// Controls whether list of policies should be added to IAM role
+	//
+	// Default: false
+	AttachPolicies *bool `json:"attach_policies,omitempty" hcl:"attach_policies,attr"`
+
+	// Number of policies JSON to attach to IAM role
+	//
+	// Default: 0
+	NumberOfPolicyJsons *int `json:"number_of_policy_jsons,omitempty" hcl:"number_of_policy_jsons,attr"`
+
+	// Number of policies to attach to IAM role
+	//
+	// Default: 0
+	NumberOfPolicies *int `json:"number_of_policies,omitempty" hcl:"number_of_policies,attr"`
+
+	// Controls whether policy_statements should be added to IAM role
+	//
+	// Default: false
+	AttachPolicyStatements *bool `json:"attach_policy_statements,omitempty" hcl:"attach_policy_statements,attr"`
+
+	// Step Function additional trusted entities for assuming roles (trust relationship)
+	//
+	// Default: []
+	TrustedEntities []string `json:"trusted_entities,omitempty" hcl:"trusted_entities,attr"`
+
+	// An additional policy document as JSON to attach to IAM role
+	PolicyJSON *string `json:"policy_json,omitempty" hcl:"policy_json,attr"`
+
+	// List of additional policy documents as JSON to attach to IAM role
+	//
+	// Default: []
+	PolicyJsons []string `json:"policy_jsons,omitempty" hcl:"policy_jsons,attr"`
+
+	// An additional policy document ARN to attach to IAM role
+	Policy *string `json:"policy,omitempty" hcl:"policy,attr"`
+
+	// Path of IAM policies to use for Step Function
+	PolicyPath *string `json:"policy_path,omitempty" hcl:"policy_path,attr"`
+
+	// List of policy statements ARN to attach to IAM role
+	//
+	// Default: []
+	Policies []string `json:"policies,omitempty" hcl:"policies,attr"`
+
+	// Map of dynamic policy statements to attach to IAM role
+	//
+	// Default: {}
+	PolicyStatements interface{} `json:"policy_statements,omitempty" hcl:"policy_statements,attr"`
+}
+
+// Outputs references the outputs of a terraform-aws-modules/step-functions/aws module call.
+type Outputs struct {
+	module tfmodules.Module
+}
+
+// Outputs returns references to the outputs of m, for use as inputs of other modules.
+func (m *Module) Outputs() Outputs {
+	return Outputs{module: m}
+}
+
+// StateMachineID references state_machine_id: The ARN of the Step Function
+func (o Outputs) StateMachineID() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "state_machine_id")
+}
+
+// StateMachineARN references state_machine_arn: The ARN of the Step Function
+func (o Outputs) StateMachineARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "state_machine_arn")
+}
+
+// StateMachineName references state_machine_name: The Name of the Step Function
+func (o Outputs) StateMachineName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "state_machine_name")
+}
+
+// StateMachineCreationDate references state_machine_creation_date: The date the Step Function was created
+func (o Outputs) StateMachineCreationDate() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "state_machine_creation_date")
+}
+
+// StateMachineStatus references state_machine_status: The current status of the Step Function
+func (o Outputs) StateMachineStatus() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "state_machine_status")
+}
+
+// StateMachineVersionARN references state_machine_version_arn: The ARN of state machine version
+func (o Outputs) StateMachineVersionARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "state_machine_version_arn")
+}
+
+// RoleARN references role_arn: The ARN of the IAM role created for the Step Function
+func (o Outputs) RoleARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "role_arn")
+}
+
+// RoleName references role_name: The name of the IAM role created for the Step Function
+func (o Outputs) RoleName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "role_name")
+}
+
+// CloudwatchLogGroupARN references cloudwatch_log_group_arn: The ARN of the CloudWatch log group created for the Step Function
+func (o Outputs) CloudwatchLogGroupARN() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudwatch_log_group_arn")
+}
+
+// CloudwatchLogGroupName references cloudwatch_log_group_name: The name of the CloudWatch log group created for the Step Function
+func (o Outputs) CloudwatchLogGroupName() tfmodules.Output {
+	return tfmodules.NewOutput(o.module, "cloudwatch_log_group_name")
+}
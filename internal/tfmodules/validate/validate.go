@@ -0,0 +1,228 @@
+// Package validate enforces the validate struct tags on tfmodules types.
+//
+// Supported rules, comma separated:
+//
+//	min=N      numbers must be >= N; strings, slices and maps need at least N elements
+//	max=N      numbers must be <= N; strings, slices and maps hold at most N elements
+//	oneof=A B  strings (or each string in a slice) must be one of the listed values
+//
+// Unset (nil) fields are not checked, and neither are strings holding Terraform
+// templates such as "${var.runtime}", whose value is only known at plan time.
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lewis/forge/internal/tfmodules/hclgen"
+)
+
+type (
+	// FieldError is a single violated rule. Path uses the Terraform argument
+	// names, e.g. event_source_mapping["sqs"].batch_size.
+	FieldError struct {
+		Path    string
+		Message string
+	}
+
+	// Errors holds every violation found in a module.
+	Errors []FieldError
+)
+
+// Error implements error.
+func (e FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// Error implements error, listing one violation per line.
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	lines := make([]string, 0, len(e)+1)
+	lines = append(lines, fmt.Sprintf("%d invalid fields:", len(e)))
+	for _, fe := range e {
+		lines = append(lines, "  "+fe.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Struct checks every validate tag in v and the structs, slices and maps it
+// holds. It returns nil or an Errors listing all violations in field order.
+// PURE: Calculation.
+func Struct(v interface{}) error {
+	var errs Errors
+	walk(reflect.ValueOf(v), "", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// walk visits v, checking the tags of every struct field it reaches.
+// PURE: Calculation.
+func walk(v reflect.Value, path string, errs *Errors) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem(), path, errs)
+		}
+
+	case reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldPath := join(path, fieldName(field))
+			if rules := field.Tag.Get("validate"); rules != "" {
+				check(v.Field(i), fieldPath, rules, errs)
+			}
+			walk(v.Field(i), fieldPath, errs)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+
+	case reflect.Map:
+		keys := v.MapKeys()
+		sortKeys(keys)
+		for _, key := range keys {
+			walk(v.MapIndex(key), fmt.Sprintf("%s[%q]", path, fmt.Sprint(key.Interface())), errs)
+		}
+	}
+}
+
+// check applies a field's comma-separated rules to its value.
+// PURE: Calculation.
+func check(v reflect.Value, path, rules string, errs *Errors) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		var msg string
+		switch name {
+		case "min", "max":
+			msg = checkBound(v, name, arg)
+		case "oneof":
+			msg = checkOneOf(v, strings.Fields(arg))
+		default:
+			msg = fmt.Sprintf("unknown validation rule %q", name)
+		}
+		if msg != "" {
+			*errs = append(*errs, FieldError{Path: path, Message: msg})
+		}
+	}
+}
+
+// checkBound checks a min or max rule, returning a message when it is violated.
+// PURE: Calculation.
+func checkBound(v reflect.Value, rule, arg string) string {
+	bound, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return fmt.Sprintf("invalid %s rule %q", rule, arg)
+	}
+
+	var (
+		got  float64
+		unit string
+	)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		got = float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		got = float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		got = v.Float()
+	case reflect.String:
+		if hclgen.IsExpression(v.String()) {
+			return ""
+		}
+		got, unit = float64(len(v.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		got, unit = float64(v.Len()), " elements"
+	default:
+		return fmt.Sprintf("%s does not apply to %s", rule, v.Kind())
+	}
+
+	if rule == "min" && got < bound {
+		return fmt.Sprintf("must be at least %s%s, got %s", arg, unit, formatNumber(got))
+	}
+	if rule == "max" && got > bound {
+		return fmt.Sprintf("must be at most %s%s, got %s", arg, unit, formatNumber(got))
+	}
+	return ""
+}
+
+// checkOneOf checks a oneof rule against a string or each string in a slice.
+// PURE: Calculation.
+func checkOneOf(v reflect.Value, allowed []string) string {
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if hclgen.IsExpression(s) {
+			return ""
+		}
+		for _, a := range allowed {
+			if s == a {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(allowed, ", "), s)
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if msg := checkOneOf(v.Index(i), allowed); msg != "" {
+				return fmt.Sprintf("element %d %s", i, msg)
+			}
+		}
+		return ""
+
+	default:
+		return fmt.Sprintf("oneof does not apply to %s", v.Kind())
+	}
+}
+
+// fieldName returns the Terraform argument name of a field, or its Go name
+// when it has no hcl tag.
+// PURE: Calculation.
+func fieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("hcl"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// join appends a field name to a path.
+// PURE: Calculation.
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// sortKeys orders map keys by their printed form so paths are reported deterministically.
+// PURE: Sorts in place.
+func sortKeys(keys []reflect.Value) {
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+}
+
+// formatNumber prints whole numbers without a decimal point.
+// PURE: Calculation.
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMapping struct {
+	BatchSize *int `hcl:"batch_size,attr" validate:"min=1,max=10000"`
+}
+
+type testModule struct {
+	Memory        *int                   `hcl:"memory_size,attr" validate:"min=128,max=10240"`
+	Ratio         *float64               `hcl:"ratio,attr" validate:"min=0,max=1"`
+	Runtime       *string                `hcl:"runtime,attr" validate:"oneof=python3.13 nodejs22.x"`
+	Architectures []string               `hcl:"architectures,attr" validate:"oneof=x86_64 arm64,max=1"`
+	Name          string                 `hcl:"name,attr" validate:"min=1,max=8"`
+	Mappings      map[string]testMapping `hcl:"event_source_mapping,attr"`
+	Layers        []testMapping          `hcl:"layers,attr"`
+	Untagged      *int                   `validate:"max=1"`
+}
+
+func intPtr(i int) *int           { return &i }
+func strPtr(s string) *string     { return &s }
+func floatPtr(f float64) *float64 { return &f }
+
+func TestStruct(t *testing.T) {
+	t.Run("accepts valid and unset fields", func(t *testing.T) {
+		err := Struct(&testModule{
+			Memory:        intPtr(512),
+			Runtime:       strPtr("python3.13"),
+			Architectures: []string{"arm64"},
+			Name:          "api",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("accepts bounds inclusively", func(t *testing.T) {
+		assert.NoError(t, Struct(&testModule{Memory: intPtr(128), Ratio: floatPtr(1), Name: "x"}))
+		assert.NoError(t, Struct(&testModule{Memory: intPtr(10240), Ratio: floatPtr(0), Name: "12345678"}))
+	})
+
+	t.Run("reports every violation with its field path", func(t *testing.T) {
+		err := Struct(&testModule{
+			Memory:        intPtr(64),
+			Ratio:         floatPtr(1.5),
+			Runtime:       strPtr("cobol85"),
+			Architectures: []string{"arm64", "sparc"},
+			Name:          "",
+			Mappings: map[string]testMapping{
+				"sqs":     {BatchSize: intPtr(0)},
+				"kinesis": {BatchSize: intPtr(100)},
+			},
+			Layers:   []testMapping{{}, {BatchSize: intPtr(20000)}},
+			Untagged: intPtr(2),
+		})
+
+		var errs Errors
+		require.True(t, errors.As(err, &errs))
+		assert.Equal(t, Errors{
+			{Path: "memory_size", Message: "must be at least 128, got 64"},
+			{Path: "ratio", Message: "must be at most 1, got 1.5"},
+			{Path: "runtime", Message: `must be one of python3.13, nodejs22.x, got "cobol85"`},
+			{Path: "architectures", Message: `element 1 must be one of x86_64, arm64, got "sparc"`},
+			{Path: "architectures", Message: "must be at most 1 elements, got 2"},
+			{Path: "name", Message: "must be at least 1 characters, got 0"},
+			{Path: `event_source_mapping["sqs"].batch_size`, Message: "must be at least 1, got 0"},
+			{Path: "layers[1].batch_size", Message: "must be at most 10000, got 20000"},
+			{Path: "Untagged", Message: "must be at most 1, got 2"},
+		}, errs)
+	})
+
+	t.Run("formats one violation per line", func(t *testing.T) {
+		err := Struct(&testModule{Memory: intPtr(64), Name: "way-too-long"})
+
+		require.Error(t, err)
+		assert.Equal(t, "2 invalid fields:\n"+
+			"  memory_size: must be at least 128, got 64\n"+
+			"  name: must be at most 8 characters, got 12", err.Error())
+	})
+
+	t.Run("formats a single violation on one line", func(t *testing.T) {
+		err := Struct(&testModule{Memory: intPtr(64), Name: "x"})
+
+		require.Error(t, err)
+		assert.Equal(t, "memory_size: must be at least 128, got 64", err.Error())
+	})
+
+	t.Run("skips Terraform references", func(t *testing.T) {
+		err := Struct(&testModule{
+			Runtime:       strPtr("${var.runtime}"),
+			Architectures: []string{"${var.architecture}"},
+			Name:          "${local.function_name_that_is_long}",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("reports rules that do not apply", func(t *testing.T) {
+		type badModule struct {
+			Enabled *bool   `hcl:"enabled,attr" validate:"min=1"`
+			Mode    *string `hcl:"mode,attr" validate:"required"`
+		}
+		enabled := true
+
+		err := Struct(&badModule{Enabled: &enabled, Mode: strPtr("x")})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "enabled: min does not apply to bool")
+		assert.Contains(t, err.Error(), `mode: unknown validation rule "required"`)
+	})
+}
@@ -0,0 +1,62 @@
+package tfmodules_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lewis/forge/internal/tfmodules"
+	"github.com/lewis/forge/internal/tfmodules/apigatewayv2"
+	"github.com/lewis/forge/internal/tfmodules/appconfig"
+	"github.com/lewis/forge/internal/tfmodules/appsync"
+	"github.com/lewis/forge/internal/tfmodules/cloudfront"
+	"github.com/lewis/forge/internal/tfmodules/dynamodb"
+	"github.com/lewis/forge/internal/tfmodules/eventbridge"
+	"github.com/lewis/forge/internal/tfmodules/lambda"
+	"github.com/lewis/forge/internal/tfmodules/s3"
+	"github.com/lewis/forge/internal/tfmodules/secretsmanager"
+	"github.com/lewis/forge/internal/tfmodules/sns"
+	"github.com/lewis/forge/internal/tfmodules/sqs"
+	"github.com/lewis/forge/internal/tfmodules/ssm"
+	"github.com/lewis/forge/internal/tfmodules/stepfunctions"
+)
+
+func TestVersionConstraint(t *testing.T) {
+	assert.Equal(t, "~> 5.0", tfmodules.VersionConstraint("5.1.0"))
+	assert.Equal(t, "~> 2.0", tfmodules.VersionConstraint("2.0.1"))
+}
+
+// TestNewModule_PinsModuleVersion checks that every typed module pins a
+// constraint its generated Variables' ModuleVersion satisfies, so the calls it
+// renders are checked against the vendored module.
+func TestNewModule_PinsModuleVersion(t *testing.T) {
+	pins := map[string]struct{ constraint, moduleVersion string }{
+		"apigatewayv2":   {apigatewayv2.NewModule("api").Version, apigatewayv2.ModuleVersion},
+		"appconfig":      {appconfig.NewModule("config").Version, appconfig.ModuleVersion},
+		"appsync":        {appsync.NewModule("graphql").Version, appsync.ModuleVersion},
+		"cloudfront":     {cloudfront.NewModule("cdn").Version, cloudfront.ModuleVersion},
+		"dynamodb":       {dynamodb.NewModule("table").Version, dynamodb.ModuleVersion},
+		"eventbridge":    {eventbridge.NewModule("bus").Version, eventbridge.ModuleVersion},
+		"lambda":         {lambda.NewModule("function").Version, lambda.ModuleVersion},
+		"s3":             {s3.NewModule("bucket").Version, s3.ModuleVersion},
+		"secretsmanager": {secretsmanager.NewModule("secret").Version, secretsmanager.ModuleVersion},
+		"sns":            {sns.NewModule("topic").Version, sns.ModuleVersion},
+		"sqs":            {sqs.NewModule("queue").Version, sqs.ModuleVersion},
+		"ssm":            {ssm.NewModule("parameter").Version, ssm.ModuleVersion},
+		"stepfunctions":  {stepfunctions.NewModule("machine").Version, stepfunctions.ModuleVersion},
+	}
+
+	for name, pin := range pins {
+		t.Run(name, func(t *testing.T) {
+			constraints, err := version.NewConstraint(pin.constraint)
+			require.NoError(t, err)
+			moduleVersion, err := version.NewVersion(pin.moduleVersion)
+			require.NoError(t, err)
+
+			assert.True(t, constraints.Check(moduleVersion),
+				"%s pins %q, which excludes ModuleVersion %s", name, pin.constraint, pin.moduleVersion)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 )
@@ -84,3 +85,24 @@ func (o *Output) Step(step, total int, message string) {
 	_, _ = o.dim.Fprintf(o.writer, "[%d/%d] ", step, total)
 	_, _ = fmt.Fprintln(o.writer, message)
 }
+
+// Diff prints a unified diff, with added lines in green, removed lines in red
+// and hunk headers in cyan.
+func (o *Output) Diff(diff string) {
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			_, _ = fmt.Fprint(o.writer, line)
+		case strings.HasPrefix(line, "+"):
+			_, _ = o.success.Fprint(o.writer, line)
+		case strings.HasPrefix(line, "-"):
+			_, _ = o.error.Fprint(o.writer, line)
+		case strings.HasPrefix(line, "@@"):
+			_, _ = o.info.Fprint(o.writer, line)
+		default:
+			_, _ = fmt.Fprint(o.writer, line)
+		}
+	}
+}
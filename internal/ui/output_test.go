@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/fatih/color"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -217,6 +218,36 @@ func TestOutputStep(t *testing.T) {
 	})
 }
 
+func TestOutputDiff(t *testing.T) {
+	diff := "--- a/infra/sqs.tf\n+++ b/infra/sqs.tf\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n"
+
+	t.Run("writes the diff unchanged without colors", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		out := NewOutput(buf)
+
+		out.Diff(diff)
+
+		assert.Equal(t, diff, buf.String())
+	})
+
+	t.Run("colors added and removed lines", func(t *testing.T) {
+		noColor := color.NoColor
+		color.NoColor = false
+		defer func() { color.NoColor = noColor }()
+
+		buf := &bytes.Buffer{}
+		out := NewOutput(buf)
+
+		out.Diff(diff)
+
+		output := buf.String()
+		assert.Contains(t, output, "--- a/infra/sqs.tf\n+++ b/infra/sqs.tf\n", "file headers aren't colored")
+		assert.Contains(t, output, "\x1b[32m+c\n")
+		assert.Contains(t, output, "\x1b[31m-b\n")
+		assert.Contains(t, output, "\x1b[36m@@ -1,2 +1,2 @@\n")
+	})
+}
+
 func TestOutputMultipleMessages(t *testing.T) {
 	t.Run("writes multiple messages in sequence", func(t *testing.T) {
 		buf := &bytes.Buffer{}
@@ -0,0 +1,27 @@
+// Package forge bundles the Terraform modules vendored in .forge/modules into
+// the forge binary, so projects can vendor them without network access.
+package forge
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// modules holds the root of every vendored module: its Terraform files, the
+// scripts they run (lambda's package.py) and its changelog, licence and readme.
+// Examples, tests and submodules are left out.
+//
+//go:embed .forge/modules/*/*.tf .forge/modules/*/*.py
+//go:embed .forge/modules/*/CHANGELOG.md .forge/modules/*/LICENSE .forge/modules/*/README.md
+var modules embed.FS
+
+// Modules returns the bundled modules, one directory per module, e.g. sqs/main.tf.
+// PURE: Calculation.
+func Modules() fs.FS {
+	sub, err := fs.Sub(modules, ".forge/modules")
+	if err != nil {
+		// fs.Sub only fails on an invalid path, and this one is constant
+		panic(err)
+	}
+	return sub
+}